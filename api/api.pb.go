@@ -7,8 +7,12 @@
 package api
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,15 +25,138 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Task message definition (remains the same)
+// BatchMode selects how BatchMutate handles failing mutations.
+type BatchMode int32
+
+const (
+	// BATCH_MODE_UNSPECIFIED is treated as BATCH_MODE_ATOMIC.
+	BatchMode_BATCH_MODE_UNSPECIFIED BatchMode = 0
+	// BATCH_MODE_ATOMIC applies all mutations or none: the first failure rolls back the batch.
+	BatchMode_BATCH_MODE_ATOMIC BatchMode = 1
+	// BATCH_MODE_BEST_EFFORT rolls back only failing mutations and commits the others.
+	BatchMode_BATCH_MODE_BEST_EFFORT BatchMode = 2
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_UNSPECIFIED",
+		1: "BATCH_MODE_ATOMIC",
+		2: "BATCH_MODE_BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_UNSPECIFIED": 0,
+		"BATCH_MODE_ATOMIC":      1,
+		"BATCH_MODE_BEST_EFFORT": 2,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[0].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[0]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
+// ImportOutcome is what ImportTasks did with a task.
+type ImportOutcome int32
+
+const (
+	ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED ImportOutcome = 0
+	// IMPORT_OUTCOME_CREATED means the task was added, or would be in a dry run.
+	ImportOutcome_IMPORT_OUTCOME_CREATED ImportOutcome = 1
+	// IMPORT_OUTCOME_DUPLICATE means the task was skipped because a task with the same
+	// title, project and due date exists or appeared earlier in the stream.
+	ImportOutcome_IMPORT_OUTCOME_DUPLICATE ImportOutcome = 2
+	// IMPORT_OUTCOME_FAILED means the task was invalid or could not be added.
+	ImportOutcome_IMPORT_OUTCOME_FAILED ImportOutcome = 3
+)
+
+// Enum value maps for ImportOutcome.
+var (
+	ImportOutcome_name = map[int32]string{
+		0: "IMPORT_OUTCOME_UNSPECIFIED",
+		1: "IMPORT_OUTCOME_CREATED",
+		2: "IMPORT_OUTCOME_DUPLICATE",
+		3: "IMPORT_OUTCOME_FAILED",
+	}
+	ImportOutcome_value = map[string]int32{
+		"IMPORT_OUTCOME_UNSPECIFIED": 0,
+		"IMPORT_OUTCOME_CREATED":     1,
+		"IMPORT_OUTCOME_DUPLICATE":   2,
+		"IMPORT_OUTCOME_FAILED":      3,
+	}
+)
+
+func (x ImportOutcome) Enum() *ImportOutcome {
+	p := new(ImportOutcome)
+	*p = x
+	return p
+}
+
+func (x ImportOutcome) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ImportOutcome) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[1].Descriptor()
+}
+
+func (ImportOutcome) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[1]
+}
+
+func (x ImportOutcome) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ImportOutcome.Descriptor instead.
+func (ImportOutcome) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{1}
+}
+
+// Task represents a single task item.
 type Task struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// due_at is an RFC 3339 timestamp; empty when the task has no due date.
+	DueAt string   `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Tags  []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// priority is one of low, medium, high or urgent; empty when unset.
+	Priority string `protobuf:"bytes,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee string `protobuf:"bytes,10,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project  string `protobuf:"bytes,11,opt,name=project,proto3" json:"project,omitempty"`
+	// completed_at is the RFC 3339 time the task was completed; empty while its
+	// status is not "completed".
+	CompletedAt string `protobuf:"bytes,12,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// version starts at 1 and grows with every change of the task. Passing it back
+	// in UpdateTaskRequest or DeleteTaskRequest makes the change conditional.
+	Version       int32 `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,9 +233,59 @@ func (x *Task) GetUpdatedAt() string {
 	return ""
 }
 
-// GetTasks messages (remain the same)
+func (x *Task) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Task) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *Task) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *Task) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+func (x *Task) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// GetTasksRequest is the request message for GetTasks RPC.
 type GetTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -143,6 +320,135 @@ func (*GetTasksRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *GetTasksRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// TaskFilter selects tasks. All set fields must match; unset fields match every task.
+// Timestamps are RFC 3339; "before" bounds are exclusive and "after" bounds inclusive.
+type TaskFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// statuses matches tasks with any of the given statuses.
+	Statuses []string `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// tags matches tasks that carry all of the given tags.
+	Tags     []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority string   `protobuf:"bytes,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee string   `protobuf:"bytes,4,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project  string   `protobuf:"bytes,5,opt,name=project,proto3" json:"project,omitempty"`
+	// title_contains matches tasks whose title contains the text, ignoring case.
+	TitleContains string `protobuf:"bytes,6,opt,name=title_contains,json=titleContains,proto3" json:"title_contains,omitempty"`
+	CreatedBefore string `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	CreatedAfter  string `protobuf:"bytes,8,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	DueBefore     string `protobuf:"bytes,9,opt,name=due_before,json=dueBefore,proto3" json:"due_before,omitempty"`
+	DueAfter      string `protobuf:"bytes,10,opt,name=due_after,json=dueAfter,proto3" json:"due_after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskFilter) Reset() {
+	*x = TaskFilter{}
+	mi := &file_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskFilter) ProtoMessage() {}
+
+func (x *TaskFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskFilter.ProtoReflect.Descriptor instead.
+func (*TaskFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaskFilter) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TaskFilter) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetTitleContains() string {
+	if x != nil {
+		return x.TitleContains
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetDueBefore() string {
+	if x != nil {
+		return x.DueBefore
+	}
+	return ""
+}
+
+func (x *TaskFilter) GetDueAfter() string {
+	if x != nil {
+		return x.DueAfter
+	}
+	return ""
+}
+
+// GetTasksReply is the response message for GetTasks RPC.
 type GetTasksReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -152,7 +458,7 @@ type GetTasksReply struct {
 
 func (x *GetTasksReply) Reset() {
 	*x = GetTasksReply{}
-	mi := &file_api_proto_msgTypes[2]
+	mi := &file_api_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +470,7 @@ func (x *GetTasksReply) String() string {
 func (*GetTasksReply) ProtoMessage() {}
 
 func (x *GetTasksReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[2]
+	mi := &file_api_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +483,7 @@ func (x *GetTasksReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTasksReply.ProtoReflect.Descriptor instead.
 func (*GetTasksReply) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetTasksReply) GetTasks() []*Task {
@@ -187,19 +493,24 @@ func (x *GetTasksReply) GetTasks() []*Task {
 	return nil
 }
 
-// AddTask messages (remain the same)
+// AddTaskRequest is the request message for AddTask RPC.
 type AddTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	DueAt         string                 `protobuf:"bytes,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority      string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee      string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project       string                 `protobuf:"bytes,8,opt,name=project,proto3" json:"project,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddTaskRequest) Reset() {
 	*x = AddTaskRequest{}
-	mi := &file_api_proto_msgTypes[3]
+	mi := &file_api_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -211,7 +522,7 @@ func (x *AddTaskRequest) String() string {
 func (*AddTaskRequest) ProtoMessage() {}
 
 func (x *AddTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[3]
+	mi := &file_api_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -224,7 +535,7 @@ func (x *AddTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTaskRequest.ProtoReflect.Descriptor instead.
 func (*AddTaskRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AddTaskRequest) GetTitle() string {
@@ -248,6 +559,42 @@ func (x *AddTaskRequest) GetStatus() string {
 	return ""
 }
 
+func (x *AddTaskRequest) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *AddTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AddTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *AddTaskRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *AddTaskRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+// AddTaskReply is the response message for AddTask RPC.
 type AddTaskReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
@@ -257,7 +604,7 @@ type AddTaskReply struct {
 
 func (x *AddTaskReply) Reset() {
 	*x = AddTaskReply{}
-	mi := &file_api_proto_msgTypes[4]
+	mi := &file_api_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -269,7 +616,7 @@ func (x *AddTaskReply) String() string {
 func (*AddTaskReply) ProtoMessage() {}
 
 func (x *AddTaskReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[4]
+	mi := &file_api_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,7 +629,7 @@ func (x *AddTaskReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTaskReply.ProtoReflect.Descriptor instead.
 func (*AddTaskReply) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AddTaskReply) GetTask() *Task {
@@ -292,7 +639,7 @@ func (x *AddTaskReply) GetTask() *Task {
 	return nil
 }
 
-// New messages for CompleteTask
+// CompleteTaskRequest is the request message for CompleteTask RPC.
 type CompleteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
@@ -302,7 +649,7 @@ type CompleteTaskRequest struct {
 
 func (x *CompleteTaskRequest) Reset() {
 	*x = CompleteTaskRequest{}
-	mi := &file_api_proto_msgTypes[5]
+	mi := &file_api_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -314,7 +661,7 @@ func (x *CompleteTaskRequest) String() string {
 func (*CompleteTaskRequest) ProtoMessage() {}
 
 func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[5]
+	mi := &file_api_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -327,7 +674,7 @@ func (x *CompleteTaskRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteTaskRequest.ProtoReflect.Descriptor instead.
 func (*CompleteTaskRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *CompleteTaskRequest) GetTaskId() string {
@@ -337,16 +684,17 @@ func (x *CompleteTaskRequest) GetTaskId() string {
 	return ""
 }
 
+// CompleteTaskReply is the response message for CompleteTask RPC.
 type CompleteTaskReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"` // Return the updated task
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CompleteTaskReply) Reset() {
 	*x = CompleteTaskReply{}
-	mi := &file_api_proto_msgTypes[6]
+	mi := &file_api_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -358,7 +706,7 @@ func (x *CompleteTaskReply) String() string {
 func (*CompleteTaskReply) ProtoMessage() {}
 
 func (x *CompleteTaskReply) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[6]
+	mi := &file_api_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +719,7 @@ func (x *CompleteTaskReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CompleteTaskReply.ProtoReflect.Descriptor instead.
 func (*CompleteTaskReply) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CompleteTaskReply) GetTask() *Task {
@@ -381,94 +729,5237 @@ func (x *CompleteTaskReply) GetTask() *Task {
 	return nil
 }
 
-var File_api_proto protoreflect.FileDescriptor
-
-const file_api_proto_rawDesc = "" +
-	"\n" +
-	"\tapi.proto\x12\x03api\"\xa4\x01\n" +
-	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"\x11\n" +
-	"\x0fGetTasksRequest\"0\n" +
-	"\rGetTasksReply\x12\x1f\n" +
-	"\x05tasks\x18\x01 \x03(\v2\t.api.TaskR\x05tasks\"`\n" +
-	"\x0eAddTaskRequest\x12\x14\n" +
-	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"-\n" +
-	"\fAddTaskReply\x12\x1d\n" +
-	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\".\n" +
-	"\x13CompleteTaskRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\tR\x06taskId\"2\n" +
-	"\x11CompleteTaskReply\x12\x1d\n" +
-	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task2\xb8\x01\n" +
-	"\vTaskService\x124\n" +
-	"\bGetTasks\x12\x14.api.GetTasksRequest\x1a\x12.api.GetTasksReply\x121\n" +
-	"\aAddTask\x12\x13.api.AddTaskRequest\x1a\x11.api.AddTaskReply\x12@\n" +
-	"\fCompleteTask\x12\x18.api.CompleteTaskRequest\x1a\x16.api.CompleteTaskReplyB\aZ\x05./apib\x06proto3"
-
-var (
-	file_api_proto_rawDescOnce sync.Once
-	file_api_proto_rawDescData []byte
-)
-
-func file_api_proto_rawDescGZIP() []byte {
-	file_api_proto_rawDescOnce.Do(func() {
-		file_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)))
-	})
-	return file_api_proto_rawDescData
+// UpdateTaskRequest is the request message for UpdateTask RPC.
+// Only the fields named in update_mask ("title", "description", "status", "due_at",
+// "tags", "priority", "assignee", "project") are changed; an empty mask updates all of them.
+type UpdateTaskRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TaskId      string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	UpdateMask  *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	DueAt       string                 `protobuf:"bytes,6,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority    string                 `protobuf:"bytes,8,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee    string                 `protobuf:"bytes,9,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project     string                 `protobuf:"bytes,10,opt,name=project,proto3" json:"project,omitempty"`
+	// version, when set, makes the update fail with ABORTED unless the task still
+	// has this version, so that concurrent changes are not overwritten.
+	Version       int32 `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_api_proto_goTypes = []any{
-	(*Task)(nil),                // 0: api.Task
-	(*GetTasksRequest)(nil),     // 1: api.GetTasksRequest
-	(*GetTasksReply)(nil),       // 2: api.GetTasksReply
-	(*AddTaskRequest)(nil),      // 3: api.AddTaskRequest
-	(*AddTaskReply)(nil),        // 4: api.AddTaskReply
-	(*CompleteTaskRequest)(nil), // 5: api.CompleteTaskRequest
-	(*CompleteTaskReply)(nil),   // 6: api.CompleteTaskReply
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
-var file_api_proto_depIdxs = []int32{
-	0, // 0: api.GetTasksReply.tasks:type_name -> api.Task
-	0, // 1: api.AddTaskReply.task:type_name -> api.Task
-	0, // 2: api.CompleteTaskReply.task:type_name -> api.Task
-	1, // 3: api.TaskService.GetTasks:input_type -> api.GetTasksRequest
-	3, // 4: api.TaskService.AddTask:input_type -> api.AddTaskRequest
-	5, // 5: api.TaskService.CompleteTask:input_type -> api.CompleteTaskRequest
-	2, // 6: api.TaskService.GetTasks:output_type -> api.GetTasksReply
-	4, // 7: api.TaskService.AddTask:output_type -> api.AddTaskReply
-	6, // 8: api.TaskService.CompleteTask:output_type -> api.CompleteTaskReply
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func init() { file_api_proto_init() }
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// UpdateTaskReply is the response message for UpdateTask RPC.
+type UpdateTaskReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskReply) Reset() {
+	*x = UpdateTaskReply{}
+	mi := &file_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskReply) ProtoMessage() {}
+
+func (x *UpdateTaskReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskReply.ProtoReflect.Descriptor instead.
+func (*UpdateTaskReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateTaskReply) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// TaskPatch lists the changes UpdateTasksByQuery applies to each matching task.
+// Only the fields named in update_mask ("title", "description", "status", "due_at",
+// "tags", "priority", "assignee", "project") are changed; the mask must not be empty.
+type TaskPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	DueAt         string                 `protobuf:"bytes,4,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Priority      string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	Assignee      string                 `protobuf:"bytes,7,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project       string                 `protobuf:"bytes,8,opt,name=project,proto3" json:"project,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,9,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskPatch) Reset() {
+	*x = TaskPatch{}
+	mi := &file_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskPatch) ProtoMessage() {}
+
+func (x *TaskPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskPatch.ProtoReflect.Descriptor instead.
+func (*TaskPatch) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TaskPatch) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *TaskPatch) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *TaskPatch) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// UpdateTasksByQueryRequest is the request message for UpdateTasksByQuery RPC.
+type UpdateTasksByQueryRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Patch  *TaskPatch             `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
+	// dry_run reports the matching tasks without changing them.
+	DryRun bool `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// sample_size is the number of matching or updated tasks returned; 0 means 10.
+	SampleSize int32 `protobuf:"varint,4,opt,name=sample_size,json=sampleSize,proto3" json:"sample_size,omitempty"`
+	// expected_count, when positive, aborts the update unless exactly that many tasks
+	// match, e.g. the count a preceding dry run reported.
+	ExpectedCount int32 `protobuf:"varint,5,opt,name=expected_count,json=expectedCount,proto3" json:"expected_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTasksByQueryRequest) Reset() {
+	*x = UpdateTasksByQueryRequest{}
+	mi := &file_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTasksByQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTasksByQueryRequest) ProtoMessage() {}
+
+func (x *UpdateTasksByQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTasksByQueryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTasksByQueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateTasksByQueryRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *UpdateTasksByQueryRequest) GetPatch() *TaskPatch {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+func (x *UpdateTasksByQueryRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *UpdateTasksByQueryRequest) GetSampleSize() int32 {
+	if x != nil {
+		return x.SampleSize
+	}
+	return 0
+}
+
+func (x *UpdateTasksByQueryRequest) GetExpectedCount() int32 {
+	if x != nil {
+		return x.ExpectedCount
+	}
+	return 0
+}
+
+// UpdateTasksByQueryReply is the response message for UpdateTasksByQuery RPC.
+type UpdateTasksByQueryReply struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	MatchedCount int32                  `protobuf:"varint,1,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	// tasks holds up to sample_size of the matching tasks for a dry run, and of the
+	// updated tasks otherwise.
+	Tasks  []*Task `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	DryRun bool    `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// task_ids lists the IDs of all updated tasks; it is empty for a dry run.
+	TaskIds       []string `protobuf:"bytes,4,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTasksByQueryReply) Reset() {
+	*x = UpdateTasksByQueryReply{}
+	mi := &file_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTasksByQueryReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTasksByQueryReply) ProtoMessage() {}
+
+func (x *UpdateTasksByQueryReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTasksByQueryReply.ProtoReflect.Descriptor instead.
+func (*UpdateTasksByQueryReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateTasksByQueryReply) GetMatchedCount() int32 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+func (x *UpdateTasksByQueryReply) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *UpdateTasksByQueryReply) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *UpdateTasksByQueryReply) GetTaskIds() []string {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+// SearchTasksRequest is the request message for SearchTasks RPC.
+type SearchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// limit is the maximum number of tasks returned; 0 means 50, at most 1000.
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// time_zone is the IANA time zone in which dates of the query such as 2026-11-01
+	// or today are resolved; empty means UTC.
+	TimeZone      string `protobuf:"bytes,3,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksRequest) Reset() {
+	*x = SearchTasksRequest{}
+	mi := &file_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksRequest) ProtoMessage() {}
+
+func (x *SearchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchTasksRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchTasksRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// SearchTasksReply is the response message for SearchTasks RPC.
+type SearchTasksReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// tasks are the matching tasks, newest first.
+	Tasks []*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// truncated is set when more tasks match than the limit allowed to return.
+	Truncated     bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTasksReply) Reset() {
+	*x = SearchTasksReply{}
+	mi := &file_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTasksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTasksReply) ProtoMessage() {}
+
+func (x *SearchTasksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTasksReply.ProtoReflect.Descriptor instead.
+func (*SearchTasksReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchTasksReply) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *SearchTasksReply) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// FullTextSearchRequest is the request message for FullTextSearch RPC.
+type FullTextSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// limit is the maximum number of hits returned; 0 means 20, at most 200.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FullTextSearchRequest) Reset() {
+	*x = FullTextSearchRequest{}
+	mi := &file_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FullTextSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FullTextSearchRequest) ProtoMessage() {}
+
+func (x *FullTextSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FullTextSearchRequest.ProtoReflect.Descriptor instead.
+func (*FullTextSearchRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FullTextSearchRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FullTextSearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// FullTextSearchReply is the response message for FullTextSearch RPC.
+type FullTextSearchReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hits are the matching tasks, best match first.
+	Hits          []*SearchHit `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FullTextSearchReply) Reset() {
+	*x = FullTextSearchReply{}
+	mi := &file_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FullTextSearchReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FullTextSearchReply) ProtoMessage() {}
+
+func (x *FullTextSearchReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FullTextSearchReply.ProtoReflect.Descriptor instead.
+func (*FullTextSearchReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FullTextSearchReply) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// SearchHit is a task found by FullTextSearch.
+type SearchHit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// score is the relevance of the task; it is only comparable within one reply.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// highlights holds a snippet for each field that matched.
+	Highlights    []*Highlight `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchHit) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetHighlights() []*Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// Highlight is a snippet of a task field with the matched words marked.
+type Highlight struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is title or description.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// fragment is the field value, or for long values an excerpt around the first match.
+	Fragment string `protobuf:"bytes,2,opt,name=fragment,proto3" json:"fragment,omitempty"`
+	// matches are the byte ranges of the matched words in fragment.
+	Matches       []*TextRange `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Highlight) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Highlight) GetFragment() string {
+	if x != nil {
+		return x.Fragment
+	}
+	return ""
+}
+
+func (x *Highlight) GetMatches() []*TextRange {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// TextRange is the half-open byte range [start, end) of a string.
+type TextRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextRange) Reset() {
+	*x = TextRange{}
+	mi := &file_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextRange) ProtoMessage() {}
+
+func (x *TextRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextRange.ProtoReflect.Descriptor instead.
+func (*TextRange) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TextRange) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TextRange) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// Attachment is a file attached to a task, e.g. from an email the task was created from.
+type Attachment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId      string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Filename    string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// size is the length of the content in bytes.
+	Size      int64  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	CreatedAt string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// content is only set by GetAttachment.
+	Content       []byte `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Attachment) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+// ListAttachmentsRequest is the request message for ListAttachments RPC.
+type ListAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsRequest) ProtoMessage() {}
+
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAttachmentsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+// ListAttachmentsReply is the response message for ListAttachments RPC.
+type ListAttachmentsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsReply) Reset() {
+	*x = ListAttachmentsReply{}
+	mi := &file_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsReply) ProtoMessage() {}
+
+func (x *ListAttachmentsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsReply.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAttachmentsReply) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// GetAttachmentRequest is the request message for GetAttachment RPC.
+type GetAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentRequest) Reset() {
+	*x = GetAttachmentRequest{}
+	mi := &file_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetAttachmentRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *GetAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+// GetAttachmentReply is the response message for GetAttachment RPC.
+type GetAttachmentReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentReply) Reset() {
+	*x = GetAttachmentReply{}
+	mi := &file_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentReply) ProtoMessage() {}
+
+func (x *GetAttachmentReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentReply.ProtoReflect.Descriptor instead.
+func (*GetAttachmentReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetAttachmentReply) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+// DeleteTaskRequest identifies a task to delete within a BatchMutate call.
+type DeleteTaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// version, when set, makes the deletion fail with ABORTED unless the task still
+	// has this version.
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteTaskRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Mutation is a single operation of a BatchMutate call.
+type Mutation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Operation:
+	//
+	//	*Mutation_Add
+	//	*Mutation_Update
+	//	*Mutation_Complete
+	//	*Mutation_Delete
+	Operation     isMutation_Operation `protobuf_oneof:"operation"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Mutation) Reset() {
+	*x = Mutation{}
+	mi := &file_api_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mutation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mutation) ProtoMessage() {}
+
+func (x *Mutation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mutation.ProtoReflect.Descriptor instead.
+func (*Mutation) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Mutation) GetOperation() isMutation_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return nil
+}
+
+func (x *Mutation) GetAdd() *AddTaskRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*Mutation_Add); ok {
+			return x.Add
+		}
+	}
+	return nil
+}
+
+func (x *Mutation) GetUpdate() *UpdateTaskRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*Mutation_Update); ok {
+			return x.Update
+		}
+	}
+	return nil
+}
+
+func (x *Mutation) GetComplete() *CompleteTaskRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*Mutation_Complete); ok {
+			return x.Complete
+		}
+	}
+	return nil
+}
+
+func (x *Mutation) GetDelete() *DeleteTaskRequest {
+	if x != nil {
+		if x, ok := x.Operation.(*Mutation_Delete); ok {
+			return x.Delete
+		}
+	}
+	return nil
+}
+
+type isMutation_Operation interface {
+	isMutation_Operation()
+}
+
+type Mutation_Add struct {
+	Add *AddTaskRequest `protobuf:"bytes,1,opt,name=add,proto3,oneof"`
+}
+
+type Mutation_Update struct {
+	Update *UpdateTaskRequest `protobuf:"bytes,2,opt,name=update,proto3,oneof"`
+}
+
+type Mutation_Complete struct {
+	Complete *CompleteTaskRequest `protobuf:"bytes,3,opt,name=complete,proto3,oneof"`
+}
+
+type Mutation_Delete struct {
+	Delete *DeleteTaskRequest `protobuf:"bytes,4,opt,name=delete,proto3,oneof"`
+}
+
+func (*Mutation_Add) isMutation_Operation() {}
+
+func (*Mutation_Update) isMutation_Operation() {}
+
+func (*Mutation_Complete) isMutation_Operation() {}
+
+func (*Mutation_Delete) isMutation_Operation() {}
+
+// BatchMutateRequest is the request message for BatchMutate RPC.
+type BatchMutateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mutations     []*Mutation            `protobuf:"bytes,1,rep,name=mutations,proto3" json:"mutations,omitempty"`
+	Mode          BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=api.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchMutateRequest) Reset() {
+	*x = BatchMutateRequest{}
+	mi := &file_api_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchMutateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMutateRequest) ProtoMessage() {}
+
+func (x *BatchMutateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMutateRequest.ProtoReflect.Descriptor instead.
+func (*BatchMutateRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BatchMutateRequest) GetMutations() []*Mutation {
+	if x != nil {
+		return x.Mutations
+	}
+	return nil
+}
+
+func (x *BatchMutateRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_UNSPECIFIED
+}
+
+// MutationResult is the outcome of one mutation, in the order of the request.
+// code, message and details have the meaning of the fields of google.rpc.Status;
+// code 0 (OK) means the mutation was applied.
+type MutationResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Index   int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Code    int32                  `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Details []*anypb.Any           `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty"`
+	// task is the added, updated, completed or deleted task when the mutation succeeded.
+	Task          *Task `protobuf:"bytes,5,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MutationResult) Reset() {
+	*x = MutationResult{}
+	mi := &file_api_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MutationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MutationResult) ProtoMessage() {}
+
+func (x *MutationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MutationResult.ProtoReflect.Descriptor instead.
+func (*MutationResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MutationResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *MutationResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *MutationResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MutationResult) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *MutationResult) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// BatchMutateReply is the response message for BatchMutate RPC.
+type BatchMutateReply struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*MutationResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// committed is false when an atomic batch was rolled back.
+	Committed     bool `protobuf:"varint,2,opt,name=committed,proto3" json:"committed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchMutateReply) Reset() {
+	*x = BatchMutateReply{}
+	mi := &file_api_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchMutateReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchMutateReply) ProtoMessage() {}
+
+func (x *BatchMutateReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchMutateReply.ProtoReflect.Descriptor instead.
+func (*BatchMutateReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BatchMutateReply) GetResults() []*MutationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchMutateReply) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// ExportTasksRequest is the request message for ExportTasks RPC.
+type ExportTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TaskFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksRequest) Reset() {
+	*x = ExportTasksRequest{}
+	mi := &file_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksRequest) ProtoMessage() {}
+
+func (x *ExportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ExportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportTasksRequest) GetFilter() *TaskFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// ExportTasksReply is one message of the ExportTasks stream.
+type ExportTasksReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportTasksReply) Reset() {
+	*x = ExportTasksReply{}
+	mi := &file_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportTasksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportTasksReply) ProtoMessage() {}
+
+func (x *ExportTasksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportTasksReply.ProtoReflect.Descriptor instead.
+func (*ExportTasksReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportTasksReply) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// ImportTasksRequest is one message of the ImportTasks stream. The options of the
+// first message apply to the whole import.
+type ImportTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*ImportTask          `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// dry_run reports what the import would do without adding any task.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// allow_duplicates adds tasks even if they duplicate an existing or earlier task.
+	AllowDuplicates bool `protobuf:"varint,3,opt,name=allow_duplicates,json=allowDuplicates,proto3" json:"allow_duplicates,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportTasksRequest) Reset() {
+	*x = ImportTasksRequest{}
+	mi := &file_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksRequest) ProtoMessage() {}
+
+func (x *ImportTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksRequest.ProtoReflect.Descriptor instead.
+func (*ImportTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportTasksRequest) GetTasks() []*ImportTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ImportTasksRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportTasksRequest) GetAllowDuplicates() bool {
+	if x != nil {
+		return x.AllowDuplicates
+	}
+	return false
+}
+
+// ImportTask is a task to import. The id, updated_at and version of the task are
+// ignored; created_at and completed_at are kept when set.
+type ImportTask struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// line is the line of the source file the task was read from; it identifies the
+	// task in the results.
+	Line          int32 `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportTask) Reset() {
+	*x = ImportTask{}
+	mi := &file_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTask) ProtoMessage() {}
+
+func (x *ImportTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTask.ProtoReflect.Descriptor instead.
+func (*ImportTask) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ImportTask) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *ImportTask) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+// ImportResult is the outcome of one imported task, in the order of the stream.
+// code, message and details have the meaning of the fields of google.rpc.Status.
+type ImportResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Line    int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Outcome ImportOutcome          `protobuf:"varint,2,opt,name=outcome,proto3,enum=api.ImportOutcome" json:"outcome,omitempty"`
+	// task_id is the ID of the added task, or of the existing task a duplicate matches.
+	TaskId        string       `protobuf:"bytes,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Code          int32        `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string       `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	Details       []*anypb.Any `protobuf:"bytes,6,rep,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ImportResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportResult) GetOutcome() ImportOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return ImportOutcome_IMPORT_OUTCOME_UNSPECIFIED
+}
+
+func (x *ImportResult) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *ImportResult) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ImportResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportResult) GetDetails() []*anypb.Any {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// ImportTasksReply is the response message for ImportTasks RPC.
+type ImportTasksReply struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Results        []*ImportResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	CreatedCount   int32                  `protobuf:"varint,2,opt,name=created_count,json=createdCount,proto3" json:"created_count,omitempty"`
+	DuplicateCount int32                  `protobuf:"varint,3,opt,name=duplicate_count,json=duplicateCount,proto3" json:"duplicate_count,omitempty"`
+	FailedCount    int32                  `protobuf:"varint,4,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	DryRun         bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportTasksReply) Reset() {
+	*x = ImportTasksReply{}
+	mi := &file_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportTasksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportTasksReply) ProtoMessage() {}
+
+func (x *ImportTasksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportTasksReply.ProtoReflect.Descriptor instead.
+func (*ImportTasksReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ImportTasksReply) GetResults() []*ImportResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportTasksReply) GetCreatedCount() int32 {
+	if x != nil {
+		return x.CreatedCount
+	}
+	return 0
+}
+
+func (x *ImportTasksReply) GetDuplicateCount() int32 {
+	if x != nil {
+		return x.DuplicateCount
+	}
+	return 0
+}
+
+func (x *ImportTasksReply) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *ImportTasksReply) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// Workspace represents a tenant whose data is isolated from every other workspace.
+type Workspace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Workspace) Reset() {
+	*x = Workspace{}
+	mi := &file_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Workspace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Workspace) ProtoMessage() {}
+
+func (x *Workspace) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Workspace.ProtoReflect.Descriptor instead.
+func (*Workspace) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Workspace) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Workspace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Workspace) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Workspace) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Workspace) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// CreateWorkspaceRequest is the request message for CreateWorkspace RPC.
+type CreateWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceRequest) Reset() {
+	*x = CreateWorkspaceRequest{}
+	mi := &file_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceRequest) ProtoMessage() {}
+
+func (x *CreateWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateWorkspaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// CreateWorkspaceReply is the response message for CreateWorkspace RPC.
+// The token is only returned once and is not stored in plain text.
+type CreateWorkspaceReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWorkspaceReply) Reset() {
+	*x = CreateWorkspaceReply{}
+	mi := &file_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkspaceReply) ProtoMessage() {}
+
+func (x *CreateWorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkspaceReply.ProtoReflect.Descriptor instead.
+func (*CreateWorkspaceReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateWorkspaceReply) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *CreateWorkspaceReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// SuspendWorkspaceRequest is the request message for SuspendWorkspace RPC.
+type SuspendWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendWorkspaceRequest) Reset() {
+	*x = SuspendWorkspaceRequest{}
+	mi := &file_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendWorkspaceRequest) ProtoMessage() {}
+
+func (x *SuspendWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*SuspendWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SuspendWorkspaceRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// SuspendWorkspaceReply is the response message for SuspendWorkspace RPC.
+type SuspendWorkspaceReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendWorkspaceReply) Reset() {
+	*x = SuspendWorkspaceReply{}
+	mi := &file_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendWorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendWorkspaceReply) ProtoMessage() {}
+
+func (x *SuspendWorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendWorkspaceReply.ProtoReflect.Descriptor instead.
+func (*SuspendWorkspaceReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SuspendWorkspaceReply) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+// ExportWorkspaceRequest is the request message for ExportWorkspace RPC.
+type ExportWorkspaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkspaceId   string                 `protobuf:"bytes,1,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportWorkspaceRequest) Reset() {
+	*x = ExportWorkspaceRequest{}
+	mi := &file_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportWorkspaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportWorkspaceRequest) ProtoMessage() {}
+
+func (x *ExportWorkspaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportWorkspaceRequest.ProtoReflect.Descriptor instead.
+func (*ExportWorkspaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExportWorkspaceRequest) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+// ExportWorkspaceReply is the response message for ExportWorkspace RPC.
+type ExportWorkspaceReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Workspace     *Workspace             `protobuf:"bytes,1,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	Tasks         []*Task                `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportWorkspaceReply) Reset() {
+	*x = ExportWorkspaceReply{}
+	mi := &file_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportWorkspaceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportWorkspaceReply) ProtoMessage() {}
+
+func (x *ExportWorkspaceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportWorkspaceReply.ProtoReflect.Descriptor instead.
+func (*ExportWorkspaceReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExportWorkspaceReply) GetWorkspace() *Workspace {
+	if x != nil {
+		return x.Workspace
+	}
+	return nil
+}
+
+func (x *ExportWorkspaceReply) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// SetLogLevelRequest is the request message for SetLogLevel RPC.
+// An empty logger changes the default level; otherwise only the named package logger
+// (e.g. "repository", "server") is changed.
+// An empty level or "default" removes the level of the named package logger, which
+// then follows the default level again.
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	Logger        string                 `protobuf:"bytes,2,opt,name=logger,proto3" json:"logger,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_api_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SetLogLevelRequest) GetLogger() string {
+	if x != nil {
+		return x.Logger
+	}
+	return ""
+}
+
+// SetLogLevelReply is the response message for SetLogLevel RPC.
+// It lists the effective levels after the change; the default level is keyed by "".
+type SetLogLevelReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Levels        map[string]string      `protobuf:"bytes,1,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelReply) Reset() {
+	*x = SetLogLevelReply{}
+	mi := &file_api_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelReply) ProtoMessage() {}
+
+func (x *SetLogLevelReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelReply.ProtoReflect.Descriptor instead.
+func (*SetLogLevelReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SetLogLevelReply) GetLevels() map[string]string {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+// RebuildSearchIndexRequest is the request message for RebuildSearchIndex RPC.
+type RebuildSearchIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildSearchIndexRequest) Reset() {
+	*x = RebuildSearchIndexRequest{}
+	mi := &file_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildSearchIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildSearchIndexRequest) ProtoMessage() {}
+
+func (x *RebuildSearchIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildSearchIndexRequest.ProtoReflect.Descriptor instead.
+func (*RebuildSearchIndexRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{45}
+}
+
+// RebuildSearchIndexReply is the response message for RebuildSearchIndex RPC.
+type RebuildSearchIndexReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// backend is the configured index, mysql or bleve.
+	Backend string `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	// indexed_tasks is the number of tasks in the rebuilt index.
+	IndexedTasks int64 `protobuf:"varint,2,opt,name=indexed_tasks,json=indexedTasks,proto3" json:"indexed_tasks,omitempty"`
+	// duration_ms is the time the rebuild took, in milliseconds.
+	DurationMs    int64 `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildSearchIndexReply) Reset() {
+	*x = RebuildSearchIndexReply{}
+	mi := &file_api_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildSearchIndexReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildSearchIndexReply) ProtoMessage() {}
+
+func (x *RebuildSearchIndexReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildSearchIndexReply.ProtoReflect.Descriptor instead.
+func (*RebuildSearchIndexReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RebuildSearchIndexReply) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *RebuildSearchIndexReply) GetIndexedTasks() int64 {
+	if x != nil {
+		return x.IndexedTasks
+	}
+	return 0
+}
+
+func (x *RebuildSearchIndexReply) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// Webhook is a subscription of a URL to task events.
+type Webhook struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// event_types holds the subscribed events, e.g. task.created; empty means all events.
+	EventTypes    []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	CreatedAt     string   `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_api_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *Webhook) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// CreateWebhookRequest is the request message for CreateWebhook RPC.
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// url must be an http or https URL.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// event_types are task.created, task.updated, task.completed or task.deleted; empty subscribes to all.
+	EventTypes    []string `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_api_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookRequest) ProtoMessage() {}
+
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+// CreateWebhookReply is the response message for CreateWebhook RPC.
+// The secret signs every delivery and is only returned here.
+type CreateWebhookReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookReply) Reset() {
+	*x = CreateWebhookReply{}
+	mi := &file_api_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookReply) ProtoMessage() {}
+
+func (x *CreateWebhookReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookReply.ProtoReflect.Descriptor instead.
+func (*CreateWebhookReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateWebhookReply) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *CreateWebhookReply) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+// ListWebhooksRequest is the request message for ListWebhooks RPC.
+type ListWebhooksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_api_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{50}
+}
+
+// ListWebhooksReply is the response message for ListWebhooks RPC.
+type ListWebhooksReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksReply) Reset() {
+	*x = ListWebhooksReply{}
+	mi := &file_api_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksReply) ProtoMessage() {}
+
+func (x *ListWebhooksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksReply.ProtoReflect.Descriptor instead.
+func (*ListWebhooksReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListWebhooksReply) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+// DeleteWebhookRequest is the request message for DeleteWebhook RPC.
+type DeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_api_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+// DeleteWebhookReply is the response message for DeleteWebhook RPC.
+type DeleteWebhookReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookReply) Reset() {
+	*x = DeleteWebhookReply{}
+	mi := &file_api_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookReply) ProtoMessage() {}
+
+func (x *DeleteWebhookReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookReply.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DeleteWebhookReply) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+// DeadLetter is a delivery of one event to one webhook that failed on every attempt.
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     string                 `protobuf:"bytes,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	EventId       string                 `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Attempts      int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,7,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	FailedAt      string                 `protobuf:"bytes,8,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_api_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetFailedAt() string {
+	if x != nil {
+		return x.FailedAt
+	}
+	return ""
+}
+
+// ListDeadLettersRequest is the request message for ListDeadLetters RPC.
+type ListDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// webhook_id limits the list to one webhook when set.
+	WebhookId     string `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_api_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListDeadLettersRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+// ListDeadLettersReply is the response message for ListDeadLetters RPC.
+type ListDeadLettersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersReply) Reset() {
+	*x = ListDeadLettersReply{}
+	mi := &file_api_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersReply) ProtoMessage() {}
+
+func (x *ListDeadLettersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersReply.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListDeadLettersReply) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+// RedeliverWebhookRequest is the request message for RedeliverWebhook RPC.
+// Either dead_letter_ids or all must be set.
+type RedeliverWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterIds []string               `protobuf:"bytes,1,rep,name=dead_letter_ids,json=deadLetterIds,proto3" json:"dead_letter_ids,omitempty"`
+	// all queues every dead letter of the workspace, or of webhook_id when set.
+	All           bool   `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
+	WebhookId     string `protobuf:"bytes,3,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookRequest) Reset() {
+	*x = RedeliverWebhookRequest{}
+	mi := &file_api_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookRequest) ProtoMessage() {}
+
+func (x *RedeliverWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RedeliverWebhookRequest) GetDeadLetterIds() []string {
+	if x != nil {
+		return x.DeadLetterIds
+	}
+	return nil
+}
+
+func (x *RedeliverWebhookRequest) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *RedeliverWebhookRequest) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+// RedeliverWebhookReply is the response message for RedeliverWebhook RPC.
+type RedeliverWebhookReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// requeued is the number of dead letters moved back to the delivery queue.
+	Requeued      int32 `protobuf:"varint,1,opt,name=requeued,proto3" json:"requeued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeliverWebhookReply) Reset() {
+	*x = RedeliverWebhookReply{}
+	mi := &file_api_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeliverWebhookReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeliverWebhookReply) ProtoMessage() {}
+
+func (x *RedeliverWebhookReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeliverWebhookReply.ProtoReflect.Descriptor instead.
+func (*RedeliverWebhookReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *RedeliverWebhookReply) GetRequeued() int32 {
+	if x != nil {
+		return x.Requeued
+	}
+	return 0
+}
+
+// TaskEvent is a domain event about a task. Events are written to the outbox in the
+// transaction of the change they describe and published on the event bus from there.
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ID of the event in the outbox; it increases with every event and is
+	// the same on every delivery of the event.
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkspaceId string `protobuf:"bytes,2,opt,name=workspace_id,json=workspaceId,proto3" json:"workspace_id,omitempty"`
+	// occurred_at is the commit time of the change, in RFC 3339 format with milliseconds.
+	OccurredAt string `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*TaskEvent_TaskCreated
+	//	*TaskEvent_TaskUpdated
+	//	*TaskEvent_TaskCompleted
+	//	*TaskEvent_TaskDeleted
+	Event         isTaskEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_api_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TaskEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetWorkspaceId() string {
+	if x != nil {
+		return x.WorkspaceId
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetEvent() isTaskEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetTaskCreated() *TaskCreated {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_TaskCreated); ok {
+			return x.TaskCreated
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetTaskUpdated() *TaskUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_TaskUpdated); ok {
+			return x.TaskUpdated
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetTaskCompleted() *TaskCompleted {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_TaskCompleted); ok {
+			return x.TaskCompleted
+		}
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetTaskDeleted() *TaskDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*TaskEvent_TaskDeleted); ok {
+			return x.TaskDeleted
+		}
+	}
+	return nil
+}
+
+type isTaskEvent_Event interface {
+	isTaskEvent_Event()
+}
+
+type TaskEvent_TaskCreated struct {
+	TaskCreated *TaskCreated `protobuf:"bytes,4,opt,name=task_created,json=taskCreated,proto3,oneof"`
+}
+
+type TaskEvent_TaskUpdated struct {
+	TaskUpdated *TaskUpdated `protobuf:"bytes,5,opt,name=task_updated,json=taskUpdated,proto3,oneof"`
+}
+
+type TaskEvent_TaskCompleted struct {
+	TaskCompleted *TaskCompleted `protobuf:"bytes,6,opt,name=task_completed,json=taskCompleted,proto3,oneof"`
+}
+
+type TaskEvent_TaskDeleted struct {
+	TaskDeleted *TaskDeleted `protobuf:"bytes,7,opt,name=task_deleted,json=taskDeleted,proto3,oneof"`
+}
+
+func (*TaskEvent_TaskCreated) isTaskEvent_Event() {}
+
+func (*TaskEvent_TaskUpdated) isTaskEvent_Event() {}
+
+func (*TaskEvent_TaskCompleted) isTaskEvent_Event() {}
+
+func (*TaskEvent_TaskDeleted) isTaskEvent_Event() {}
+
+// TaskCreated is published when a task is added.
+type TaskCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskCreated) Reset() {
+	*x = TaskCreated{}
+	mi := &file_api_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskCreated) ProtoMessage() {}
+
+func (x *TaskCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskCreated.ProtoReflect.Descriptor instead.
+func (*TaskCreated) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *TaskCreated) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// TaskUpdated is published when fields of a task change, except for a change of the
+// status to completed, which is published as TaskCompleted.
+type TaskUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Task  *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// previous is the task before the update.
+	Previous *Task `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+	// changed_fields names the fields that differ between previous and task, e.g. title or tags.
+	ChangedFields []string `protobuf:"bytes,3,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskUpdated) Reset() {
+	*x = TaskUpdated{}
+	mi := &file_api_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskUpdated) ProtoMessage() {}
+
+func (x *TaskUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskUpdated.ProtoReflect.Descriptor instead.
+func (*TaskUpdated) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *TaskUpdated) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskUpdated) GetPrevious() *Task {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *TaskUpdated) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+// TaskCompleted is published when the status of a task changes to completed.
+type TaskCompleted struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Task           *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,2,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TaskCompleted) Reset() {
+	*x = TaskCompleted{}
+	mi := &file_api_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskCompleted) ProtoMessage() {}
+
+func (x *TaskCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskCompleted.ProtoReflect.Descriptor instead.
+func (*TaskCompleted) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TaskCompleted) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskCompleted) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+// TaskDeleted is published when a task is deleted; task is its last state.
+type TaskDeleted struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskDeleted) Reset() {
+	*x = TaskDeleted{}
+	mi := &file_api_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDeleted) ProtoMessage() {}
+
+func (x *TaskDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDeleted.ProtoReflect.Descriptor instead.
+func (*TaskDeleted) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *TaskDeleted) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// NotificationPreferences holds where and which emails an assignee receives.
+type NotificationPreferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// assignee is matched against Task.assignee.
+	Assignee string `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Email    string `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// reminders enables an email ahead of the due date of each open task.
+	Reminders bool `protobuf:"varint,3,opt,name=reminders,proto3" json:"reminders,omitempty"`
+	// digest enables a daily email listing the open and overdue tasks.
+	Digest bool `protobuf:"varint,4,opt,name=digest,proto3" json:"digest,omitempty"`
+	// time_zone is the IANA time zone of the digest schedule and of the dates in
+	// emails; empty means UTC.
+	TimeZone      string `protobuf:"bytes,5,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotificationPreferences) Reset() {
+	*x = NotificationPreferences{}
+	mi := &file_api_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotificationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationPreferences) ProtoMessage() {}
+
+func (x *NotificationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationPreferences.ProtoReflect.Descriptor instead.
+func (*NotificationPreferences) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *NotificationPreferences) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NotificationPreferences) GetReminders() bool {
+	if x != nil {
+		return x.Reminders
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetDigest() bool {
+	if x != nil {
+		return x.Digest
+	}
+	return false
+}
+
+func (x *NotificationPreferences) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+// SetNotificationPreferencesRequest is the request message for SetNotificationPreferences RPC.
+type SetNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationPreferencesRequest) Reset() {
+	*x = SetNotificationPreferencesRequest{}
+	mi := &file_api_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *SetNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*SetNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SetNotificationPreferencesRequest) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// SetNotificationPreferencesReply is the response message for SetNotificationPreferences RPC.
+type SetNotificationPreferencesReply struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetNotificationPreferencesReply) Reset() {
+	*x = SetNotificationPreferencesReply{}
+	mi := &file_api_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetNotificationPreferencesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetNotificationPreferencesReply) ProtoMessage() {}
+
+func (x *SetNotificationPreferencesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetNotificationPreferencesReply.ProtoReflect.Descriptor instead.
+func (*SetNotificationPreferencesReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SetNotificationPreferencesReply) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// ListNotificationPreferencesRequest is the request message for ListNotificationPreferences RPC.
+type ListNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationPreferencesRequest) Reset() {
+	*x = ListNotificationPreferencesRequest{}
+	mi := &file_api_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *ListNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{67}
+}
+
+// ListNotificationPreferencesReply is the response message for ListNotificationPreferences RPC.
+type ListNotificationPreferencesReply struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Preferences   []*NotificationPreferences `protobuf:"bytes,1,rep,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationPreferencesReply) Reset() {
+	*x = ListNotificationPreferencesReply{}
+	mi := &file_api_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationPreferencesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationPreferencesReply) ProtoMessage() {}
+
+func (x *ListNotificationPreferencesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationPreferencesReply.ProtoReflect.Descriptor instead.
+func (*ListNotificationPreferencesReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListNotificationPreferencesReply) GetPreferences() []*NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// DeleteNotificationPreferencesRequest is the request message for DeleteNotificationPreferences RPC.
+type DeleteNotificationPreferencesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      string                 `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationPreferencesRequest) Reset() {
+	*x = DeleteNotificationPreferencesRequest{}
+	mi := &file_api_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationPreferencesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationPreferencesRequest) ProtoMessage() {}
+
+func (x *DeleteNotificationPreferencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationPreferencesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationPreferencesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DeleteNotificationPreferencesRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+// DeleteNotificationPreferencesReply is the response message for DeleteNotificationPreferences RPC.
+type DeleteNotificationPreferencesReply struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Preferences   *NotificationPreferences `protobuf:"bytes,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNotificationPreferencesReply) Reset() {
+	*x = DeleteNotificationPreferencesReply{}
+	mi := &file_api_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNotificationPreferencesReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNotificationPreferencesReply) ProtoMessage() {}
+
+func (x *DeleteNotificationPreferencesReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNotificationPreferencesReply.ProtoReflect.Descriptor instead.
+func (*DeleteNotificationPreferencesReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *DeleteNotificationPreferencesReply) GetPreferences() *NotificationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+// SendDigestRequest is the request message for SendDigest RPC.
+type SendDigestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      string                 `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendDigestRequest) Reset() {
+	*x = SendDigestRequest{}
+	mi := &file_api_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendDigestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDigestRequest) ProtoMessage() {}
+
+func (x *SendDigestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDigestRequest.ProtoReflect.Descriptor instead.
+func (*SendDigestRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *SendDigestRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+// SendDigestReply is the response message for SendDigest RPC.
+type SendDigestReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	OpenTasks     int32                  `protobuf:"varint,2,opt,name=open_tasks,json=openTasks,proto3" json:"open_tasks,omitempty"`
+	OverdueTasks  int32                  `protobuf:"varint,3,opt,name=overdue_tasks,json=overdueTasks,proto3" json:"overdue_tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendDigestReply) Reset() {
+	*x = SendDigestReply{}
+	mi := &file_api_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendDigestReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendDigestReply) ProtoMessage() {}
+
+func (x *SendDigestReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendDigestReply.ProtoReflect.Descriptor instead.
+func (*SendDigestReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SendDigestReply) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SendDigestReply) GetOpenTasks() int32 {
+	if x != nil {
+		return x.OpenTasks
+	}
+	return 0
+}
+
+func (x *SendDigestReply) GetOverdueTasks() int32 {
+	if x != nil {
+		return x.OverdueTasks
+	}
+	return 0
+}
+
+// InboundSender is an email address allowed to create tasks.
+type InboundSender struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// email is compared without regard to case.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// assignee is set as the assignee of the tasks created from the sender's emails.
+	Assignee      string `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	CreatedAt     string `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InboundSender) Reset() {
+	*x = InboundSender{}
+	mi := &file_api_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InboundSender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InboundSender) ProtoMessage() {}
+
+func (x *InboundSender) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InboundSender.ProtoReflect.Descriptor instead.
+func (*InboundSender) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *InboundSender) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *InboundSender) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *InboundSender) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// AddInboundSenderRequest is the request message for AddInboundSender RPC.
+type AddInboundSenderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Assignee      string                 `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddInboundSenderRequest) Reset() {
+	*x = AddInboundSenderRequest{}
+	mi := &file_api_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddInboundSenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddInboundSenderRequest) ProtoMessage() {}
+
+func (x *AddInboundSenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddInboundSenderRequest.ProtoReflect.Descriptor instead.
+func (*AddInboundSenderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *AddInboundSenderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AddInboundSenderRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+// AddInboundSenderReply is the response message for AddInboundSender RPC.
+type AddInboundSenderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        *InboundSender         `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddInboundSenderReply) Reset() {
+	*x = AddInboundSenderReply{}
+	mi := &file_api_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddInboundSenderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddInboundSenderReply) ProtoMessage() {}
+
+func (x *AddInboundSenderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddInboundSenderReply.ProtoReflect.Descriptor instead.
+func (*AddInboundSenderReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *AddInboundSenderReply) GetSender() *InboundSender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+// ListInboundSendersRequest is the request message for ListInboundSenders RPC.
+type ListInboundSendersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboundSendersRequest) Reset() {
+	*x = ListInboundSendersRequest{}
+	mi := &file_api_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboundSendersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboundSendersRequest) ProtoMessage() {}
+
+func (x *ListInboundSendersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboundSendersRequest.ProtoReflect.Descriptor instead.
+func (*ListInboundSendersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{76}
+}
+
+// ListInboundSendersReply is the response message for ListInboundSenders RPC.
+type ListInboundSendersReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Senders       []*InboundSender       `protobuf:"bytes,1,rep,name=senders,proto3" json:"senders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInboundSendersReply) Reset() {
+	*x = ListInboundSendersReply{}
+	mi := &file_api_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInboundSendersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInboundSendersReply) ProtoMessage() {}
+
+func (x *ListInboundSendersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInboundSendersReply.ProtoReflect.Descriptor instead.
+func (*ListInboundSendersReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListInboundSendersReply) GetSenders() []*InboundSender {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+// RemoveInboundSenderRequest is the request message for RemoveInboundSender RPC.
+type RemoveInboundSenderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveInboundSenderRequest) Reset() {
+	*x = RemoveInboundSenderRequest{}
+	mi := &file_api_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveInboundSenderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveInboundSenderRequest) ProtoMessage() {}
+
+func (x *RemoveInboundSenderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveInboundSenderRequest.ProtoReflect.Descriptor instead.
+func (*RemoveInboundSenderRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RemoveInboundSenderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// RemoveInboundSenderReply is the response message for RemoveInboundSender RPC.
+type RemoveInboundSenderReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        *InboundSender         `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveInboundSenderReply) Reset() {
+	*x = RemoveInboundSenderReply{}
+	mi := &file_api_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveInboundSenderReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveInboundSenderReply) ProtoMessage() {}
+
+func (x *RemoveInboundSenderReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveInboundSenderReply.ProtoReflect.Descriptor instead.
+func (*RemoveInboundSenderReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RemoveInboundSenderReply) GetSender() *InboundSender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+// CalendarFeed is an iCalendar feed of the tasks of an assignee or a project.
+// When both are set, the feed lists the tasks that match both.
+type CalendarFeed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Assignee      string                 `protobuf:"bytes,2,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project       string                 `protobuf:"bytes,3,opt,name=project,proto3" json:"project,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarFeed) Reset() {
+	*x = CalendarFeed{}
+	mi := &file_api_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarFeed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarFeed) ProtoMessage() {}
+
+func (x *CalendarFeed) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarFeed.ProtoReflect.Descriptor instead.
+func (*CalendarFeed) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *CalendarFeed) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CalendarFeed) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CalendarFeed) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *CalendarFeed) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// CreateCalendarFeedRequest is the request message for CreateCalendarFeed RPC.
+// At least one of assignee and project must be set.
+type CreateCalendarFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignee      string                 `protobuf:"bytes,1,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Project       string                 `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCalendarFeedRequest) Reset() {
+	*x = CreateCalendarFeedRequest{}
+	mi := &file_api_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCalendarFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCalendarFeedRequest) ProtoMessage() {}
+
+func (x *CreateCalendarFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCalendarFeedRequest.ProtoReflect.Descriptor instead.
+func (*CreateCalendarFeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *CreateCalendarFeedRequest) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *CreateCalendarFeedRequest) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+// CreateCalendarFeedReply is the response message for CreateCalendarFeed RPC.
+type CreateCalendarFeedReply struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Feed  *CalendarFeed          `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	// url is the secret address of the feed, for subscribing in a calendar app.
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCalendarFeedReply) Reset() {
+	*x = CreateCalendarFeedReply{}
+	mi := &file_api_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCalendarFeedReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCalendarFeedReply) ProtoMessage() {}
+
+func (x *CreateCalendarFeedReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCalendarFeedReply.ProtoReflect.Descriptor instead.
+func (*CreateCalendarFeedReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *CreateCalendarFeedReply) GetFeed() *CalendarFeed {
+	if x != nil {
+		return x.Feed
+	}
+	return nil
+}
+
+func (x *CreateCalendarFeedReply) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// ListCalendarFeedsRequest is the request message for ListCalendarFeeds RPC.
+type ListCalendarFeedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCalendarFeedsRequest) Reset() {
+	*x = ListCalendarFeedsRequest{}
+	mi := &file_api_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarFeedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarFeedsRequest) ProtoMessage() {}
+
+func (x *ListCalendarFeedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarFeedsRequest.ProtoReflect.Descriptor instead.
+func (*ListCalendarFeedsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{83}
+}
+
+// ListCalendarFeedsReply is the response message for ListCalendarFeeds RPC.
+type ListCalendarFeedsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []*CalendarFeed        `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCalendarFeedsReply) Reset() {
+	*x = ListCalendarFeedsReply{}
+	mi := &file_api_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCalendarFeedsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCalendarFeedsReply) ProtoMessage() {}
+
+func (x *ListCalendarFeedsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCalendarFeedsReply.ProtoReflect.Descriptor instead.
+func (*ListCalendarFeedsReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListCalendarFeedsReply) GetFeeds() []*CalendarFeed {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+// DeleteCalendarFeedRequest is the request message for DeleteCalendarFeed RPC.
+type DeleteCalendarFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FeedId        string                 `protobuf:"bytes,1,opt,name=feed_id,json=feedId,proto3" json:"feed_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalendarFeedRequest) Reset() {
+	*x = DeleteCalendarFeedRequest{}
+	mi := &file_api_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarFeedRequest) ProtoMessage() {}
+
+func (x *DeleteCalendarFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarFeedRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarFeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DeleteCalendarFeedRequest) GetFeedId() string {
+	if x != nil {
+		return x.FeedId
+	}
+	return ""
+}
+
+// DeleteCalendarFeedReply is the response message for DeleteCalendarFeed RPC.
+type DeleteCalendarFeedReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feed          *CalendarFeed          `protobuf:"bytes,1,opt,name=feed,proto3" json:"feed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCalendarFeedReply) Reset() {
+	*x = DeleteCalendarFeedReply{}
+	mi := &file_api_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCalendarFeedReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCalendarFeedReply) ProtoMessage() {}
+
+func (x *DeleteCalendarFeedReply) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCalendarFeedReply.ProtoReflect.Descriptor instead.
+func (*DeleteCalendarFeedReply) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *DeleteCalendarFeedReply) GetFeed() *CalendarFeed {
+	if x != nil {
+		return x.Feed
+	}
+	return nil
+}
+
+var File_api_proto protoreflect.FileDescriptor
+
+const file_api_proto_rawDesc = "" +
+	"\n" +
+	"\tapi.proto\x12\x03api\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/protobuf/any.proto\x1a google/protobuf/field_mask.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xde\x02\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x15\n" +
+	"\x06due_at\x18\a \x01(\tR\x05dueAt\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpriority\x18\t \x01(\tR\bpriority\x12\x1a\n" +
+	"\bassignee\x18\n" +
+	" \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\v \x01(\tR\aproject\x12!\n" +
+	"\fcompleted_at\x18\f \x01(\tR\vcompletedAt\x12\x18\n" +
+	"\aversion\x18\r \x01(\x05R\aversion\":\n" +
+	"\x0fGetTasksRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.api.TaskFilterR\x06filter\"\xbd\x02\n" +
+	"\n" +
+	"TaskFilter\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\tR\bpriority\x12\x1a\n" +
+	"\bassignee\x18\x04 \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\x05 \x01(\tR\aproject\x12%\n" +
+	"\x0etitle_contains\x18\x06 \x01(\tR\rtitleContains\x12%\n" +
+	"\x0ecreated_before\x18\a \x01(\tR\rcreatedBefore\x12#\n" +
+	"\rcreated_after\x18\b \x01(\tR\fcreatedAfter\x12\x1d\n" +
+	"\n" +
+	"due_before\x18\t \x01(\tR\tdueBefore\x12\x1b\n" +
+	"\tdue_after\x18\n" +
+	" \x01(\tR\bdueAfter\"0\n" +
+	"\rGetTasksReply\x12\x1f\n" +
+	"\x05tasks\x18\x01 \x03(\v2\t.api.TaskR\x05tasks\"\xdd\x01\n" +
+	"\x0eAddTaskRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x15\n" +
+	"\x06due_at\x18\x04 \x01(\tR\x05dueAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x1a\n" +
+	"\bassignee\x18\a \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\b \x01(\tR\aproject\"-\n" +
+	"\fAddTaskReply\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\".\n" +
+	"\x13CompleteTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"2\n" +
+	"\x11CompleteTaskReply\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\"\xd0\x02\n" +
+	"\x11UpdateTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x15\n" +
+	"\x06due_at\x18\x06 \x01(\tR\x05dueAt\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpriority\x18\b \x01(\tR\bpriority\x12\x1a\n" +
+	"\bassignee\x18\t \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\n" +
+	" \x01(\tR\aproject\x12\x18\n" +
+	"\aversion\x18\v \x01(\x05R\aversion\"0\n" +
+	"\x0fUpdateTaskReply\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\"\x95\x02\n" +
+	"\tTaskPatch\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x15\n" +
+	"\x06due_at\x18\x04 \x01(\tR\x05dueAt\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\x1a\n" +
+	"\bassignee\x18\a \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\b \x01(\tR\aproject\x12;\n" +
+	"\vupdate_mask\x18\t \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\xcb\x01\n" +
+	"\x19UpdateTasksByQueryRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.api.TaskFilterR\x06filter\x12$\n" +
+	"\x05patch\x18\x02 \x01(\v2\x0e.api.TaskPatchR\x05patch\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vsample_size\x18\x04 \x01(\x05R\n" +
+	"sampleSize\x12%\n" +
+	"\x0eexpected_count\x18\x05 \x01(\x05R\rexpectedCount\"\x93\x01\n" +
+	"\x17UpdateTasksByQueryReply\x12#\n" +
+	"\rmatched_count\x18\x01 \x01(\x05R\fmatchedCount\x12\x1f\n" +
+	"\x05tasks\x18\x02 \x03(\v2\t.api.TaskR\x05tasks\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12\x19\n" +
+	"\btask_ids\x18\x04 \x03(\tR\ataskIds\"]\n" +
+	"\x12SearchTasksRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
+	"\ttime_zone\x18\x03 \x01(\tR\btimeZone\"Q\n" +
+	"\x10SearchTasksReply\x12\x1f\n" +
+	"\x05tasks\x18\x01 \x03(\v2\t.api.TaskR\x05tasks\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"A\n" +
+	"\x15FullTextSearchRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"9\n" +
+	"\x13FullTextSearchReply\x12\"\n" +
+	"\x04hits\x18\x01 \x03(\v2\x0e.api.SearchHitR\x04hits\"p\n" +
+	"\tSearchHit\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12.\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2\x0e.api.HighlightR\n" +
+	"highlights\"g\n" +
+	"\tHighlight\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1a\n" +
+	"\bfragment\x18\x02 \x01(\tR\bfragment\x12(\n" +
+	"\amatches\x18\x03 \x03(\v2\x0e.api.TextRangeR\amatches\"3\n" +
+	"\tTextRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\xc1\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\acontent\x18\a \x01(\fR\acontent\"1\n" +
+	"\x16ListAttachmentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"I\n" +
+	"\x14ListAttachmentsReply\x121\n" +
+	"\vattachments\x18\x01 \x03(\v2\x0f.api.AttachmentR\vattachments\"T\n" +
+	"\x14GetAttachmentRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12#\n" +
+	"\rattachment_id\x18\x02 \x01(\tR\fattachmentId\"E\n" +
+	"\x12GetAttachmentReply\x12/\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x0f.api.AttachmentR\n" +
+	"attachment\"F\n" +
+	"\x11DeleteTaskRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\xdc\x01\n" +
+	"\bMutation\x12'\n" +
+	"\x03add\x18\x01 \x01(\v2\x13.api.AddTaskRequestH\x00R\x03add\x120\n" +
+	"\x06update\x18\x02 \x01(\v2\x16.api.UpdateTaskRequestH\x00R\x06update\x126\n" +
+	"\bcomplete\x18\x03 \x01(\v2\x18.api.CompleteTaskRequestH\x00R\bcomplete\x120\n" +
+	"\x06delete\x18\x04 \x01(\v2\x16.api.DeleteTaskRequestH\x00R\x06deleteB\v\n" +
+	"\toperation\"e\n" +
+	"\x12BatchMutateRequest\x12+\n" +
+	"\tmutations\x18\x01 \x03(\v2\r.api.MutationR\tmutations\x12\"\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x0e.api.BatchModeR\x04mode\"\xa3\x01\n" +
+	"\x0eMutationResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12.\n" +
+	"\adetails\x18\x04 \x03(\v2\x14.google.protobuf.AnyR\adetails\x12\x1d\n" +
+	"\x04task\x18\x05 \x01(\v2\t.api.TaskR\x04task\"_\n" +
+	"\x10BatchMutateReply\x12-\n" +
+	"\aresults\x18\x01 \x03(\v2\x13.api.MutationResultR\aresults\x12\x1c\n" +
+	"\tcommitted\x18\x02 \x01(\bR\tcommitted\"=\n" +
+	"\x12ExportTasksRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.api.TaskFilterR\x06filter\"3\n" +
+	"\x10ExportTasksReply\x12\x1f\n" +
+	"\x05tasks\x18\x01 \x03(\v2\t.api.TaskR\x05tasks\"\x7f\n" +
+	"\x12ImportTasksRequest\x12%\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x0f.api.ImportTaskR\x05tasks\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12)\n" +
+	"\x10allow_duplicates\x18\x03 \x01(\bR\x0fallowDuplicates\"?\n" +
+	"\n" +
+	"ImportTask\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\"\xc7\x01\n" +
+	"\fImportResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12,\n" +
+	"\aoutcome\x18\x02 \x01(\x0e2\x12.api.ImportOutcomeR\aoutcome\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\tR\x06taskId\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12.\n" +
+	"\adetails\x18\x06 \x03(\v2\x14.google.protobuf.AnyR\adetails\"\xc9\x01\n" +
+	"\x10ImportTasksReply\x12+\n" +
+	"\aresults\x18\x01 \x03(\v2\x11.api.ImportResultR\aresults\x12#\n" +
+	"\rcreated_count\x18\x02 \x01(\x05R\fcreatedCount\x12'\n" +
+	"\x0fduplicate_count\x18\x03 \x01(\x05R\x0eduplicateCount\x12!\n" +
+	"\ffailed_count\x18\x04 \x01(\x05R\vfailedCount\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"\x85\x01\n" +
+	"\tWorkspace\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\",\n" +
+	"\x16CreateWorkspaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"Z\n" +
+	"\x14CreateWorkspaceReply\x12,\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x0e.api.WorkspaceR\tworkspace\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"<\n" +
+	"\x17SuspendWorkspaceRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"E\n" +
+	"\x15SuspendWorkspaceReply\x12,\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x0e.api.WorkspaceR\tworkspace\";\n" +
+	"\x16ExportWorkspaceRequest\x12!\n" +
+	"\fworkspace_id\x18\x01 \x01(\tR\vworkspaceId\"e\n" +
+	"\x14ExportWorkspaceReply\x12,\n" +
+	"\tworkspace\x18\x01 \x01(\v2\x0e.api.WorkspaceR\tworkspace\x12\x1f\n" +
+	"\x05tasks\x18\x02 \x03(\v2\t.api.TaskR\x05tasks\"B\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x16\n" +
+	"\x06logger\x18\x02 \x01(\tR\x06logger\"\x88\x01\n" +
+	"\x10SetLogLevelReply\x129\n" +
+	"\x06levels\x18\x01 \x03(\v2!.api.SetLogLevelReply.LevelsEntryR\x06levels\x1a9\n" +
+	"\vLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x1b\n" +
+	"\x19RebuildSearchIndexRequest\"y\n" +
+	"\x17RebuildSearchIndexReply\x12\x18\n" +
+	"\abackend\x18\x01 \x01(\tR\abackend\x12#\n" +
+	"\rindexed_tasks\x18\x02 \x01(\x03R\findexedTasks\x12\x1f\n" +
+	"\vduration_ms\x18\x03 \x01(\x03R\n" +
+	"durationMs\"k\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"I\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\"T\n" +
+	"\x12CreateWebhookReply\x12&\n" +
+	"\awebhook\x18\x01 \x01(\v2\f.api.WebhookR\awebhook\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\"\x15\n" +
+	"\x13ListWebhooksRequest\"=\n" +
+	"\x11ListWebhooksReply\x12(\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\f.api.WebhookR\bwebhooks\"5\n" +
+	"\x14DeleteWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\"<\n" +
+	"\x12DeleteWebhookReply\x12&\n" +
+	"\awebhook\x18\x01 \x01(\v2\f.api.WebhookR\awebhook\"\xdf\x01\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\tR\twebhookId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x12\x1b\n" +
+	"\tfailed_at\x18\b \x01(\tR\bfailedAt\"7\n" +
+	"\x16ListDeadLettersRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\"J\n" +
+	"\x14ListDeadLettersReply\x122\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x0f.api.DeadLetterR\vdeadLetters\"r\n" +
+	"\x17RedeliverWebhookRequest\x12&\n" +
+	"\x0fdead_letter_ids\x18\x01 \x03(\tR\rdeadLetterIds\x12\x10\n" +
+	"\x03all\x18\x02 \x01(\bR\x03all\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x03 \x01(\tR\twebhookId\"3\n" +
+	"\x15RedeliverWebhookReply\x12\x1a\n" +
+	"\brequeued\x18\x01 \x01(\x05R\brequeued\"\xca\x02\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fworkspace_id\x18\x02 \x01(\tR\vworkspaceId\x12\x1f\n" +
+	"\voccurred_at\x18\x03 \x01(\tR\n" +
+	"occurredAt\x125\n" +
+	"\ftask_created\x18\x04 \x01(\v2\x10.api.TaskCreatedH\x00R\vtaskCreated\x125\n" +
+	"\ftask_updated\x18\x05 \x01(\v2\x10.api.TaskUpdatedH\x00R\vtaskUpdated\x12;\n" +
+	"\x0etask_completed\x18\x06 \x01(\v2\x12.api.TaskCompletedH\x00R\rtaskCompleted\x125\n" +
+	"\ftask_deleted\x18\a \x01(\v2\x10.api.TaskDeletedH\x00R\vtaskDeletedB\a\n" +
+	"\x05event\",\n" +
+	"\vTaskCreated\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\"z\n" +
+	"\vTaskUpdated\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\x12%\n" +
+	"\bprevious\x18\x02 \x01(\v2\t.api.TaskR\bprevious\x12%\n" +
+	"\x0echanged_fields\x18\x03 \x03(\tR\rchangedFields\"W\n" +
+	"\rTaskCompleted\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\x12'\n" +
+	"\x0fprevious_status\x18\x02 \x01(\tR\x0epreviousStatus\",\n" +
+	"\vTaskDeleted\x12\x1d\n" +
+	"\x04task\x18\x01 \x01(\v2\t.api.TaskR\x04task\"\x9e\x01\n" +
+	"\x17NotificationPreferences\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1c\n" +
+	"\treminders\x18\x03 \x01(\bR\treminders\x12\x16\n" +
+	"\x06digest\x18\x04 \x01(\bR\x06digest\x12\x1b\n" +
+	"\ttime_zone\x18\x05 \x01(\tR\btimeZone\"c\n" +
+	"!SetNotificationPreferencesRequest\x12>\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1c.api.NotificationPreferencesR\vpreferences\"a\n" +
+	"\x1fSetNotificationPreferencesReply\x12>\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1c.api.NotificationPreferencesR\vpreferences\"$\n" +
+	"\"ListNotificationPreferencesRequest\"b\n" +
+	" ListNotificationPreferencesReply\x12>\n" +
+	"\vpreferences\x18\x01 \x03(\v2\x1c.api.NotificationPreferencesR\vpreferences\"B\n" +
+	"$DeleteNotificationPreferencesRequest\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\"d\n" +
+	"\"DeleteNotificationPreferencesReply\x12>\n" +
+	"\vpreferences\x18\x01 \x01(\v2\x1c.api.NotificationPreferencesR\vpreferences\"/\n" +
+	"\x11SendDigestRequest\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\"k\n" +
+	"\x0fSendDigestReply\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"open_tasks\x18\x02 \x01(\x05R\topenTasks\x12#\n" +
+	"\roverdue_tasks\x18\x03 \x01(\x05R\foverdueTasks\"`\n" +
+	"\rInboundSender\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\"K\n" +
+	"\x17AddInboundSenderRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\"C\n" +
+	"\x15AddInboundSenderReply\x12*\n" +
+	"\x06sender\x18\x01 \x01(\v2\x12.api.InboundSenderR\x06sender\"\x1b\n" +
+	"\x19ListInboundSendersRequest\"G\n" +
+	"\x17ListInboundSendersReply\x12,\n" +
+	"\asenders\x18\x01 \x03(\v2\x12.api.InboundSenderR\asenders\"2\n" +
+	"\x1aRemoveInboundSenderRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"F\n" +
+	"\x18RemoveInboundSenderReply\x12*\n" +
+	"\x06sender\x18\x01 \x01(\v2\x12.api.InboundSenderR\x06sender\"s\n" +
+	"\fCalendarFeed\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bassignee\x18\x02 \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\x03 \x01(\tR\aproject\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"Q\n" +
+	"\x19CreateCalendarFeedRequest\x12\x1a\n" +
+	"\bassignee\x18\x01 \x01(\tR\bassignee\x12\x18\n" +
+	"\aproject\x18\x02 \x01(\tR\aproject\"R\n" +
+	"\x17CreateCalendarFeedReply\x12%\n" +
+	"\x04feed\x18\x01 \x01(\v2\x11.api.CalendarFeedR\x04feed\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\x1a\n" +
+	"\x18ListCalendarFeedsRequest\"A\n" +
+	"\x16ListCalendarFeedsReply\x12'\n" +
+	"\x05feeds\x18\x01 \x03(\v2\x11.api.CalendarFeedR\x05feeds\"4\n" +
+	"\x19DeleteCalendarFeedRequest\x12\x17\n" +
+	"\afeed_id\x18\x01 \x01(\tR\x06feedId\"@\n" +
+	"\x17DeleteCalendarFeedReply\x12%\n" +
+	"\x04feed\x18\x01 \x01(\v2\x11.api.CalendarFeedR\x04feed*Z\n" +
+	"\tBatchMode\x12\x1a\n" +
+	"\x16BATCH_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11BATCH_MODE_ATOMIC\x10\x01\x12\x1a\n" +
+	"\x16BATCH_MODE_BEST_EFFORT\x10\x02*\x84\x01\n" +
+	"\rImportOutcome\x12\x1e\n" +
+	"\x1aIMPORT_OUTCOME_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16IMPORT_OUTCOME_CREATED\x10\x01\x12\x1c\n" +
+	"\x18IMPORT_OUTCOME_DUPLICATE\x10\x02\x12\x19\n" +
+	"\x15IMPORT_OUTCOME_FAILED\x10\x032\xad\t\n" +
+	"\vTaskService\x12G\n" +
+	"\bGetTasks\x12\x14.api.GetTasksRequest\x1a\x12.api.GetTasksReply\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/tasks\x12G\n" +
+	"\aAddTask\x12\x13.api.AddTaskRequest\x1a\x11.api.AddTaskReply\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/tasks\x12i\n" +
+	"\fCompleteTask\x12\x18.api.CompleteTaskRequest\x1a\x16.api.CompleteTaskReply\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/v1/tasks/{task_id}:complete\x12Z\n" +
+	"\n" +
+	"UpdateTask\x12\x16.api.UpdateTaskRequest\x1a\x14.api.UpdateTaskReply\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/tasks/{task_id}\x12_\n" +
+	"\vBatchMutate\x12\x17.api.BatchMutateRequest\x1a\x15.api.BatchMutateReply\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/tasks:batchMutate\x12v\n" +
+	"\x12UpdateTasksByQuery\x12\x1e.api.UpdateTasksByQueryRequest\x1a\x1c.api.UpdateTasksByQueryReply\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/tasks:updateByQuery\x12W\n" +
+	"\vSearchTasks\x12\x17.api.SearchTasksRequest\x1a\x15.api.SearchTasksReply\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tasks:search\x12h\n" +
+	"\x0eFullTextSearch\x12\x1a.api.FullTextSearchRequest\x1a\x18.api.FullTextSearchReply\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/tasks:fullTextSearch\x12r\n" +
+	"\x0fListAttachments\x12\x1b.api.ListAttachmentsRequest\x1a\x19.api.ListAttachmentsReply\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/tasks/{task_id}/attachments\x12|\n" +
+	"\rGetAttachment\x12\x19.api.GetAttachmentRequest\x1a\x17.api.GetAttachmentReply\"7\x82\xd3\xe4\x93\x021\x12//v1/tasks/{task_id}/attachments/{attachment_id}\x12Y\n" +
+	"\vExportTasks\x12\x17.api.ExportTasksRequest\x1a\x15.api.ExportTasksReply\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/tasks:export0\x01\x12\\\n" +
+	"\vImportTasks\x12\x17.api.ImportTasksRequest\x1a\x15.api.ImportTasksReply\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/tasks:import(\x012\x85\x03\n" +
+	"\fAdminService\x12I\n" +
+	"\x0fCreateWorkspace\x12\x1b.api.CreateWorkspaceRequest\x1a\x19.api.CreateWorkspaceReply\x12L\n" +
+	"\x10SuspendWorkspace\x12\x1c.api.SuspendWorkspaceRequest\x1a\x1a.api.SuspendWorkspaceReply\x12I\n" +
+	"\x0fExportWorkspace\x12\x1b.api.ExportWorkspaceRequest\x1a\x19.api.ExportWorkspaceReply\x12=\n" +
+	"\vSetLogLevel\x12\x17.api.SetLogLevelRequest\x1a\x15.api.SetLogLevelReply\x12R\n" +
+	"\x12RebuildSearchIndex\x12\x1e.api.RebuildSearchIndexRequest\x1a\x1c.api.RebuildSearchIndexReply2\xf5\x02\n" +
+	"\x0eWebhookService\x12C\n" +
+	"\rCreateWebhook\x12\x19.api.CreateWebhookRequest\x1a\x17.api.CreateWebhookReply\x12@\n" +
+	"\fListWebhooks\x12\x18.api.ListWebhooksRequest\x1a\x16.api.ListWebhooksReply\x12C\n" +
+	"\rDeleteWebhook\x12\x19.api.DeleteWebhookRequest\x1a\x17.api.DeleteWebhookReply\x12I\n" +
+	"\x0fListDeadLetters\x12\x1b.api.ListDeadLettersRequest\x1a\x19.api.ListDeadLettersReply\x12L\n" +
+	"\x10RedeliverWebhook\x12\x1c.api.RedeliverWebhookRequest\x1a\x1a.api.RedeliverWebhookReply2\xa1\x03\n" +
+	"\x13NotificationService\x12j\n" +
+	"\x1aSetNotificationPreferences\x12&.api.SetNotificationPreferencesRequest\x1a$.api.SetNotificationPreferencesReply\x12m\n" +
+	"\x1bListNotificationPreferences\x12'.api.ListNotificationPreferencesRequest\x1a%.api.ListNotificationPreferencesReply\x12s\n" +
+	"\x1dDeleteNotificationPreferences\x12).api.DeleteNotificationPreferencesRequest\x1a'.api.DeleteNotificationPreferencesReply\x12:\n" +
+	"\n" +
+	"SendDigest\x12\x16.api.SendDigestRequest\x1a\x14.api.SendDigestReply2\x89\x02\n" +
+	"\x0eInboundService\x12L\n" +
+	"\x10AddInboundSender\x12\x1c.api.AddInboundSenderRequest\x1a\x1a.api.AddInboundSenderReply\x12R\n" +
+	"\x12ListInboundSenders\x12\x1e.api.ListInboundSendersRequest\x1a\x1c.api.ListInboundSendersReply\x12U\n" +
+	"\x13RemoveInboundSender\x12\x1f.api.RemoveInboundSenderRequest\x1a\x1d.api.RemoveInboundSenderReply2\x8a\x02\n" +
+	"\x0fCalendarService\x12R\n" +
+	"\x12CreateCalendarFeed\x12\x1e.api.CreateCalendarFeedRequest\x1a\x1c.api.CreateCalendarFeedReply\x12O\n" +
+	"\x11ListCalendarFeeds\x12\x1d.api.ListCalendarFeedsRequest\x1a\x1b.api.ListCalendarFeedsReply\x12R\n" +
+	"\x12DeleteCalendarFeed\x12\x1e.api.DeleteCalendarFeedRequest\x1a\x1c.api.DeleteCalendarFeedReplyB\x82\x01\x92Ax\x12\x1e\n" +
+	"\x17fx-grpc-app TaskService2\x031.0ZH\n" +
+	"F\n" +
+	"\x06bearer\x12<\b\x02\x12'Workspace API token as \"Bearer <token>\"\x1a\rAuthorization \x02b\f\n" +
+	"\n" +
+	"\n" +
+	"\x06bearer\x12\x00Z\x05./apib\x06proto3"
+
+var (
+	file_api_proto_rawDescOnce sync.Once
+	file_api_proto_rawDescData []byte
+)
+
+func file_api_proto_rawDescGZIP() []byte {
+	file_api_proto_rawDescOnce.Do(func() {
+		file_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)))
+	})
+	return file_api_proto_rawDescData
+}
+
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 88)
+var file_api_proto_goTypes = []any{
+	(BatchMode)(0),                               // 0: api.BatchMode
+	(ImportOutcome)(0),                           // 1: api.ImportOutcome
+	(*Task)(nil),                                 // 2: api.Task
+	(*GetTasksRequest)(nil),                      // 3: api.GetTasksRequest
+	(*TaskFilter)(nil),                           // 4: api.TaskFilter
+	(*GetTasksReply)(nil),                        // 5: api.GetTasksReply
+	(*AddTaskRequest)(nil),                       // 6: api.AddTaskRequest
+	(*AddTaskReply)(nil),                         // 7: api.AddTaskReply
+	(*CompleteTaskRequest)(nil),                  // 8: api.CompleteTaskRequest
+	(*CompleteTaskReply)(nil),                    // 9: api.CompleteTaskReply
+	(*UpdateTaskRequest)(nil),                    // 10: api.UpdateTaskRequest
+	(*UpdateTaskReply)(nil),                      // 11: api.UpdateTaskReply
+	(*TaskPatch)(nil),                            // 12: api.TaskPatch
+	(*UpdateTasksByQueryRequest)(nil),            // 13: api.UpdateTasksByQueryRequest
+	(*UpdateTasksByQueryReply)(nil),              // 14: api.UpdateTasksByQueryReply
+	(*SearchTasksRequest)(nil),                   // 15: api.SearchTasksRequest
+	(*SearchTasksReply)(nil),                     // 16: api.SearchTasksReply
+	(*FullTextSearchRequest)(nil),                // 17: api.FullTextSearchRequest
+	(*FullTextSearchReply)(nil),                  // 18: api.FullTextSearchReply
+	(*SearchHit)(nil),                            // 19: api.SearchHit
+	(*Highlight)(nil),                            // 20: api.Highlight
+	(*TextRange)(nil),                            // 21: api.TextRange
+	(*Attachment)(nil),                           // 22: api.Attachment
+	(*ListAttachmentsRequest)(nil),               // 23: api.ListAttachmentsRequest
+	(*ListAttachmentsReply)(nil),                 // 24: api.ListAttachmentsReply
+	(*GetAttachmentRequest)(nil),                 // 25: api.GetAttachmentRequest
+	(*GetAttachmentReply)(nil),                   // 26: api.GetAttachmentReply
+	(*DeleteTaskRequest)(nil),                    // 27: api.DeleteTaskRequest
+	(*Mutation)(nil),                             // 28: api.Mutation
+	(*BatchMutateRequest)(nil),                   // 29: api.BatchMutateRequest
+	(*MutationResult)(nil),                       // 30: api.MutationResult
+	(*BatchMutateReply)(nil),                     // 31: api.BatchMutateReply
+	(*ExportTasksRequest)(nil),                   // 32: api.ExportTasksRequest
+	(*ExportTasksReply)(nil),                     // 33: api.ExportTasksReply
+	(*ImportTasksRequest)(nil),                   // 34: api.ImportTasksRequest
+	(*ImportTask)(nil),                           // 35: api.ImportTask
+	(*ImportResult)(nil),                         // 36: api.ImportResult
+	(*ImportTasksReply)(nil),                     // 37: api.ImportTasksReply
+	(*Workspace)(nil),                            // 38: api.Workspace
+	(*CreateWorkspaceRequest)(nil),               // 39: api.CreateWorkspaceRequest
+	(*CreateWorkspaceReply)(nil),                 // 40: api.CreateWorkspaceReply
+	(*SuspendWorkspaceRequest)(nil),              // 41: api.SuspendWorkspaceRequest
+	(*SuspendWorkspaceReply)(nil),                // 42: api.SuspendWorkspaceReply
+	(*ExportWorkspaceRequest)(nil),               // 43: api.ExportWorkspaceRequest
+	(*ExportWorkspaceReply)(nil),                 // 44: api.ExportWorkspaceReply
+	(*SetLogLevelRequest)(nil),                   // 45: api.SetLogLevelRequest
+	(*SetLogLevelReply)(nil),                     // 46: api.SetLogLevelReply
+	(*RebuildSearchIndexRequest)(nil),            // 47: api.RebuildSearchIndexRequest
+	(*RebuildSearchIndexReply)(nil),              // 48: api.RebuildSearchIndexReply
+	(*Webhook)(nil),                              // 49: api.Webhook
+	(*CreateWebhookRequest)(nil),                 // 50: api.CreateWebhookRequest
+	(*CreateWebhookReply)(nil),                   // 51: api.CreateWebhookReply
+	(*ListWebhooksRequest)(nil),                  // 52: api.ListWebhooksRequest
+	(*ListWebhooksReply)(nil),                    // 53: api.ListWebhooksReply
+	(*DeleteWebhookRequest)(nil),                 // 54: api.DeleteWebhookRequest
+	(*DeleteWebhookReply)(nil),                   // 55: api.DeleteWebhookReply
+	(*DeadLetter)(nil),                           // 56: api.DeadLetter
+	(*ListDeadLettersRequest)(nil),               // 57: api.ListDeadLettersRequest
+	(*ListDeadLettersReply)(nil),                 // 58: api.ListDeadLettersReply
+	(*RedeliverWebhookRequest)(nil),              // 59: api.RedeliverWebhookRequest
+	(*RedeliverWebhookReply)(nil),                // 60: api.RedeliverWebhookReply
+	(*TaskEvent)(nil),                            // 61: api.TaskEvent
+	(*TaskCreated)(nil),                          // 62: api.TaskCreated
+	(*TaskUpdated)(nil),                          // 63: api.TaskUpdated
+	(*TaskCompleted)(nil),                        // 64: api.TaskCompleted
+	(*TaskDeleted)(nil),                          // 65: api.TaskDeleted
+	(*NotificationPreferences)(nil),              // 66: api.NotificationPreferences
+	(*SetNotificationPreferencesRequest)(nil),    // 67: api.SetNotificationPreferencesRequest
+	(*SetNotificationPreferencesReply)(nil),      // 68: api.SetNotificationPreferencesReply
+	(*ListNotificationPreferencesRequest)(nil),   // 69: api.ListNotificationPreferencesRequest
+	(*ListNotificationPreferencesReply)(nil),     // 70: api.ListNotificationPreferencesReply
+	(*DeleteNotificationPreferencesRequest)(nil), // 71: api.DeleteNotificationPreferencesRequest
+	(*DeleteNotificationPreferencesReply)(nil),   // 72: api.DeleteNotificationPreferencesReply
+	(*SendDigestRequest)(nil),                    // 73: api.SendDigestRequest
+	(*SendDigestReply)(nil),                      // 74: api.SendDigestReply
+	(*InboundSender)(nil),                        // 75: api.InboundSender
+	(*AddInboundSenderRequest)(nil),              // 76: api.AddInboundSenderRequest
+	(*AddInboundSenderReply)(nil),                // 77: api.AddInboundSenderReply
+	(*ListInboundSendersRequest)(nil),            // 78: api.ListInboundSendersRequest
+	(*ListInboundSendersReply)(nil),              // 79: api.ListInboundSendersReply
+	(*RemoveInboundSenderRequest)(nil),           // 80: api.RemoveInboundSenderRequest
+	(*RemoveInboundSenderReply)(nil),             // 81: api.RemoveInboundSenderReply
+	(*CalendarFeed)(nil),                         // 82: api.CalendarFeed
+	(*CreateCalendarFeedRequest)(nil),            // 83: api.CreateCalendarFeedRequest
+	(*CreateCalendarFeedReply)(nil),              // 84: api.CreateCalendarFeedReply
+	(*ListCalendarFeedsRequest)(nil),             // 85: api.ListCalendarFeedsRequest
+	(*ListCalendarFeedsReply)(nil),               // 86: api.ListCalendarFeedsReply
+	(*DeleteCalendarFeedRequest)(nil),            // 87: api.DeleteCalendarFeedRequest
+	(*DeleteCalendarFeedReply)(nil),              // 88: api.DeleteCalendarFeedReply
+	nil,                                          // 89: api.SetLogLevelReply.LevelsEntry
+	(*fieldmaskpb.FieldMask)(nil),                // 90: google.protobuf.FieldMask
+	(*anypb.Any)(nil),                            // 91: google.protobuf.Any
+}
+var file_api_proto_depIdxs = []int32{
+	4,  // 0: api.GetTasksRequest.filter:type_name -> api.TaskFilter
+	2,  // 1: api.GetTasksReply.tasks:type_name -> api.Task
+	2,  // 2: api.AddTaskReply.task:type_name -> api.Task
+	2,  // 3: api.CompleteTaskReply.task:type_name -> api.Task
+	90, // 4: api.UpdateTaskRequest.update_mask:type_name -> google.protobuf.FieldMask
+	2,  // 5: api.UpdateTaskReply.task:type_name -> api.Task
+	90, // 6: api.TaskPatch.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 7: api.UpdateTasksByQueryRequest.filter:type_name -> api.TaskFilter
+	12, // 8: api.UpdateTasksByQueryRequest.patch:type_name -> api.TaskPatch
+	2,  // 9: api.UpdateTasksByQueryReply.tasks:type_name -> api.Task
+	2,  // 10: api.SearchTasksReply.tasks:type_name -> api.Task
+	19, // 11: api.FullTextSearchReply.hits:type_name -> api.SearchHit
+	2,  // 12: api.SearchHit.task:type_name -> api.Task
+	20, // 13: api.SearchHit.highlights:type_name -> api.Highlight
+	21, // 14: api.Highlight.matches:type_name -> api.TextRange
+	22, // 15: api.ListAttachmentsReply.attachments:type_name -> api.Attachment
+	22, // 16: api.GetAttachmentReply.attachment:type_name -> api.Attachment
+	6,  // 17: api.Mutation.add:type_name -> api.AddTaskRequest
+	10, // 18: api.Mutation.update:type_name -> api.UpdateTaskRequest
+	8,  // 19: api.Mutation.complete:type_name -> api.CompleteTaskRequest
+	27, // 20: api.Mutation.delete:type_name -> api.DeleteTaskRequest
+	28, // 21: api.BatchMutateRequest.mutations:type_name -> api.Mutation
+	0,  // 22: api.BatchMutateRequest.mode:type_name -> api.BatchMode
+	91, // 23: api.MutationResult.details:type_name -> google.protobuf.Any
+	2,  // 24: api.MutationResult.task:type_name -> api.Task
+	30, // 25: api.BatchMutateReply.results:type_name -> api.MutationResult
+	4,  // 26: api.ExportTasksRequest.filter:type_name -> api.TaskFilter
+	2,  // 27: api.ExportTasksReply.tasks:type_name -> api.Task
+	35, // 28: api.ImportTasksRequest.tasks:type_name -> api.ImportTask
+	2,  // 29: api.ImportTask.task:type_name -> api.Task
+	1,  // 30: api.ImportResult.outcome:type_name -> api.ImportOutcome
+	91, // 31: api.ImportResult.details:type_name -> google.protobuf.Any
+	36, // 32: api.ImportTasksReply.results:type_name -> api.ImportResult
+	38, // 33: api.CreateWorkspaceReply.workspace:type_name -> api.Workspace
+	38, // 34: api.SuspendWorkspaceReply.workspace:type_name -> api.Workspace
+	38, // 35: api.ExportWorkspaceReply.workspace:type_name -> api.Workspace
+	2,  // 36: api.ExportWorkspaceReply.tasks:type_name -> api.Task
+	89, // 37: api.SetLogLevelReply.levels:type_name -> api.SetLogLevelReply.LevelsEntry
+	49, // 38: api.CreateWebhookReply.webhook:type_name -> api.Webhook
+	49, // 39: api.ListWebhooksReply.webhooks:type_name -> api.Webhook
+	49, // 40: api.DeleteWebhookReply.webhook:type_name -> api.Webhook
+	56, // 41: api.ListDeadLettersReply.dead_letters:type_name -> api.DeadLetter
+	62, // 42: api.TaskEvent.task_created:type_name -> api.TaskCreated
+	63, // 43: api.TaskEvent.task_updated:type_name -> api.TaskUpdated
+	64, // 44: api.TaskEvent.task_completed:type_name -> api.TaskCompleted
+	65, // 45: api.TaskEvent.task_deleted:type_name -> api.TaskDeleted
+	2,  // 46: api.TaskCreated.task:type_name -> api.Task
+	2,  // 47: api.TaskUpdated.task:type_name -> api.Task
+	2,  // 48: api.TaskUpdated.previous:type_name -> api.Task
+	2,  // 49: api.TaskCompleted.task:type_name -> api.Task
+	2,  // 50: api.TaskDeleted.task:type_name -> api.Task
+	66, // 51: api.SetNotificationPreferencesRequest.preferences:type_name -> api.NotificationPreferences
+	66, // 52: api.SetNotificationPreferencesReply.preferences:type_name -> api.NotificationPreferences
+	66, // 53: api.ListNotificationPreferencesReply.preferences:type_name -> api.NotificationPreferences
+	66, // 54: api.DeleteNotificationPreferencesReply.preferences:type_name -> api.NotificationPreferences
+	75, // 55: api.AddInboundSenderReply.sender:type_name -> api.InboundSender
+	75, // 56: api.ListInboundSendersReply.senders:type_name -> api.InboundSender
+	75, // 57: api.RemoveInboundSenderReply.sender:type_name -> api.InboundSender
+	82, // 58: api.CreateCalendarFeedReply.feed:type_name -> api.CalendarFeed
+	82, // 59: api.ListCalendarFeedsReply.feeds:type_name -> api.CalendarFeed
+	82, // 60: api.DeleteCalendarFeedReply.feed:type_name -> api.CalendarFeed
+	3,  // 61: api.TaskService.GetTasks:input_type -> api.GetTasksRequest
+	6,  // 62: api.TaskService.AddTask:input_type -> api.AddTaskRequest
+	8,  // 63: api.TaskService.CompleteTask:input_type -> api.CompleteTaskRequest
+	10, // 64: api.TaskService.UpdateTask:input_type -> api.UpdateTaskRequest
+	29, // 65: api.TaskService.BatchMutate:input_type -> api.BatchMutateRequest
+	13, // 66: api.TaskService.UpdateTasksByQuery:input_type -> api.UpdateTasksByQueryRequest
+	15, // 67: api.TaskService.SearchTasks:input_type -> api.SearchTasksRequest
+	17, // 68: api.TaskService.FullTextSearch:input_type -> api.FullTextSearchRequest
+	23, // 69: api.TaskService.ListAttachments:input_type -> api.ListAttachmentsRequest
+	25, // 70: api.TaskService.GetAttachment:input_type -> api.GetAttachmentRequest
+	32, // 71: api.TaskService.ExportTasks:input_type -> api.ExportTasksRequest
+	34, // 72: api.TaskService.ImportTasks:input_type -> api.ImportTasksRequest
+	39, // 73: api.AdminService.CreateWorkspace:input_type -> api.CreateWorkspaceRequest
+	41, // 74: api.AdminService.SuspendWorkspace:input_type -> api.SuspendWorkspaceRequest
+	43, // 75: api.AdminService.ExportWorkspace:input_type -> api.ExportWorkspaceRequest
+	45, // 76: api.AdminService.SetLogLevel:input_type -> api.SetLogLevelRequest
+	47, // 77: api.AdminService.RebuildSearchIndex:input_type -> api.RebuildSearchIndexRequest
+	50, // 78: api.WebhookService.CreateWebhook:input_type -> api.CreateWebhookRequest
+	52, // 79: api.WebhookService.ListWebhooks:input_type -> api.ListWebhooksRequest
+	54, // 80: api.WebhookService.DeleteWebhook:input_type -> api.DeleteWebhookRequest
+	57, // 81: api.WebhookService.ListDeadLetters:input_type -> api.ListDeadLettersRequest
+	59, // 82: api.WebhookService.RedeliverWebhook:input_type -> api.RedeliverWebhookRequest
+	67, // 83: api.NotificationService.SetNotificationPreferences:input_type -> api.SetNotificationPreferencesRequest
+	69, // 84: api.NotificationService.ListNotificationPreferences:input_type -> api.ListNotificationPreferencesRequest
+	71, // 85: api.NotificationService.DeleteNotificationPreferences:input_type -> api.DeleteNotificationPreferencesRequest
+	73, // 86: api.NotificationService.SendDigest:input_type -> api.SendDigestRequest
+	76, // 87: api.InboundService.AddInboundSender:input_type -> api.AddInboundSenderRequest
+	78, // 88: api.InboundService.ListInboundSenders:input_type -> api.ListInboundSendersRequest
+	80, // 89: api.InboundService.RemoveInboundSender:input_type -> api.RemoveInboundSenderRequest
+	83, // 90: api.CalendarService.CreateCalendarFeed:input_type -> api.CreateCalendarFeedRequest
+	85, // 91: api.CalendarService.ListCalendarFeeds:input_type -> api.ListCalendarFeedsRequest
+	87, // 92: api.CalendarService.DeleteCalendarFeed:input_type -> api.DeleteCalendarFeedRequest
+	5,  // 93: api.TaskService.GetTasks:output_type -> api.GetTasksReply
+	7,  // 94: api.TaskService.AddTask:output_type -> api.AddTaskReply
+	9,  // 95: api.TaskService.CompleteTask:output_type -> api.CompleteTaskReply
+	11, // 96: api.TaskService.UpdateTask:output_type -> api.UpdateTaskReply
+	31, // 97: api.TaskService.BatchMutate:output_type -> api.BatchMutateReply
+	14, // 98: api.TaskService.UpdateTasksByQuery:output_type -> api.UpdateTasksByQueryReply
+	16, // 99: api.TaskService.SearchTasks:output_type -> api.SearchTasksReply
+	18, // 100: api.TaskService.FullTextSearch:output_type -> api.FullTextSearchReply
+	24, // 101: api.TaskService.ListAttachments:output_type -> api.ListAttachmentsReply
+	26, // 102: api.TaskService.GetAttachment:output_type -> api.GetAttachmentReply
+	33, // 103: api.TaskService.ExportTasks:output_type -> api.ExportTasksReply
+	37, // 104: api.TaskService.ImportTasks:output_type -> api.ImportTasksReply
+	40, // 105: api.AdminService.CreateWorkspace:output_type -> api.CreateWorkspaceReply
+	42, // 106: api.AdminService.SuspendWorkspace:output_type -> api.SuspendWorkspaceReply
+	44, // 107: api.AdminService.ExportWorkspace:output_type -> api.ExportWorkspaceReply
+	46, // 108: api.AdminService.SetLogLevel:output_type -> api.SetLogLevelReply
+	48, // 109: api.AdminService.RebuildSearchIndex:output_type -> api.RebuildSearchIndexReply
+	51, // 110: api.WebhookService.CreateWebhook:output_type -> api.CreateWebhookReply
+	53, // 111: api.WebhookService.ListWebhooks:output_type -> api.ListWebhooksReply
+	55, // 112: api.WebhookService.DeleteWebhook:output_type -> api.DeleteWebhookReply
+	58, // 113: api.WebhookService.ListDeadLetters:output_type -> api.ListDeadLettersReply
+	60, // 114: api.WebhookService.RedeliverWebhook:output_type -> api.RedeliverWebhookReply
+	68, // 115: api.NotificationService.SetNotificationPreferences:output_type -> api.SetNotificationPreferencesReply
+	70, // 116: api.NotificationService.ListNotificationPreferences:output_type -> api.ListNotificationPreferencesReply
+	72, // 117: api.NotificationService.DeleteNotificationPreferences:output_type -> api.DeleteNotificationPreferencesReply
+	74, // 118: api.NotificationService.SendDigest:output_type -> api.SendDigestReply
+	77, // 119: api.InboundService.AddInboundSender:output_type -> api.AddInboundSenderReply
+	79, // 120: api.InboundService.ListInboundSenders:output_type -> api.ListInboundSendersReply
+	81, // 121: api.InboundService.RemoveInboundSender:output_type -> api.RemoveInboundSenderReply
+	84, // 122: api.CalendarService.CreateCalendarFeed:output_type -> api.CreateCalendarFeedReply
+	86, // 123: api.CalendarService.ListCalendarFeeds:output_type -> api.ListCalendarFeedsReply
+	88, // 124: api.CalendarService.DeleteCalendarFeed:output_type -> api.DeleteCalendarFeedReply
+	93, // [93:125] is the sub-list for method output_type
+	61, // [61:93] is the sub-list for method input_type
+	61, // [61:61] is the sub-list for extension type_name
+	61, // [61:61] is the sub-list for extension extendee
+	0,  // [0:61] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_init() }
 func file_api_proto_init() {
 	if File_api_proto != nil {
 		return
 	}
+	file_api_proto_msgTypes[26].OneofWrappers = []any{
+		(*Mutation_Add)(nil),
+		(*Mutation_Update)(nil),
+		(*Mutation_Complete)(nil),
+		(*Mutation_Delete)(nil),
+	}
+	file_api_proto_msgTypes[59].OneofWrappers = []any{
+		(*TaskEvent_TaskCreated)(nil),
+		(*TaskEvent_TaskUpdated)(nil),
+		(*TaskEvent_TaskCompleted)(nil),
+		(*TaskEvent_TaskDeleted)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   88,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   6,
 		},
 		GoTypes:           file_api_proto_goTypes,
 		DependencyIndexes: file_api_proto_depIdxs,
+		EnumInfos:         file_api_proto_enumTypes,
 		MessageInfos:      file_api_proto_msgTypes,
 	}.Build()
 	File_api_proto = out.File
@@ -0,0 +1,9 @@
+package api
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI v2 description of the REST routes of TaskService,
+// generated by protoc-gen-openapiv2 from the google.api.http annotations in api.proto.
+//
+//go:embed api.swagger.json
+var OpenAPISpec []byte
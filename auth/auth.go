@@ -0,0 +1,159 @@
+package auth
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Module exports the Authenticator provider for FX.
+var Module = fx.Options(
+	fx.Provide(NewAuthenticator),
+)
+
+const (
+	// WorkspaceStatusActive is the status of a workspace that may use the API.
+	WorkspaceStatusActive = "active"
+	// WorkspaceStatusSuspended is the status of a workspace whose tokens are rejected.
+	WorkspaceStatusSuspended = "suspended"
+)
+
+// publicMethodPrefixes lists RPCs that may be called without a token.
+var publicMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+type adminContextKey struct{}
+
+// IsAdmin reports whether ctx was authenticated with the admin token.
+func IsAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminContextKey{}).(bool)
+	return admin
+}
+
+type AuthenticatorParams struct {
+	fx.In
+	Logger     *zap.Logger
+	Config     *cfg.Config
+	Workspaces repo.WorkspaceRepository
+}
+
+// Authenticator resolves the bearer token of each RPC into a tenant or the admin principal.
+type Authenticator struct {
+	logger     *zap.Logger
+	adminToken string
+	workspaces repo.WorkspaceRepository
+}
+
+// NewAuthenticator creates a new Authenticator.
+func NewAuthenticator(p AuthenticatorParams) *Authenticator {
+	logger := p.Logger.Named("auth")
+	if p.Config.AdminToken == "" {
+		logger.Warn("ADMIN_TOKEN is not set; AdminService calls will be rejected")
+	}
+	return &Authenticator{logger: logger, adminToken: p.Config.AdminToken, workspaces: p.Workspaces}
+}
+
+// UnaryServerInterceptor returns a unary interceptor that authenticates every call.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor that authenticates every call.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate validates the bearer token and returns a context carrying the resolved principal.
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	for _, prefix := range publicMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return ctx, nil
+		}
+	}
+
+	token, err := bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(fullMethod, "/"+pb.AdminService_ServiceDesc.ServiceName+"/") {
+		if a.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(a.adminToken)) != 1 {
+			a.logger.Warn("Rejected AdminService call with invalid admin token", zap.String("method", fullMethod))
+			return nil, status.Errorf(codes.PermissionDenied, "admin token required")
+		}
+		return context.WithValue(ctx, adminContextKey{}, true), nil
+	}
+
+	workspace, err := a.workspaces.FetchWorkspaceByTokenHash(ctx, HashToken(token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid API token")
+		}
+		a.logger.Error("Failed to resolve API token", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to resolve API token: %v", err)
+	}
+	if workspace.GetStatus() != WorkspaceStatusActive {
+		st := status.Newf(codes.PermissionDenied, "workspace '%s' is %s", workspace.GetName(), workspace.GetStatus())
+		if withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+			Reason:   "WORKSPACE_" + strings.ToUpper(workspace.GetStatus()),
+			Domain:   "fx-grpc-app",
+			Metadata: map[string]string{"workspace_id": workspace.GetId()},
+		}); err == nil {
+			st = withDetails
+		}
+		return nil, st.Err()
+	}
+	return tenant.WithID(ctx, workspace.GetId()), nil
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Errorf(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Errorf(codes.Unauthenticated, "missing authorization header")
+	}
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found || token == "" {
+		return "", status.Errorf(codes.Unauthenticated, "authorization header must use the Bearer scheme")
+	}
+	return token, nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
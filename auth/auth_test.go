@@ -0,0 +1,149 @@
+package auth
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWorkspaces resolves token hashes to workspaces.
+type fakeWorkspaces struct {
+	repo.WorkspaceRepository
+	byTokenHash map[string]*pb.Workspace
+}
+
+func (f *fakeWorkspaces) FetchWorkspaceByTokenHash(ctx context.Context, tokenHash string) (*pb.Workspace, error) {
+	workspace, ok := f.byTokenHash[tokenHash]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return workspace, nil
+}
+
+func newTestAuthenticator() *Authenticator {
+	return NewAuthenticator(AuthenticatorParams{
+		Logger: zap.NewNop(),
+		Config: &cfg.Config{AdminToken: "admin-secret"},
+		Workspaces: &fakeWorkspaces{byTokenHash: map[string]*pb.Workspace{
+			HashToken("active-token"):    {Id: "1", Name: "acme", Status: WorkspaceStatusActive},
+			HashToken("suspended-token"): {Id: "2", Name: "globex", Status: WorkspaceStatusSuspended},
+		}},
+	})
+}
+
+// withAuthorization returns an incoming context with the given authorization header,
+// or without metadata for "".
+func withAuthorization(header string) context.Context {
+	if header == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", header))
+}
+
+func TestUnaryServerInterceptorRejectsCalls(t *testing.T) {
+	interceptor := newTestAuthenticator().UnaryServerInterceptor()
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		method     string
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{name: "no metadata", ctx: context.Background(), method: "/TaskService/GetTasks", wantCode: codes.Unauthenticated},
+		{name: "no authorization header", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "1")),
+			method: "/TaskService/GetTasks", wantCode: codes.Unauthenticated},
+		{name: "empty bearer token", ctx: withAuthorization("Bearer "), method: "/TaskService/GetTasks", wantCode: codes.Unauthenticated},
+		{name: "basic scheme", ctx: withAuthorization("Basic active-token"), method: "/TaskService/GetTasks", wantCode: codes.Unauthenticated},
+		{name: "unknown token", ctx: withAuthorization("Bearer unknown-token"), method: "/TaskService/GetTasks", wantCode: codes.Unauthenticated},
+		{name: "suspended workspace", ctx: withAuthorization("Bearer suspended-token"), method: "/TaskService/GetTasks",
+			wantCode: codes.PermissionDenied, wantReason: "WORKSPACE_SUSPENDED"},
+		{name: "workspace token for AdminService", ctx: withAuthorization("Bearer active-token"),
+			method: "/" + pb.AdminService_ServiceDesc.ServiceName + "/CreateWorkspace", wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req any) (any, error) {
+				called = true
+				return nil, nil
+			})
+			if called {
+				t.Fatal("handler was called")
+			}
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v (%s), want %v", st.Code(), st.Message(), tt.wantCode)
+			}
+			if tt.wantReason == "" {
+				return
+			}
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == tt.wantReason {
+					return
+				}
+			}
+			t.Errorf("details = %v, want an ErrorInfo with reason %s", st.Details(), tt.wantReason)
+		})
+	}
+}
+
+func TestUnaryServerInterceptorResolvesTenant(t *testing.T) {
+	interceptor := newTestAuthenticator().UnaryServerInterceptor()
+	var tenantID string
+	_, err := interceptor(withAuthorization("Bearer active-token"), nil, &grpc.UnaryServerInfo{FullMethod: "/TaskService/GetTasks"},
+		func(ctx context.Context, req any) (any, error) {
+			tenantID, _ = tenant.IDFromContext(ctx)
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("interceptor returned %v", err)
+	}
+	if tenantID != "1" {
+		t.Errorf("tenant = %q, want 1", tenantID)
+	}
+
+	_, err = interceptor(withAuthorization("Bearer admin-secret"), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/" + pb.AdminService_ServiceDesc.ServiceName + "/CreateWorkspace"},
+		func(ctx context.Context, req any) (any, error) {
+			if !IsAdmin(ctx) {
+				t.Error("AdminService call is not authenticated as admin")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("interceptor returned %v for the admin token", err)
+	}
+}
+
+// fakeServerStream is a server stream that only has a context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptorRejectsSuspendedWorkspace(t *testing.T) {
+	interceptor := newTestAuthenticator().StreamServerInterceptor()
+	for header, want := range map[string]codes.Code{"": codes.Unauthenticated, "Bearer suspended-token": codes.PermissionDenied} {
+		err := interceptor(nil, &fakeServerStream{ctx: withAuthorization(header)}, &grpc.StreamServerInfo{FullMethod: "/TaskService/ExportTasks"},
+			func(srv any, stream grpc.ServerStream) error {
+				t.Errorf("handler was called with authorization %q", header)
+				return nil
+			})
+		if code := status.Code(err); code != want {
+			t.Errorf("authorization %q: code = %v, want %v", header, code, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package caldav
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/ical"
+	repo "Go_Test/repository"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// object is a task as a calendar object resource.
+type object struct {
+	task *pb.Task
+	// name is the last segment of the URL of the object.
+	name string
+	uid  string
+}
+
+// etag changes with every change of the task, as its version does.
+func (o *object) etag() string {
+	return fmt.Sprintf(`"%s-%d"`, o.task.GetId(), o.task.GetVersion())
+}
+
+// data renders the object as an iCalendar object with a single VTODO.
+func (o *object) data() ([]byte, error) {
+	var buf bytes.Buffer
+	err := ical.Encode(&buf, &ical.Calendar{
+		Tasks:      []*pb.Task{o.task},
+		Components: ical.VTodo,
+		UIDs:       map[string]string{o.task.GetId(): o.uid},
+	})
+	return buf.Bytes(), err
+}
+
+// lastModified formats the last change of the task for the Last-Modified header.
+func (o *object) lastModified() string {
+	t, err := time.Parse(time.RFC3339, o.task.GetUpdatedAt())
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(http.TimeFormat)
+}
+
+// defaultName returns the object name of a task that no client created.
+func defaultName(taskID string) string {
+	return "task-" + taskID + ".ics"
+}
+
+// defaultTaskID parses a name returned by defaultName.
+func defaultTaskID(name string) (string, bool) {
+	id, ok := strings.CutPrefix(name, "task-")
+	if !ok {
+		return "", false
+	}
+	id, ok = strings.CutSuffix(id, ".ics")
+	if _, err := strconv.ParseUint(id, 10, 64); !ok || err != nil {
+		return "", false
+	}
+	return id, true
+}
+
+// newObject names task after the object a client created it as, if any.
+func newObject(task *pb.Task, objects map[string]repo.CalendarObject) *object {
+	if o, ok := objects[task.GetId()]; ok {
+		return &object{task: task, name: o.Name, uid: o.UID}
+	}
+	return &object{task: task, name: defaultName(task.GetId()), uid: ical.UID(task.GetId())}
+}
+
+// calendar is a project and its tasks.
+type calendar struct {
+	project string
+	objects []*object
+}
+
+func (c *calendar) displayName() string {
+	if c.project == "" {
+		return "Inbox"
+	}
+	return c.project
+}
+
+func (c *calendar) description() string {
+	if c.project == "" {
+		return "Tasks without a project"
+	}
+	return "Tasks of project " + c.project
+}
+
+// ctag changes whenever a task of the calendar is added, changed, moved away or
+// deleted, as the set of task IDs and versions does.
+func (c *calendar) ctag() string {
+	h := sha256.New()
+	for _, o := range c.objects {
+		fmt.Fprintf(h, "%s:%d\n", o.task.GetId(), o.task.GetVersion())
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// object returns the object with the given name, or nil.
+func (c *calendar) object(name string) *object {
+	for _, o := range c.objects {
+		if o.name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// loadCalendars returns the calendars of the tenant of ctx, the inbox first and
+// the projects by name.
+func (h *Handler) loadCalendars(ctx context.Context) ([]*calendar, error) {
+	tasks, err := h.tasks.FetchTasks(ctx, repo.TaskFilter{})
+	if err != nil {
+		return nil, err
+	}
+	objects, err := h.tasks.ListCalendarObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byProject := map[string]*calendar{"": {project: ""}}
+	for _, task := range tasks {
+		c, ok := byProject[task.GetProject()]
+		if !ok {
+			c = &calendar{project: task.GetProject()}
+			byProject[task.GetProject()] = c
+		}
+		c.objects = append(c.objects, newObject(task, objects))
+	}
+	calendars := make([]*calendar, 0, len(byProject))
+	for _, c := range byProject {
+		calendars = append(calendars, c)
+	}
+	slices.SortFunc(calendars, func(a, b *calendar) int { return strings.Compare(a.project, b.project) })
+	return calendars, nil
+}
+
+// loadCalendar returns the calendar of project, or nil if the project has no tasks.
+// The inbox always exists.
+func (h *Handler) loadCalendar(ctx context.Context, project string) (*calendar, error) {
+	calendars, err := h.loadCalendars(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range calendars {
+		if c.project == project {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// errOtherCalendar is returned by lookup for an object name that belongs to a task
+// of another calendar.
+var errOtherCalendar = errors.New("calendar object belongs to another calendar")
+
+// lookup returns the object with the given name in the calendar of project, or
+// sql.ErrNoRows.
+func (h *Handler) lookup(ctx context.Context, project, name string) (*object, error) {
+	taskID, uid, err := h.resolveName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	task, err := h.tasks.FetchTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.GetProject() != project {
+		return nil, errOtherCalendar
+	}
+	return &object{task: task, name: name, uid: uid}, nil
+}
+
+// resolveName returns the ID and UID of the task behind an object name.
+func (h *Handler) resolveName(ctx context.Context, name string) (taskID, uid string, err error) {
+	recorded, err := h.tasks.FetchCalendarObjectByName(ctx, name)
+	if err == nil {
+		return recorded.TaskID, recorded.UID, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", "", err
+	}
+	taskID, ok := defaultTaskID(name)
+	if !ok {
+		return "", "", sql.ErrNoRows
+	}
+	// A task that a client created is only found under the name it gave it.
+	objects, err := h.tasks.ListCalendarObjects(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := objects[taskID]; ok {
+		return "", "", sql.ErrNoRows
+	}
+	return taskID, ical.UID(taskID), nil
+}
@@ -0,0 +1,172 @@
+package caldav
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	goical "github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	davclient "github.com/emersion/go-webdav/caldav"
+)
+
+// ifMatchClient adds an If-Match header to PUT requests, which the go-webdav
+// client does not send itself.
+type ifMatchClient struct {
+	webdav.HTTPClient
+	etag string
+}
+
+func (c *ifMatchClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPut && c.etag != "" {
+		req.Header.Set("If-Match", `"`+c.etag+`"`)
+	}
+	return c.HTTPClient.Do(req)
+}
+
+// newTestClient serves a test handler over HTTP and returns a go-webdav CalDAV
+// client of the server that authenticates with testToken.
+func newTestClient(t *testing.T) (*davclient.Client, *ifMatchClient, *fakeTasks) {
+	t.Helper()
+	h, tasks := newTestHandler()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+
+	httpClient := &ifMatchClient{HTTPClient: webdav.HTTPClientWithBasicAuth(server.Client(), "alice", testToken)}
+	client, err := davclient.NewClient(httpClient, server.URL+Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, httpClient, tasks
+}
+
+// todoRequest asks for the whole calendar data of VTODO objects.
+var todoRequest = davclient.CalendarCompRequest{
+	Name:     "VCALENDAR",
+	AllProps: true,
+	Comps:    []davclient.CalendarCompRequest{{Name: "VTODO", AllProps: true}},
+}
+
+// summaries returns the SUMMARY of the VTODO of every object, keyed by path.
+func summaries(t *testing.T, objects []davclient.CalendarObject) map[string]string {
+	t.Helper()
+	found := make(map[string]string)
+	for _, o := range objects {
+		if o.ETag == "" || o.Data == nil {
+			t.Errorf("%s has ETag %q and data %v", o.Path, o.ETag, o.Data)
+			continue
+		}
+		todos := o.Data.Children
+		if len(todos) != 1 || todos[0].Name != goical.CompToDo {
+			t.Errorf("%s holds %d components, want one VTODO", o.Path, len(todos))
+			continue
+		}
+		summary, _ := todos[0].Props.Text(goical.PropSummary)
+		found[o.Path] = summary
+	}
+	return found
+}
+
+func TestClientDiscovery(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	ctx := context.Background()
+
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	if err != nil || principal != principalPath {
+		t.Fatalf("FindCurrentUserPrincipal = %q, %v; want %q", principal, err, principalPath)
+	}
+	home, err := client.FindCalendarHomeSet(ctx, principal)
+	if err != nil || home != homePath {
+		t.Fatalf("FindCalendarHomeSet = %q, %v; want %q", home, err, homePath)
+	}
+	calendars, err := client.FindCalendars(ctx, home)
+	if err != nil {
+		t.Fatalf("FindCalendars: %v", err)
+	}
+	names := make(map[string]string)
+	for _, c := range calendars {
+		names[c.Path] = c.Name
+		if !slices.Contains(c.SupportedComponentSet, goical.CompToDo) {
+			t.Errorf("calendar %s supports %v, want VTODO", c.Path, c.SupportedComponentSet)
+		}
+	}
+	if len(names) != 2 || names[homePath+"_inbox/"] != "Inbox" || names[homePath+"work/"] == "" {
+		t.Errorf("FindCalendars found %v, want the inbox and work", names)
+	}
+}
+
+func TestClientQueryAndMultiGet(t *testing.T) {
+	client, _, _ := newTestClient(t)
+	ctx := context.Background()
+	calendarPath := homePath + "work/"
+
+	objects, err := client.QueryCalendar(ctx, calendarPath, &davclient.CalendarQuery{
+		CompRequest: todoRequest,
+		CompFilter:  davclient.CompFilter{Name: "VCALENDAR", Comps: []davclient.CompFilter{{Name: "VTODO"}}},
+	})
+	if err != nil {
+		t.Fatalf("QueryCalendar: %v", err)
+	}
+	got := summaries(t, objects)
+	if len(got) != 2 || got[calendarPath+"task-2.ics"] != "Write report" || got[calendarPath+"task-3.ics"] != "Book flights" {
+		t.Errorf("QueryCalendar found %v, want tasks 2 and 3", got)
+	}
+
+	objects, err = client.MultiGetCalendar(ctx, calendarPath, &davclient.CalendarMultiGet{
+		Paths:       []string{calendarPath + "task-2.ics", calendarPath + "task-3.ics"},
+		CompRequest: todoRequest,
+	})
+	if err != nil {
+		t.Fatalf("MultiGetCalendar: %v", err)
+	}
+	got = summaries(t, objects)
+	if len(got) != 2 || got[calendarPath+"task-2.ics"] != "Write report" || got[calendarPath+"task-3.ics"] != "Book flights" {
+		t.Errorf("MultiGetCalendar found %v, want tasks 2 and 3", got)
+	}
+	for _, o := range objects {
+		if o.Path == calendarPath+"task-2.ics" && o.ETag != "2-3" {
+			t.Errorf("MultiGetCalendar returned ETag %q for task 2, want 2-3", o.ETag)
+		}
+	}
+}
+
+func TestClientPutWithIfMatch(t *testing.T) {
+	client, httpClient, _ := newTestClient(t)
+	ctx := context.Background()
+	path := homePath + "work/task-2.ics"
+
+	current, err := client.GetCalendarObject(ctx, path)
+	if err != nil || current.ETag != "2-3" {
+		t.Fatalf("GetCalendarObject = %v, %v; want ETag 2-3", current, err)
+	}
+	cal := current.Data
+	cal.Children[0].Props.SetText(goical.PropSummary, "Write the final report")
+
+	// The HTTP error of go-webdav is internal; its message starts with the status.
+	httpClient.etag = "2-2"
+	if _, err := client.PutCalendarObject(ctx, path, cal); err == nil || !strings.Contains(err.Error(), "412 Precondition Failed") {
+		t.Errorf("PutCalendarObject with a stale ETag: %v, want 412", err)
+	}
+
+	httpClient.etag = current.ETag
+	if _, err := client.PutCalendarObject(ctx, path, cal); err != nil {
+		t.Fatalf("PutCalendarObject with the current ETag: %v", err)
+	}
+	updated, err := client.GetCalendarObject(ctx, path)
+	if err != nil {
+		t.Fatalf("GetCalendarObject after PUT: %v", err)
+	}
+	if updated.ETag == current.ETag {
+		t.Errorf("the ETag stayed %q after PUT", updated.ETag)
+	}
+	if got := summaries(t, []davclient.CalendarObject{*updated}); got[path] != "Write the final report" {
+		t.Errorf("object after PUT has summary %q", got[path])
+	}
+
+	if _, err := client.PutCalendarObject(ctx, path, cal); err == nil || !strings.Contains(err.Error(), "412 Precondition Failed") {
+		t.Errorf("PutCalendarObject with the replaced ETag: %v, want 412", err)
+	}
+}
@@ -0,0 +1,275 @@
+// Package caldav serves the tasks of a workspace over CalDAV (RFC 4791), so that
+// task apps such as Thunderbird, Apple Reminders and tasks.org can read, create,
+// change, complete and delete them.
+//
+// Every project is a calendar of VTODOs, and the tasks without a project form one
+// more calendar, the inbox. The ETag of a task is built from its version, which
+// grows with every change, and the ctag of a calendar from the IDs and versions
+// of its tasks, so that clients can tell what changed since they last synced.
+// Clients sign in with HTTP Basic authentication and an API token as password.
+package caldav
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the CalDAV handler for FX.
+var Module = fx.Options(
+	fx.Provide(NewHandler),
+)
+
+const (
+	// Path is the path prefix of all CalDAV resources on the REST gateway.
+	Path = "/caldav/"
+	// WellKnownPath lets clients find Path from the host name alone (RFC 6764).
+	WellKnownPath = "/.well-known/caldav"
+
+	principalPath = Path + "principal/"
+	homePath      = Path + "calendars/"
+
+	// inboxSegment names the calendar of the tasks without a project. Project
+	// names that start with an underscore get a second one in URLs, so that no
+	// project can take the name of the inbox.
+	inboxSegment = "_inbox"
+
+	// davCapabilities is the DAV header of every response.
+	davCapabilities = "1, calendar-access"
+	allowedMethods  = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, PROPPATCH, REPORT"
+	// maxBodySize limits the XML bodies of PROPFIND, PROPPATCH and REPORT requests.
+	maxBodySize = 1 << 20
+)
+
+// Methods lists the methods to route to the handler. Collections cannot be
+// created, but MKCALENDAR and MKCOL are answered with an explanation.
+var Methods = []string{
+	http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+	"PROPFIND", "PROPPATCH", "REPORT", "MKCALENDAR", "MKCOL",
+}
+
+// Handler serves the CalDAV resources below Path.
+type Handler struct {
+	tasks      repo.TaskRepository
+	workspaces repo.WorkspaceRepository
+	logger     *zap.Logger
+	// location is the time zone of floating times and dates sent by clients.
+	location *time.Location
+}
+
+type HandlerParams struct {
+	fx.In
+	Logger     *zap.Logger
+	Tasks      repo.TaskRepository
+	Workspaces repo.WorkspaceRepository
+}
+
+// NewHandler creates the CalDAV handler, to be mounted at Path and WellKnownPath.
+// Floating times are read in the local time zone of the server.
+func NewHandler(p HandlerParams) *Handler {
+	return &Handler{
+		tasks:      p.Tasks,
+		workspaces: p.Workspaces,
+		logger:     p.Logger.Named("caldav"),
+		location:   time.Local,
+	}
+}
+
+// request is an authenticated CalDAV request.
+type request struct {
+	w         http.ResponseWriter
+	r         *http.Request
+	ctx       context.Context
+	logger    *zap.Logger
+	workspace *pb.Workspace
+	target    target
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == WellKnownPath {
+		http.Redirect(w, r, Path, http.StatusMovedPermanently)
+		return
+	}
+	w.Header().Set("DAV", davCapabilities)
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Allow", allowedMethods)
+		return
+	}
+
+	workspace, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+	t, ok := parsePath(r.URL.EscapedPath())
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	q := &request{
+		w:         w,
+		r:         r,
+		ctx:       tenant.WithID(r.Context(), workspace.GetId()),
+		logger:    h.logger.With(zap.String("tenant_id", workspace.GetId()), zap.String("method", r.Method), zap.String("path", r.URL.Path)),
+		workspace: workspace,
+		target:    t,
+	}
+	switch r.Method {
+	case "PROPFIND":
+		h.propfind(q)
+	case "PROPPATCH":
+		h.proppatch(q)
+	case "REPORT":
+		h.report(q)
+	case http.MethodGet, http.MethodHead:
+		h.get(q)
+	case http.MethodPut:
+		h.put(q)
+	case http.MethodDelete:
+		h.delete(q)
+	case "MKCALENDAR", "MKCOL":
+		http.Error(w, "calendars cannot be created; a project appears as a calendar once it has a task", http.StatusForbidden)
+	default:
+		w.Header().Set("Allow", allowedMethods)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// authenticate resolves the API token of the request into its workspace. Clients
+// send the token as the password of HTTP Basic authentication, with any user
+// name, or as a bearer token.
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (*pb.Workspace, bool) {
+	_, token, ok := r.BasicAuth()
+	if !ok {
+		token, ok = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if !ok || token == "" {
+		unauthorized(w)
+		return nil, false
+	}
+	workspace, err := h.workspaces.FetchWorkspaceByTokenHash(r.Context(), auth.HashToken(token))
+	if err == sql.ErrNoRows {
+		unauthorized(w)
+		return nil, false
+	}
+	if err != nil {
+		h.logger.Error("Failed to resolve API token", zap.Error(err))
+		http.Error(w, "failed to resolve API token", http.StatusInternalServerError)
+		return nil, false
+	}
+	if workspace.GetStatus() != auth.WorkspaceStatusActive {
+		http.Error(w, "workspace is "+workspace.GetStatus(), http.StatusForbidden)
+		return nil, false
+	}
+	return workspace, true
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="fx-grpc-app", charset="UTF-8"`)
+	http.Error(w, "an API token is required as password", http.StatusUnauthorized)
+}
+
+// internalError logs err and answers the request with 500.
+func (q *request) internalError(message string, err error) {
+	q.logger.Error("Failed to "+message, zap.Error(err))
+	http.Error(q.w, "failed to "+message, http.StatusInternalServerError)
+}
+
+// targetKind is the kind of resource a URL names.
+type targetKind int
+
+const (
+	rootTarget targetKind = iota
+	principalTarget
+	homeTarget
+	calendarTarget
+	objectTarget
+)
+
+// target is the resource a request URL names. project is set for calendars and
+// calendar objects, name for calendar objects only.
+type target struct {
+	kind    targetKind
+	project string
+	name    string
+}
+
+// parsePath parses the escaped path of a request URL below Path.
+func parsePath(escaped string) (target, bool) {
+	rest, ok := strings.CutPrefix(escaped, Path)
+	if !ok {
+		return target{}, escaped+"/" == Path
+	}
+	segments := strings.Split(strings.TrimSuffix(rest, "/"), "/")
+	switch {
+	case rest == "":
+		return target{kind: rootTarget}, true
+	case len(segments) == 1 && segments[0] == "principal":
+		return target{kind: principalTarget}, true
+	case segments[0] != "calendars":
+		return target{}, false
+	case len(segments) == 1:
+		return target{kind: homeTarget}, true
+	}
+	project, ok := parseCalendarSegment(segments[1])
+	if !ok {
+		return target{}, false
+	}
+	switch len(segments) {
+	case 2:
+		return target{kind: calendarTarget, project: project}, true
+	case 3:
+		name, err := url.PathUnescape(segments[2])
+		if err != nil || name == "" || strings.HasSuffix(rest, "/") {
+			return target{}, false
+		}
+		return target{kind: objectTarget, project: project, name: name}, true
+	}
+	return target{}, false
+}
+
+// calendarSegment returns the escaped URL segment of the calendar of project.
+func calendarSegment(project string) string {
+	if project == "" {
+		return inboxSegment
+	}
+	if strings.HasPrefix(project, "_") {
+		project = "_" + project
+	}
+	return url.PathEscape(project)
+}
+
+// parseCalendarSegment reverses calendarSegment.
+func parseCalendarSegment(escaped string) (string, bool) {
+	segment, err := url.PathUnescape(escaped)
+	switch {
+	case err != nil || segment == "":
+		return "", false
+	case segment == inboxSegment:
+		return "", true
+	case strings.HasPrefix(segment, "__"):
+		return segment[1:], true
+	case strings.HasPrefix(segment, "_"):
+		return "", false
+	}
+	return segment, true
+}
+
+// calendarHref returns the URL path of the calendar of project.
+func calendarHref(project string) string {
+	return homePath + calendarSegment(project) + "/"
+}
+
+// objectHref returns the URL path of a calendar object.
+func objectHref(project, name string) string {
+	return calendarHref(project) + url.PathEscape(name)
+}
@@ -0,0 +1,519 @@
+package caldav
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+const testToken = "alice-token"
+
+// fakeWorkspaces knows the token testToken of the active workspace 1.
+type fakeWorkspaces struct {
+	repo.WorkspaceRepository
+}
+
+func (fakeWorkspaces) FetchWorkspaceByTokenHash(ctx context.Context, tokenHash string) (*pb.Workspace, error) {
+	if tokenHash != auth.HashToken(testToken) {
+		return nil, sql.ErrNoRows
+	}
+	return &pb.Workspace{Id: "1", Name: "Alice's tasks", Status: auth.WorkspaceStatusActive}, nil
+}
+
+// fakeTasks keeps the tasks of workspace 1 in memory. It supports the methods
+// the handler calls; the others panic through the nil embedded interface.
+type fakeTasks struct {
+	repo.TaskRepository
+
+	mu      sync.Mutex
+	tasks   []*pb.Task
+	objects []repo.CalendarObject
+	nextID  int
+}
+
+type fakeTaskTx struct {
+	*fakeTasks
+}
+
+func (tx fakeTaskTx) Savepoint(ctx context.Context, fn func() error) error { return fn() }
+
+func (f *fakeTasks) InTransaction(ctx context.Context, fn func(tx repo.TaskTx) error) error {
+	return fn(fakeTaskTx{f})
+}
+
+func requireTenant(ctx context.Context) {
+	if id, _ := tenant.IDFromContext(ctx); id != "1" {
+		panic("repository called without the tenant of the workspace")
+	}
+}
+
+func (f *fakeTasks) FetchTasks(ctx context.Context, filter repo.TaskFilter) ([]*pb.Task, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tasks := make([]*pb.Task, len(f.tasks))
+	for i, task := range f.tasks {
+		tasks[i] = proto.Clone(task).(*pb.Task)
+	}
+	return tasks, nil
+}
+
+func (f *fakeTasks) FetchTaskByID(ctx context.Context, taskID string) (*pb.Task, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, task := range f.tasks {
+		if task.GetId() == taskID {
+			return proto.Clone(task).(*pb.Task), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeTasks) AddTask(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextID++
+	added := proto.Clone(task).(*pb.Task)
+	added.Id = strconv.Itoa(f.nextID)
+	added.Version = 1
+	added.UpdatedAt = "2026-10-19T08:00:00Z"
+	f.tasks = append(f.tasks, added)
+	return proto.Clone(added).(*pb.Task), nil
+}
+
+func (f *fakeTasks) UpdateTask(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, existing := range f.tasks {
+		if existing.GetId() != task.GetId() {
+			continue
+		}
+		if task.GetVersion() != 0 && task.GetVersion() != existing.GetVersion() {
+			return nil, repo.ErrVersionConflict
+		}
+		updated := proto.Clone(task).(*pb.Task)
+		updated.Version = existing.GetVersion() + 1
+		updated.UpdatedAt = "2026-10-19T09:00:00Z"
+		f.tasks[i] = updated
+		return proto.Clone(updated).(*pb.Task), nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (f *fakeTasks) DeleteTask(ctx context.Context, taskID string, version int32) error {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	i := slices.IndexFunc(f.tasks, func(task *pb.Task) bool { return task.GetId() == taskID })
+	switch {
+	case i < 0:
+		return sql.ErrNoRows
+	case version != 0 && version != f.tasks[i].GetVersion():
+		return repo.ErrVersionConflict
+	}
+	f.tasks = slices.Delete(f.tasks, i, i+1)
+	f.objects = slices.DeleteFunc(f.objects, func(o repo.CalendarObject) bool { return o.TaskID == taskID })
+	return nil
+}
+
+func (f *fakeTasks) AddCalendarObject(ctx context.Context, object repo.CalendarObject) error {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects = append(f.objects, object)
+	return nil
+}
+
+func (f *fakeTasks) ListCalendarObjects(ctx context.Context) (map[string]repo.CalendarObject, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	objects := make(map[string]repo.CalendarObject)
+	for _, o := range f.objects {
+		objects[o.TaskID] = o
+	}
+	return objects, nil
+}
+
+func (f *fakeTasks) FetchCalendarObjectByName(ctx context.Context, name string) (repo.CalendarObject, error) {
+	requireTenant(ctx)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, o := range f.objects {
+		if o.Name == name {
+			return o, nil
+		}
+	}
+	return repo.CalendarObject{}, sql.ErrNoRows
+}
+
+// newTestHandler returns a handler with task 1 in the inbox and tasks 2 and 3
+// in project work.
+func newTestHandler() (*Handler, *fakeTasks) {
+	tasks := &fakeTasks{nextID: 3, tasks: []*pb.Task{
+		{Id: "1", Title: "Water plants", Status: "pending", Version: 1, UpdatedAt: "2026-10-18T08:00:00Z"},
+		{Id: "2", Title: "Write report", Status: "pending", Project: "work", Priority: "high", Assignee: "alice",
+			DueAt: "2026-10-20T09:00:00Z", Version: 3, UpdatedAt: "2026-10-18T09:00:00Z"},
+		{Id: "3", Title: "Book flights", Status: "completed", Project: "work", Version: 1,
+			CompletedAt: "2026-10-17T12:00:00Z", UpdatedAt: "2026-10-17T12:00:00Z"},
+	}}
+	h := NewHandler(HandlerParams{Logger: zap.NewNop(), Tasks: tasks, Workspaces: fakeWorkspaces{}})
+	h.location = time.UTC
+	return h, tasks
+}
+
+// do sends an authenticated request with the given headers, as pairs of name
+// and value.
+func do(h http.Handler, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.SetBasicAuth("alice", testToken)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// multistatus is the part of a 207 response that the tests inspect.
+type multistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Status    string `xml:"DAV: status"`
+		Propstats []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				Props []struct {
+					XMLName xml.Name
+					Text    string   `xml:",chardata"`
+					Hrefs   []string `xml:"DAV: href"`
+					Inner   string   `xml:",innerxml"`
+				} `xml:",any"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// prop is a property found with status 200.
+type prop struct {
+	text  string
+	hrefs []string
+	inner string
+}
+
+// parseMultistatus returns the properties found per href, keyed by local name,
+// and the status of hrefs answered without properties.
+func parseMultistatus(t *testing.T, rec *httptest.ResponseRecorder) (map[string]map[string]prop, map[string]string) {
+	t.Helper()
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("status = %d, want 207; body: %s", rec.Code, rec.Body)
+	}
+	var ms multistatus
+	if err := xml.Unmarshal(rec.Body.Bytes(), &ms); err != nil {
+		t.Fatalf("invalid multistatus: %v\n%s", err, rec.Body)
+	}
+	found := make(map[string]map[string]prop)
+	statuses := make(map[string]string)
+	for _, resp := range ms.Responses {
+		if resp.Status != "" {
+			statuses[resp.Href] = resp.Status
+		}
+		props := make(map[string]prop)
+		for _, ps := range resp.Propstats {
+			if !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			for _, p := range ps.Prop.Props {
+				props[p.XMLName.Local] = prop{text: p.Text, hrefs: p.Hrefs, inner: p.Inner}
+			}
+		}
+		found[resp.Href] = props
+	}
+	return found, statuses
+}
+
+func propfind(props ...string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>` +
+		`<d:propfind xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/"><d:prop>` +
+		strings.Join(props, "") + `</d:prop></d:propfind>`
+}
+
+func TestDiscovery(t *testing.T) {
+	h, _ := newTestHandler()
+
+	rec := do(h, http.MethodGet, WellKnownPath, "")
+	if rec.Code != http.StatusMovedPermanently || rec.Header().Get("Location") != Path {
+		t.Fatalf("%s answered %d to %q, want a redirect to %s", WellKnownPath, rec.Code, rec.Header().Get("Location"), Path)
+	}
+
+	rec = do(h, "PROPFIND", Path, propfind("<d:current-user-principal/>"), "Depth", "0")
+	found, _ := parseMultistatus(t, rec)
+	principal := found[Path]["current-user-principal"].hrefs
+	if len(principal) != 1 || principal[0] != principalPath {
+		t.Fatalf("current-user-principal = %v, want %s", principal, principalPath)
+	}
+	if dav := rec.Header().Get("DAV"); !strings.Contains(dav, "calendar-access") {
+		t.Errorf("DAV header = %q, want calendar-access", dav)
+	}
+
+	rec = do(h, "PROPFIND", principal[0], propfind("<c:calendar-home-set/>", "<d:displayname/>"), "Depth", "0")
+	found, _ = parseMultistatus(t, rec)
+	home := found[principalPath]["calendar-home-set"].hrefs
+	if len(home) != 1 || home[0] != homePath {
+		t.Fatalf("calendar-home-set = %v, want %s", home, homePath)
+	}
+	if name := found[principalPath]["displayname"].text; name != "Alice's tasks" {
+		t.Errorf("principal displayname = %q, want the workspace name", name)
+	}
+
+	rec = do(h, "PROPFIND", home[0], propfind("<d:resourcetype/>", "<d:displayname/>", "<c:supported-calendar-component-set/>", "<cs:getctag/>"), "Depth", "1")
+	found, _ = parseMultistatus(t, rec)
+	calendars := map[string]string{homePath + "_inbox/": "Inbox", homePath + "work/": "work"}
+	for href, name := range calendars {
+		props, ok := found[href]
+		if !ok {
+			t.Errorf("calendar %s is missing from the home set; got %v", href, found)
+			continue
+		}
+		if !strings.Contains(props["resourcetype"].inner, "calendar") {
+			t.Errorf("resourcetype of %s = %q, want a calendar", href, props["resourcetype"].inner)
+		}
+		if props["displayname"].text != name {
+			t.Errorf("displayname of %s = %q, want %q", href, props["displayname"].text, name)
+		}
+		if !strings.Contains(props["supported-calendar-component-set"].inner, `name="VTODO"`) {
+			t.Errorf("%s does not support VTODO: %q", href, props["supported-calendar-component-set"].inner)
+		}
+		if props["getctag"].text == "" {
+			t.Errorf("%s has no getctag", href)
+		}
+	}
+	if len(found) != len(calendars)+1 {
+		t.Errorf("home set lists %d resources, want the home and %d calendars", len(found), len(calendars))
+	}
+
+	rec = do(h, "PROPFIND", homePath+"work/", propfind("<d:getetag/>"), "Depth", "1")
+	found, _ = parseMultistatus(t, rec)
+	for _, name := range []string{"task-2.ics", "task-3.ics"} {
+		if found[homePath+"work/"+name]["getetag"].text == "" {
+			t.Errorf("calendar work does not list %s with an ETag; got %v", name, found)
+		}
+	}
+	if _, ok := found[homePath+"work/task-1.ics"]; ok {
+		t.Error("calendar work lists task 1 of the inbox")
+	}
+}
+
+func TestAuthenticationIsRequired(t *testing.T) {
+	h, _ := newTestHandler()
+	for _, token := range []string{"", "wrong-token"} {
+		req := httptest.NewRequest("PROPFIND", Path, nil)
+		if token != "" {
+			req.SetBasicAuth("alice", token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("token %q: status = %d, want 401 with a challenge", token, rec.Code)
+		}
+	}
+}
+
+func TestCalendarMultiget(t *testing.T) {
+	h, _ := newTestHandler()
+	body := `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <d:href>/caldav/calendars/work/task-2.ics</d:href>
+  <d:href>http://tasks.example.com/caldav/calendars/work/task-3.ics</d:href>
+  <d:href>/caldav/calendars/work/task-1.ics</d:href>
+  <d:href>/caldav/calendars/work/missing.ics</d:href>
+</c:calendar-multiget>`
+	found, statuses := parseMultistatus(t, do(h, "REPORT", homePath+"work/", body, "Depth", "1"))
+
+	report := found[homePath+"work/task-2.ics"]
+	if report["getetag"].text != `"2-3"` {
+		t.Errorf("getetag of task 2 = %q, want %q", report["getetag"].text, `"2-3"`)
+	}
+	data := report["calendar-data"].text
+	for _, want := range []string{"BEGIN:VTODO", "UID:task-2@", "SUMMARY:Write report", "STATUS:NEEDS-ACTION", "DUE:20261020T090000Z"} {
+		if !strings.Contains(data, want) {
+			t.Errorf("calendar-data of task 2 lacks %q:\n%s", want, data)
+		}
+	}
+	if data := found[homePath+"work/task-3.ics"]["calendar-data"].text; !strings.Contains(data, "STATUS:COMPLETED") {
+		t.Errorf("calendar-data of task 3, requested by absolute URL, is not completed:\n%s", data)
+	}
+	// Task 1 exists, but in the inbox rather than in this calendar.
+	for _, name := range []string{"task-1.ics", "missing.ics"} {
+		if status := statuses[homePath+"work/"+name]; !strings.Contains(status, " 404 ") {
+			t.Errorf("status of %s = %q, want 404", name, status)
+		}
+	}
+}
+
+func TestCalendarQuery(t *testing.T) {
+	h, _ := newTestHandler()
+	query := func(filter string) string {
+		return `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter>` + filter + `</c:filter>
+</c:calendar-query>`
+	}
+
+	found, _ := parseMultistatus(t, do(h, "REPORT", homePath+"work/",
+		query(`<c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter>`), "Depth", "1"))
+	if len(found) != 2 {
+		t.Fatalf("VTODO query returned %d objects, want 2: %v", len(found), found)
+	}
+	for _, name := range []string{"task-2.ics", "task-3.ics"} {
+		report := found[homePath+"work/"+name]
+		if report["getetag"].text == "" || !strings.Contains(report["calendar-data"].text, "BEGIN:VTODO") {
+			t.Errorf("VTODO query returned %s without ETag or VTODO: %v", name, report)
+		}
+	}
+
+	found, _ = parseMultistatus(t, do(h, "REPORT", homePath+"work/",
+		query(`<c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT"/></c:comp-filter>`), "Depth", "1"))
+	if len(found) != 0 {
+		t.Errorf("VEVENT query returned %d objects, want none", len(found))
+	}
+
+	rec := do(h, "REPORT", homePath, query(`<c:comp-filter name="VCALENDAR"/>`), "Depth", "1")
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "supported-report") {
+		t.Errorf("report on the home set answered %d, want 403 supported-report: %s", rec.Code, rec.Body)
+	}
+}
+
+const updatedTodo = "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\n" +
+	"BEGIN:VTODO\r\nUID:task-2@example.com\r\nSUMMARY:Write the final report\r\nSTATUS:NEEDS-ACTION\r\n" +
+	"DUE:20261021T090000Z\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+
+func TestConditionalRequests(t *testing.T) {
+	h, tasks := newTestHandler()
+	path := homePath + "work/task-2.ics"
+
+	rec := do(h, http.MethodGet, path, "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag != `"2-3"` {
+		t.Fatalf("GET answered %d with ETag %q, want 200 with %q", rec.Code, etag, `"2-3"`)
+	}
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/calendar") {
+		t.Errorf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+	if rec := do(h, http.MethodGet, path, "", "If-None-Match", etag); rec.Code != http.StatusNotModified {
+		t.Errorf("GET with the current ETag in If-None-Match answered %d, want 304", rec.Code)
+	}
+
+	if rec := do(h, http.MethodPut, path, updatedTodo, "If-Match", `"2-2"`); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with a stale If-Match answered %d, want 412", rec.Code)
+	}
+	if rec := do(h, http.MethodPut, path, updatedTodo, "If-None-Match", "*"); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with If-None-Match: * on an existing object answered %d, want 412", rec.Code)
+	}
+	if task, _ := tasks.FetchTaskByID(tenant.WithID(context.Background(), "1"), "2"); task.GetTitle() != "Write report" {
+		t.Fatalf("a failed precondition changed the task to %q", task.GetTitle())
+	}
+
+	if rec := do(h, http.MethodPut, path, updatedTodo, "If-Match", etag); rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT with the current If-Match answered %d, want 204: %s", rec.Code, rec.Body)
+	}
+	rec = do(h, http.MethodGet, path, "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "SUMMARY:Write the final report") {
+		t.Fatalf("GET after PUT answered %d:\n%s", rec.Code, rec.Body)
+	}
+	task, _ := tasks.FetchTaskByID(tenant.WithID(context.Background(), "1"), "2")
+	if task.GetAssignee() != "alice" || task.GetProject() != "work" {
+		t.Errorf("PUT dropped the assignee or project: %v", task)
+	}
+
+	if rec := do(h, http.MethodDelete, path, "", "If-Match", etag); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("DELETE with a stale If-Match answered %d, want 412", rec.Code)
+	}
+	current := do(h, http.MethodGet, path, "").Header().Get("ETag")
+	if rec := do(h, http.MethodDelete, path, "", "If-Match", current); rec.Code != http.StatusNoContent {
+		t.Fatalf("DELETE with the current If-Match answered %d, want 204", rec.Code)
+	}
+	if rec := do(h, http.MethodGet, path, ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET after DELETE answered %d, want 404", rec.Code)
+	}
+}
+
+func TestPutCreatesObjectUnderClientName(t *testing.T) {
+	h, tasks := newTestHandler()
+	path := homePath + "work/0F1E2D3C.ics"
+	todo := strings.Replace(updatedTodo, "UID:task-2@example.com", "UID:0F1E2D3C@client.example.com", 1)
+
+	if rec := do(h, http.MethodPut, path, todo, "If-Match", `"4-1"`); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("PUT with If-Match on a missing object answered %d, want 412", rec.Code)
+	}
+	if rec := do(h, http.MethodPut, path, todo, "If-None-Match", "*"); rec.Code != http.StatusCreated {
+		t.Fatalf("PUT with If-None-Match: * on a new object answered %d, want 201: %s", rec.Code, rec.Body)
+	}
+	if rec := do(h, http.MethodPut, path, todo, "If-None-Match", "*"); rec.Code != http.StatusPreconditionFailed {
+		t.Errorf("second PUT with If-None-Match: * answered %d, want 412", rec.Code)
+	}
+
+	rec := do(h, http.MethodGet, path, "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "UID:0F1E2D3C@client.example.com") {
+		t.Fatalf("GET of the created object answered %d:\n%s", rec.Code, rec.Body)
+	}
+	task, err := tasks.FetchTaskByID(tenant.WithID(context.Background(), "1"), "4")
+	if err != nil || task.GetProject() != "work" || task.GetTitle() != "Write the final report" {
+		t.Errorf("created task = %v, %v; want it in project work", task, err)
+	}
+	if rec := do(h, http.MethodGet, homePath+"work/task-4.ics", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("the created task is also served under its default name (%d)", rec.Code)
+	}
+}
+
+func TestTagsChangeAfterUpdate(t *testing.T) {
+	h, _ := newTestHandler()
+	calendarPath := homePath + "work/"
+	objectPath := calendarPath + "task-2.ics"
+	tags := func() (ctag, etag string) {
+		t.Helper()
+		found, _ := parseMultistatus(t, do(h, "PROPFIND", calendarPath, propfind("<cs:getctag/>", "<d:getetag/>"), "Depth", "1"))
+		return found[calendarPath]["getctag"].text, found[objectPath]["getetag"].text
+	}
+
+	ctag, etag := tags()
+	if ctag == "" || etag == "" {
+		t.Fatalf("getctag = %q, getetag = %q; want both", ctag, etag)
+	}
+	if again, _ := tags(); again != ctag {
+		t.Fatalf("getctag changed from %q to %q without an update", ctag, again)
+	}
+	if rec := do(h, http.MethodPut, objectPath, updatedTodo, "If-Match", etag); rec.Code != http.StatusNoContent {
+		t.Fatalf("PUT answered %d: %s", rec.Code, rec.Body)
+	}
+	newCtag, newEtag := tags()
+	if newCtag == ctag {
+		t.Errorf("getctag %q did not change after an update", ctag)
+	}
+	if newEtag == etag {
+		t.Errorf("getetag %q did not change after an update", etag)
+	}
+	if got := do(h, http.MethodGet, objectPath, "").Header().Get("ETag"); got != newEtag {
+		t.Errorf("GET returned ETag %q, PROPFIND %q", got, newEtag)
+	}
+}
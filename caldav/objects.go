@@ -0,0 +1,251 @@
+package caldav
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/ical"
+	repo "Go_Test/repository"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTitleLength matches the width of tasks.title in characters.
+	maxTitleLength = 255
+	// maxDescriptionLength matches the size of tasks.description in bytes.
+	maxDescriptionLength = 65535
+	// maxTagLength matches the width of task_tags.tag.
+	maxTagLength = 100
+	// maxNameLength and maxUIDLength match the columns of caldav_objects.
+	maxNameLength = 255
+	maxUIDLength  = 1024
+)
+
+// lookupObject returns the object named by the target of q, or answers q with
+// 404 and returns false.
+func (h *Handler) lookupObject(q *request) (*object, bool) {
+	o, err := h.lookup(q.ctx, q.target.project, q.target.name)
+	if err == sql.ErrNoRows || errors.Is(err, errOtherCalendar) {
+		http.NotFound(q.w, q.r)
+		return nil, false
+	}
+	if err != nil {
+		q.internalError("look up calendar object", err)
+		return nil, false
+	}
+	return o, true
+}
+
+// get serves a calendar object as an iCalendar object with a single VTODO.
+func (h *Handler) get(q *request) {
+	if q.target.kind != objectTarget {
+		w := q.w
+		w.Header().Set("Allow", "OPTIONS, PROPFIND, PROPPATCH, REPORT")
+		http.Error(w, "collections are read with PROPFIND and REPORT", http.StatusMethodNotAllowed)
+		return
+	}
+	o, ok := h.lookupObject(q)
+	if !ok {
+		return
+	}
+	q.w.Header().Set("ETag", o.etag())
+	if modified := o.lastModified(); modified != "" {
+		q.w.Header().Set("Last-Modified", modified)
+	}
+	if matchesETag(q.r.Header.Get("If-None-Match"), o.etag()) {
+		q.w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	data, err := o.data()
+	if err != nil {
+		q.internalError("encode calendar object", err)
+		return
+	}
+	q.w.Header().Set("Content-Type", objectContentType)
+	q.w.Write(data)
+}
+
+// put creates or replaces the task of a calendar object. The stored task differs
+// from the uploaded VTODO, which may carry properties that tasks do not have, so
+// the response carries no ETag and clients read the object again.
+func (h *Handler) put(q *request) {
+	if q.target.kind != objectTarget {
+		http.Error(q.w, "only calendar objects can be written", http.StatusMethodNotAllowed)
+		return
+	}
+	name := q.target.name
+	if utf8.RuneCountInString(name) > maxNameLength {
+		http.Error(q.w, fmt.Sprintf("object names are limited to %d characters", maxNameLength), http.StatusBadRequest)
+		return
+	}
+	uid, task, err := ical.ParseTodo(http.MaxBytesReader(q.w, q.r.Body, maxBodySize), h.location)
+	if err != nil {
+		precondition := "valid-calendar-data"
+		if errors.Is(err, ical.ErrNoTodo) || errors.Is(err, ical.ErrUnsupportedComponent) {
+			precondition = "supported-calendar-component"
+		}
+		q.preconditionFailed(http.StatusForbidden, nsCalDAV, precondition, err.Error())
+		return
+	}
+	if len(uid) > maxUIDLength {
+		http.Error(q.w, fmt.Sprintf("UIDs are limited to %d bytes", maxUIDLength), http.StatusBadRequest)
+		return
+	}
+	normalizeTask(task)
+
+	existing, err := h.lookup(q.ctx, q.target.project, name)
+	switch {
+	case errors.Is(err, errOtherCalendar):
+		http.Error(q.w, "an object of another calendar has this name", http.StatusConflict)
+		return
+	case err == sql.ErrNoRows:
+		existing = nil
+	case err != nil:
+		q.internalError("look up calendar object", err)
+		return
+	}
+	ifMatch, ifNoneMatch := q.r.Header.Get("If-Match"), q.r.Header.Get("If-None-Match")
+	if existing == nil && ifMatch != "" || existing != nil && matchesETag(ifNoneMatch, existing.etag()) ||
+		existing != nil && ifMatch != "" && !matchesETag(ifMatch, existing.etag()) {
+		http.Error(q.w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+
+	if existing == nil {
+		task.Project = q.target.project
+		var created *pb.Task
+		err := h.tasks.InTransaction(q.ctx, func(tx repo.TaskTx) error {
+			var err error
+			if created, err = tx.AddTask(q.ctx, task); err != nil {
+				return err
+			}
+			return tx.AddCalendarObject(q.ctx, repo.CalendarObject{TaskID: created.GetId(), Name: name, UID: uid})
+		})
+		if err != nil {
+			q.internalError("create task", err)
+			return
+		}
+		q.logger.Info("CalDAV client created task", zap.String("task_id", created.GetId()), zap.String("name", name))
+		q.w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	// Assignees have no VTODO property, and statuses such as blocked share
+	// NEEDS-ACTION with pending: both are kept unless the client changed them.
+	previous := existing.task
+	task.Id = previous.GetId()
+	task.Project = previous.GetProject()
+	task.Assignee = previous.GetAssignee()
+	if ical.TodoStatus(task.GetStatus()) == ical.TodoStatus(previous.GetStatus()) {
+		task.Status = previous.GetStatus()
+	}
+	// The version guards against changes made since the lookup.
+	task.Version = previous.GetVersion()
+	updated, err := h.tasks.UpdateTask(q.ctx, task)
+	switch {
+	case errors.Is(err, repo.ErrVersionConflict):
+		http.Error(q.w, "the task has been changed concurrently", http.StatusPreconditionFailed)
+		return
+	case err == sql.ErrNoRows:
+		http.NotFound(q.w, q.r)
+		return
+	case err != nil:
+		q.internalError("update task", err)
+		return
+	}
+	q.logger.Info("CalDAV client updated task", zap.String("task_id", updated.GetId()), zap.Int32("version", updated.GetVersion()))
+	q.w.WriteHeader(http.StatusNoContent)
+}
+
+// delete deletes the task of a calendar object. Calendars cannot be deleted, as
+// that would delete all tasks of a project.
+func (h *Handler) delete(q *request) {
+	if q.target.kind != objectTarget {
+		http.Error(q.w, "calendars cannot be deleted", http.StatusForbidden)
+		return
+	}
+	o, ok := h.lookupObject(q)
+	if !ok {
+		return
+	}
+	ifMatch := q.r.Header.Get("If-Match")
+	if ifMatch != "" && !matchesETag(ifMatch, o.etag()) {
+		http.Error(q.w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+	var version int32
+	if ifMatch != "" {
+		version = o.task.GetVersion()
+	}
+	err := h.tasks.DeleteTask(q.ctx, o.task.GetId(), version)
+	switch {
+	case errors.Is(err, repo.ErrVersionConflict):
+		http.Error(q.w, "the task has been changed concurrently", http.StatusPreconditionFailed)
+		return
+	case err == sql.ErrNoRows:
+		http.NotFound(q.w, q.r)
+		return
+	case err != nil:
+		q.internalError("delete task", err)
+		return
+	}
+	q.logger.Info("CalDAV client deleted task", zap.String("task_id", o.task.GetId()))
+	q.w.WriteHeader(http.StatusNoContent)
+}
+
+// preconditionFailed answers q with a DAV:error body naming the failed precondition.
+func (q *request) preconditionFailed(code int, space, precondition, message string) {
+	q.logger.Debug("CalDAV precondition failed", zap.String("precondition", precondition), zap.String("reason", message))
+	q.w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	q.w.WriteHeader(code)
+	fmt.Fprintf(q.w, `<?xml version="1.0" encoding="utf-8"?>`+"\n"+`<d:error xmlns:d="%s" xmlns:x="%s"><x:%s/><d:responsedescription>%s</d:responsedescription></d:error>`+"\n",
+		nsDAV, space, precondition, escape(message))
+}
+
+// matchesETag reports whether an If-Match or If-None-Match header matches etag.
+func matchesETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeTask fits the fields of a task read from a VTODO to the limits of the
+// tasks table and the rules of the TaskService: titles must be one non-empty line,
+// tags must not contain whitespace.
+func normalizeTask(task *pb.Task) {
+	task.Title = truncate(strings.Join(strings.Fields(task.GetTitle()), " "), maxTitleLength)
+	if task.Title == "" {
+		task.Title = "(no title)"
+	}
+	if len(task.GetDescription()) > maxDescriptionLength {
+		description := task.GetDescription()[:maxDescriptionLength]
+		task.Description = strings.ToValidUTF8(description, "")
+	}
+	tags := make([]string, 0, len(task.GetTags()))
+	for _, tag := range task.GetTags() {
+		tag = strings.ReplaceAll(strings.Join(strings.Fields(tag), "-"), ",", "-")
+		if tag != "" && len(tag) <= maxTagLength && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	slices.Sort(tags)
+	task.Tags = tags
+}
+
+// truncate shortens s to at most n characters.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
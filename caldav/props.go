@@ -0,0 +1,380 @@
+package caldav
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// XML namespaces of the WebDAV, CalDAV and CalendarServer (ctag) properties.
+const (
+	nsDAV       = "DAV:"
+	nsCalDAV    = "urn:ietf:params:xml:ns:caldav"
+	nsCalServer = "http://calendarserver.org/ns/"
+)
+
+// prefixes are declared on every multistatus response.
+var prefixes = map[string]string{nsDAV: "d", nsCalDAV: "c", nsCalServer: "cs"}
+
+var (
+	resourceTypeName     = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	displayNameName      = xml.Name{Space: nsDAV, Local: "displayname"}
+	userPrincipalName    = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	principalURLName     = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	ownerName            = xml.Name{Space: nsDAV, Local: "owner"}
+	privilegeSetName     = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	reportSetName        = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	etagName             = xml.Name{Space: nsDAV, Local: "getetag"}
+	contentTypeName      = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	lastModifiedName     = xml.Name{Space: nsDAV, Local: "getlastmodified"}
+	homeSetName          = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	descriptionName      = xml.Name{Space: nsCalDAV, Local: "calendar-description"}
+	componentSetName     = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	calendarDataName     = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	ctagName             = xml.Name{Space: nsCalServer, Local: "getctag"}
+	calendarQueryName    = xml.Name{Space: nsCalDAV, Local: "calendar-query"}
+	calendarMultigetName = xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}
+)
+
+// objectContentType is the media type of calendar objects.
+const objectContentType = "text/calendar; charset=utf-8; component=VTODO"
+
+// property is a property of a resource; value is its content as escaped XML.
+type property struct {
+	name  xml.Name
+	value string
+}
+
+// resource is a resource with its properties, as listed by PROPFIND and REPORT.
+type resource struct {
+	href  string
+	props []property
+	// object is set for calendar objects, whose calendar-data is only rendered
+	// when a client asks for it.
+	object *object
+}
+
+func href(path string) string {
+	return "<d:href>" + escape(path) + "</d:href>"
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// userPrincipal is shared by all resources: clients look for it wherever they
+// start the discovery.
+func userPrincipal() property {
+	return property{userPrincipalName, href(principalPath)}
+}
+
+func rootResource() resource {
+	return resource{href: Path, props: []property{
+		{resourceTypeName, "<d:collection/>"},
+		userPrincipal(),
+	}}
+}
+
+func (q *request) principalResource() resource {
+	return resource{href: principalPath, props: []property{
+		{resourceTypeName, "<d:principal/>"},
+		{displayNameName, escape(q.workspace.GetName())},
+		userPrincipal(),
+		{principalURLName, href(principalPath)},
+		{homeSetName, href(homePath)},
+	}}
+}
+
+func homeResource() resource {
+	return resource{href: homePath, props: []property{
+		{resourceTypeName, "<d:collection/>"},
+		userPrincipal(),
+	}}
+}
+
+func calendarResource(c *calendar) resource {
+	ctag := c.ctag()
+	return resource{href: calendarHref(c.project), props: []property{
+		{resourceTypeName, "<d:collection/><c:calendar/>"},
+		{displayNameName, escape(c.displayName())},
+		{descriptionName, escape(c.description())},
+		userPrincipal(),
+		{ownerName, href(principalPath)},
+		{componentSetName, `<c:comp name="VTODO"/>`},
+		{reportSetName, "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>"},
+		{privilegeSetName, "<d:privilege><d:read/></d:privilege><d:privilege><d:write/></d:privilege>" +
+			"<d:privilege><d:write-content/></d:privilege><d:privilege><d:bind/></d:privilege>" +
+			"<d:privilege><d:unbind/></d:privilege>"},
+		{ctagName, ctag},
+		{etagName, escape(`"` + ctag + `"`)},
+	}}
+}
+
+func objectResource(c *calendar, o *object) resource {
+	return resource{href: objectHref(c.project, o.name), object: o, props: []property{
+		{resourceTypeName, ""},
+		{etagName, escape(o.etag())},
+		{contentTypeName, objectContentType},
+		{lastModifiedName, o.lastModified()},
+	}}
+}
+
+// propRequest is the body of PROPFIND and REPORT requests. Only the parts of the
+// request types that this server supports are read.
+type propRequest struct {
+	XMLName  xml.Name
+	AllProp  *struct{}  `xml:"DAV: allprop"`
+	PropName *struct{}  `xml:"DAV: propname"`
+	Prop     *propNames `xml:"DAV: prop"`
+	// Hrefs are the objects of a calendar-multiget report.
+	Hrefs []string `xml:"DAV: href"`
+	// Filter is the filter of a calendar-query report.
+	Filter *struct {
+		Comp compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+type propNames struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type compFilter struct {
+	Name  string       `xml:"name,attr"`
+	Comps []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// readPropRequest decodes the XML body of q. An empty body asks for all properties.
+func (q *request) readPropRequest() (*propRequest, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(q.w, q.r.Body, maxBodySize))
+	if err != nil {
+		http.Error(q.w, "failed to read request body", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+	var req propRequest
+	if len(bytes.TrimSpace(body)) == 0 {
+		req.AllProp = &struct{}{}
+		return &req, true
+	}
+	if err := xml.Unmarshal(body, &req); err != nil {
+		http.Error(q.w, "malformed XML body: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+// response is a resource in a multistatus response.
+type response struct {
+	href    string
+	found   []property
+	missing []xml.Name
+	// denied are properties that may not be changed.
+	denied []xml.Name
+	// status, when set, replaces the properties, e.g. with 404 for a missing object.
+	status int
+}
+
+// respond selects the properties that req asks for from each resource.
+func (q *request) respond(resources []resource, req *propRequest) ([]response, error) {
+	responses := make([]response, 0, len(resources))
+	for _, res := range resources {
+		resp := response{href: res.href}
+		switch {
+		case req.PropName != nil:
+			for _, p := range res.props {
+				resp.found = append(resp.found, property{name: p.name})
+			}
+		case req.Prop == nil:
+			resp.found = res.props
+		default:
+			for _, name := range req.Prop.Names {
+				p, ok, err := res.property(name.XMLName)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					resp.found = append(resp.found, p)
+				} else {
+					resp.missing = append(resp.missing, name.XMLName)
+				}
+			}
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// property returns the property of res with the given name.
+func (res *resource) property(name xml.Name) (property, bool, error) {
+	for _, p := range res.props {
+		if p.name == name {
+			return p, true, nil
+		}
+	}
+	if name == calendarDataName && res.object != nil {
+		data, err := res.object.data()
+		if err != nil {
+			return property{}, false, err
+		}
+		return property{name, escape(string(data))}, true, nil
+	}
+	return property{}, false, nil
+}
+
+// writeMultistatus writes responses as a 207 Multi-Status response.
+func (q *request) writeMultistatus(responses []response) {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	fmt.Fprintf(&b, `<d:multistatus xmlns:d="%s" xmlns:c="%s" xmlns:cs="%s">`, nsDAV, nsCalDAV, nsCalServer)
+	for _, resp := range responses {
+		b.WriteString("<d:response>" + href(resp.href))
+		if resp.status != 0 {
+			writeStatus(&b, resp.status)
+		}
+		if len(resp.found) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, p := range resp.found {
+				writeElement(&b, p.name, p.value)
+			}
+			b.WriteString("</d:prop>")
+			writeStatus(&b, http.StatusOK)
+			b.WriteString("</d:propstat>")
+		}
+		writeNames(&b, resp.missing, http.StatusNotFound)
+		writeNames(&b, resp.denied, http.StatusForbidden)
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>\n")
+
+	q.w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	q.w.WriteHeader(http.StatusMultiStatus)
+	io.WriteString(q.w, b.String())
+}
+
+// writeNames writes a propstat of empty properties with the given status.
+func writeNames(b *strings.Builder, names []xml.Name, code int) {
+	if len(names) == 0 {
+		return
+	}
+	b.WriteString("<d:propstat><d:prop>")
+	for _, name := range names {
+		writeElement(b, name, "")
+	}
+	b.WriteString("</d:prop>")
+	writeStatus(b, code)
+	b.WriteString("</d:propstat>")
+}
+
+func writeStatus(b *strings.Builder, code int) {
+	fmt.Fprintf(b, "<d:status>HTTP/1.1 %d %s</d:status>", code, http.StatusText(code))
+}
+
+// writeElement writes an element with the given escaped content. Names outside
+// the declared namespaces get a namespace declaration of their own.
+func writeElement(b *strings.Builder, name xml.Name, value string) {
+	tag := name.Local
+	attrs := ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		attrs = ` xmlns:x="` + escape(name.Space) + `"`
+	}
+	if value == "" {
+		b.WriteString("<" + tag + attrs + "/>")
+		return
+	}
+	b.WriteString("<" + tag + attrs + ">" + value + "</" + tag + ">")
+}
+
+// propfind lists the properties of the target and, unless the Depth header is 0,
+// of its members. Depth infinity is treated as 1.
+func (h *Handler) propfind(q *request) {
+	req, ok := q.readPropRequest()
+	if !ok {
+		return
+	}
+	members := q.r.Header.Get("Depth") != "0"
+	var resources []resource
+	switch q.target.kind {
+	case rootTarget:
+		resources = append(resources, rootResource())
+		if members {
+			resources = append(resources, q.principalResource(), homeResource())
+		}
+	case principalTarget:
+		resources = append(resources, q.principalResource())
+	case homeTarget:
+		resources = append(resources, homeResource())
+		if members {
+			calendars, err := h.loadCalendars(q.ctx)
+			if err != nil {
+				q.internalError("load calendars", err)
+				return
+			}
+			for _, c := range calendars {
+				resources = append(resources, calendarResource(c))
+			}
+		}
+	case calendarTarget:
+		c, err := h.loadCalendar(q.ctx, q.target.project)
+		if err != nil {
+			q.internalError("load calendar", err)
+			return
+		}
+		if c == nil {
+			http.NotFound(q.w, q.r)
+			return
+		}
+		resources = append(resources, calendarResource(c))
+		if members {
+			for _, o := range c.objects {
+				resources = append(resources, objectResource(c, o))
+			}
+		}
+	case objectTarget:
+		o, ok := h.lookupObject(q)
+		if !ok {
+			return
+		}
+		resources = append(resources, objectResource(&calendar{project: q.target.project}, o))
+	}
+	responses, err := q.respond(resources, req)
+	if err != nil {
+		q.internalError("render properties", err)
+		return
+	}
+	q.writeMultistatus(responses)
+}
+
+// proppatch refuses to change properties: names and colours of calendars follow
+// from the projects.
+func (h *Handler) proppatch(q *request) {
+	body, err := io.ReadAll(http.MaxBytesReader(q.w, q.r.Body, maxBodySize))
+	if err != nil {
+		http.Error(q.w, "failed to read request body", http.StatusRequestEntityTooLarge)
+		return
+	}
+	var req struct {
+		Props []propNames `xml:"DAV: set>prop"`
+		Drop  []propNames `xml:"DAV: remove>prop"`
+	}
+	if err := xml.Unmarshal(body, &req); err != nil {
+		http.Error(q.w, "malformed XML body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp := response{href: q.r.URL.EscapedPath()}
+	for _, props := range append(req.Props, req.Drop...) {
+		for _, name := range props.Names {
+			resp.denied = append(resp.denied, name.XMLName)
+		}
+	}
+	q.writeMultistatus([]response{resp})
+}
@@ -0,0 +1,84 @@
+package caldav
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// report answers the calendar-query and calendar-multiget reports on a calendar.
+// A calendar-query returns every task of the calendar when its filter asks for
+// VTODOs, and nothing otherwise; other filter conditions are not evaluated, which
+// clients tolerate as they filter again themselves.
+func (h *Handler) report(q *request) {
+	req, ok := q.readPropRequest()
+	if !ok {
+		return
+	}
+	if q.target.kind != calendarTarget || (req.XMLName != calendarQueryName && req.XMLName != calendarMultigetName) {
+		q.preconditionFailed(http.StatusForbidden, nsDAV, "supported-report", "only calendar-query and calendar-multiget reports on calendars are supported")
+		return
+	}
+	c, err := h.loadCalendar(q.ctx, q.target.project)
+	if err != nil {
+		q.internalError("load calendar", err)
+		return
+	}
+	if c == nil {
+		c = &calendar{project: q.target.project}
+	}
+
+	var resources []resource
+	var missing []response
+	if req.XMLName == calendarQueryName {
+		if req.Filter == nil || matchesTodos(req.Filter.Comp) {
+			for _, o := range c.objects {
+				resources = append(resources, objectResource(c, o))
+			}
+		}
+	} else {
+		for _, ref := range req.Hrefs {
+			if o := c.object(h.objectName(ref, q.target.project)); o != nil {
+				resources = append(resources, objectResource(c, o))
+			} else {
+				missing = append(missing, response{href: strings.TrimSpace(ref), status: http.StatusNotFound})
+			}
+		}
+	}
+	responses, err := q.respond(resources, req)
+	if err != nil {
+		q.internalError("render calendar objects", err)
+		return
+	}
+	q.writeMultistatus(append(responses, missing...))
+}
+
+// matchesTodos reports whether a comp-filter of a calendar-query selects VTODOs.
+func matchesTodos(filter compFilter) bool {
+	if !strings.EqualFold(filter.Name, "VCALENDAR") {
+		return false
+	}
+	if len(filter.Comps) == 0 {
+		return true
+	}
+	for _, comp := range filter.Comps {
+		if strings.EqualFold(comp.Name, "VTODO") {
+			return true
+		}
+	}
+	return false
+}
+
+// objectName returns the name of the object that ref, a path or an absolute URL,
+// names in the calendar of project, or "" if it names none.
+func (h *Handler) objectName(ref, project string) string {
+	u, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ""
+	}
+	t, ok := parsePath(u.EscapedPath())
+	if !ok || t.kind != objectTarget || t.project != project {
+		return ""
+	}
+	return t.name
+}
@@ -4,54 +4,203 @@ import (
 	pb "Go_Test/api"
 	cfg "Go_Test/config"
 	"context"
+	"crypto/tls"
 	"fmt"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// Module exports providers for the gRPC client connection and TaskService client for FX.
+// Module exports providers for the gRPC client connection and the TaskService, AdminService, WebhookService, NotificationService, InboundService and CalendarService clients for FX.
 var Module = fx.Options(
 	fx.Provide(NewGRPCConnection),
 	fx.Provide(NewTaskServiceClient),
+	fx.Provide(NewAdminServiceClient),
+	fx.Provide(NewWebhookServiceClient),
+	fx.Provide(NewNotificationServiceClient),
+	fx.Provide(NewInboundServiceClient),
+	fx.Provide(NewCalendarServiceClient),
 )
 
 type GRPCConnectionParams struct {
 	fx.In
-	Lifecycle fx.Lifecycle
-	Logger    *zap.Logger
-	Config    *cfg.Config
+	Lifecycle      fx.Lifecycle
+	Logger         *zap.Logger
+	Config         *cfg.Config
+	TracerProvider trace.TracerProvider
 }
 
 // NewGRPCConnection creates and manages the lifecycle of a gRPC client connection.
+// The target, transport security and token come from the selected client context
+// (see ResolveConnectionSettings).
 func NewGRPCConnection(p GRPCConnectionParams) (*grpc.ClientConn, error) {
-	p.Logger.Info("Setting up gRPC client connection", zap.String("target", p.Config.GRPCClientTarget))
+	logger := p.Logger.Named("client")
+	settings, err := ResolveConnectionSettings(p.Config)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Setting up gRPC client connection",
+		zap.String("context", settings.ContextName),
+		zap.String("target", settings.Target),
+		zap.Bool("tls", settings.TLS != nil))
+
+	// The dial does not block: an unreachable server surfaces as an Unavailable
+	// error of the first RPC instead of stalling the command.
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithBlock(),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(p.TracerProvider))),
+		// Task attachments are up to 16 MiB, above the default limit of 4 MiB.
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(32 << 20)),
+	}
+	if settings.TLS != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(settings.TLS)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if settings.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerTokenCredentials{token: settings.Token}))
+	} else {
+		logger.Warn("No API token configured; calls will be rejected as unauthenticated")
 	}
-	conn, err := grpc.DialContext(context.Background(), p.Config.GRPCClientTarget, opts...)
+	conn, err := grpc.DialContext(context.Background(), settings.Target, opts...)
 	if err != nil {
-		p.Logger.Error("Failed to dial gRPC server", zap.Error(err))
-		return nil, fmt.Errorf("failed to dial gRPC server %s: %w", p.Config.GRPCClientTarget, err)
+		logger.Error("Failed to dial gRPC server", zap.Error(err))
+		return nil, fmt.Errorf("failed to dial gRPC server %s: %w", settings.Target, err)
 	}
 	p.Lifecycle.Append(fx.Hook{
 		OnStop: func(ctx context.Context) error {
-			p.Logger.Info("Closing gRPC client connection")
+			logger.Info("Closing gRPC client connection")
 			if err := conn.Close(); err != nil {
-				p.Logger.Error("Failed to close gRPC client connection", zap.Error(err))
+				logger.Error("Failed to close gRPC client connection", zap.Error(err))
 				return err
 			}
-			p.Logger.Info("gRPC client connection closed")
+			logger.Info("gRPC client connection closed")
 			return nil
 		},
 	})
 	return conn, nil
 }
 
+// ConnectionSettings is the resolved dial configuration of the client.
+type ConnectionSettings struct {
+	// ContextName is empty when no client context is in use.
+	ContextName string
+	Target      string
+	TLS         *tls.Config
+	Token       string
+}
+
+// ResolveConnectionSettings picks the client context named by Config.Context, or the
+// current context of the contexts file, and returns its dial settings. Without any
+// context, GRPCClientTarget, APIToken and the client TLS settings are used.
+// GRPCClientTarget, APIToken and the client TLS settings set by an environment variable
+// or a flag override those of the context; a context without a token uses APIToken.
+func ResolveConnectionSettings(c *cfg.Config) (*ConnectionSettings, error) {
+	path, err := ResolveContextsPath(c.ContextsFile)
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := LoadContexts(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.Context
+	if name == "" {
+		name = contexts.CurrentContext
+	}
+	selected := Context{
+		Target: c.GRPCClientTarget,
+		Token:  c.APIToken,
+		TLS: TLSConfig{
+			Enabled:    c.ClientTLS || c.ClientCAFile != "" || c.ClientServerName != "",
+			CAFile:     c.ClientCAFile,
+			ServerName: c.ClientServerName,
+		},
+	}
+	if name != "" {
+		stored, ok := contexts.Get(name)
+		if !ok {
+			return nil, fmt.Errorf("client context %q not found in %s", name, path)
+		}
+		selected = *stored
+		if c.FromEnvOrFlag("grpc_client_target") {
+			selected.Target = c.GRPCClientTarget
+		}
+		if selected.Token == "" || c.FromEnvOrFlag("api_token") {
+			selected.Token = c.APIToken
+		}
+		if c.FromEnvOrFlag("client_tls") {
+			selected.TLS.Enabled = c.ClientTLS
+		}
+		if c.FromEnvOrFlag("client_ca_file") {
+			selected.TLS.CAFile = c.ClientCAFile
+			selected.TLS.Enabled = selected.TLS.Enabled || c.ClientCAFile != ""
+		}
+		if c.FromEnvOrFlag("client_server_name") {
+			selected.TLS.ServerName = c.ClientServerName
+			selected.TLS.Enabled = selected.TLS.Enabled || c.ClientServerName != ""
+		}
+	}
+
+	settings := &ConnectionSettings{ContextName: selected.Name, Target: selected.Target, Token: selected.Token}
+	if selected.TLS.Enabled {
+		tlsConfig, err := selected.TLS.clientTLSConfig()
+		if err != nil {
+			if name != "" {
+				return nil, fmt.Errorf("client context %q: %w", name, err)
+			}
+			return nil, err
+		}
+		settings.TLS = tlsConfig
+	}
+	return settings, nil
+}
+
+// NewAdminServiceClient creates a new AdminService client stub.
+func NewAdminServiceClient(conn *grpc.ClientConn) pb.AdminServiceClient {
+	return pb.NewAdminServiceClient(conn)
+}
+
+// bearerTokenCredentials attaches an API token to every RPC as an authorization header.
+type bearerTokenCredentials struct {
+	token string
+}
+
+func (c bearerTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+// RequireTransportSecurity is false so that tokens can also be used with local, non-TLS contexts.
+func (c bearerTokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
 // NewTaskServiceClient creates a new TaskService client stub.
 func NewTaskServiceClient(conn *grpc.ClientConn) pb.TaskServiceClient {
 	return pb.NewTaskServiceClient(conn)
 }
+
+// NewWebhookServiceClient creates a new WebhookService client stub.
+func NewWebhookServiceClient(conn *grpc.ClientConn) pb.WebhookServiceClient {
+	return pb.NewWebhookServiceClient(conn)
+}
+
+// NewNotificationServiceClient creates a new NotificationService client stub.
+func NewNotificationServiceClient(conn *grpc.ClientConn) pb.NotificationServiceClient {
+	return pb.NewNotificationServiceClient(conn)
+}
+
+// NewInboundServiceClient creates a new InboundService client stub.
+func NewInboundServiceClient(conn *grpc.ClientConn) pb.InboundServiceClient {
+	return pb.NewInboundServiceClient(conn)
+}
+
+// NewCalendarServiceClient creates a new CalendarService client stub.
+func NewCalendarServiceClient(conn *grpc.ClientConn) pb.CalendarServiceClient {
+	return pb.NewCalendarServiceClient(conn)
+}
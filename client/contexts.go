@@ -0,0 +1,164 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Context is a named client profile: the server to dial and how to authenticate to it.
+type Context struct {
+	Name   string    `yaml:"name"`
+	Target string    `yaml:"target"`
+	TLS    TLSConfig `yaml:"tls,omitempty"`
+	Token  string    `yaml:"token,omitempty"`
+}
+
+// TLSConfig holds the transport security settings of a Context.
+type TLSConfig struct {
+	Enabled            bool   `yaml:"enabled,omitempty"`
+	CAFile             string `yaml:"ca_file,omitempty"`
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	ServerName         string `yaml:"server_name,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty"`
+}
+
+// Contexts is the content of the user's contexts file.
+type Contexts struct {
+	CurrentContext string    `yaml:"current_context,omitempty"`
+	Contexts       []Context `yaml:"contexts"`
+}
+
+// DefaultContextsPath returns the contexts file location under the user's
+// config directory, e.g. ~/.config/fx-grpc-app/contexts.yaml.
+func DefaultContextsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user config directory: %w", err)
+	}
+	return filepath.Join(dir, "fx-grpc-app", "contexts.yaml"), nil
+}
+
+// ResolveContextsPath returns path, or the default location when path is empty.
+func ResolveContextsPath(path string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	return DefaultContextsPath()
+}
+
+// LoadContexts reads the contexts file at path. A missing file yields an empty set.
+func LoadContexts(path string) (*Contexts, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Contexts{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contexts file: %w", err)
+	}
+	var contexts Contexts
+	if err := yaml.Unmarshal(data, &contexts); err != nil {
+		return nil, fmt.Errorf("failed to parse contexts file %s: %w", path, err)
+	}
+	return &contexts, nil
+}
+
+// Save writes the contexts file to path. The file may contain tokens, so it is
+// only readable by the current user: it is written to a temporary file with mode
+// 0600 that replaces path, which also tightens the mode of an existing file.
+func (c *Contexts) Save(path string) error {
+	sort.Slice(c.Contexts, func(i, j int) bool { return c.Contexts[i].Name < c.Contexts[j].Name })
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to encode contexts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create contexts directory: %w", err)
+	}
+	// CreateTemp creates the file with mode 0600.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".contexts-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write contexts file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write contexts file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write contexts file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write contexts file: %w", err)
+	}
+	return nil
+}
+
+// Get returns the context with the given name.
+func (c *Contexts) Get(name string) (*Context, bool) {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			return &c.Contexts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Upsert adds ctx, replacing any existing context with the same name.
+func (c *Contexts) Upsert(ctx Context) {
+	if existing, ok := c.Get(ctx.Name); ok {
+		*existing = ctx
+		return
+	}
+	c.Contexts = append(c.Contexts, ctx)
+}
+
+// Remove deletes the named context and reports whether it existed.
+// Removing the current context also clears the current selection.
+func (c *Contexts) Remove(name string) bool {
+	for i := range c.Contexts {
+		if c.Contexts[i].Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// clientTLSConfig builds a crypto/tls configuration from the context's TLS settings.
+func (t TLSConfig) clientTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
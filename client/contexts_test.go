@@ -0,0 +1,172 @@
+package client
+
+import (
+	cfg "Go_Test/config"
+	"encoding/pem"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// testContexts has a current context with TLS and a token and a plain one without a token.
+func testContexts() *Contexts {
+	return &Contexts{
+		CurrentContext: "prod",
+		Contexts: []Context{
+			{Name: "prod", Target: "tasks.example.com:443", Token: "prod-token", TLS: TLSConfig{Enabled: true, ServerName: "tasks.example.com"}},
+			{Name: "local", Target: "localhost:50051"},
+		},
+	}
+}
+
+// loadConfig saves contexts and loads the configuration with the given command-line
+// arguments; environment variables and a config file set by the test apply as well.
+func loadConfig(t *testing.T, contexts *Contexts, args ...string) *cfg.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "contexts.yaml")
+	if contexts != nil {
+		if err := contexts.Save(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := os.LookupEnv("CONFIG_FILE"); !ok {
+		t.Setenv("CONFIG_FILE", "")
+	}
+	t.Setenv("CLIENT_CONTEXTS_FILE", path)
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	c, _, err := cfg.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestResolveConnectionSettingsSelectsContext(t *testing.T) {
+	// Set in the config file, the target and token do not override a context.
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte("grpc_client_target: file:50051\napi_token: file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", configFile)
+	tests := []struct {
+		name                       string
+		contexts                   *Contexts
+		args                       []string
+		wantContext, target, token string
+		tls                        bool
+	}{
+		{"current context", testContexts(), nil, "prod", "tasks.example.com:443", "prod-token", true},
+		{"--context", testContexts(), []string{"--context", "local"}, "local", "localhost:50051", "file-token", false},
+		{"no current context", &Contexts{Contexts: testContexts().Contexts}, nil, "", "file:50051", "file-token", false},
+		{"no contexts file", nil, []string{"--grpc-client-target", "dev:50051"}, "", "dev:50051", "file-token", false},
+	}
+	for _, tt := range tests {
+		settings, err := ResolveConnectionSettings(loadConfig(t, tt.contexts, tt.args...))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if settings.ContextName != tt.wantContext || settings.Target != tt.target || settings.Token != tt.token || (settings.TLS != nil) != tt.tls {
+			t.Errorf("%s: context %q, target %q, token %q, TLS %v; want %q, %q, %q, %v", tt.name,
+				settings.ContextName, settings.Target, settings.Token, settings.TLS != nil, tt.wantContext, tt.target, tt.token, tt.tls)
+		}
+	}
+}
+
+func TestResolveConnectionSettingsMissingContext(t *testing.T) {
+	contexts := testContexts()
+	contexts.CurrentContext = "staging"
+	for _, args := range [][]string{nil, {"--context", "dev"}} {
+		_, err := ResolveConnectionSettings(loadConfig(t, contexts, args...))
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("%v: error %v, want the context not to be found", args, err)
+		}
+	}
+}
+
+func TestResolveConnectionSettingsOverrides(t *testing.T) {
+	settings, err := ResolveConnectionSettings(loadConfig(t, testContexts(), "--api-token", "flag-token"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Target != "tasks.example.com:443" || settings.Token != "flag-token" {
+		t.Errorf("--api-token: target %q, token %q; want the context's target and flag-token", settings.Target, settings.Token)
+	}
+
+	t.Setenv("GRPC_CLIENT_TARGET", "staging.example.com:443")
+	t.Setenv("API_TOKEN", "env-token")
+	settings, err = ResolveConnectionSettings(loadConfig(t, testContexts(), "--client-server-name", "staging.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.ContextName != "prod" || settings.Target != "staging.example.com:443" || settings.Token != "env-token" {
+		t.Errorf("env overrides: context %q, target %q, token %q", settings.ContextName, settings.Target, settings.Token)
+	}
+	if settings.TLS == nil || settings.TLS.ServerName != "staging.example.com" {
+		t.Errorf("--client-server-name: TLS %+v, want server name staging.example.com", settings.TLS)
+	}
+
+	settings, err = ResolveConnectionSettings(loadConfig(t, testContexts(), "--client-tls=false"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.TLS != nil {
+		t.Errorf("--client-tls=false kept TLS for a context that enables it")
+	}
+
+	// A CA file turns on TLS for a plain context.
+	server := httptest.NewTLSServer(nil)
+	server.Close()
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CLIENT_CA_FILE", caFile)
+	settings, err = ResolveConnectionSettings(loadConfig(t, testContexts(), "--context", "local"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.TLS == nil || settings.TLS.RootCAs == nil {
+		t.Errorf("CLIENT_CA_FILE: TLS %+v, want the CA as root", settings.TLS)
+	}
+}
+
+func TestSaveRestrictsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contexts.yaml")
+	if err := os.WriteFile(path, []byte("contexts: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := testContexts().Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o600 {
+		t.Errorf("contexts file has mode %o, want 600", mode)
+	}
+	contexts, err := LoadContexts(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contexts.CurrentContext != "prod" || len(contexts.Contexts) != 2 || contexts.Contexts[0].Name != "local" {
+		t.Errorf("saved contexts read back as %+v", contexts)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Save left %d files in the directory, want 1", len(entries))
+	}
+}
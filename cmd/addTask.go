@@ -3,11 +3,13 @@ package cmd
 import (
 	pb "Go_Test/api"
 	"Go_Test/client"
+	"Go_Test/output"
 	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -16,16 +18,21 @@ var (
 	taskTitle       string
 	taskDescription string
 	taskStatus      string
+	taskDue         string
+	taskTags        []string
+	taskPriority    string
+	taskAssignee    string
+	taskProject     string
 )
 
 // addTaskCmd represents the command to add a new task.
 var addTaskCmd = &cobra.Command{
-	Use:   "add-task --title <title> [--description <desc>] [--status <status>]",
+	Use:   "add-task --title <title> [--description <desc>] [--status <status>] [--due <time>] [--tag <tag>]... [--priority <priority>] [--assignee <name>] [--project <project>]",
 	Short: "Adds a new task via the gRPC server",
 	Long:  `Connects to the gRPC server and calls the AddTask RPC method with the provided details to create a new task.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if taskTitle == "" {
-			return fmt.Errorf("title is required. Use --title or -t flag")
+			return usageErrorf("title is required. Use --title or -t flag")
 		}
 
 		app := fx.New(
@@ -36,10 +43,18 @@ var addTaskCmd = &cobra.Command{
 					Title:       taskTitle,
 					Description: taskDescription,
 					Status:      taskStatus,
+					DueAt:       taskDue,
+					Tags:        taskTags,
+					Priority:    taskPriority,
+					Assignee:    taskAssignee,
+					Project:     taskProject,
 				},
 			),
 			fx.Invoke(runAddTaskLogic),
 		)
+		if err := app.Err(); err != nil {
+			return err
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
@@ -54,37 +69,40 @@ var addTaskCmd = &cobra.Command{
 	},
 }
 
-func runAddTaskLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, req *pb.AddTaskRequest) {
+func runAddTaskLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.AddTaskRequest) error {
 	logger.Info("Executing AddTask logic via CLI command",
 		zap.String("title", req.GetTitle()),
 		zap.String("description", req.GetDescription()),
 		zap.String("status", req.GetStatus()))
 
-	reqCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	spanCtx, span := startCommandSpan(tp, "add-task")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
 	defer cancel()
 
 	reply, err := taskClient.AddTask(reqCtx, req)
 	if err != nil {
-		logger.Error("Failed to add task via CLI", zap.Error(err))
-		fmt.Printf("Error: Could not add task: %v\n", err)
-		return
+		logger.Debug("Failed to add task via CLI", zap.Error(err))
+		return newRPCError("add task", err)
 	}
 
 	createdTask := reply.GetTask()
 	logger.Info("Task added successfully via CLI", zap.String("id", createdTask.GetId()))
-	fmt.Println("--- Task Added Successfully ---")
-	fmt.Printf("ID: %s\n", createdTask.GetId())
-	fmt.Printf("Title: %s\n", createdTask.GetTitle())
-	fmt.Printf("Description: %s\n", createdTask.GetDescription())
-	fmt.Printf("Status: %s\n", createdTask.GetStatus())
-	fmt.Printf("Created At: %s\n", createdTask.GetCreatedAt())
-	fmt.Printf("Updated At: %s\n", createdTask.GetUpdatedAt())
-	fmt.Println("-----------------------------")
+	if err := output.PrintItem(printer, taskTable, createdTask); err != nil {
+		return fmt.Errorf("failed to print task: %w", err)
+	}
+	return nil
 }
 
 func init() {
 	addTaskCmd.Flags().StringVarP(&taskTitle, "title", "t", "", "Title of the task (required)")
 	addTaskCmd.Flags().StringVarP(&taskDescription, "description", "d", "", "Description of the task")
 	addTaskCmd.Flags().StringVarP(&taskStatus, "status", "s", "", "Status of the task (e.g., pending, in_progress). Defaults to 'pending' server-side if empty.")
+	addTaskCmd.Flags().StringVar(&taskDue, "due", "", "Due date as an RFC 3339 timestamp, e.g. 2025-01-31T17:00:00+01:00")
+	addTaskCmd.Flags().StringArrayVar(&taskTags, "tag", nil, "Tag of the task; repeat the flag for several tags")
+	addTaskCmd.Flags().StringVar(&taskPriority, "priority", "", "Priority of the task: low, medium, high or urgent")
+	addTaskCmd.Flags().StringVar(&taskAssignee, "assignee", "", "Person the task is assigned to")
+	addTaskCmd.Flags().StringVar(&taskProject, "project", "", "Project the task belongs to")
 	clientCmd.AddCommand(addTaskCmd)
 }
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// adminCmd represents the base command for workspace administration.
+// It groups the AdminService actions like create-workspace, suspend-workspace, export-workspace.
+// These commands must be run with API_TOKEN set to the server's admin token.
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Manages workspaces through the AdminService",
+	Long:  `A parent command for administrative actions. Calls are authenticated with the server's admin token, which must be provided through API_TOKEN or the token of the selected client context.`,
+}
+
+func init() {
+	clientCmd.AddCommand(adminCmd)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	attachmentFile  string
+	attachmentForce bool
+)
+
+// attachmentsCmd represents the base command for task attachments.
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "Lists and downloads the attachments of tasks",
+	Long: `A parent command for the attachment RPC methods of the TaskService. Attachments are added to a task
+when it is created from an email with attached files (see the inbound command).`,
+}
+
+var attachmentsListCmd = &cobra.Command{
+	Use:   "list <task_id>",
+	Short: "Lists the attachments of a task",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("attachments list", &pb.ListAttachmentsRequest{TaskId: args[0]}, runAttachmentsListLogic)
+	},
+}
+
+var attachmentsDownloadCmd = &cobra.Command{
+	Use:   "download <task_id> <attachment_id>",
+	Short: "Downloads an attachment of a task",
+	Long: `Calls the GetAttachment RPC method and saves the attachment under its own file name in the current
+directory, or under the path given with --file. --file - writes it to stdout. Existing files are only
+overwritten with --force.`,
+	Example: `  client attachments download 42 7
+  client attachments download 42 7 --file report.pdf
+  client attachments download 42 7 --file - | less`,
+	Args: usageArgs(cobra.ExactArgs(2)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("attachments download", &pb.GetAttachmentRequest{TaskId: args[0], AttachmentId: args[1]}, runAttachmentsDownloadLogic)
+	},
+}
+
+func runAttachmentsListLogic(taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListAttachmentsRequest) error {
+	spanCtx, span := startCommandSpan(tp, "attachments list")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.ListAttachments(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list attachments via CLI", zap.Error(err))
+		return newRPCError("list attachments", err)
+	}
+	if err := output.PrintList(printer, attachmentTable, reply.GetAttachments()); err != nil {
+		return fmt.Errorf("failed to print attachments: %w", err)
+	}
+	return nil
+}
+
+func runAttachmentsDownloadLogic(taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.GetAttachmentRequest) error {
+	logger.Info("Executing GetAttachment logic via CLI command", zap.String("task_id", req.GetTaskId()), zap.String("attachment_id", req.GetAttachmentId()))
+
+	spanCtx, span := startCommandSpan(tp, "attachments download")
+	defer span.End()
+
+	// Attachments are up to 16 MiB, so the transfer may take longer than other calls.
+	reqCtx, cancel := context.WithTimeout(spanCtx, 60*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.GetAttachment(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to get attachment via CLI", zap.Error(err))
+		return newRPCError("download attachment", err)
+	}
+	attachment := reply.GetAttachment()
+	if attachmentFile == "-" {
+		if _, err := os.Stdout.Write(attachment.GetContent()); err != nil {
+			return fmt.Errorf("failed to write attachment to stdout: %w", err)
+		}
+		return nil
+	}
+
+	path := attachmentFile
+	if path == "" {
+		// The server stores names without directories; Base guards against old or foreign data.
+		path = filepath.Base(attachment.GetFilename())
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if attachmentForce {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return usageErrorf("%s already exists; use --force to overwrite it or --file to choose another path", path)
+		}
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := f.Write(attachment.GetContent()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	attachment.Content = nil
+	if err := output.PrintItem(printer, attachmentTable, attachment); err != nil {
+		return fmt.Errorf("failed to print attachment: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	attachmentsDownloadCmd.Flags().StringVar(&attachmentFile, "file", "", "Path to save the attachment to, or - for stdout (default: its file name)")
+	attachmentsDownloadCmd.Flags().BoolVar(&attachmentForce, "force", false, "Overwrite an existing file")
+	attachmentsCmd.AddCommand(attachmentsListCmd, attachmentsDownloadCmd)
+	clientCmd.AddCommand(attachmentsCmd)
+}
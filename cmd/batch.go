@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// batchBestEffort selects BATCH_MODE_BEST_EFFORT for the batch command.
+var batchBestEffort bool
+
+// batchCmd represents the command to apply many mutations with one BatchMutate call.
+var batchCmd = &cobra.Command{
+	Use:   "batch [--best-effort] < mutations.jsonl",
+	Short: "Applies add, update, complete and delete operations read as JSON Lines from standard input",
+	Long: `Reads one mutation per line from standard input and applies all of them with a single BatchMutate call,
+in one database transaction. Each line is a Mutation message in protobuf JSON form:
+
+  {"add": {"title": "Write report", "tags": ["work"], "priority": "high"}}
+  {"update": {"taskId": "12", "title": "New title", "updateMask": "title"}}
+  {"complete": {"taskId": "13"}}
+  {"delete": {"taskId": "14"}}
+
+Empty lines are ignored. By default the batch is atomic: if one mutation fails, none is applied.
+With --best-effort only the failing mutations are skipped. The command exits with the code of the
+first failed mutation.`,
+	Example: `  printf '{"complete":{"taskId":"%s"}}\n' 12 13 14 | client batch`,
+	Args:    usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mutations, err := readMutations(os.Stdin)
+		if err != nil {
+			return err
+		}
+		req := &pb.BatchMutateRequest{Mutations: mutations, Mode: pb.BatchMode_BATCH_MODE_ATOMIC}
+		if batchBestEffort {
+			req.Mode = pb.BatchMode_BATCH_MODE_BEST_EFFORT
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(req),
+			fx.Invoke(runBatchLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for batch: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for batch: %w", err)
+		}
+		return nil
+	},
+}
+
+// readMutations parses one protobuf JSON Mutation per non-empty line of r.
+func readMutations(r io.Reader) ([]*pb.Mutation, error) {
+	var mutations []*pb.Mutation
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var mutation pb.Mutation
+		if err := protojson.Unmarshal([]byte(text), &mutation); err != nil {
+			return nil, usageErrorf("invalid mutation on line %d: %v", line, err)
+		}
+		if mutation.GetOperation() == nil {
+			return nil, usageErrorf("invalid mutation on line %d: must set one of add, update, complete or delete", line)
+		}
+		mutations = append(mutations, &mutation)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mutations: %w", err)
+	}
+	if len(mutations) == 0 {
+		return nil, usageErrorf("no mutations on standard input")
+	}
+	return mutations, nil
+}
+
+func runBatchLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.BatchMutateRequest) error {
+	logger.Info("Executing BatchMutate logic via CLI command",
+		zap.Int("mutations", len(req.GetMutations())),
+		zap.Stringer("mode", req.GetMode()))
+
+	spanCtx, span := startCommandSpan(tp, "batch")
+	defer span.End()
+
+	// A batch may hold many row locks; allow it more time than a single call.
+	reqCtx, cancel := context.WithTimeout(spanCtx, 60*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.BatchMutate(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to apply batch via CLI", zap.Error(err))
+		return newRPCError("apply batch", err)
+	}
+
+	if err := output.PrintList(printer, mutationResultTable, reply.GetResults()); err != nil {
+		return fmt.Errorf("failed to print batch results: %w", err)
+	}
+	return batchError(reply)
+}
+
+// batchError returns an rpcError carrying the status of the first failed mutation,
+// or nil when all mutations were applied. In an atomic batch the mutations around
+// the failure are reported as Aborted; the failure itself is the more useful status.
+func batchError(reply *pb.BatchMutateReply) error {
+	var first *pb.MutationResult
+	failed := 0
+	for _, result := range reply.GetResults() {
+		if result.GetCode() == int32(codes.OK) {
+			continue
+		}
+		failed++
+		if first == nil || (first.GetCode() == int32(codes.Aborted) && result.GetCode() != int32(codes.Aborted)) {
+			first = result
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	summary := fmt.Sprintf("%d of %d mutations failed", failed, len(reply.GetResults()))
+	if !reply.GetCommitted() {
+		summary = "batch rolled back"
+	}
+	st := &spb.Status{
+		Code:    first.GetCode(),
+		Message: fmt.Sprintf("%s; mutation %d: %s", summary, first.GetIndex(), first.GetMessage()),
+		Details: first.GetDetails(),
+	}
+	return newRPCError("apply batch", status.ErrorProto(st))
+}
+
+func init() {
+	batchCmd.Flags().BoolVar(&batchBestEffort, "best-effort", false, "Apply all mutations that succeed instead of rolling back the batch on the first failure")
+	clientCmd.AddCommand(batchCmd)
+}
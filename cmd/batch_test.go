@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadMutations(t *testing.T) {
+	input := "" +
+		`{"add": {"title": "Write report", "tags": ["work"], "priority": "high"}}` + "\n" +
+		"\n" +
+		`  {"update": {"taskId": "12", "title": "New title", "updateMask": "title"}}  ` + "\r\n" +
+		`{"complete": {"task_id": "13"}}` + "\n" +
+		`{"delete": {"taskId": "14", "version": 3}}`
+	mutations, err := readMutations(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readMutations: %v", err)
+	}
+	if len(mutations) != 4 {
+		t.Fatalf("read %d mutations, want 4", len(mutations))
+	}
+	if add := mutations[0].GetAdd(); add.GetTitle() != "Write report" || add.GetPriority() != "high" || len(add.GetTags()) != 1 {
+		t.Errorf("add mutation %v", add)
+	}
+	if update := mutations[1].GetUpdate(); update.GetTaskId() != "12" || strings.Join(update.GetUpdateMask().GetPaths(), ",") != "title" {
+		t.Errorf("update mutation %v", update)
+	}
+	if complete := mutations[2].GetComplete(); complete.GetTaskId() != "13" {
+		t.Errorf("complete mutation %v", complete)
+	}
+	if del := mutations[3].GetDelete(); del.GetTaskId() != "14" || del.GetVersion() != 3 {
+		t.Errorf("delete mutation %v", del)
+	}
+}
+
+func TestReadMutationsErrors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"malformed line", `{"add": {"title": "A"}}` + "\n\n" + `{"add": {"title": }` + "\n", "invalid mutation on line 3"},
+		{"unknown field", `{"add": {"titel": "A"}}`, "invalid mutation on line 1"},
+		{"no operation", `{"add": {"title": "A"}}` + "\n{}\n", "line 2: must set one of add, update, complete or delete"},
+		{"two operations", `{"add": {"title": "A"}, "complete": {"taskId": "1"}}`, "invalid mutation on line 1"},
+		{"empty input", "\n  \n", "no mutations on standard input"},
+	}
+	for _, tt := range tests {
+		_, err := readMutations(strings.NewReader(tt.input))
+		var usageErr *usageError
+		if !errors.As(err, &usageErr) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error %v, want a usage error containing %q", tt.name, err, tt.want)
+		}
+	}
+
+	long := `{"add": {"title": "` + strings.Repeat("a", 1<<20) + `"}}`
+	if _, err := readMutations(strings.NewReader(long)); err == nil || !strings.Contains(err.Error(), "failed to read mutations") {
+		t.Errorf("line over 1 MiB: error %v, want a read error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	calendarAssignee string
+	calendarProject  string
+)
+
+// calendarCmd represents the base command for the calendar feeds of the workspace.
+var calendarCmd = &cobra.Command{
+	Use:   "calendar",
+	Short: "Manages iCalendar feeds of tasks with due dates",
+	Long: `A parent command for the CalendarService. A calendar feed is a secret URL on the REST gateway that
+calendar apps subscribe to; it lists the tasks with a due date of an assignee, a project or both as to-dos and
+as events at their due dates.`,
+}
+
+var calendarCreateCmd = &cobra.Command{
+	Use:   "create --assignee <name> | --project <name>",
+	Short: "Creates a calendar feed and prints its URL",
+	Long: `Calls the CreateCalendarFeed RPC method. The URL is printed only once; anyone who knows it can read the
+feed, so treat it like a token and delete the feed to revoke it. Append ?components=vtodo or ?components=vevent
+to the URL for apps that should show only to-dos or only events.`,
+	Example: `  client calendar create --assignee alice
+  client calendar create --project website --assignee bob`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if calendarAssignee == "" && calendarProject == "" {
+			return usageErrorf("--assignee or --project is required")
+		}
+		return runClientApp("calendar create", &pb.CreateCalendarFeedRequest{Assignee: calendarAssignee, Project: calendarProject}, runCalendarCreateLogic)
+	},
+}
+
+var calendarListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the calendar feeds of the workspace",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("calendar list", &pb.ListCalendarFeedsRequest{}, runCalendarListLogic)
+	},
+}
+
+var calendarDeleteCmd = &cobra.Command{
+	Use:   "delete <feed_id>",
+	Short: "Deletes a calendar feed, which revokes its URL",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("calendar delete", &pb.DeleteCalendarFeedRequest{FeedId: args[0]}, runCalendarDeleteLogic)
+	},
+}
+
+func runCalendarCreateLogic(calendarClient pb.CalendarServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.CreateCalendarFeedRequest) error {
+	logger.Info("Executing CreateCalendarFeed logic via CLI command", zap.String("assignee", req.GetAssignee()), zap.String("project", req.GetProject()))
+
+	spanCtx, span := startCommandSpan(tp, "calendar create")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := calendarClient.CreateCalendarFeed(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to create calendar feed via CLI", zap.Error(err))
+		return newRPCError("create calendar feed", err)
+	}
+	if err := output.PrintItem(printer, createdCalendarFeedTable, reply); err != nil {
+		return fmt.Errorf("failed to print calendar feed: %w", err)
+	}
+	return nil
+}
+
+func runCalendarListLogic(calendarClient pb.CalendarServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListCalendarFeedsRequest) error {
+	spanCtx, span := startCommandSpan(tp, "calendar list")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := calendarClient.ListCalendarFeeds(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list calendar feeds via CLI", zap.Error(err))
+		return newRPCError("list calendar feeds", err)
+	}
+	if err := output.PrintList(printer, calendarFeedTable, reply.GetFeeds()); err != nil {
+		return fmt.Errorf("failed to print calendar feeds: %w", err)
+	}
+	return nil
+}
+
+func runCalendarDeleteLogic(calendarClient pb.CalendarServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.DeleteCalendarFeedRequest) error {
+	logger.Info("Executing DeleteCalendarFeed logic via CLI command", zap.String("feed_id", req.GetFeedId()))
+
+	spanCtx, span := startCommandSpan(tp, "calendar delete")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := calendarClient.DeleteCalendarFeed(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to delete calendar feed via CLI", zap.Error(err))
+		return newRPCError("delete calendar feed", err)
+	}
+	if err := output.PrintItem(printer, calendarFeedTable, reply.GetFeed()); err != nil {
+		return fmt.Errorf("failed to print calendar feed: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	calendarCreateCmd.Flags().StringVar(&calendarAssignee, "assignee", "", "Only tasks assigned to this person")
+	calendarCreateCmd.Flags().StringVar(&calendarProject, "project", "", "Only tasks of this project")
+	calendarCmd.AddCommand(calendarCreateCmd, calendarListCmd, calendarDeleteCmd)
+	clientCmd.AddCommand(calendarCmd)
+}
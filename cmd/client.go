@@ -1,16 +1,42 @@
 package cmd
 
 import (
+	"Go_Test/output"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
+var (
+	verbose      bool
+	outputFormat string
+)
+
 // clientCmd represents the base command for client-side operations.
 // It groups various client actions like get-tasks, add-task, complete-task.
 var clientCmd = &cobra.Command{
 	Use:   "client",
 	Short: "Manages client-side gRPC operations for TaskService",
 	Long:  `A parent command for various client actions interacting with the TaskService.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case verbose:
+			logLevelOverride = "debug"
+		case cmd.Flags().Changed("log-level"):
+			logLevelOverride = ""
+		default:
+			logLevelOverride = "warn"
+		}
+		quietFxEvents = !verbose
+		var err error
+		if printer, err = output.NewPrinter(outputFormat, os.Stdout); err != nil {
+			return &usageError{err: err}
+		}
+		return nil
+	},
 }
 
 func init() {
+	clientCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Show debug logs of the client and its gRPC connection")
+	clientCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: "+output.Formats)
 }
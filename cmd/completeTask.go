@@ -3,11 +3,13 @@ package cmd
 import (
 	pb "Go_Test/api"
 	"Go_Test/client"
+	"Go_Test/output"
 	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -23,7 +25,7 @@ var completeTaskCmd = &cobra.Command{
 	Long:  `Connects to the gRPC server and calls the CompleteTask RPC method for the given task ID. Handles errors for non-existent or already completed tasks.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if completeTaskID == "" {
-			return fmt.Errorf("task ID is required. Use --id flag")
+			return usageErrorf("task ID is required. Use --id flag")
 		}
 
 		app := fx.New(
@@ -36,6 +38,9 @@ var completeTaskCmd = &cobra.Command{
 			),
 			fx.Invoke(runCompleteTaskLogic),
 		)
+		if err := app.Err(); err != nil {
+			return err
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
@@ -50,28 +55,28 @@ var completeTaskCmd = &cobra.Command{
 	},
 }
 
-func runCompleteTaskLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, req *pb.CompleteTaskRequest) {
+func runCompleteTaskLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.CompleteTaskRequest) error {
 	logger.Info("Executing CompleteTask logic via CLI command",
 		zap.String("task_id", req.GetTaskId()))
 
-	reqCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	spanCtx, span := startCommandSpan(tp, "complete-task")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
 	defer cancel()
 
 	reply, err := taskClient.CompleteTask(reqCtx, req)
 	if err != nil {
-		logger.Error("Failed to complete task via CLI", zap.Error(err))
-		fmt.Printf("Error completing task: %v\n", err)
-		return
+		logger.Debug("Failed to complete task via CLI", zap.Error(err))
+		return newRPCError("complete task", err)
 	}
 
 	completedTask := reply.GetTask()
 	logger.Info("Task completed successfully via CLI", zap.String("id", completedTask.GetId()))
-	fmt.Println("--- Task Completed Successfully ---")
-	fmt.Printf("ID: %s\n", completedTask.GetId())
-	fmt.Printf("Title: %s\n", completedTask.GetTitle())
-	fmt.Printf("Status: %s\n", completedTask.GetStatus())
-	fmt.Printf("Updated At: %s\n", completedTask.GetUpdatedAt())
-	fmt.Println("-------------------------------")
+	if err := output.PrintItem(printer, taskTable, completedTask); err != nil {
+		return fmt.Errorf("failed to print task: %w", err)
+	}
+	return nil
 }
 
 func init() {
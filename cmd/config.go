@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"Go_Test/config"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configCmd represents the base command for inspecting the configuration.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspects the application configuration",
+	Long:  `A parent command for configuration helpers. Configuration is layered: defaults, then the config file (--config or CONFIG_FILE), then environment variables, then command-line flags.`,
+}
+
+// configShowCmd represents the command to print the effective configuration.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Prints the effective configuration with secrets redacted",
+	Long:  `Loads and validates the configuration exactly like the server and client commands do, then prints every setting as YAML together with the layer it came from. The output can be used as a config file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, settings, err := config.Load()
+		if err != nil {
+			return err
+		}
+		for _, setting := range settings {
+			encoded, err := yaml.Marshal(setting.Redacted())
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", setting.Key, err)
+			}
+			fmt.Printf("%s: %s # %s\n", setting.Key, strings.TrimSpace(string(encoded)), setting.Source)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
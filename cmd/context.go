@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"Go_Test/client"
+	"Go_Test/config"
+	"Go_Test/output"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// contextAddOptions holds the flags of the context add command.
+var contextAddOptions struct {
+	target string
+	tls    client.TLSConfig
+	token  string
+}
+
+// contextCmd represents the base command for managing client contexts.
+// It groups the actions that edit the contexts file: use, list, add, remove.
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manages named client contexts for switching between servers",
+	Long: `A parent command for client contexts. A context names a server target together with its TLS settings and API token.
+The current context is used by all client commands unless --context (or CLIENT_CONTEXT) selects another one.
+Contexts are stored in contexts_file, by default ~/.config/fx-grpc-app/contexts.yaml.`,
+}
+
+// contextUseCmd represents the command to select the current context.
+var contextUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Makes a context the current context",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateContexts(func(contexts *client.Contexts) error {
+			if _, ok := contexts.Get(args[0]); !ok {
+				return fmt.Errorf("client context %q does not exist", args[0])
+			}
+			contexts.CurrentContext = args[0]
+			fmt.Printf("Switched to context %q.\n", args[0])
+			return nil
+		})
+	},
+}
+
+// contextListCmd represents the command to list all contexts.
+var contextListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists all contexts; the current one is marked with *",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contexts, _, err := loadContexts()
+		if err != nil {
+			return err
+		}
+		entries := make([]contextEntry, 0, len(contexts.Contexts))
+		for _, c := range contexts.Contexts {
+			entries = append(entries, contextEntry{
+				Current:  c.Name == contexts.CurrentContext,
+				Name:     c.Name,
+				Target:   c.Target,
+				TLS:      c.TLS.Enabled,
+				HasToken: c.Token != "",
+			})
+		}
+		return output.PrintList(printer, contextTable, entries)
+	},
+}
+
+// contextAddCmd represents the command to add or replace a context.
+var contextAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Adds a context, replacing an existing one with the same name",
+	Long:  `Adds a context. The first context added becomes the current context. The token is stored in the contexts file, which is only readable by the current user.`,
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tlsConfig := contextAddOptions.tls
+		if tlsConfig.CAFile != "" || tlsConfig.CertFile != "" || tlsConfig.ServerName != "" || tlsConfig.InsecureSkipVerify {
+			tlsConfig.Enabled = true
+		}
+		if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+			return usageErrorf("--cert-file and --key-file must be given together")
+		}
+		return updateContexts(func(contexts *client.Contexts) error {
+			contexts.Upsert(client.Context{
+				Name:   args[0],
+				Target: contextAddOptions.target,
+				TLS:    tlsConfig,
+				Token:  contextAddOptions.token,
+			})
+			if contexts.CurrentContext == "" {
+				contexts.CurrentContext = args[0]
+			}
+			fmt.Printf("Context %q saved.\n", args[0])
+			return nil
+		})
+	},
+}
+
+// contextRemoveCmd represents the command to delete a context.
+var contextRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Removes a context",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateContexts(func(contexts *client.Contexts) error {
+			if !contexts.Remove(args[0]) {
+				return fmt.Errorf("client context %q does not exist", args[0])
+			}
+			fmt.Printf("Context %q removed.\n", args[0])
+			return nil
+		})
+	},
+}
+
+// loadContexts reads the contexts file named by the configuration.
+func loadContexts() (*client.Contexts, string, error) {
+	c, _, err := config.Load()
+	if err != nil {
+		return nil, "", err
+	}
+	path, err := client.ResolveContextsPath(c.ContextsFile)
+	if err != nil {
+		return nil, "", err
+	}
+	contexts, err := client.LoadContexts(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return contexts, path, nil
+}
+
+// updateContexts loads the contexts file, applies fn and saves the result.
+func updateContexts(fn func(contexts *client.Contexts) error) error {
+	contexts, path, err := loadContexts()
+	if err != nil {
+		return err
+	}
+	if err := fn(contexts); err != nil {
+		return err
+	}
+	return contexts.Save(path)
+}
+
+func init() {
+	contextAddCmd.Flags().StringVar(&contextAddOptions.target, "target", "", "Address of the gRPC server (host:port)")
+	contextAddCmd.Flags().BoolVar(&contextAddOptions.tls.Enabled, "tls", false, "Connect with TLS")
+	contextAddCmd.Flags().StringVar(&contextAddOptions.tls.CAFile, "ca-file", "", "PEM file with the CA certificates that sign the server certificate (implies --tls)")
+	contextAddCmd.Flags().StringVar(&contextAddOptions.tls.CertFile, "cert-file", "", "PEM client certificate for mutual TLS (implies --tls)")
+	contextAddCmd.Flags().StringVar(&contextAddOptions.tls.KeyFile, "key-file", "", "PEM private key of the client certificate")
+	contextAddCmd.Flags().StringVar(&contextAddOptions.tls.ServerName, "server-name", "", "Override the server name used to verify the certificate (implies --tls)")
+	contextAddCmd.Flags().BoolVar(&contextAddOptions.tls.InsecureSkipVerify, "insecure-skip-verify", false, "Do not verify the server certificate (implies --tls)")
+	contextAddCmd.Flags().StringVar(&contextAddOptions.token, "token", "", "API token sent with every call made through this context")
+	contextAddCmd.MarkFlagRequired("target")
+
+	contextCmd.AddCommand(contextUseCmd, contextListCmd, contextAddCmd, contextRemoveCmd)
+	clientCmd.AddCommand(contextCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	workspaceName string
+)
+
+// createWorkspaceCmd represents the command to create a new workspace.
+var createWorkspaceCmd = &cobra.Command{
+	Use:   "create-workspace --name <name>",
+	Short: "Creates a new workspace and prints its API token",
+	Long:  `Connects to the gRPC server and calls the CreateWorkspace RPC method. The returned API token is only shown once.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if workspaceName == "" {
+			return usageErrorf("workspace name is required. Use --name flag")
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.CreateWorkspaceRequest{
+					Name: workspaceName,
+				},
+			),
+			fx.Invoke(runCreateWorkspaceLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for create-workspace: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for create-workspace: %w", err)
+		}
+		return nil
+	},
+}
+
+func runCreateWorkspaceLogic(lc fx.Lifecycle, adminClient pb.AdminServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.CreateWorkspaceRequest) error {
+	logger.Info("Executing CreateWorkspace logic via CLI command", zap.String("name", req.GetName()))
+
+	spanCtx, span := startCommandSpan(tp, "admin create-workspace")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := adminClient.CreateWorkspace(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to create workspace via CLI", zap.Error(err))
+		return newRPCError("create workspace", err)
+	}
+
+	workspace := reply.GetWorkspace()
+	logger.Info("Workspace created successfully via CLI", zap.String("id", workspace.GetId()))
+	if err := output.PrintItem(printer, createdWorkspaceTable, reply); err != nil {
+		return fmt.Errorf("failed to print workspace: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Store the API token now; it cannot be retrieved again.")
+	return nil
+}
+
+func init() {
+	createWorkspaceCmd.Flags().StringVarP(&workspaceName, "name", "n", "", "Name of the workspace (required)")
+	adminCmd.AddCommand(createWorkspaceCmd)
+}
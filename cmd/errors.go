@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"Go_Test/config"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Exit codes of the CLI. They are part of its interface for scripts and are
+// documented in the README; do not renumber them.
+const (
+	exitOK                 = 0
+	exitError              = 1  // any error without a more specific code
+	exitUsage              = 2  // invalid flags, arguments or configuration
+	exitInvalidArgument    = 3  // InvalidArgument, OutOfRange
+	exitNotFound           = 4  // NotFound
+	exitConflict           = 5  // AlreadyExists, Aborted
+	exitFailedPrecondition = 6  // FailedPrecondition
+	exitUnauthenticated    = 7  // Unauthenticated
+	exitPermissionDenied   = 8  // PermissionDenied
+	exitResourceExhausted  = 9  // ResourceExhausted
+	exitUnavailable        = 10 // Unavailable, DeadlineExceeded
+	exitServerError        = 11 // Internal, Unknown, DataLoss, Unimplemented
+)
+
+// codeExitCodes maps gRPC status codes to exit codes.
+var codeExitCodes = map[codes.Code]int{
+	codes.OK:                 exitOK,
+	codes.InvalidArgument:    exitInvalidArgument,
+	codes.OutOfRange:         exitInvalidArgument,
+	codes.NotFound:           exitNotFound,
+	codes.AlreadyExists:      exitConflict,
+	codes.Aborted:            exitConflict,
+	codes.FailedPrecondition: exitFailedPrecondition,
+	codes.Unauthenticated:    exitUnauthenticated,
+	codes.PermissionDenied:   exitPermissionDenied,
+	codes.ResourceExhausted:  exitResourceExhausted,
+	codes.Unavailable:        exitUnavailable,
+	codes.DeadlineExceeded:   exitUnavailable,
+	codes.Internal:           exitServerError,
+	codes.Unknown:            exitServerError,
+	codes.DataLoss:           exitServerError,
+	codes.Unimplemented:      exitServerError,
+}
+
+// usageError reports invalid flags or arguments. It exits with exitUsage.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageErrorf returns a usageError with a formatted message.
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// usageArgs wraps a cobra argument validator so that its errors are usage errors.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return &usageError{err: err}
+		}
+		return nil
+	}
+}
+
+// rpcError reports a failed RPC of a client command. The exit code is derived from
+// its gRPC status, and the status details are printed by printError.
+type rpcError struct {
+	action string
+	err    error
+}
+
+// newRPCError wraps err, returned by the RPC that implements action (e.g. "add task").
+func newRPCError(action string, err error) error {
+	return &rpcError{action: action, err: err}
+}
+
+func (e *rpcError) Error() string {
+	if st, ok := status.FromError(e.err); ok {
+		return fmt.Sprintf("could not %s: %s (%s)", e.action, st.Message(), st.Code())
+	}
+	return fmt.Sprintf("could not %s: %v", e.action, e.err)
+}
+
+func (e *rpcError) Unwrap() error { return e.err }
+
+// exitCode returns the documented exit code for an error returned by a command.
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) || errors.Is(err, config.ErrInvalid) {
+		return exitUsage
+	}
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		if code, ok := codeExitCodes[status.Code(rpcErr.err)]; ok {
+			return code
+		}
+	}
+	return exitError
+}
+
+// printError writes err to w, followed by the google.rpc error details of a
+// failed RPC in readable form and, for usage errors, a pointer to the help text.
+func printError(w io.Writer, cmd *cobra.Command, err error) {
+	// Configuration errors reach us wrapped in FX's dependency graph; only the
+	// configuration error itself is of interest.
+	for inner := errors.Unwrap(err); inner != nil && errors.Is(inner, config.ErrInvalid); inner = errors.Unwrap(inner) {
+		err = inner
+	}
+	fmt.Fprintf(w, "Error: %v\n", err)
+
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		if st, ok := status.FromError(rpcErr.err); ok {
+			for _, detail := range st.Details() {
+				for _, line := range describeDetail(detail) {
+					fmt.Fprintf(w, "  %s\n", line)
+				}
+			}
+		}
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) && cmd != nil {
+		fmt.Fprintf(w, "Run '%s --help' for usage.\n", cmd.CommandPath())
+	}
+}
+
+// describeDetail renders one google.rpc error detail as human-readable lines.
+func describeDetail(detail any) []string {
+	switch d := detail.(type) {
+	case *errdetails.BadRequest:
+		lines := make([]string, 0, len(d.GetFieldViolations()))
+		for _, v := range d.GetFieldViolations() {
+			lines = append(lines, fmt.Sprintf("Invalid field %s: %s", v.GetField(), v.GetDescription()))
+		}
+		return lines
+	case *errdetails.ResourceInfo:
+		line := fmt.Sprintf("Resource %s %q", d.GetResourceType(), d.GetResourceName())
+		if d.GetOwner() != "" {
+			line += " in " + d.GetOwner()
+		}
+		if d.GetDescription() != "" {
+			line += ": " + d.GetDescription()
+		}
+		return []string{line}
+	case *errdetails.PreconditionFailure:
+		lines := make([]string, 0, len(d.GetViolations()))
+		for _, v := range d.GetViolations() {
+			lines = append(lines, fmt.Sprintf("Precondition %s failed for %s: %s", v.GetType(), v.GetSubject(), v.GetDescription()))
+		}
+		return lines
+	case *errdetails.ErrorInfo:
+		line := fmt.Sprintf("Reason: %s (%s)", d.GetReason(), d.GetDomain())
+		if len(d.GetMetadata()) > 0 {
+			keys := make([]string, 0, len(d.GetMetadata()))
+			for key := range d.GetMetadata() {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			pairs := make([]string, len(keys))
+			for i, key := range keys {
+				pairs[i] = key + "=" + d.GetMetadata()[key]
+			}
+			line += " " + strings.Join(pairs, " ")
+		}
+		return []string{line}
+	case *errdetails.QuotaFailure:
+		lines := make([]string, 0, len(d.GetViolations()))
+		for _, v := range d.GetViolations() {
+			lines = append(lines, fmt.Sprintf("Quota exceeded for %s: %s", v.GetSubject(), v.GetDescription()))
+		}
+		return lines
+	case *errdetails.RetryInfo:
+		return []string{fmt.Sprintf("Retry after %s", d.GetRetryDelay().AsDuration())}
+	case *errdetails.Help:
+		lines := make([]string, 0, len(d.GetLinks()))
+		for _, link := range d.GetLinks() {
+			lines = append(lines, fmt.Sprintf("See %s: %s", link.GetDescription(), link.GetUrl()))
+		}
+		return lines
+	case *errdetails.LocalizedMessage:
+		return []string{d.GetMessage()}
+	case *errdetails.DebugInfo:
+		return []string{"Debug info: " + d.GetDetail()}
+	case proto.Message:
+		return []string{"Detail: " + protojson.Format(d)}
+	case error:
+		return []string{"Undecodable detail: " + d.Error()}
+	}
+	return []string{fmt.Sprintf("Detail: %v", detail)}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/config"
+	"Go_Test/ical"
+	"Go_Test/taskfile"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// transferTimeout limits export and import, which stream all tasks of a workspace.
+const transferTimeout = 5 * time.Minute
+
+var (
+	exportFormat     string
+	exportComponents string
+	exportFile       string
+	// exportFilter holds the filter flags of export.
+	exportFilter taskFilterOptions
+	// exportLocation is the time zone of the dates in Markdown and todo.txt exports.
+	exportLocation *time.Location
+)
+
+// exportCmd represents the command to export tasks into a file.
+var exportCmd = &cobra.Command{
+	Use:   "export [--format json|csv|markdown|todotxt|ics] [--file PATH] [filter flags]",
+	Short: "Exports tasks as JSON, CSV, a Markdown checklist, todo.txt or iCalendar",
+	Long: `Calls the ExportTasks RPC method and writes the matching tasks to stdout, or to the path given with --file.
+Without --format the format follows the extension of --file, and is json otherwise.
+
+  json      every field of every task; the format for backups, read back by client import
+  csv       a header row and a row per task, for spreadsheets
+  markdown  a "- [ ]" checklist item per task in quick-add syntax, the description indented below it
+  todotxt   a line per task in the todo.txt format, without descriptions and times of due dates
+  ics       an iCalendar file of VTODOs for task and calendar apps; --components vevent adds an event
+            at the due date of every task, for apps that ignore to-dos
+
+Dates in markdown and todotxt exports are local to time_zone (CLIENT_TIME_ZONE).`,
+	Example: `  client export --file backup.json
+  client export --format markdown --project website --status pending
+  client export --format ics --assignee alice --file alice.ics
+  client export --format ics --project website --components vtodo,vevent`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("format") && exportFile != "" && exportFile != "-" {
+			if strings.EqualFold(filepath.Ext(exportFile), ".ics") {
+				exportFormat = "ics"
+			} else if format, ok := taskfile.FormatOf(exportFile); ok {
+				exportFormat = string(format)
+			}
+		}
+		if exportFormat == "ics" {
+			if _, err := ical.ParseComponents(exportComponents); err != nil {
+				return usageErrorf("--components: %v", err)
+			}
+		} else if format, err := taskfile.ParseFormat(exportFormat); err != nil {
+			return usageErrorf("--format: %v", err)
+		} else {
+			exportFormat = string(format)
+		}
+		c, _, err := config.Load()
+		if err != nil {
+			return err
+		}
+		exportLocation = c.Location()
+		filter, err := exportFilter.filter(time.Now())
+		if err != nil {
+			return err
+		}
+		return runClientApp("export", &pb.ExportTasksRequest{Filter: filter}, runExportLogic)
+	},
+}
+
+func runExportLogic(taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ExportTasksRequest) error {
+	logger.Info("Executing export logic via CLI command", zap.String("format", exportFormat))
+
+	spanCtx, span := startCommandSpan(tp, "export")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, transferTimeout)
+	defer cancel()
+
+	stream, err := taskClient.ExportTasks(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to export tasks via CLI", zap.Error(err))
+		return newRPCError("export tasks", err)
+	}
+	count := 0
+	err = writeExport(exportFile, func(w io.Writer) error {
+		if exportFormat == "ics" {
+			components, _ := ical.ParseComponents(exportComponents)
+			cal := &ical.Calendar{Name: "Tasks", Components: components}
+			err := receiveTasks(stream, func(task *pb.Task) error {
+				cal.Tasks = append(cal.Tasks, task)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			count = len(cal.Tasks)
+			return ical.Encode(w, cal)
+		}
+		enc, err := taskfile.NewEncoder(w, taskfile.Format(exportFormat), exportLocation)
+		if err != nil {
+			return err
+		}
+		err = receiveTasks(stream, func(task *pb.Task) error {
+			count++
+			return enc.Encode(task)
+		})
+		if err != nil {
+			return err
+		}
+		return enc.Close()
+	})
+	if err != nil {
+		logger.Debug("Failed to write export via CLI", zap.Error(err))
+		return err
+	}
+	logger.Info("Tasks exported via CLI", zap.Int("count", count))
+	return nil
+}
+
+// receiveTasks calls fn with every task of an ExportTasks stream.
+func receiveTasks(stream grpc.ServerStreamingClient[pb.ExportTasksReply], fn func(*pb.Task) error) error {
+	for {
+		reply, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return newRPCError("export tasks", err)
+		}
+		for _, task := range reply.GetTasks() {
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeExport calls write with stdout, or with the file at path, replacing an
+// existing one. The file is removed again if write fails, so that an interrupted
+// export leaves no partial file behind.
+func writeExport(path string, write func(io.Writer) error) error {
+	if path == "" || path == "-" {
+		if err := write(os.Stdout); err != nil {
+			return exportWriteError("export", err)
+		}
+		return nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return exportWriteError(path, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// exportWriteError returns err unchanged if it is the error of an RPC, and as a
+// failure to write name otherwise.
+func exportWriteError(name string, err error) error {
+	var rpcErr *rpcError
+	if errors.As(err, &rpcErr) {
+		return err
+	}
+	return fmt.Errorf("failed to write %s: %w", name, err)
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&exportFormat, "format", "json", "Export format: json, csv, markdown, todotxt or ics")
+	exportCmd.Flags().StringVar(&exportComponents, "components", "vtodo", "Calendar components of --format ics: vtodo, vevent or both")
+	exportCmd.Flags().StringVar(&exportFile, "file", "", "Path to write the export to (default: stdout)")
+	exportFilter.register(exportCmd.Flags())
+	clientCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	exportWorkspaceID string
+)
+
+// exportWorkspaceCmd represents the command to export a workspace and its tasks.
+var exportWorkspaceCmd = &cobra.Command{
+	Use:   "export-workspace --id <workspace_id>",
+	Short: "Exports a workspace and all of its tasks as JSON",
+	Long:  `Connects to the gRPC server, calls the ExportWorkspace RPC method for the given workspace ID and prints the result to stdout. The output defaults to JSON; --output table prints a summary.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportWorkspaceID == "" {
+			return usageErrorf("workspace ID is required. Use --id flag")
+		}
+		// The export is meant to be saved, so it defaults to JSON rather than a table.
+		if !cmd.Flags().Changed("output") {
+			printer, _ = output.NewPrinter(string(output.JSON), os.Stdout)
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.ExportWorkspaceRequest{
+					WorkspaceId: exportWorkspaceID,
+				},
+			),
+			fx.Invoke(runExportWorkspaceLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for export-workspace: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for export-workspace: %w", err)
+		}
+		return nil
+	},
+}
+
+func runExportWorkspaceLogic(lc fx.Lifecycle, adminClient pb.AdminServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ExportWorkspaceRequest) error {
+	logger.Info("Executing ExportWorkspace logic via CLI command", zap.String("workspace_id", req.GetWorkspaceId()))
+
+	spanCtx, span := startCommandSpan(tp, "admin export-workspace")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := adminClient.ExportWorkspace(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to export workspace via CLI", zap.Error(err))
+		return newRPCError("export workspace", err)
+	}
+
+	logger.Info("Workspace exported successfully via CLI",
+		zap.String("id", reply.GetWorkspace().GetId()),
+		zap.Int("tasks", len(reply.GetTasks())))
+	if err := output.PrintItem(printer, exportedWorkspaceTable, reply); err != nil {
+		return fmt.Errorf("failed to print workspace export: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	exportWorkspaceCmd.Flags().StringVar(&exportWorkspaceID, "id", "", "ID of the workspace to export (required)")
+	adminCmd.AddCommand(exportWorkspaceCmd)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// taskFilterOptions holds the task filter flags shared by get-tasks and update-tasks.
+type taskFilterOptions struct {
+	statuses      []string
+	tags          []string
+	priority      string
+	assignee      string
+	project       string
+	titleContains string
+	createdBefore string
+	createdAfter  string
+	olderThan     string
+	dueBefore     string
+	dueAfter      string
+}
+
+// register adds the filter flags to fs.
+func (o *taskFilterOptions) register(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&o.statuses, "status", nil, "Only tasks with this status; repeat for any of several")
+	fs.StringArrayVar(&o.tags, "tag", nil, "Only tasks with this tag; repeat to require several")
+	fs.StringVar(&o.priority, "priority", "", "Only tasks with this priority")
+	fs.StringVar(&o.assignee, "assignee", "", "Only tasks assigned to this person")
+	fs.StringVar(&o.project, "project", "", "Only tasks of this project")
+	fs.StringVar(&o.titleContains, "title-contains", "", "Only tasks whose title contains this text, ignoring case")
+	fs.StringVar(&o.createdBefore, "created-before", "", "Only tasks created before this time (RFC 3339 or YYYY-MM-DD)")
+	fs.StringVar(&o.createdAfter, "created-after", "", "Only tasks created at or after this time (RFC 3339 or YYYY-MM-DD)")
+	fs.StringVar(&o.olderThan, "older-than", "", "Only tasks created longer ago than this age, e.g. 36h, 30d or 2w")
+	fs.StringVar(&o.dueBefore, "due-before", "", "Only tasks due before this time (RFC 3339 or YYYY-MM-DD)")
+	fs.StringVar(&o.dueAfter, "due-after", "", "Only tasks due at or after this time (RFC 3339 or YYYY-MM-DD)")
+}
+
+// filter builds the API filter from the flags; now resolves --older-than.
+// Invalid values are reported as usage errors.
+func (o *taskFilterOptions) filter(now time.Time) (*pb.TaskFilter, error) {
+	if o.olderThan != "" && o.createdBefore != "" {
+		return nil, usageErrorf("--older-than and --created-before cannot be combined")
+	}
+	f := &pb.TaskFilter{
+		Statuses:      o.statuses,
+		Tags:          o.tags,
+		Priority:      o.priority,
+		Assignee:      o.assignee,
+		Project:       o.project,
+		TitleContains: o.titleContains,
+	}
+	for _, bound := range []struct {
+		flag  string
+		value string
+		dest  *string
+	}{
+		{"--created-before", o.createdBefore, &f.CreatedBefore},
+		{"--created-after", o.createdAfter, &f.CreatedAfter},
+		{"--due-before", o.dueBefore, &f.DueBefore},
+		{"--due-after", o.dueAfter, &f.DueAfter},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := parseTimeFlag(bound.value)
+		if err != nil {
+			return nil, usageErrorf("%s: %v", bound.flag, err)
+		}
+		*bound.dest = t.Format(time.RFC3339)
+	}
+	if o.olderThan != "" {
+		age, err := parseAge(o.olderThan)
+		if err != nil {
+			return nil, usageErrorf("--older-than: %v", err)
+		}
+		f.CreatedBefore = now.Add(-age).Format(time.RFC3339)
+	}
+	return f, nil
+}
+
+// empty reports whether no filter flag was given.
+func (o *taskFilterOptions) empty() bool {
+	return len(o.statuses) == 0 && len(o.tags) == 0 && o.priority == "" && o.assignee == "" && o.project == "" &&
+		o.titleContains == "" && o.createdBefore == "" && o.createdAfter == "" && o.olderThan == "" &&
+		o.dueBefore == "" && o.dueAfter == ""
+}
+
+// parseTimeFlag accepts an RFC 3339 timestamp or a date, meaning local midnight.
+func parseTimeFlag(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	return t, nil
+}
+
+// parseAge parses a Go duration, extended by the units d (days) and w (weeks).
+func parseAge(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("%q is not an age such as 36h, 30d or 2w", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%q is not an age such as 36h, 30d or 2w", value)
+	}
+	return age, nil
+}
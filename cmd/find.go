@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// findLimit is the --limit flag of find.
+var findLimit int32
+
+// findCmd represents the command to search the full-text index.
+var findCmd = &cobra.Command{
+	Use:   "find <words>...",
+	Short: "Finds tasks by the words of their title and description",
+	Long: `Calls the FullTextSearch RPC method and prints the matching tasks, best match first, with a snippet
+of the matching text; matched words are marked with asterisks. Unlike search, find ranks tasks by
+relevance and matches whole words, in any order.`,
+	Example: `  client find login bug
+  client find --limit 5 "database migration"`,
+	Args: usageArgs(cobra.MinimumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(&pb.FullTextSearchRequest{Text: strings.Join(args, " "), Limit: findLimit}),
+			fx.Invoke(runFindLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully: %w", err)
+		}
+		return nil
+	},
+}
+
+func runFindLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.FullTextSearchRequest) error {
+	logger.Info("Executing FullTextSearch logic via CLI command", zap.String("text", req.GetText()))
+	spanCtx, span := startCommandSpan(tp, "find")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.FullTextSearch(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to search tasks via CLI", zap.Error(err))
+		return newRPCError("search tasks", err)
+	}
+	logger.Info("Full-text search results received successfully via CLI", zap.Int("count", len(reply.GetHits())))
+	if err := output.PrintList(printer, searchHitTable, reply.GetHits()); err != nil {
+		return fmt.Errorf("failed to print tasks: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	findCmd.Flags().Int32Var(&findLimit, "limit", 0, "Maximum number of tasks to show (default 20, at most 200)")
+	clientCmd.AddCommand(findCmd)
+}
@@ -3,26 +3,41 @@ package cmd
 import (
 	pb "Go_Test/api"
 	"Go_Test/client"
+	"Go_Test/output"
 	"context"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
+// getTasksFilter holds the filter flags of get-tasks.
+var getTasksFilter taskFilterOptions
+
 // getTasksCmd represents the command to fetch and display all tasks.
 var getTasksCmd = &cobra.Command{
-	Use:   "get-tasks",
-	Short: "Fetches and displays the list of all tasks from the server",
-	Long:  `Connects to the gRPC server, calls the GetTasks RPC method, and prints the results.`,
+	Use:   "get-tasks [filter flags]",
+	Short: "Fetches and displays the list of tasks from the server",
+	Long:  `Connects to the gRPC server, calls the GetTasks RPC method, and prints the results. The filter flags restrict the list to matching tasks.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := getTasksFilter.filter(time.Now())
+		if err != nil {
+			return err
+		}
+
 		app := fx.New(
 			commonFxOptions(),
 			client.Module,
+			fx.Supply(&pb.GetTasksRequest{Filter: filter}),
 			fx.Invoke(runGetTasksLogic),
 		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 		if err := app.Start(ctx); err != nil {
@@ -35,34 +50,27 @@ var getTasksCmd = &cobra.Command{
 	},
 }
 
-func runGetTasksLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger) {
+func runGetTasksLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.GetTasksRequest) error {
 	logger.Info("Executing GetTasks logic via CLI command")
-	reqCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	spanCtx, span := startCommandSpan(tp, "get-tasks")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
 	defer cancel()
 
-	tasksReply, err := taskClient.GetTasks(reqCtx, &pb.GetTasksRequest{})
+	tasksReply, err := taskClient.GetTasks(reqCtx, req)
 	if err != nil {
-		logger.Error("Failed to get tasks via CLI", zap.Error(err))
-		fmt.Printf("Error: Could not get tasks: %v\n", err)
-		return
+		logger.Debug("Failed to get tasks via CLI", zap.Error(err))
+		return newRPCError("get tasks", err)
 	}
 	logger.Info("Tasks received successfully via CLI", zap.Int("count", len(tasksReply.GetTasks())))
-	if len(tasksReply.GetTasks()) == 0 {
-		fmt.Println("No tasks found.")
-		return
-	}
-	fmt.Println("--- Tasks ---")
-	for i, task := range tasksReply.GetTasks() {
-		fmt.Printf("%d. ID: %s\n", i+1, task.GetId())
-		fmt.Printf("   Title: %s\n", task.GetTitle())
-		fmt.Printf("   Description: %s\n", task.GetDescription())
-		fmt.Printf("   Status: %s\n", task.GetStatus())
-		fmt.Printf("   Created At: %s\n", task.GetCreatedAt())
-		fmt.Printf("   Updated At: %s\n", task.GetUpdatedAt())
-		fmt.Println("---------------")
+	if err := output.PrintList(printer, taskTable, tasksReply.GetTasks()); err != nil {
+		return fmt.Errorf("failed to print tasks: %w", err)
 	}
+	return nil
 }
 
 func init() {
+	getTasksFilter.register(getTasksCmd.Flags())
 	clientCmd.AddCommand(getTasksCmd)
 }
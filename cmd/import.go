@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/config"
+	"Go_Test/output"
+	"Go_Test/taskfile"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// importBatchSize is the number of tasks per ImportTasks message.
+	importBatchSize = 100
+	// maxImportTasks matches the limit of the server for one ImportTasks call.
+	maxImportTasks = 100000
+)
+
+var (
+	importFormat          string
+	importDryRun          bool
+	importAllowDuplicates bool
+)
+
+// importRequest holds the tasks read from the file of the import command, and the
+// results of the lines that could not be read.
+type importRequest struct {
+	tasks    []*pb.ImportTask
+	failures []*pb.ImportResult
+}
+
+// importCmd represents the command to add the tasks of a file.
+var importCmd = &cobra.Command{
+	Use:   "import FILE|- [--format json|csv|markdown|todotxt] [--dry-run] [--allow-duplicates]",
+	Short: "Imports tasks from a JSON, CSV, Markdown checklist or todo.txt file",
+	Long: `Reads tasks from FILE, or from standard input for "-", and adds them with the ImportTasks RPC method.
+Without --format the format follows the extension of FILE: .json, .csv, .md or .txt (todo.txt).
+The formats are those of client export; see client export --help.
+
+Tasks with the same title (ignoring case and spacing), project and due date as an existing task or
+an earlier task of the file are skipped as duplicates unless --allow-duplicates is given, so an
+interrupted import can simply be run again. --dry-run checks every task and reports what would be
+imported without adding anything.
+
+Every task is reported with the line it starts on. The command exits with the code of the first
+failed line; duplicates are not failures.`,
+	Example: `  client import backup.json --dry-run
+  client import tasks.csv
+  client import todo.txt --allow-duplicates
+  cat notes.md | client import - --format markdown`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := args[0]
+		format, err := importFileFormat(path)
+		if err != nil {
+			return err
+		}
+		c, _, err := config.Load()
+		if err != nil {
+			return err
+		}
+		req, err := readImportFile(path, format, time.Now().In(c.Location()))
+		if err != nil {
+			return err
+		}
+		return runClientApp("import", req, runImportLogic)
+	},
+}
+
+// importFileFormat returns the format given with --format, or the format of path.
+func importFileFormat(path string) (taskfile.Format, error) {
+	if importFormat != "" {
+		format, err := taskfile.ParseFormat(importFormat)
+		if err != nil {
+			return "", usageErrorf("--format: %v", err)
+		}
+		return format, nil
+	}
+	if path == "-" {
+		return "", usageErrorf("--format is required when reading standard input")
+	}
+	format, ok := taskfile.FormatOf(path)
+	if !ok {
+		return "", usageErrorf("cannot tell the format of %s from its extension; use --format", path)
+	}
+	return format, nil
+}
+
+// readImportFile reads the tasks of the file at path, or of standard input for "-".
+// Lines that hold no valid task become failed results.
+func readImportFile(path string, format taskfile.Format, now time.Time) (*importRequest, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer f.Close()
+		r = f
+	}
+	dec, err := taskfile.NewDecoder(r, format, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	req := &importRequest{}
+	for {
+		record, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		var lineErr *taskfile.LineError
+		if errors.As(err, &lineErr) {
+			req.failures = append(req.failures, &pb.ImportResult{
+				Line:    int32(lineErr.Line),
+				Outcome: pb.ImportOutcome_IMPORT_OUTCOME_FAILED,
+				Code:    int32(codes.InvalidArgument),
+				Message: lineErr.Err.Error(),
+			})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		req.tasks = append(req.tasks, &pb.ImportTask{Task: record.Task, Line: int32(record.Line)})
+	}
+	if len(req.tasks) > maxImportTasks {
+		return nil, usageErrorf("%s contains %d tasks; import at most %d at a time", path, len(req.tasks), maxImportTasks)
+	}
+	if len(req.tasks) == 0 && len(req.failures) == 0 {
+		return nil, usageErrorf("%s contains no tasks", path)
+	}
+	return req, nil
+}
+
+func runImportLogic(taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *importRequest) error {
+	logger.Info("Executing import logic via CLI command",
+		zap.Int("tasks", len(req.tasks)),
+		zap.Int("unreadable_lines", len(req.failures)),
+		zap.Bool("dry_run", importDryRun))
+
+	spanCtx, span := startCommandSpan(tp, "import")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, transferTimeout)
+	defer cancel()
+
+	reply := &pb.ImportTasksReply{DryRun: importDryRun}
+	if len(req.tasks) > 0 {
+		var err error
+		if reply, err = importTasks(reqCtx, taskClient, req.tasks); err != nil {
+			logger.Debug("Failed to import tasks via CLI", zap.Error(err))
+			return newRPCError("import tasks", err)
+		}
+	}
+	results := append(reply.GetResults(), req.failures...)
+	sort.SliceStable(results, func(i, j int) bool { return results[i].GetLine() < results[j].GetLine() })
+	reply.Results = results
+	reply.FailedCount += int32(len(req.failures))
+
+	if err := output.PrintList(printer, importResultTable, results); err != nil {
+		return fmt.Errorf("failed to print import results: %w", err)
+	}
+	printImportSummary(os.Stderr, reply)
+	return importError(reply)
+}
+
+// importTasks streams tasks to the ImportTasks RPC in messages of importBatchSize
+// tasks; the options go with the first.
+func importTasks(ctx context.Context, taskClient pb.TaskServiceClient, tasks []*pb.ImportTask) (*pb.ImportTasksReply, error) {
+	stream, err := taskClient.ImportTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(tasks); start += importBatchSize {
+		msg := &pb.ImportTasksRequest{Tasks: tasks[start:min(start+importBatchSize, len(tasks))]}
+		if start == 0 {
+			msg.DryRun, msg.AllowDuplicates = importDryRun, importAllowDuplicates
+		}
+		// A failed Send means the server has ended the call; CloseAndRecv returns its status.
+		if err := stream.Send(msg); err != nil {
+			break
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// printImportSummary writes the counts of an import.
+func printImportSummary(w io.Writer, reply *pb.ImportTasksReply) {
+	created := "Created"
+	if reply.GetDryRun() {
+		created = "Would create"
+	}
+	fmt.Fprintf(w, "%s %d tasks; %d duplicates skipped, %d failed.\n",
+		created, reply.GetCreatedCount(), reply.GetDuplicateCount(), reply.GetFailedCount())
+	if reply.GetDryRun() {
+		fmt.Fprintln(w, "Nothing was imported (--dry-run).")
+	}
+}
+
+// importError returns an rpcError carrying the status of the first failed line, or
+// nil when no line failed.
+func importError(reply *pb.ImportTasksReply) error {
+	for _, result := range reply.GetResults() {
+		if result.GetOutcome() != pb.ImportOutcome_IMPORT_OUTCOME_FAILED {
+			continue
+		}
+		st := &spb.Status{
+			Code:    result.GetCode(),
+			Message: fmt.Sprintf("%d of %d tasks failed; line %d: %s", reply.GetFailedCount(), len(reply.GetResults()), result.GetLine(), result.GetMessage()),
+			Details: result.GetDetails(),
+		}
+		return newRPCError("import tasks", status.ErrorProto(st))
+	}
+	return nil
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFormat, "format", "", "File format: json, csv, markdown or todotxt (default: from the file extension)")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Check the tasks and report what would be imported without adding them")
+	importCmd.Flags().BoolVar(&importAllowDuplicates, "allow-duplicates", false, "Add tasks even if they duplicate an existing task or an earlier line")
+	clientCmd.AddCommand(importCmd)
+}
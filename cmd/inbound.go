@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	inboundAssignee string
+)
+
+// inboundCmd represents the base command for the inbound email allow-list of the workspace.
+var inboundCmd = &cobra.Command{
+	Use:   "inbound",
+	Short: "Manages the senders allowed to create tasks by email",
+	Long: `A parent command for the InboundService. An email to the inbound address of the server (inbound_address)
+creates a task in the workspace that allows its sender. The subject becomes the title, the body the description
+and attached files become task attachments; tasks+<project>@ puts the task into a project.`,
+}
+
+var inboundAllowCmd = &cobra.Command{
+	Use:   "allow <email>",
+	Short: "Allows an address to create tasks, or changes its assignee",
+	Long: `Calls the AddInboundSender RPC method. Tasks created from emails of the address are assigned to
+--assignee. An address can be allowed by one workspace only.`,
+	Example: `  client inbound allow alice@example.com --assignee alice`,
+	Args:    usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("inbound allow", &pb.AddInboundSenderRequest{Email: args[0], Assignee: inboundAssignee}, runInboundAllowLogic)
+	},
+}
+
+var inboundListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the senders allowed to create tasks",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("inbound list", &pb.ListInboundSendersRequest{}, runInboundListLogic)
+	},
+}
+
+var inboundRemoveCmd = &cobra.Command{
+	Use:   "remove <email>",
+	Short: "Stops accepting task emails from an address",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("inbound remove", &pb.RemoveInboundSenderRequest{Email: args[0]}, runInboundRemoveLogic)
+	},
+}
+
+func runInboundAllowLogic(inboundClient pb.InboundServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.AddInboundSenderRequest) error {
+	logger.Info("Executing AddInboundSender logic via CLI command", zap.String("email", req.GetEmail()))
+
+	spanCtx, span := startCommandSpan(tp, "inbound allow")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := inboundClient.AddInboundSender(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to add inbound sender via CLI", zap.Error(err))
+		return newRPCError("allow sender", err)
+	}
+	if err := output.PrintItem(printer, inboundSenderTable, reply.GetSender()); err != nil {
+		return fmt.Errorf("failed to print inbound sender: %w", err)
+	}
+	return nil
+}
+
+func runInboundListLogic(inboundClient pb.InboundServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListInboundSendersRequest) error {
+	spanCtx, span := startCommandSpan(tp, "inbound list")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := inboundClient.ListInboundSenders(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list inbound senders via CLI", zap.Error(err))
+		return newRPCError("list inbound senders", err)
+	}
+	if err := output.PrintList(printer, inboundSenderTable, reply.GetSenders()); err != nil {
+		return fmt.Errorf("failed to print inbound senders: %w", err)
+	}
+	return nil
+}
+
+func runInboundRemoveLogic(inboundClient pb.InboundServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.RemoveInboundSenderRequest) error {
+	logger.Info("Executing RemoveInboundSender logic via CLI command", zap.String("email", req.GetEmail()))
+
+	spanCtx, span := startCommandSpan(tp, "inbound remove")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := inboundClient.RemoveInboundSender(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to remove inbound sender via CLI", zap.Error(err))
+		return newRPCError("remove sender", err)
+	}
+	if err := output.PrintItem(printer, inboundSenderTable, reply.GetSender()); err != nil {
+		return fmt.Errorf("failed to print inbound sender: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	inboundAllowCmd.Flags().StringVar(&inboundAssignee, "assignee", "", "Assignee of the tasks created from emails of the address")
+	inboundCmd.AddCommand(inboundAllowCmd, inboundListCmd, inboundRemoveCmd)
+	clientCmd.AddCommand(inboundCmd)
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	notifyEmail     string
+	notifyReminders bool
+	notifyDigest    bool
+	notifyTimeZone  string
+)
+
+// notifyCmd represents the base command for the email notifications of the workspace.
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Manages the email notifications of the workspace",
+	Long: `A parent command for the NotificationService. Assignees with notification preferences receive an
+email shortly before each of their open tasks is due and, when enabled, a daily digest of their open and
+overdue tasks. The server sends emails only when it is configured with an SMTP server (smtp_host).`,
+}
+
+var notifySetCmd = &cobra.Command{
+	Use:   "set <assignee>",
+	Short: "Creates or changes the notification preferences of an assignee",
+	Long: `Calls the SetNotificationPreferences RPC method. Flags that are not given keep their current value;
+for a new assignee --email is required, reminders default to on and the digest to off.`,
+	Example: `  client notify set alice --email alice@example.com --digest --time-zone Europe/Berlin
+  client notify set alice --reminders=false`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := &notifySetOptions{
+			prefs: &pb.NotificationPreferences{
+				Assignee:  args[0],
+				Email:     notifyEmail,
+				Reminders: notifyReminders,
+				Digest:    notifyDigest,
+				TimeZone:  notifyTimeZone,
+			},
+			changed: cmd.Flags().Changed,
+		}
+		return runClientApp("notify set", opts, runNotifySetLogic)
+	},
+}
+
+var notifyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the notification preferences of the workspace",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("notify list", &pb.ListNotificationPreferencesRequest{}, runNotifyListLogic)
+	},
+}
+
+var notifyDeleteCmd = &cobra.Command{
+	Use:   "delete <assignee>",
+	Short: "Deletes the notification preferences of an assignee, which stops all of their emails",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("notify delete", &pb.DeleteNotificationPreferencesRequest{Assignee: args[0]}, runNotifyDeleteLogic)
+	},
+}
+
+var notifySendDigestCmd = &cobra.Command{
+	Use:   "send-digest <assignee>",
+	Short: "Sends the digest of an assignee now",
+	Long:  `Calls the SendDigest RPC method, which emails the digest right away, even when the daily digest is off.`,
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("notify send-digest", &pb.SendDigestRequest{Assignee: args[0]}, runNotifySendDigestLogic)
+	},
+}
+
+// notifySetOptions holds the preferences given to notify set and which of their
+// flags were set explicitly.
+type notifySetOptions struct {
+	prefs   *pb.NotificationPreferences
+	changed func(flag string) bool
+}
+
+func runNotifySetLogic(notificationClient pb.NotificationServiceClient, logger *zap.Logger, tp trace.TracerProvider, opts *notifySetOptions) error {
+	logger.Info("Executing SetNotificationPreferences logic via CLI command", zap.String("assignee", opts.prefs.GetAssignee()))
+
+	spanCtx, span := startCommandSpan(tp, "notify set")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	// The RPC replaces all preferences, so unset flags are filled in from the current ones.
+	list, err := notificationClient.ListNotificationPreferences(reqCtx, &pb.ListNotificationPreferencesRequest{})
+	if err != nil {
+		logger.Debug("Failed to list notification preferences via CLI", zap.Error(err))
+		return newRPCError("set notification preferences", err)
+	}
+	prefs := opts.prefs
+	var current *pb.NotificationPreferences
+	for _, p := range list.GetPreferences() {
+		if p.GetAssignee() == prefs.GetAssignee() {
+			current = p
+		}
+	}
+	if current == nil && !opts.changed("email") {
+		return usageErrorf("--email is required for an assignee without notification preferences")
+	}
+	if current != nil {
+		if !opts.changed("email") {
+			prefs.Email = current.GetEmail()
+		}
+		if !opts.changed("reminders") {
+			prefs.Reminders = current.GetReminders()
+		}
+		if !opts.changed("digest") {
+			prefs.Digest = current.GetDigest()
+		}
+		if !opts.changed("time-zone") {
+			prefs.TimeZone = current.GetTimeZone()
+		}
+	}
+
+	reply, err := notificationClient.SetNotificationPreferences(reqCtx, &pb.SetNotificationPreferencesRequest{Preferences: prefs})
+	if err != nil {
+		logger.Debug("Failed to set notification preferences via CLI", zap.Error(err))
+		return newRPCError("set notification preferences", err)
+	}
+	if err := output.PrintItem(printer, notificationTable, reply.GetPreferences()); err != nil {
+		return fmt.Errorf("failed to print notification preferences: %w", err)
+	}
+	return nil
+}
+
+func runNotifyListLogic(notificationClient pb.NotificationServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListNotificationPreferencesRequest) error {
+	spanCtx, span := startCommandSpan(tp, "notify list")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := notificationClient.ListNotificationPreferences(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list notification preferences via CLI", zap.Error(err))
+		return newRPCError("list notification preferences", err)
+	}
+	if err := output.PrintList(printer, notificationTable, reply.GetPreferences()); err != nil {
+		return fmt.Errorf("failed to print notification preferences: %w", err)
+	}
+	return nil
+}
+
+func runNotifyDeleteLogic(notificationClient pb.NotificationServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.DeleteNotificationPreferencesRequest) error {
+	logger.Info("Executing DeleteNotificationPreferences logic via CLI command", zap.String("assignee", req.GetAssignee()))
+
+	spanCtx, span := startCommandSpan(tp, "notify delete")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := notificationClient.DeleteNotificationPreferences(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to delete notification preferences via CLI", zap.Error(err))
+		return newRPCError("delete notification preferences", err)
+	}
+	if err := output.PrintItem(printer, notificationTable, reply.GetPreferences()); err != nil {
+		return fmt.Errorf("failed to print notification preferences: %w", err)
+	}
+	return nil
+}
+
+func runNotifySendDigestLogic(notificationClient pb.NotificationServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.SendDigestRequest) error {
+	logger.Info("Executing SendDigest logic via CLI command", zap.String("assignee", req.GetAssignee()))
+
+	spanCtx, span := startCommandSpan(tp, "notify send-digest")
+	defer span.End()
+
+	// Sending waits for the SMTP server, which may take longer than other calls.
+	reqCtx, cancel := context.WithTimeout(spanCtx, 45*time.Second)
+	defer cancel()
+
+	reply, err := notificationClient.SendDigest(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to send digest via CLI", zap.Error(err))
+		return newRPCError("send digest", err)
+	}
+	if err := output.PrintItem(printer, sendDigestTable, reply); err != nil {
+		return fmt.Errorf("failed to print digest result: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	notifySetCmd.Flags().StringVar(&notifyEmail, "email", "", "Email address of the assignee")
+	notifySetCmd.Flags().BoolVar(&notifyReminders, "reminders", true, "Send a reminder before each task is due")
+	notifySetCmd.Flags().BoolVar(&notifyDigest, "digest", false, "Send a daily digest of open and overdue tasks")
+	notifySetCmd.Flags().StringVar(&notifyTimeZone, "time-zone", "", "IANA time zone of the digest schedule and of dates in emails (default: UTC)")
+	notifyCmd.AddCommand(notifySetCmd, notifyListCmd, notifyDeleteCmd, notifySendDigestCmd)
+	clientCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/output"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// printer renders the results of client commands in the format selected with --output.
+// It is set up by the client command's PersistentPreRunE.
+var printer *output.Printer
+
+// taskTable describes how tasks are shown by get-tasks, add-task and complete-task.
+var taskTable = output.TableSpec[*pb.Task]{
+	Columns: []output.Column[*pb.Task]{
+		{Header: "ID", Value: (*pb.Task).GetId},
+		{Header: "TITLE", Value: (*pb.Task).GetTitle},
+		{Header: "STATUS", Value: (*pb.Task).GetStatus},
+		{Header: "PRIORITY", Value: (*pb.Task).GetPriority},
+		{Header: "DUE AT", Value: (*pb.Task).GetDueAt},
+		{Header: "TAGS", Wide: true, Value: func(t *pb.Task) string { return strings.Join(t.GetTags(), ",") }},
+		{Header: "ASSIGNEE", Wide: true, Value: (*pb.Task).GetAssignee},
+		{Header: "PROJECT", Wide: true, Value: (*pb.Task).GetProject},
+		{Header: "DESCRIPTION", Wide: true, Value: (*pb.Task).GetDescription},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.Task).GetCreatedAt},
+		{Header: "UPDATED AT", Value: (*pb.Task).GetUpdatedAt},
+	},
+	Empty: "No tasks found.",
+}
+
+// mutationResultTable describes how the results of the batch command are shown.
+var mutationResultTable = output.TableSpec[*pb.MutationResult]{
+	Columns: []output.Column[*pb.MutationResult]{
+		{Header: "INDEX", Value: func(r *pb.MutationResult) string { return strconv.Itoa(int(r.GetIndex())) }},
+		{Header: "RESULT", Value: func(r *pb.MutationResult) string { return codes.Code(r.GetCode()).String() }},
+		{Header: "TASK ID", Value: func(r *pb.MutationResult) string { return r.GetTask().GetId() }},
+		{Header: "TITLE", Value: func(r *pb.MutationResult) string { return r.GetTask().GetTitle() }},
+		{Header: "STATUS", Wide: true, Value: func(r *pb.MutationResult) string { return r.GetTask().GetStatus() }},
+		{Header: "MESSAGE", Value: (*pb.MutationResult).GetMessage},
+	},
+}
+
+// importResultTable describes how the results of the import command are shown.
+var importResultTable = output.TableSpec[*pb.ImportResult]{
+	Columns: []output.Column[*pb.ImportResult]{
+		{Header: "LINE", Value: func(r *pb.ImportResult) string { return strconv.Itoa(int(r.GetLine())) }},
+		{Header: "RESULT", Value: func(r *pb.ImportResult) string {
+			return strings.ToLower(strings.TrimPrefix(r.GetOutcome().String(), "IMPORT_OUTCOME_"))
+		}},
+		{Header: "CODE", Wide: true, Value: func(r *pb.ImportResult) string {
+			if r.GetOutcome() != pb.ImportOutcome_IMPORT_OUTCOME_FAILED {
+				return ""
+			}
+			return codes.Code(r.GetCode()).String()
+		}},
+		{Header: "TASK ID", Value: (*pb.ImportResult).GetTaskId},
+		{Header: "MESSAGE", Value: (*pb.ImportResult).GetMessage},
+	},
+	Empty: "No tasks imported.",
+}
+
+// workspaceTable describes how workspaces are shown by the admin commands.
+var workspaceTable = output.TableSpec[*pb.Workspace]{
+	Columns: []output.Column[*pb.Workspace]{
+		{Header: "ID", Value: (*pb.Workspace).GetId},
+		{Header: "NAME", Value: (*pb.Workspace).GetName},
+		{Header: "STATUS", Value: (*pb.Workspace).GetStatus},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.Workspace).GetCreatedAt},
+		{Header: "UPDATED AT", Value: (*pb.Workspace).GetUpdatedAt},
+	},
+}
+
+// createdWorkspaceTable shows a new workspace together with its one-time API token.
+var createdWorkspaceTable = output.TableSpec[*pb.CreateWorkspaceReply]{
+	Columns: []output.Column[*pb.CreateWorkspaceReply]{
+		{Header: "ID", Value: func(r *pb.CreateWorkspaceReply) string { return r.GetWorkspace().GetId() }},
+		{Header: "NAME", Value: func(r *pb.CreateWorkspaceReply) string { return r.GetWorkspace().GetName() }},
+		{Header: "STATUS", Value: func(r *pb.CreateWorkspaceReply) string { return r.GetWorkspace().GetStatus() }},
+		{Header: "TOKEN", Value: (*pb.CreateWorkspaceReply).GetToken},
+	},
+}
+
+// exportedWorkspaceTable summarises an exported workspace; the full export needs a machine-readable format.
+var exportedWorkspaceTable = output.TableSpec[*pb.ExportWorkspaceReply]{
+	Columns: []output.Column[*pb.ExportWorkspaceReply]{
+		{Header: "ID", Value: func(r *pb.ExportWorkspaceReply) string { return r.GetWorkspace().GetId() }},
+		{Header: "NAME", Value: func(r *pb.ExportWorkspaceReply) string { return r.GetWorkspace().GetName() }},
+		{Header: "STATUS", Value: func(r *pb.ExportWorkspaceReply) string { return r.GetWorkspace().GetStatus() }},
+		{Header: "TASKS", Value: func(r *pb.ExportWorkspaceReply) string { return strconv.Itoa(len(r.GetTasks())) }},
+	},
+}
+
+// contextEntry is one client context as listed by context list; tokens are never printed.
+type contextEntry struct {
+	Current  bool   `json:"current"`
+	Name     string `json:"name"`
+	Target   string `json:"target"`
+	TLS      bool   `json:"tls"`
+	HasToken bool   `json:"has_token"`
+}
+
+// contextTable describes how client contexts are shown by context list.
+var contextTable = output.TableSpec[contextEntry]{
+	Columns: []output.Column[contextEntry]{
+		{Header: "CURRENT", Value: func(e contextEntry) string {
+			if e.Current {
+				return "*"
+			}
+			return ""
+		}},
+		{Header: "NAME", Value: func(e contextEntry) string { return e.Name }},
+		{Header: "TARGET", Value: func(e contextEntry) string { return e.Target }},
+		{Header: "TLS", Value: func(e contextEntry) string { return strconv.FormatBool(e.TLS) }},
+		{Header: "TOKEN", Value: func(e contextEntry) string {
+			if e.HasToken {
+				return "<redacted>"
+			}
+			return "<none>"
+		}},
+	},
+	Empty: "No client contexts defined.",
+}
+
+// logLevelEntry is one entry of the effective log levels returned by SetLogLevel.
+type logLevelEntry struct {
+	Logger string `json:"logger"`
+	Level  string `json:"level"`
+}
+
+// logLevelTable describes how the effective log levels are shown by set-log-level.
+var logLevelTable = output.TableSpec[logLevelEntry]{
+	Columns: []output.Column[logLevelEntry]{
+		{Header: "LOGGER", Value: func(e logLevelEntry) string {
+			if e.Logger == "" {
+				return "(default)"
+			}
+			return e.Logger
+		}},
+		{Header: "LEVEL", Value: func(e logLevelEntry) string { return e.Level }},
+	},
+}
+
+// searchHitTable describes how the hits of find are shown.
+var searchHitTable = output.TableSpec[*pb.SearchHit]{
+	Columns: []output.Column[*pb.SearchHit]{
+		{Header: "ID", Value: func(h *pb.SearchHit) string { return h.GetTask().GetId() }},
+		{Header: "SCORE", Value: func(h *pb.SearchHit) string { return strconv.FormatFloat(h.GetScore(), 'f', 2, 64) }},
+		{Header: "TITLE", Value: func(h *pb.SearchHit) string { return h.GetTask().GetTitle() }},
+		{Header: "STATUS", Value: func(h *pb.SearchHit) string { return h.GetTask().GetStatus() }},
+		{Header: "MATCH", Value: func(h *pb.SearchHit) string {
+			for _, highlight := range h.GetHighlights() {
+				if highlight.GetField() == "description" {
+					return markMatches(highlight)
+				}
+			}
+			if len(h.GetHighlights()) > 0 {
+				return markMatches(h.GetHighlights()[0])
+			}
+			return ""
+		}},
+	},
+	Empty: "No tasks found.",
+}
+
+// markMatches renders a highlight with its matched words between asterisks.
+func markMatches(h *pb.Highlight) string {
+	fragment := h.GetFragment()
+	var b strings.Builder
+	last := 0
+	for _, m := range h.GetMatches() {
+		start, end := int(m.GetStart()), int(m.GetEnd())
+		if start < last || end > len(fragment) || start > end {
+			continue
+		}
+		b.WriteString(fragment[last:start])
+		b.WriteString("*" + fragment[start:end] + "*")
+		last = end
+	}
+	b.WriteString(fragment[last:])
+	return b.String()
+}
+
+// rebuildSearchIndexTable describes how the result of admin reindex is shown.
+var rebuildSearchIndexTable = output.TableSpec[*pb.RebuildSearchIndexReply]{
+	Columns: []output.Column[*pb.RebuildSearchIndexReply]{
+		{Header: "BACKEND", Value: (*pb.RebuildSearchIndexReply).GetBackend},
+		{Header: "INDEXED TASKS", Value: func(r *pb.RebuildSearchIndexReply) string { return strconv.FormatInt(r.GetIndexedTasks(), 10) }},
+		{Header: "DURATION", Value: func(r *pb.RebuildSearchIndexReply) string {
+			return (time.Duration(r.GetDurationMs()) * time.Millisecond).String()
+		}},
+	},
+}
+
+// webhookTable describes how webhooks are shown by the webhook commands.
+var webhookTable = output.TableSpec[*pb.Webhook]{
+	Columns: []output.Column[*pb.Webhook]{
+		{Header: "ID", Value: (*pb.Webhook).GetId},
+		{Header: "URL", Value: (*pb.Webhook).GetUrl},
+		{Header: "EVENTS", Value: func(w *pb.Webhook) string {
+			if len(w.GetEventTypes()) == 0 {
+				return "*"
+			}
+			return strings.Join(w.GetEventTypes(), ",")
+		}},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.Webhook).GetCreatedAt},
+	},
+	Empty: "No webhooks found.",
+}
+
+// createdWebhookTable shows a new webhook together with its one-time signing secret.
+var createdWebhookTable = output.TableSpec[*pb.CreateWebhookReply]{
+	Columns: []output.Column[*pb.CreateWebhookReply]{
+		{Header: "ID", Value: func(r *pb.CreateWebhookReply) string { return r.GetWebhook().GetId() }},
+		{Header: "URL", Value: func(r *pb.CreateWebhookReply) string { return r.GetWebhook().GetUrl() }},
+		{Header: "SECRET", Value: (*pb.CreateWebhookReply).GetSecret},
+	},
+}
+
+// deadLetterTable describes how failed webhook deliveries are shown by webhook dead-letters.
+var deadLetterTable = output.TableSpec[*pb.DeadLetter]{
+	Columns: []output.Column[*pb.DeadLetter]{
+		{Header: "ID", Value: (*pb.DeadLetter).GetId},
+		{Header: "WEBHOOK ID", Value: (*pb.DeadLetter).GetWebhookId},
+		{Header: "URL", Wide: true, Value: (*pb.DeadLetter).GetUrl},
+		{Header: "EVENT ID", Value: (*pb.DeadLetter).GetEventId},
+		{Header: "EVENT", Value: (*pb.DeadLetter).GetEventType},
+		{Header: "ATTEMPTS", Value: func(d *pb.DeadLetter) string { return strconv.Itoa(int(d.GetAttempts())) }},
+		{Header: "LAST ERROR", Value: (*pb.DeadLetter).GetLastError},
+		{Header: "FAILED AT", Value: (*pb.DeadLetter).GetFailedAt},
+	},
+	Empty: "No dead letters.",
+}
+
+// notificationTable describes how notification preferences are shown by the notify commands.
+var notificationTable = output.TableSpec[*pb.NotificationPreferences]{
+	Columns: []output.Column[*pb.NotificationPreferences]{
+		{Header: "ASSIGNEE", Value: (*pb.NotificationPreferences).GetAssignee},
+		{Header: "EMAIL", Value: (*pb.NotificationPreferences).GetEmail},
+		{Header: "REMINDERS", Value: func(p *pb.NotificationPreferences) string { return onOff(p.GetReminders()) }},
+		{Header: "DIGEST", Value: func(p *pb.NotificationPreferences) string { return onOff(p.GetDigest()) }},
+		{Header: "TIME ZONE", Value: func(p *pb.NotificationPreferences) string {
+			if p.GetTimeZone() == "" {
+				return "UTC"
+			}
+			return p.GetTimeZone()
+		}},
+	},
+	Empty: "No notification preferences found.",
+}
+
+// sendDigestTable describes how the result of notify send-digest is shown.
+var sendDigestTable = output.TableSpec[*pb.SendDigestReply]{
+	Columns: []output.Column[*pb.SendDigestReply]{
+		{Header: "EMAIL", Value: (*pb.SendDigestReply).GetEmail},
+		{Header: "OPEN", Value: func(r *pb.SendDigestReply) string { return strconv.Itoa(int(r.GetOpenTasks())) }},
+		{Header: "OVERDUE", Value: func(r *pb.SendDigestReply) string { return strconv.Itoa(int(r.GetOverdueTasks())) }},
+	},
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+	return "off"
+}
+
+// attachmentTable describes how task attachments are shown by the attachments commands.
+var attachmentTable = output.TableSpec[*pb.Attachment]{
+	Columns: []output.Column[*pb.Attachment]{
+		{Header: "ID", Value: (*pb.Attachment).GetId},
+		{Header: "FILENAME", Value: (*pb.Attachment).GetFilename},
+		{Header: "TYPE", Value: (*pb.Attachment).GetContentType},
+		{Header: "SIZE", Value: func(a *pb.Attachment) string { return strconv.FormatInt(a.GetSize(), 10) }},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.Attachment).GetCreatedAt},
+	},
+	Empty: "No attachments found.",
+}
+
+// inboundSenderTable describes how allowed senders are shown by the inbound commands.
+var inboundSenderTable = output.TableSpec[*pb.InboundSender]{
+	Columns: []output.Column[*pb.InboundSender]{
+		{Header: "EMAIL", Value: (*pb.InboundSender).GetEmail},
+		{Header: "ASSIGNEE", Value: (*pb.InboundSender).GetAssignee},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.InboundSender).GetCreatedAt},
+	},
+	Empty: "No inbound senders found.",
+}
+
+// calendarFeedTable describes how calendar feeds are shown by the calendar commands.
+var calendarFeedTable = output.TableSpec[*pb.CalendarFeed]{
+	Columns: []output.Column[*pb.CalendarFeed]{
+		{Header: "ID", Value: (*pb.CalendarFeed).GetId},
+		{Header: "ASSIGNEE", Value: (*pb.CalendarFeed).GetAssignee},
+		{Header: "PROJECT", Value: (*pb.CalendarFeed).GetProject},
+		{Header: "CREATED AT", Wide: true, Value: (*pb.CalendarFeed).GetCreatedAt},
+	},
+	Empty: "No calendar feeds found.",
+}
+
+// createdCalendarFeedTable shows a new calendar feed together with its one-time URL.
+var createdCalendarFeedTable = output.TableSpec[*pb.CreateCalendarFeedReply]{
+	Columns: []output.Column[*pb.CreateCalendarFeedReply]{
+		{Header: "ID", Value: func(r *pb.CreateCalendarFeedReply) string { return r.GetFeed().GetId() }},
+		{Header: "URL", Value: (*pb.CreateCalendarFeedReply).GetUrl},
+	},
+}
+
+// redeliverTable describes how the result of webhook redeliver is shown.
+var redeliverTable = output.TableSpec[*pb.RedeliverWebhookReply]{
+	Columns: []output.Column[*pb.RedeliverWebhookReply]{
+		{Header: "REQUEUED", Value: func(r *pb.RedeliverWebhookReply) string { return strconv.Itoa(int(r.GetRequeued())) }},
+	},
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/config"
+	"Go_Test/quickadd"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/fx"
+)
+
+// quickAddYes skips the confirmation prompt of quick-add.
+var quickAddYes bool
+
+// quickAddCmd represents the command to add a task from a free-form description.
+var quickAddCmd = &cobra.Command{
+	Use:   "quick-add <text>...",
+	Short: "Adds a task described in natural language",
+	Long: `Parses a free-form description into a task, shows the result and calls the AddTask RPC method after confirmation.
+
+  #tag       adds a tag (repeatable)
+  !priority  sets the priority: low, medium, high or urgent
+  @name      sets the assignee
+  +Project   sets the project
+  dates      today, tonight, tomorrow, friday, next friday, next week, in 3 days, 2025-01-31
+  times      5pm, 5:30pm, at 17:00, noon, in 2 hours
+
+Everything else forms the title; words in double quotes are always part of the title.
+Relative dates are resolved in time_zone (CLIENT_TIME_ZONE), by default the local time zone.
+A date without a time means the end of that day; a time without a date means its next occurrence.`,
+	Example: `  client quick-add Fix login bug tomorrow 5pm #backend !high @alice +ProjectX
+  client quick-add --yes 'Plan "next week" retro friday at 10am'`,
+	Args: usageArgs(cobra.MinimumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, _, err := config.Load()
+		if err != nil {
+			return err
+		}
+		loc := c.Location()
+		parsed, err := quickadd.Parse(strings.Join(args, " "), time.Now().In(loc))
+		if err != nil {
+			return usageErrorf("could not parse task: %v", err)
+		}
+		req := &pb.AddTaskRequest{
+			Title:    parsed.Title,
+			Tags:     parsed.Tags,
+			Priority: parsed.Priority,
+			Assignee: parsed.Assignee,
+			Project:  parsed.Project,
+		}
+		if !parsed.Due.IsZero() {
+			req.DueAt = parsed.Due.Format(time.RFC3339)
+		}
+
+		printQuickAddSummary(os.Stderr, parsed)
+		if !quickAddYes {
+			confirmed, err := confirm(os.Stdin, os.Stderr, "Add this task? [Y/n] ", true)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Fprintln(os.Stderr, "Aborted.")
+				return nil
+			}
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(req),
+			fx.Invoke(runAddTaskLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for quick-add: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for quick-add: %w", err)
+		}
+		return nil
+	},
+}
+
+// printQuickAddSummary writes the parsed fields of a task, omitting empty ones.
+func printQuickAddSummary(w io.Writer, task *quickadd.Task) {
+	fmt.Fprintf(w, "Title:     %s\n", task.Title)
+	if !task.Due.IsZero() {
+		fmt.Fprintf(w, "Due:       %s\n", task.Due.Format("Mon, 02 Jan 2006 15:04 MST"))
+	}
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(w, "Tags:      %s\n", strings.Join(task.Tags, ", "))
+	}
+	if task.Priority != "" {
+		fmt.Fprintf(w, "Priority:  %s\n", task.Priority)
+	}
+	if task.Assignee != "" {
+		fmt.Fprintf(w, "Assignee:  %s\n", task.Assignee)
+	}
+	if task.Project != "" {
+		fmt.Fprintf(w, "Project:   %s\n", task.Project)
+	}
+}
+
+// confirm asks a yes/no question on w and reads the answer from r; an empty answer
+// means defaultYes. Without an answer, e.g. when standard input is not a terminal,
+// confirmation is required via --yes.
+func confirm(r io.Reader, w io.Writer, prompt string, defaultYes bool) (bool, error) {
+	fmt.Fprint(w, prompt)
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !(errors.Is(err, io.EOF) && answer != "") {
+		fmt.Fprintln(w)
+		return false, usageErrorf("no confirmation received; use --yes to skip the prompt")
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+func init() {
+	quickAddCmd.Flags().BoolVarP(&quickAddYes, "yes", "y", false, "Add the task without asking for confirmation")
+	clientCmd.AddCommand(quickAddCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// reindexCmd represents the command to rebuild the server's full-text search index.
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuilds the full-text search index from scratch",
+	Long: `Connects to the gRPC server and calls the RebuildSearchIndex RPC method. With the mysql backend the
+FULLTEXT index of the tasks table is dropped and created again, which also adds it to databases created
+before it existed. With the bleve backend a new index is built from all tasks and then replaces the old one.`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(&pb.RebuildSearchIndexRequest{}),
+			fx.Invoke(runReindexLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		// Rebuilding a large index takes a while; the RPC has its own, longer timeout.
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for reindex: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for reindex: %w", err)
+		}
+		return nil
+	},
+}
+
+func runReindexLogic(lc fx.Lifecycle, adminClient pb.AdminServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.RebuildSearchIndexRequest) error {
+	logger.Info("Executing RebuildSearchIndex logic via CLI command")
+
+	spanCtx, span := startCommandSpan(tp, "admin reindex")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Minute)
+	defer cancel()
+
+	reply, err := adminClient.RebuildSearchIndex(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to rebuild search index via CLI", zap.Error(err))
+		return newRPCError("rebuild search index", err)
+	}
+	if err := output.PrintItem(printer, rebuildSearchIndexTable, reply); err != nil {
+		return fmt.Errorf("failed to print reindex result: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	adminCmd.AddCommand(reindexCmd)
+}
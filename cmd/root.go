@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"Go_Test/config"
-	"fmt"
+	"Go_Test/logging"
+	"Go_Test/tracing"
+	"context"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
-	"go.uber.org/zap"
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -19,32 +22,57 @@ var rootCmd = &cobra.Command{
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Errors are printed once, with any gRPC error details, and select the exit code
+// (see exitCode).
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		printError(os.Stderr, cmd, err)
+		os.Exit(exitCode(err))
 	}
 }
 
-// commonFxOptions provides shared FX options for logger and configuration,
+// logLevelOverride, when set, replaces the configured log level.
+// Client commands set it to keep their output quiet unless --verbose is given.
+var logLevelOverride string
+
+// quietFxEvents hides FX's own event log, including its report of a failed fx.Invoke.
+// Client commands set it because they print the returned error themselves.
+var quietFxEvents bool
+
+// commonFxOptions provides shared FX options for logger, configuration and tracing,
 // used by multiple CLI commands.
 func commonFxOptions() fx.Option {
 	return fx.Options(
-		fx.Provide(NewLogger),
 		config.Module,
+		fx.Decorate(func(c *config.Config) *config.Config {
+			if logLevelOverride != "" {
+				c.LogLevel = logLevelOverride
+			}
+			if quietFxEvents {
+				c.LogLevels = strings.TrimPrefix(c.LogLevels+",fx=fatal", ",")
+			}
+			return c
+		}),
+		logging.Module,
+		tracing.Module,
 	)
 }
 
-// NewLogger provides a zap logger instance for FX.
-func NewLogger() (*zap.Logger, error) {
-	logger, err := zap.NewDevelopment()
-	if err != nil {
-		return nil, err
-	}
-	return logger, nil
+// startCommandSpan starts the root span of a CLI command.
+// RPCs issued with the returned context are recorded as its children.
+func startCommandSpan(tp trace.TracerProvider, command string) (context.Context, trace.Span) {
+	return tp.Tracer("Go_Test/cmd").Start(context.Background(), "cli "+command, trace.WithSpanKind(trace.SpanKindClient))
 }
 
 func init() {
+	// Execute prints errors itself; usage is only pointed to for usage errors.
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	config.RegisterFlags(rootCmd.PersistentFlags())
 	rootCmd.AddCommand(serverCmd)
 	rootCmd.AddCommand(clientCmd)
 }
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/config"
+	"Go_Test/output"
+	"Go_Test/query"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// searchLimit is the --limit flag of search.
+var searchLimit int32
+
+// searchCmd represents the command to find tasks with a query of the search language.
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Finds the tasks that match a search query",
+	Long: `Validates the query, calls the SearchTasks RPC method and prints the matching tasks, newest first.
+
+  word, "a phrase"     text in the title or description, ignoring case
+  status:open          status: open (not completed), done, or any status such as pending
+  tag:infra            carries the tag
+  priority>=high       priority: low, medium, high, urgent; also <, <=, > and >=
+  assignee:alice       also project:, title:, description: and id:
+  due<2026-11-01       due:, created: and updated: with :, <, <=, > and >=
+  due:none             no due date; also assignee:none, project:none and priority:none
+  a OR b, -a, NOT a    alternatives and negation; terms are combined with AND
+  (a OR b) c           parentheses group terms
+
+Dates are YYYY-MM-DD, RFC 3339 timestamps, today, tomorrow or yesterday, resolved in
+time_zone (CLIENT_TIME_ZONE), by default UTC. Quote the whole query in the shell.`,
+	Example: `  client search 'status:open tag:infra due<2026-11-01 "login bug"'
+  client search '(tag:backend OR tag:api) -status:done priority>=high'`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, _, err := config.Load()
+		if err != nil {
+			return err
+		}
+		// Parsing locally reports syntax errors without a connection to the server.
+		if _, err := query.Parse(args[0], time.Now().In(c.Location())); err != nil {
+			return usageErrorf("%s", describeQueryError(args[0], err))
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(&pb.SearchTasksRequest{Query: args[0], Limit: searchLimit, TimeZone: c.TimeZone}),
+			fx.Invoke(runSearchLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully: %w", err)
+		}
+		return nil
+	},
+}
+
+func runSearchLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.SearchTasksRequest) error {
+	logger.Info("Executing SearchTasks logic via CLI command", zap.String("query", req.GetQuery()))
+	spanCtx, span := startCommandSpan(tp, "search")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.SearchTasks(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to search tasks via CLI", zap.Error(err))
+		return newRPCError("search tasks", err)
+	}
+	logger.Info("Search results received successfully via CLI", zap.Int("count", len(reply.GetTasks())))
+	if err := output.PrintList(printer, taskTable, reply.GetTasks()); err != nil {
+		return fmt.Errorf("failed to print tasks: %w", err)
+	}
+	if reply.GetTruncated() {
+		fmt.Fprintf(os.Stderr, "More tasks match; showing the first %d. Use --limit or narrow the query.\n", len(reply.GetTasks()))
+	}
+	return nil
+}
+
+// describeQueryError renders a query error with the query and a caret under the
+// offending position.
+func describeQueryError(input string, err error) string {
+	var qErr *query.Error
+	if !errors.As(err, &qErr) {
+		return "invalid query: " + err.Error()
+	}
+	return fmt.Sprintf("invalid query: %s\n  %s\n  %s^", qErr.Msg, input, strings.Repeat(" ", qErr.Pos))
+}
+
+func init() {
+	searchCmd.Flags().Int32Var(&searchLimit, "limit", 0, "Maximum number of tasks to show (default 50, at most 1000)")
+	clientCmd.AddCommand(searchCmd)
+}
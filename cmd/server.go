@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"Go_Test/auth"
+	"Go_Test/caldav"
 	"Go_Test/database"
+	"Go_Test/events"
+	"Go_Test/gateway"
+	"Go_Test/ical"
+	"Go_Test/inbound"
+	"Go_Test/metrics"
+	"Go_Test/notify"
 	"Go_Test/repository"
 	"Go_Test/server"
+	"Go_Test/webhook"
 	"context"
 	"os"
 	"os/signal"
@@ -25,8 +34,20 @@ var serverCmd = &cobra.Command{
 			commonFxOptions(),
 			database.Module,
 			repository.Module,
+			auth.Module,
+			metrics.Module,
 			server.Module,
-			fx.Invoke(func(*grpc.Server, *zap.Logger) {}), // Ensure server and logger are initialized
+			gateway.Module,
+			webhook.Module,
+			events.Module,
+			notify.Module,
+			inbound.Module,
+			ical.Module,
+			caldav.Module,
+			// Ensure servers, background workers and logger are initialized
+			fx.Invoke(func(*grpc.Server, *metrics.Server, *gateway.Server, *zap.Logger) {}),
+			fx.Invoke(func(*webhook.Dispatcher, *events.Relay, *notify.Notifier) {}),
+			fx.Invoke(func(*inbound.SMTPReceiver, *inbound.MaildirPoller) {}),
 		)
 
 		ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/logging"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	logLevel      string
+	logLevelScope string
+)
+
+// setLogLevelCmd represents the command to change the server's log level at runtime.
+var setLogLevelCmd = &cobra.Command{
+	Use:   "set-log-level --level <level> [--logger <package>]",
+	Short: "Changes the server's log level without a restart",
+	Long: `Connects to the gRPC server and calls the SetLogLevel RPC method. Without --logger the default level is changed; with --logger only that package (e.g. repository, server, auth) is changed.
+--level default removes the level of the package, which then follows the default level again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if logLevel == "" {
+			return usageErrorf("level is required. Use --level flag")
+		}
+		if logLevel == logging.DefaultLevel && logLevelScope == "" {
+			return usageErrorf("--level %s requires --logger; the default level cannot be removed", logging.DefaultLevel)
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.SetLogLevelRequest{
+					Level:  logLevel,
+					Logger: logLevelScope,
+				},
+			),
+			fx.Invoke(runSetLogLevelLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for set-log-level: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for set-log-level: %w", err)
+		}
+		return nil
+	},
+}
+
+func runSetLogLevelLogic(lc fx.Lifecycle, adminClient pb.AdminServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.SetLogLevelRequest) error {
+	logger.Info("Executing SetLogLevel logic via CLI command", zap.String("level", req.GetLevel()), zap.String("logger", req.GetLogger()))
+
+	spanCtx, span := startCommandSpan(tp, "admin set-log-level")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := adminClient.SetLogLevel(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to set log level via CLI", zap.Error(err))
+		return newRPCError("set log level", err)
+	}
+
+	entries := make([]logLevelEntry, 0, len(reply.GetLevels()))
+	for name, level := range reply.GetLevels() {
+		entries = append(entries, logLevelEntry{Logger: name, Level: level})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Logger < entries[j].Logger })
+	if err := output.PrintList(printer, logLevelTable, entries); err != nil {
+		return fmt.Errorf("failed to print log levels: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	setLogLevelCmd.Flags().StringVarP(&logLevel, "level", "l", "", "New log level: debug, info, warn, error, or default to remove the level of --logger (required)")
+	setLogLevelCmd.Flags().StringVar(&logLevelScope, "logger", "", "Package logger to change (e.g. repository, server); empty changes the default level")
+	adminCmd.AddCommand(setLogLevelCmd)
+}
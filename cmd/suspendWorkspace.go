@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	suspendWorkspaceID string
+)
+
+// suspendWorkspaceCmd represents the command to suspend a workspace.
+var suspendWorkspaceCmd = &cobra.Command{
+	Use:   "suspend-workspace --id <workspace_id>",
+	Short: "Suspends a workspace so that its tokens are rejected",
+	Long:  `Connects to the gRPC server and calls the SuspendWorkspace RPC method for the given workspace ID.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if suspendWorkspaceID == "" {
+			return usageErrorf("workspace ID is required. Use --id flag")
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.SuspendWorkspaceRequest{
+					WorkspaceId: suspendWorkspaceID,
+				},
+			),
+			fx.Invoke(runSuspendWorkspaceLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for suspend-workspace: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for suspend-workspace: %w", err)
+		}
+		return nil
+	},
+}
+
+func runSuspendWorkspaceLogic(lc fx.Lifecycle, adminClient pb.AdminServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.SuspendWorkspaceRequest) error {
+	logger.Info("Executing SuspendWorkspace logic via CLI command", zap.String("workspace_id", req.GetWorkspaceId()))
+
+	spanCtx, span := startCommandSpan(tp, "admin suspend-workspace")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := adminClient.SuspendWorkspace(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to suspend workspace via CLI", zap.Error(err))
+		return newRPCError("suspend workspace", err)
+	}
+
+	workspace := reply.GetWorkspace()
+	logger.Info("Workspace suspended successfully via CLI", zap.String("id", workspace.GetId()))
+	if err := output.PrintItem(printer, workspaceTable, workspace); err != nil {
+		return fmt.Errorf("failed to print workspace: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	suspendWorkspaceCmd.Flags().StringVar(&suspendWorkspaceID, "id", "", "ID of the workspace to suspend (required)")
+	adminCmd.AddCommand(suspendWorkspaceCmd)
+}
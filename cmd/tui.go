@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/tui"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	tuiRefreshInterval time.Duration
+)
+
+// tuiCmd represents the command to browse and edit tasks in a full-screen terminal interface.
+var tuiCmd = &cobra.Command{
+	Use:   "tui [--refresh <interval>]",
+	Short: "Opens a full-screen terminal interface for browsing and editing tasks",
+	Long: `Connects to the gRPC server and shows the tasks of the workspace in a list with a detail pane.
+Tasks can be added (a), edited (e), completed (c) and filtered (/). The list is reloaded every --refresh interval.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if tuiRefreshInterval < time.Second {
+			return usageErrorf("--refresh must be at least 1s")
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(tui.Options{RefreshInterval: tuiRefreshInterval, RequestTimeout: 10 * time.Second}),
+			fx.Invoke(runTUILogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for tui: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for tui: %w", err)
+		}
+		return nil
+	},
+}
+
+func runTUILogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, opts tui.Options) error {
+	logger.Info("Executing TUI via CLI command", zap.Duration("refresh", opts.RefreshInterval))
+
+	spanCtx, span := startCommandSpan(tp, "tui")
+	defer span.End()
+
+	if err := tui.Run(spanCtx, taskClient, opts); err != nil {
+		return fmt.Errorf("terminal interface failed: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	tuiCmd.Flags().DurationVar(&tuiRefreshInterval, "refresh", 5*time.Second, "How often the task list is reloaded from the server")
+	clientCmd.AddCommand(tuiCmd)
+}
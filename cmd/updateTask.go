@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+var (
+	updateTaskID          string
+	updateTaskTitle       string
+	updateTaskDescription string
+	updateTaskStatus      string
+	updateTaskDue         string
+	updateTaskTags        []string
+	updateTaskPriority    string
+	updateTaskAssignee    string
+	updateTaskProject     string
+)
+
+// updateTaskFlags maps the flags of update-task to the update mask paths they set.
+var updateTaskFlags = []struct{ flag, path string }{
+	{"title", "title"},
+	{"description", "description"},
+	{"status", "status"},
+	{"due", "due_at"},
+	{"tag", "tags"},
+	{"priority", "priority"},
+	{"assignee", "assignee"},
+	{"project", "project"},
+}
+
+// updateTaskCmd represents the command to change the fields of a task.
+var updateTaskCmd = &cobra.Command{
+	Use:   "update-task --id <task_id> [--title <title>] [--description <desc>] [--status <status>] [--due <time>] [--tag <tag>]... [--priority <priority>] [--assignee <name>] [--project <project>]",
+	Short: "Changes the fields of a task",
+	Long: `Connects to the gRPC server and calls the UpdateTask RPC method. Only the fields given as flags are changed.
+An empty value clears a field, e.g. --due "" removes the due date and --tag "" removes all tags.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateTaskID == "" {
+			return usageErrorf("task ID is required. Use --id flag")
+		}
+		var paths []string
+		for _, f := range updateTaskFlags {
+			if cmd.Flags().Changed(f.flag) {
+				paths = append(paths, f.path)
+			}
+		}
+		if len(paths) == 0 {
+			return usageErrorf("nothing to update. Use --title, --description, --status, --due, --tag, --priority, --assignee or --project")
+		}
+		var tags []string
+		for _, tag := range updateTaskTags {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.UpdateTaskRequest{
+					TaskId:      updateTaskID,
+					Title:       updateTaskTitle,
+					Description: updateTaskDescription,
+					Status:      updateTaskStatus,
+					DueAt:       updateTaskDue,
+					Tags:        tags,
+					Priority:    updateTaskPriority,
+					Assignee:    updateTaskAssignee,
+					Project:     updateTaskProject,
+					UpdateMask:  &fieldmaskpb.FieldMask{Paths: paths},
+				},
+			),
+			fx.Invoke(runUpdateTaskLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for update-task: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for update-task: %w", err)
+		}
+		return nil
+	},
+}
+
+func runUpdateTaskLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.UpdateTaskRequest) error {
+	logger.Info("Executing UpdateTask logic via CLI command",
+		zap.String("task_id", req.GetTaskId()),
+		zap.Strings("update_mask", req.GetUpdateMask().GetPaths()))
+
+	spanCtx, span := startCommandSpan(tp, "update-task")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := taskClient.UpdateTask(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to update task via CLI", zap.Error(err))
+		return newRPCError("update task", err)
+	}
+
+	updatedTask := reply.GetTask()
+	logger.Info("Task updated successfully via CLI", zap.String("id", updatedTask.GetId()))
+	if err := output.PrintItem(printer, taskTable, updatedTask); err != nil {
+		return fmt.Errorf("failed to print task: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	updateTaskCmd.Flags().StringVar(&updateTaskID, "id", "", "ID of the task to update (required)")
+	updateTaskCmd.Flags().StringVarP(&updateTaskTitle, "title", "t", "", "New title of the task")
+	updateTaskCmd.Flags().StringVarP(&updateTaskDescription, "description", "d", "", "New description of the task")
+	updateTaskCmd.Flags().StringVarP(&updateTaskStatus, "status", "s", "", "New status of the task (e.g., pending, in_progress)")
+	updateTaskCmd.Flags().StringVar(&updateTaskDue, "due", "", "New due date as an RFC 3339 timestamp; empty clears it")
+	updateTaskCmd.Flags().StringArrayVar(&updateTaskTags, "tag", nil, "New tags, replacing the current ones; repeat the flag for several tags")
+	updateTaskCmd.Flags().StringVar(&updateTaskPriority, "priority", "", "New priority: low, medium, high, urgent or empty")
+	updateTaskCmd.Flags().StringVar(&updateTaskAssignee, "assignee", "", "New assignee of the task")
+	updateTaskCmd.Flags().StringVar(&updateTaskProject, "project", "", "New project of the task")
+	clientCmd.AddCommand(updateTaskCmd)
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updateTasksOptions holds the flags of update-tasks that control the confirmation.
+type updateTasksOptions struct {
+	all          bool
+	dryRun       bool
+	yes          bool
+	confirmAbove int
+	sample       int
+}
+
+var (
+	updateTasksFilter taskFilterOptions
+	updateTasksPatch  struct {
+		status   string
+		due      string
+		tags     []string
+		priority string
+		assignee string
+		project  string
+	}
+	updateTasksOpts updateTasksOptions
+)
+
+// updateTasksPatchFlags maps the patch flags of update-tasks to the update mask paths they set.
+var updateTasksPatchFlags = []struct{ flag, path string }{
+	{"set-status", "status"},
+	{"set-due", "due_at"},
+	{"set-tag", "tags"},
+	{"set-priority", "priority"},
+	{"set-assignee", "assignee"},
+	{"set-project", "project"},
+}
+
+// updateTasksCmd represents the command to change every task that matches a filter.
+var updateTasksCmd = &cobra.Command{
+	Use:   "update-tasks [filter flags] [--set-status <status>] [--set-tag <tag>]... [--set-priority <priority>] ...",
+	Short: "Changes every task that matches a filter",
+	Long: `Connects to the gRPC server and calls the UpdateTasksByQuery RPC method. The filter flags select the tasks,
+the --set-* flags name the changes; an empty --set-* value clears the field.
+
+The command first performs a dry run and, when more than --confirm-above tasks match, shows a sample and
+asks for confirmation. The update then only proceeds if the number of matching tasks has not changed.
+All matching tasks are updated in one transaction. Without filter flags, --all is required.`,
+	Example: `  client update-tasks --tag release-1.2 --older-than 30d --status pending --set-status completed
+  client update-tasks --assignee alice --set-assignee bob --dry-run`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateTasksFilter.empty() && !updateTasksOpts.all {
+			return usageErrorf("no filter given. Use filter flags, or --all to update every task")
+		}
+		filter, err := updateTasksFilter.filter(time.Now())
+		if err != nil {
+			return err
+		}
+		var paths []string
+		for _, f := range updateTasksPatchFlags {
+			if cmd.Flags().Changed(f.flag) {
+				paths = append(paths, f.path)
+			}
+		}
+		if len(paths) == 0 {
+			return usageErrorf("nothing to change. Use --set-status, --set-due, --set-tag, --set-priority, --set-assignee or --set-project")
+		}
+		if updateTasksOpts.sample < 1 || updateTasksOpts.sample > 100 {
+			return usageErrorf("--sample must be between 1 and 100")
+		}
+		var tags []string
+		for _, tag := range updateTasksPatch.tags {
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		app := fx.New(
+			commonFxOptions(),
+			client.Module,
+			fx.Supply(
+				&pb.UpdateTasksByQueryRequest{
+					Filter: filter,
+					Patch: &pb.TaskPatch{
+						Status:     updateTasksPatch.status,
+						DueAt:      updateTasksPatch.due,
+						Tags:       tags,
+						Priority:   updateTasksPatch.priority,
+						Assignee:   updateTasksPatch.assignee,
+						Project:    updateTasksPatch.project,
+						UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+					},
+					SampleSize: int32(updateTasksOpts.sample),
+				},
+				updateTasksOpts,
+			),
+			fx.Invoke(runUpdateTasksLogic),
+		)
+		if err := app.Err(); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		if err := app.Start(ctx); err != nil {
+			return fmt.Errorf("fx app failed to start for update-tasks: %w", err)
+		}
+		if err := app.Stop(ctx); err != nil {
+			return fmt.Errorf("fx app failed to stop gracefully for update-tasks: %w", err)
+		}
+		return nil
+	},
+}
+
+func runUpdateTasksLogic(lc fx.Lifecycle, taskClient pb.TaskServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.UpdateTasksByQueryRequest, opts updateTasksOptions) error {
+	logger.Info("Executing UpdateTasksByQuery logic via CLI command",
+		zap.Strings("update_mask", req.GetPatch().GetUpdateMask().GetPaths()),
+		zap.Bool("dry_run", opts.dryRun))
+
+	spanCtx, span := startCommandSpan(tp, "update-tasks")
+	defer span.End()
+
+	dryRun := proto.Clone(req).(*pb.UpdateTasksByQueryRequest)
+	dryRun.DryRun = true
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+	preview, err := taskClient.UpdateTasksByQuery(reqCtx, dryRun)
+	if err != nil {
+		logger.Debug("Failed to preview task update via CLI", zap.Error(err))
+		return newRPCError("preview task update", err)
+	}
+	matched := int(preview.GetMatchedCount())
+
+	if opts.dryRun {
+		fmt.Fprintf(os.Stderr, "%d tasks match the filter; showing %d.\n", matched, len(preview.GetTasks()))
+		if err := output.PrintList(printer, taskTable, preview.GetTasks()); err != nil {
+			return fmt.Errorf("failed to print tasks: %w", err)
+		}
+		return nil
+	}
+	if matched == 0 {
+		fmt.Fprintln(os.Stderr, "No tasks match the filter.")
+		return nil
+	}
+	if matched > opts.confirmAbove && !opts.yes {
+		fmt.Fprintf(os.Stderr, "%d tasks match the filter, for example:\n", matched)
+		if sample, err := output.NewPrinter(string(output.Table), os.Stderr); err == nil {
+			output.PrintList(sample, taskTable, preview.GetTasks())
+		}
+		confirmed, err := confirm(os.Stdin, os.Stderr, fmt.Sprintf("Update %d tasks? [y/N] ", matched), false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Fprintln(os.Stderr, "Aborted.")
+			return nil
+		}
+	}
+
+	req.ExpectedCount = int32(matched)
+	// Updating many tasks in one transaction may take longer than a single call.
+	updateCtx, cancelUpdate := context.WithTimeout(spanCtx, 60*time.Second)
+	defer cancelUpdate()
+	reply, err := taskClient.UpdateTasksByQuery(updateCtx, req)
+	if err != nil {
+		logger.Debug("Failed to update tasks via CLI", zap.Error(err))
+		return newRPCError("update tasks", err)
+	}
+	logger.Info("Tasks updated successfully via CLI", zap.Int32("count", reply.GetMatchedCount()))
+	fmt.Fprintf(os.Stderr, "Updated %d tasks; showing %d.\n", reply.GetMatchedCount(), len(reply.GetTasks()))
+	if err := output.PrintList(printer, taskTable, reply.GetTasks()); err != nil {
+		return fmt.Errorf("failed to print tasks: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	updateTasksFilter.register(updateTasksCmd.Flags())
+	updateTasksCmd.Flags().StringVar(&updateTasksPatch.status, "set-status", "", "New status of the matching tasks")
+	updateTasksCmd.Flags().StringVar(&updateTasksPatch.due, "set-due", "", "New due date as an RFC 3339 timestamp; empty clears it")
+	updateTasksCmd.Flags().StringArrayVar(&updateTasksPatch.tags, "set-tag", nil, "New tags, replacing the current ones; repeat for several")
+	updateTasksCmd.Flags().StringVar(&updateTasksPatch.priority, "set-priority", "", "New priority: low, medium, high, urgent or empty")
+	updateTasksCmd.Flags().StringVar(&updateTasksPatch.assignee, "set-assignee", "", "New assignee of the matching tasks")
+	updateTasksCmd.Flags().StringVar(&updateTasksPatch.project, "set-project", "", "New project of the matching tasks")
+	updateTasksCmd.Flags().BoolVar(&updateTasksOpts.all, "all", false, "Allow an empty filter, which matches every task")
+	updateTasksCmd.Flags().BoolVar(&updateTasksOpts.dryRun, "dry-run", false, "Only show how many and which tasks match")
+	updateTasksCmd.Flags().BoolVarP(&updateTasksOpts.yes, "yes", "y", false, "Update without asking for confirmation")
+	updateTasksCmd.Flags().IntVar(&updateTasksOpts.confirmAbove, "confirm-above", 10, "Ask for confirmation when more than this many tasks match")
+	updateTasksCmd.Flags().IntVar(&updateTasksOpts.sample, "sample", 10, "Number of matching tasks shown by the dry run, the confirmation and the result")
+	clientCmd.AddCommand(updateTasksCmd)
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/client"
+	"Go_Test/output"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var (
+	webhookEvents           []string
+	webhookDeadLetterFilter string
+	webhookRedeliverAll     bool
+	webhookRedeliverWebhook string
+)
+
+// webhookCmd represents the base command for the webhook subscriptions of the workspace.
+var webhookCmd = &cobra.Command{
+	Use:   "webhook",
+	Short: "Manages the webhooks of the workspace",
+	Long: `A parent command for the WebhookService. Every task change (task.created, task.updated, task.completed,
+task.deleted) is posted as a signed JSON request to the webhooks subscribed to its event type. Failed
+deliveries are retried with exponential backoff and end up as dead letters, which can be redelivered.`,
+}
+
+var webhookCreateCmd = &cobra.Command{
+	Use:   "create <url>",
+	Short: "Subscribes a URL to task events and prints its signing secret",
+	Long: `Calls the CreateWebhook RPC method. Without --events the webhook receives every event. The secret
+is only shown once; receivers use it to check the X-Webhook-Signature header of each delivery.`,
+	Example: `  client webhook create https://example.com/hooks/tasks
+  client webhook create https://example.com/hooks/done --events task.completed`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("webhook create", &pb.CreateWebhookRequest{Url: args[0], EventTypes: webhookEvents}, runWebhookCreateLogic)
+	},
+}
+
+var webhookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the webhooks of the workspace",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("webhook list", &pb.ListWebhooksRequest{}, runWebhookListLogic)
+	},
+}
+
+var webhookDeleteCmd = &cobra.Command{
+	Use:   "delete <webhook_id>",
+	Short: "Deletes a webhook together with its pending deliveries and dead letters",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("webhook delete", &pb.DeleteWebhookRequest{WebhookId: args[0]}, runWebhookDeleteLogic)
+	},
+}
+
+var webhookDeadLettersCmd = &cobra.Command{
+	Use:   "dead-letters",
+	Short: "Lists the deliveries that failed after the last retry",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runClientApp("webhook dead-letters", &pb.ListDeadLettersRequest{WebhookId: webhookDeadLetterFilter}, runWebhookDeadLettersLogic)
+	},
+}
+
+var webhookRedeliverCmd = &cobra.Command{
+	Use:   "redeliver [dead_letter_id...]",
+	Short: "Queues dead letters for another round of delivery attempts",
+	Long: `Calls the RedeliverWebhook RPC method for the given dead letters, or with --all for every dead letter
+of the workspace (or of --webhook). Redelivered events keep their event ID and get a fresh set of attempts.`,
+	Example: `  client webhook redeliver 12 13
+  client webhook redeliver --all --webhook 3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if webhookRedeliverAll == (len(args) > 0) {
+			return usageErrorf("pass either dead letter IDs or --all")
+		}
+		req := &pb.RedeliverWebhookRequest{DeadLetterIds: args, All: webhookRedeliverAll, WebhookId: webhookRedeliverWebhook}
+		return runClientApp("webhook redeliver", req, runWebhookRedeliverLogic)
+	},
+}
+
+// runClientApp runs logic, an fx.Invoke target, with the client module and req supplied.
+func runClientApp(command string, req any, logic any) error {
+	app := fx.New(
+		commonFxOptions(),
+		client.Module,
+		fx.Supply(req),
+		fx.Invoke(logic),
+	)
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := app.Start(ctx); err != nil {
+		return fmt.Errorf("fx app failed to start for %s: %w", command, err)
+	}
+	if err := app.Stop(ctx); err != nil {
+		return fmt.Errorf("fx app failed to stop gracefully for %s: %w", command, err)
+	}
+	return nil
+}
+
+func runWebhookCreateLogic(webhookClient pb.WebhookServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.CreateWebhookRequest) error {
+	logger.Info("Executing CreateWebhook logic via CLI command", zap.String("url", req.GetUrl()))
+
+	spanCtx, span := startCommandSpan(tp, "webhook create")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := webhookClient.CreateWebhook(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to create webhook via CLI", zap.Error(err))
+		return newRPCError("create webhook", err)
+	}
+	if err := output.PrintItem(printer, createdWebhookTable, reply); err != nil {
+		return fmt.Errorf("failed to print webhook: %w", err)
+	}
+	return nil
+}
+
+func runWebhookListLogic(webhookClient pb.WebhookServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListWebhooksRequest) error {
+	spanCtx, span := startCommandSpan(tp, "webhook list")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := webhookClient.ListWebhooks(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list webhooks via CLI", zap.Error(err))
+		return newRPCError("list webhooks", err)
+	}
+	if err := output.PrintList(printer, webhookTable, reply.GetWebhooks()); err != nil {
+		return fmt.Errorf("failed to print webhooks: %w", err)
+	}
+	return nil
+}
+
+func runWebhookDeleteLogic(webhookClient pb.WebhookServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.DeleteWebhookRequest) error {
+	logger.Info("Executing DeleteWebhook logic via CLI command", zap.String("webhook_id", req.GetWebhookId()))
+
+	spanCtx, span := startCommandSpan(tp, "webhook delete")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := webhookClient.DeleteWebhook(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to delete webhook via CLI", zap.Error(err))
+		return newRPCError("delete webhook", err)
+	}
+	if err := output.PrintItem(printer, webhookTable, reply.GetWebhook()); err != nil {
+		return fmt.Errorf("failed to print webhook: %w", err)
+	}
+	return nil
+}
+
+func runWebhookDeadLettersLogic(webhookClient pb.WebhookServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.ListDeadLettersRequest) error {
+	spanCtx, span := startCommandSpan(tp, "webhook dead-letters")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := webhookClient.ListDeadLetters(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to list dead letters via CLI", zap.Error(err))
+		return newRPCError("list dead letters", err)
+	}
+	if err := output.PrintList(printer, deadLetterTable, reply.GetDeadLetters()); err != nil {
+		return fmt.Errorf("failed to print dead letters: %w", err)
+	}
+	return nil
+}
+
+func runWebhookRedeliverLogic(webhookClient pb.WebhookServiceClient, logger *zap.Logger, tp trace.TracerProvider, req *pb.RedeliverWebhookRequest) error {
+	logger.Info("Executing RedeliverWebhook logic via CLI command", zap.Strings("dead_letter_ids", req.GetDeadLetterIds()), zap.Bool("all", req.GetAll()))
+
+	spanCtx, span := startCommandSpan(tp, "webhook redeliver")
+	defer span.End()
+
+	reqCtx, cancel := context.WithTimeout(spanCtx, 10*time.Second)
+	defer cancel()
+
+	reply, err := webhookClient.RedeliverWebhook(reqCtx, req)
+	if err != nil {
+		logger.Debug("Failed to redeliver dead letters via CLI", zap.Error(err))
+		return newRPCError("redeliver dead letters", err)
+	}
+	if err := output.PrintItem(printer, redeliverTable, reply); err != nil {
+		return fmt.Errorf("failed to print redelivery result: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	webhookCreateCmd.Flags().StringSliceVar(&webhookEvents, "events", nil, "Comma-separated event types to subscribe to (default: all)")
+	webhookDeadLettersCmd.Flags().StringVar(&webhookDeadLetterFilter, "webhook", "", "Only list the dead letters of this webhook")
+	webhookRedeliverCmd.Flags().BoolVar(&webhookRedeliverAll, "all", false, "Redeliver every dead letter")
+	webhookRedeliverCmd.Flags().StringVar(&webhookRedeliverWebhook, "webhook", "", "Only redeliver dead letters of this webhook")
+	webhookCmd.AddCommand(webhookCreateCmd, webhookListCmd, webhookDeleteCmd, webhookDeadLettersCmd, webhookRedeliverCmd)
+	clientCmd.AddCommand(webhookCmd)
+}
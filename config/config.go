@@ -1,23 +1,162 @@
 package config
 
 import (
-	"fmt"
-	"os"
+	"net"
+	"strings"
+	"time"
+	_ "time/tzdata" // time_zone must work on hosts without a zoneinfo database
 
+	"github.com/go-sql-driver/mysql"
 	"go.uber.org/fx"
 )
 
 // Config holds application configuration parameters.
+//
+// Every field tagged with `key` can be set, in increasing order of precedence, from
+// its default, from the config file (using the key), from the environment variable named
+// by `env`, and from the persistent command-line flag named after the key with dashes
+// (e.g. db_host becomes --db-host). Fields tagged `secret` are redacted by `config show`.
 type Config struct {
-	GRPCServerAddress string
-	GRPCClientTarget  string
+	GRPCServerAddress string `key:"grpc_server_address" env:"GRPC_SERVER_ADDRESS" usage:"Listen address of the gRPC server; defaults to :<grpc_port>"`
+	GRPCPort          string `key:"grpc_port" env:"GRPC_PORT" usage:"Port of the gRPC server, used when grpc_server_address is empty"`
+	// GRPCClientTarget is dialed when no client context is selected; set by an environment
+	// variable or a flag, it also overrides the target of the selected context.
+	GRPCClientTarget string `key:"grpc_client_target" env:"GRPC_CLIENT_TARGET" usage:"Address the client CLI dials; overrides the target of the client context"`
 
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBDSN      string
+	// Context selects a named client context; when empty the current context of the
+	// contexts file is used, and without one the client falls back to GRPCClientTarget.
+	Context      string `key:"context" env:"CLIENT_CONTEXT" usage:"Client context to use instead of the current one"`
+	ContextsFile string `key:"contexts_file" env:"CLIENT_CONTEXTS_FILE" usage:"Client contexts file (default: ~/.config/fx-grpc-app/contexts.yaml)"`
+	// ClientTLS, ClientCAFile and ClientServerName secure the client connection like the
+	// TLS settings of a client context, which they override when set by an environment
+	// variable or a flag. A CA file or server name implies TLS.
+	ClientTLS        bool   `key:"client_tls" env:"CLIENT_TLS" usage:"Dial the server over TLS"`
+	ClientCAFile     string `key:"client_ca_file" env:"CLIENT_CA_FILE" usage:"CA certificate that verifies the server; implies client_tls"`
+	ClientServerName string `key:"client_server_name" env:"CLIENT_SERVER_NAME" usage:"Name expected in the server certificate; implies client_tls"`
+	// TimeZone is the IANA time zone in which the client resolves relative dates such as
+	// "tomorrow 5pm". An empty value uses the local time zone of the machine, except in
+	// search, whose dates are resolved by the server and then default to UTC.
+	TimeZone string `key:"time_zone" env:"CLIENT_TIME_ZONE" usage:"IANA time zone for relative dates in quick-add and search, e.g. Europe/Berlin (default: local)"`
+
+	// LogFormat is the log encoding: "console" or "json".
+	LogFormat string `key:"log_format" env:"LOG_FORMAT" usage:"Log encoding: console or json"`
+	// LogLevel is the default minimum log level (debug, info, warn, error).
+	LogLevel string `key:"log_level" env:"LOG_LEVEL" usage:"Default log level: debug, info, warn or error"`
+	// LogLevels holds per-package level overrides, e.g. "repository=debug,server=warn".
+	LogLevels string `key:"log_levels" env:"LOG_LEVELS" usage:"Per-package log level overrides, e.g. repository=debug,server=warn"`
+	// LogSamplingInitial and LogSamplingThereafter configure log sampling per second:
+	// the first LogSamplingInitial identical entries are logged, then every LogSamplingThereafter-th.
+	// A LogSamplingInitial of 0 disables sampling.
+	LogSamplingInitial    int `key:"log_sampling_initial" env:"LOG_SAMPLING_INITIAL" usage:"Identical log entries per second before sampling starts (0 disables sampling)"`
+	LogSamplingThereafter int `key:"log_sampling_thereafter" env:"LOG_SAMPLING_THEREAFTER" usage:"Log every Nth identical entry once sampling has started"`
+	// LogFile, when set, writes logs to a size-rotated file instead of standard error.
+	LogFile           string `key:"log_file" env:"LOG_FILE" usage:"Write logs to this file instead of standard error"`
+	LogFileMaxSizeMB  int    `key:"log_file_max_size_mb" env:"LOG_FILE_MAX_SIZE_MB" usage:"Rotate the log file after it reaches this size in megabytes"`
+	LogFileMaxBackups int    `key:"log_file_max_backups" env:"LOG_FILE_MAX_BACKUPS" usage:"Number of rotated log files to keep"`
+	LogFileMaxAgeDays int    `key:"log_file_max_age_days" env:"LOG_FILE_MAX_AGE_DAYS" usage:"Days to keep rotated log files"`
+
+	// MetricsAddress is the listen address of the Prometheus /metrics HTTP endpoint.
+	// An empty value disables the endpoint.
+	MetricsAddress string `key:"metrics_address" env:"METRICS_ADDRESS" usage:"Listen address of the Prometheus /metrics endpoint (empty disables it)"`
+
+	// CORSAllowedOrigins lists the origins, e.g. "https://app.example.com", whose browser
+	// scripts may call the TaskService over gRPC-Web and Connect; "*" allows any origin.
+	// An empty value answers no cross-origin requests.
+	CORSAllowedOrigins string `key:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS" usage:"Comma-separated origins allowed to call gRPC-Web and Connect from browsers, or * (empty allows none)"`
+
+	// GatewayAddress is the listen address of the REST/JSON gateway. An empty value disables it.
+	GatewayAddress string `key:"gateway_address" env:"GATEWAY_ADDRESS" usage:"Listen address of the REST/JSON gateway (empty disables it)"`
+
+	// WebUIPath is the path under which the gateway serves the browser UI, e.g. "/ui/".
+	// An empty value disables the UI; it is also unavailable while the gateway is disabled.
+	WebUIPath string `key:"web_ui_path" env:"WEB_UI_PATH" usage:"Path of the browser UI on the REST/JSON gateway (empty disables it)"`
+
+	// PublicURL is the base URL under which clients reach the REST/JSON gateway, such
+	// as https://tasks.example.com behind a reverse proxy. It is used in the links the
+	// server hands out, e.g. calendar feed URLs. Empty means http://localhost:<port>.
+	PublicURL string `key:"public_url" env:"PUBLIC_URL" usage:"Base URL of the REST/JSON gateway used in links, e.g. https://tasks.example.com (default: http://localhost:<gateway port>)"`
+
+	// TracingExporter selects where spans are sent: "none", "otlp", "stdout" or "file".
+	TracingExporter string `key:"tracing_exporter" env:"TRACING_EXPORTER" usage:"Span exporter: none, otlp, stdout or file"`
+	// TracingServiceName is reported as the service.name resource attribute.
+	TracingServiceName string `key:"tracing_service_name" env:"OTEL_SERVICE_NAME" usage:"service.name reported with every span"`
+	// OTLPEndpoint is the host:port of the OTLP/gRPC collector used by the "otlp" exporter.
+	OTLPEndpoint string `key:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" usage:"OTLP/gRPC collector address"`
+	// OTLPInsecure disables TLS towards the OTLP collector.
+	OTLPInsecure bool `key:"otlp_insecure" env:"OTEL_EXPORTER_OTLP_INSECURE" usage:"Connect to the OTLP collector without TLS"`
+	// TracingFile is the file spans are appended to by the "file" exporter.
+	TracingFile string `key:"tracing_file" env:"TRACING_FILE" usage:"File the file exporter appends spans to"`
+
+	// AdminToken authenticates AdminService calls on the server.
+	AdminToken string `key:"admin_token" env:"ADMIN_TOKEN" secret:"true" usage:"Token required for AdminService calls"`
+	// APIToken is sent by the client as a bearer token and selects the workspace.
+	APIToken string `key:"api_token" env:"API_TOKEN" secret:"true" usage:"Bearer token sent by the client CLI"`
+
+	// SearchIndex selects the full-text search backend: "mysql" uses the FULLTEXT index of
+	// the tasks table, "bleve" an embedded index kept in memory or at SearchIndexPath.
+	SearchIndex     string `key:"search_index" env:"SEARCH_INDEX" usage:"Full-text search backend: mysql or bleve"`
+	SearchIndexPath string `key:"search_index_path" env:"SEARCH_INDEX_PATH" usage:"Directory of the bleve search index (empty keeps it in memory)"`
+
+	// WebhookMaxAttempts is the number of delivery attempts after which a webhook delivery
+	// is moved to the dead letters; retries back off exponentially from 30 seconds.
+	WebhookMaxAttempts int `key:"webhook_max_attempts" env:"WEBHOOK_MAX_ATTEMPTS" usage:"Delivery attempts before a webhook event becomes a dead letter"`
+	// WebhookTimeoutSeconds bounds each webhook POST, including reading the response.
+	WebhookTimeoutSeconds int `key:"webhook_timeout_seconds" env:"WEBHOOK_TIMEOUT_SECONDS" usage:"Timeout of a webhook delivery in seconds"`
+
+	// EventBus selects where the event relay publishes task events: "inprocess" hands
+	// them to subscribers within the server, "file" appends them as JSON lines to
+	// EventBusFile, and "nats" publishes them to NATS under NATSSubjectPrefix.
+	EventBus     string `key:"event_bus" env:"EVENT_BUS" usage:"Event bus for task events: inprocess, file or nats"`
+	EventBusFile string `key:"event_bus_file" env:"EVENT_BUS_FILE" usage:"File the file event bus appends task events to"`
+	NATSURL      string `key:"nats_url" env:"NATS_URL" usage:"NATS server URL of the nats event bus"`
+	// NATSSubjectPrefix is the first token of every subject; events are published as
+	// <prefix>.<workspace_id>.<event_type>, e.g. tasks.1.task.completed.
+	NATSSubjectPrefix string `key:"nats_subject_prefix" env:"NATS_SUBJECT_PREFIX" usage:"Subject prefix of task events published to NATS"`
+	// NATSEmbeddedAddress, when set, starts a NATS server inside the server process at
+	// this address, and the nats event bus publishes to it instead of NATSURL.
+	NATSEmbeddedAddress string `key:"nats_embedded_address" env:"NATS_EMBEDDED_ADDRESS" usage:"Listen address of an embedded NATS server for the nats event bus (empty disables it)"`
+
+	// SMTPHost is the mail server that sends due-date reminders and digests. An empty
+	// value disables email notifications.
+	SMTPHost     string `key:"smtp_host" env:"SMTP_HOST" usage:"SMTP server for reminder and digest emails (empty disables them)"`
+	SMTPPort     string `key:"smtp_port" env:"SMTP_PORT" usage:"SMTP server port"`
+	SMTPUsername string `key:"smtp_username" env:"SMTP_USERNAME" usage:"SMTP user name (empty sends without authentication)"`
+	SMTPPassword string `key:"smtp_password" env:"SMTP_PASSWORD" secret:"true" usage:"SMTP password"`
+	// SMTPFrom is the From address of every email, e.g. "Tasks <tasks@example.com>".
+	SMTPFrom string `key:"smtp_from" env:"SMTP_FROM" usage:"From address of reminder and digest emails"`
+	// SMTPTLS selects how the connection is secured: "starttls" upgrades a plain
+	// connection, "tls" connects with implicit TLS (usually port 465) and "none" sends
+	// in the clear, which only suits local test servers such as MailHog.
+	SMTPTLS string `key:"smtp_tls" env:"SMTP_TLS" usage:"SMTP connection security: starttls, tls or none"`
+	// ReminderLeadMinutes is how long before its due date a task's reminder is sent.
+	ReminderLeadMinutes int `key:"reminder_lead_minutes" env:"REMINDER_LEAD_MINUTES" usage:"Minutes before the due date at which reminders are sent"`
+	// DigestHour is the hour of the day, in each assignee's time zone, after which the
+	// daily digest is sent.
+	DigestHour int `key:"digest_hour" env:"DIGEST_HOUR" usage:"Hour of the day (0-23) at which daily digests are sent"`
+
+	// InboundSMTPAddress is the listen address of the embedded SMTP receiver that turns
+	// emails to InboundAddress into tasks. An empty value disables it.
+	InboundSMTPAddress string `key:"inbound_smtp_address" env:"INBOUND_SMTP_ADDRESS" usage:"Listen address of the SMTP receiver for task emails (empty disables it)"`
+	// InboundMaildir is a Maildir whose new messages are turned into tasks, as an
+	// alternative to the SMTP receiver. An empty value disables it.
+	InboundMaildir string `key:"inbound_maildir" env:"INBOUND_MAILDIR" usage:"Maildir polled for task emails (empty disables it)"`
+	// InboundAddress is the address tasks are mailed to. A tag after a plus sign in
+	// its local part selects the project, e.g. tasks+infra@example.com.
+	InboundAddress string `key:"inbound_address" env:"INBOUND_ADDRESS" usage:"Email address that creates tasks; tasks+<project>@ selects a project"`
+	// InboundMaxMessageSize bounds the size of an inbound email, attachments included.
+	InboundMaxMessageSize int `key:"inbound_max_message_size" env:"INBOUND_MAX_MESSAGE_SIZE" usage:"Largest inbound task email in bytes"`
+
+	DBHost     string `key:"db_host" env:"DB_HOST" usage:"MySQL host"`
+	DBPort     string `key:"db_port" env:"DB_PORT" usage:"MySQL port"`
+	DBUser     string `key:"db_user" env:"DB_USER" usage:"MySQL user"`
+	DBPassword string `key:"db_password" env:"DB_PASSWORD" secret:"true" usage:"MySQL password"`
+	DBName     string `key:"db_name" env:"DB_NAME" usage:"MySQL database name"`
+	// DBDSN is derived from the DB* fields and cannot be set directly.
+	DBDSN string
+
+	// sources maps the key of every setting not left at its default to the layer
+	// that set it, as reported by Load.
+	sources map[string]string
 }
 
 // Module exports the Config provider for FX.
@@ -25,33 +164,128 @@ var Module = fx.Options(
 	fx.Provide(NewConfig),
 )
 
-// NewConfig creates a new configuration object, loading values from environment variables with defaults.
+// defaults returns the configuration used when no file, environment variable or flag sets a value.
+func defaults() *Config {
+	return &Config{
+		GRPCPort:              "50051",
+		GRPCClientTarget:      "localhost:50051",
+		LogFormat:             "console",
+		LogLevel:              "info",
+		LogSamplingThereafter: 100,
+		LogFileMaxSizeMB:      100,
+		LogFileMaxBackups:     5,
+		LogFileMaxAgeDays:     28,
+		MetricsAddress:        ":9090",
+		GatewayAddress:        ":8080",
+		WebUIPath:             "/ui/",
+		TracingExporter:       "none",
+		TracingServiceName:    "fx-grpc-app",
+		OTLPEndpoint:          "localhost:4317",
+		OTLPInsecure:          true,
+		TracingFile:           "traces.jsonl",
+		SearchIndex:           "mysql",
+		WebhookMaxAttempts:    8,
+		WebhookTimeoutSeconds: 10,
+		EventBus:              "inprocess",
+		EventBusFile:          "events.jsonl",
+		NATSURL:               "nats://localhost:4222",
+		NATSSubjectPrefix:     "tasks",
+		SMTPPort:              "587",
+		SMTPFrom:              "fx-grpc-app <noreply@localhost>",
+		SMTPTLS:               "starttls",
+		ReminderLeadMinutes:   60,
+		DigestHour:            8,
+		InboundAddress:        "tasks@localhost",
+		InboundMaxMessageSize: 25 << 20,
+		DBHost:                "localhost",
+		DBPort:                "3306",
+		DBUser:                "user",
+		DBPassword:            "password",
+		DBName:                "taskdb",
+	}
+}
+
+// NewConfig creates a new configuration object by layering the config file, environment
+// variables and command-line flags over the defaults, and validates the result.
 func NewConfig() (*Config, error) {
-	dbUser := getEnv("DB_USER", "user")
-	dbPassword := getEnv("DB_PASSWORD", "password")
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "1433")
-	dbName := getEnv("DB_NAME", "taskdb")
+	c, _, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		dbUser, dbPassword, dbHost, dbPort, dbName,
-	)
+// finalize fills in derived fields once all layers have been applied.
+func (c *Config) finalize() {
+	if c.GRPCServerAddress == "" {
+		c.GRPCServerAddress = ":" + c.GRPCPort
+	}
+	// FormatDSN escapes what the DSN syntax reserves, such as '@' or '/' in passwords.
+	dsn := mysql.NewConfig()
+	dsn.User = c.DBUser
+	dsn.Passwd = c.DBPassword
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(c.DBHost, c.DBPort)
+	dsn.DBName = c.DBName
+	dsn.ParseTime = true
+	dsn.Loc = time.Local
+	dsn.Params = map[string]string{"charset": "utf8mb4"}
+	c.DBDSN = dsn.FormatDSN()
+}
 
-	return &Config{
-		GRPCServerAddress: ":50051",
-		GRPCClientTarget:  "localhost:50051",
-		DBHost:            dbHost,
-		DBPort:            dbPort,
-		DBUser:            dbUser,
-		DBPassword:        dbPassword,
-		DBName:            dbName,
-		DBDSN:             dsn,
-	}, nil
-}
-
-func getEnv(key, fallback string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return fallback
+// FromEnvOrFlag reports whether the setting with the given key was set by an
+// environment variable or a command-line flag rather than a default or the config file.
+func (c *Config) FromEnvOrFlag(key string) bool {
+	source := c.sources[key]
+	return strings.HasPrefix(source, "env ") || strings.HasPrefix(source, "flag ")
+}
+
+// GatewayURL returns the base URL of the REST/JSON gateway without a trailing slash:
+// PublicURL when set, and otherwise the gateway port on localhost.
+func (c *Config) GatewayURL() string {
+	if c.PublicURL != "" {
+		return strings.TrimSuffix(c.PublicURL, "/")
+	}
+	_, port, err := net.SplitHostPort(c.GatewayAddress)
+	if err != nil {
+		port = "8080"
+	}
+	return "http://localhost:" + port
+}
+
+// LocalGRPCTarget returns an address at which this process can dial its own gRPC
+// server; an unspecified host in GRPCServerAddress means the loopback interface.
+func (c *Config) LocalGRPCTarget() string {
+	host, port, err := net.SplitHostPort(c.GRPCServerAddress)
+	if err != nil {
+		return c.GRPCServerAddress
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// CORSOrigins returns the entries of CORSAllowedOrigins.
+func (c *Config) CORSOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(c.CORSAllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// Location returns the time zone selected by TimeZone, or the local time zone when it is empty.
+func (c *Config) Location() *time.Location {
+	if c.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		// validate rejects unknown zones, so this only happens for hand-built configs.
+		return time.Local
+	}
+	return loc
 }
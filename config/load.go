@@ -0,0 +1,228 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileEnv names the environment variable that points at the config file
+// when the --config flag is not given.
+const configFileEnv = "CONFIG_FILE"
+
+// ErrInvalid is wrapped by the errors Load returns when the config file cannot be read
+// or a setting cannot be parsed or fails validation.
+var ErrInvalid = errors.New("invalid configuration")
+
+// Setting describes one configuration value and the layer it was taken from.
+type Setting struct {
+	Key    string
+	Env    string
+	Flag   string
+	Usage  string
+	Secret bool
+	Value  any
+	Source string
+}
+
+// Redacted returns the value of the setting for display: secrets that are set
+// are replaced by "<redacted>".
+func (s Setting) Redacted() any {
+	if s.Secret && s.Value != "" {
+		return "<redacted>"
+	}
+	return s.Value
+}
+
+// boundFlags is the flag set registered through RegisterFlags; it is consulted by Load.
+var boundFlags *pflag.FlagSet
+
+// RegisterFlags adds a --config flag and one flag per setting to fs.
+// It is called once with the persistent flags of the root command.
+func RegisterFlags(fs *pflag.FlagSet) {
+	fs.String("config", "", "Path to a YAML or TOML config file (default: $"+configFileEnv+")")
+	d := reflect.ValueOf(defaults()).Elem()
+	forEachSetting(func(field reflect.StructField, index int) {
+		name := flagName(field)
+		usage := field.Tag.Get("usage")
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			fs.Bool(name, d.Field(index).Bool(), usage)
+		case reflect.Int:
+			fs.Int(name, int(d.Field(index).Int()), usage)
+		default:
+			if field.Tag.Get("secret") == "true" {
+				fs.String(name, "", usage)
+			} else {
+				fs.String(name, d.Field(index).String(), usage)
+			}
+		}
+	})
+	boundFlags = fs
+}
+
+// Load builds the effective configuration: defaults, then the config file, then environment
+// variables, then command-line flags. It returns the validated Config together with
+// the value and source of every setting.
+func Load() (*Config, []Setting, error) {
+	c := defaults()
+	v := reflect.ValueOf(c).Elem()
+	sources := make(map[string]string)
+	var errs []error
+
+	path := os.Getenv(configFileEnv)
+	if boundFlags != nil && boundFlags.Changed("config") {
+		path, _ = boundFlags.GetString("config")
+	}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		known := make(map[string]bool)
+		forEachSetting(func(field reflect.StructField, index int) {
+			key := field.Tag.Get("key")
+			known[key] = true
+			raw, ok := values[key]
+			if !ok {
+				return
+			}
+			if err := setValue(v.Field(index), raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s (from %s): %w", key, path, err))
+				return
+			}
+			sources[key] = "file " + path
+		})
+		for key := range values {
+			if !known[key] {
+				errs = append(errs, fmt.Errorf("%s: unknown key in %s", key, path))
+			}
+		}
+	}
+
+	forEachSetting(func(field reflect.StructField, index int) {
+		key, env := field.Tag.Get("key"), field.Tag.Get("env")
+		raw, ok := os.LookupEnv(env)
+		if !ok {
+			return
+		}
+		if err := setValue(v.Field(index), raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (from env %s): %w", key, env, err))
+			return
+		}
+		sources[key] = "env " + env
+	})
+
+	if boundFlags != nil {
+		forEachSetting(func(field reflect.StructField, index int) {
+			key, name := field.Tag.Get("key"), flagName(field)
+			if !boundFlags.Changed(name) {
+				return
+			}
+			if err := setValue(v.Field(index), boundFlags.Lookup(name).Value.String()); err != nil {
+				errs = append(errs, fmt.Errorf("%s (from flag --%s): %w", key, name, err))
+				return
+			}
+			sources[key] = "flag --" + name
+		})
+	}
+
+	errs = append(errs, c.validate()...)
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("%w:\n%w", ErrInvalid, errors.Join(errs...))
+	}
+	c.finalize()
+	c.sources = sources
+
+	var settings []Setting
+	forEachSetting(func(field reflect.StructField, index int) {
+		key := field.Tag.Get("key")
+		source, ok := sources[key]
+		if !ok {
+			source = "default"
+		}
+		settings = append(settings, Setting{
+			Key:    key,
+			Env:    field.Tag.Get("env"),
+			Flag:   "--" + flagName(field),
+			Usage:  field.Tag.Get("usage"),
+			Secret: field.Tag.Get("secret") == "true",
+			Value:  v.Field(index).Interface(),
+			Source: source,
+		})
+	})
+	return c, settings, nil
+}
+
+// readFile decodes a YAML or TOML config file, chosen by its extension, into a flat map.
+func readFile(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	values := make(map[string]any)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s: extension must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// setValue parses raw into the config field v according to the field's type.
+func setValue(v reflect.Value, raw any) error {
+	var s string
+	switch raw := raw.(type) {
+	case nil:
+		s = ""
+	case map[string]any, []any:
+		return fmt.Errorf("must be a single value")
+	default:
+		s = fmt.Sprint(raw)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", s)
+		}
+		v.SetInt(int64(n))
+	default:
+		v.SetString(s)
+	}
+	return nil
+}
+
+// forEachSetting calls fn for every Config field that carries a `key` tag.
+func forEachSetting(fn func(field reflect.StructField, index int)) {
+	t := reflect.TypeOf(Config{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("key") != "" {
+			fn(t.Field(i), i)
+		}
+	}
+}
+
+func flagName(field reflect.StructField) string {
+	return strings.ReplaceAll(field.Tag.Get("key"), "_", "-")
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/spf13/pflag"
+)
+
+// load runs Load with the given command-line arguments parsed into a fresh flag
+// set and without a config file unless CONFIG_FILE or --config names one.
+func load(t *testing.T, args ...string) (*Config, map[string]Setting, error) {
+	t.Helper()
+	previous := boundFlags
+	t.Cleanup(func() { boundFlags = previous })
+	if _, ok := os.LookupEnv(configFileEnv); !ok {
+		t.Setenv(configFileEnv, "")
+	}
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	c, settings, err := Load()
+	byKey := make(map[string]Setting)
+	for _, setting := range settings {
+		byKey[setting.Key] = setting
+	}
+	return c, byKey, err
+}
+
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", "db_host: file-host\ndb_port: \"3307\"\ndb_name: filedb\nlog_level: debug\n")
+	t.Setenv(configFileEnv, path)
+	t.Setenv("DB_PORT", "3308")
+	t.Setenv("DB_NAME", "envdb")
+
+	c, settings, err := load(t, "--db-name", "flagdb")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	tests := []struct {
+		key, value, source string
+	}{
+		{"db_user", "user", "default"},
+		{"db_host", "file-host", "file " + path},
+		{"log_level", "debug", "file " + path},
+		{"db_port", "3308", "env DB_PORT"},
+		{"db_name", "flagdb", "flag --db-name"},
+	}
+	for _, tt := range tests {
+		setting := settings[tt.key]
+		if setting.Value != tt.value || setting.Source != tt.source {
+			t.Errorf("%s = %v from %q, want %q from %q", tt.key, setting.Value, setting.Source, tt.value, tt.source)
+		}
+	}
+	if c.DBHost != "file-host" || c.DBPort != "3308" || c.DBName != "flagdb" {
+		t.Errorf("config has db %s:%s/%s, want file-host:3308/flagdb", c.DBHost, c.DBPort, c.DBName)
+	}
+}
+
+func TestLoadConfigFlagOverridesEnv(t *testing.T) {
+	t.Setenv(configFileEnv, writeFile(t, "env.yaml", "db_name: envfile\n"))
+	path := writeFile(t, "flag.yaml", "db_name: flagfile\n")
+	c, _, err := load(t, "--config", path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.DBName != "flagfile" {
+		t.Errorf("db_name = %q, want it from the file named by --config", c.DBName)
+	}
+}
+
+func TestLoadYAMLAndTOML(t *testing.T) {
+	yamlData := "grpc_port: 6000\notlp_insecure: false\nwebhook_max_attempts: 3\ndb_host: yaml-host\n"
+	tests := []struct {
+		name, data, host string
+	}{
+		{"config.yaml", yamlData, "yaml-host"},
+		{"config.yml", yamlData, "yaml-host"},
+		{"config.toml", "grpc_port = 6000\notlp_insecure = false\nwebhook_max_attempts = 3\ndb_host = \"toml-host\"\n", "toml-host"},
+	}
+	for _, tt := range tests {
+		t.Setenv(configFileEnv, writeFile(t, tt.name, tt.data))
+		c, _, err := load(t)
+		if err != nil {
+			t.Errorf("%s: Load: %v", tt.name, err)
+			continue
+		}
+		if c.GRPCPort != "6000" || c.OTLPInsecure || c.WebhookMaxAttempts != 3 || c.DBHost != tt.host {
+			t.Errorf("%s: grpc_port %q, otlp_insecure %v, webhook_max_attempts %d, db_host %q",
+				tt.name, c.GRPCPort, c.OTLPInsecure, c.WebhookMaxAttempts, c.DBHost)
+		}
+	}
+
+	t.Setenv(configFileEnv, writeFile(t, "config.json", "{}"))
+	if _, _, err := load(t); !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), ".toml") {
+		t.Errorf("Load of a .json file: %v, want an unsupported extension error", err)
+	}
+	t.Setenv(configFileEnv, writeFile(t, "broken.toml", "db_host = \n"))
+	if _, _, err := load(t); !errors.Is(err, ErrInvalid) {
+		t.Errorf("Load of a malformed TOML file: %v, want ErrInvalid", err)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, "config.yaml", "db_host: db\ndb_hots: typo\nlogging:\n  level: debug\n")
+	t.Setenv(configFileEnv, path)
+	_, _, err := load(t)
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Load: %v, want ErrInvalid", err)
+	}
+	for _, want := range []string{"db_hots: unknown key in " + path, "logging: unknown key in " + path} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestLoadAggregatesErrors(t *testing.T) {
+	t.Setenv(configFileEnv, writeFile(t, "config.yaml", "grpc_port: 70000\nlog_format: xml\ndigest_hour: [8]\n"))
+	t.Setenv("WEBHOOK_MAX_ATTEMPTS", "many")
+	_, _, err := load(t, "--tracing-exporter", "zipkin")
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("Load: %v, want ErrInvalid", err)
+	}
+	for _, want := range []string{
+		"digest_hour (from ",
+		"webhook_max_attempts (from env WEBHOOK_MAX_ATTEMPTS): \"many\" is not an integer",
+		"grpc_port: \"70000\" is not a valid port",
+		"log_format: \"xml\" must be console or json",
+		"tracing_exporter: \"zipkin\" must be none, otlp, stdout or file",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not contain %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestSettingRedacted(t *testing.T) {
+	t.Setenv("DB_PASSWORD", "hunter2")
+	_, settings, err := load(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	tests := []struct {
+		key  string
+		want any
+	}{
+		{"db_password", "<redacted>"},
+		{"api_token", ""},
+		{"db_user", "user"},
+		{"grpc_port", "50051"},
+		{"webhook_max_attempts", 8},
+	}
+	for _, tt := range tests {
+		if got := settings[tt.key].Redacted(); got != tt.want {
+			t.Errorf("%s: Redacted() = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestDSNEscapesCredentials(t *testing.T) {
+	t.Setenv("DB_USER", "app@rw")
+	t.Setenv("DB_PASSWORD", "p@ss/w:rd?x=1&y")
+	t.Setenv("DB_HOST", "::1")
+	c, _, err := load(t, "--db-name", "task-db")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	dsn, err := mysql.ParseDSN(c.DBDSN)
+	if err != nil {
+		t.Fatalf("ParseDSN(%q): %v", c.DBDSN, err)
+	}
+	if dsn.User != c.DBUser || dsn.Passwd != c.DBPassword || dsn.Addr != "[::1]:3306" || dsn.DBName != "task-db" || !dsn.ParseTime {
+		t.Errorf("DSN %q parses as user %q, password %q, address %q, database %q, parseTime %v",
+			c.DBDSN, dsn.User, dsn.Passwd, dsn.Addr, dsn.DBName, dsn.ParseTime)
+	}
+}
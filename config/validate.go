@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validate checks the layered configuration and returns one error per invalid setting.
+func (c *Config) validate() []error {
+	var errs []error
+	fail := func(key string, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if !isPort(c.GRPCPort) {
+		fail("grpc_port", "%q is not a valid port", c.GRPCPort)
+	}
+	if c.GRPCServerAddress != "" && !isListenAddress(c.GRPCServerAddress) {
+		fail("grpc_server_address", "%q must have the form host:port or :port", c.GRPCServerAddress)
+	}
+	if c.GRPCClientTarget == "" {
+		fail("grpc_client_target", "must not be empty")
+	}
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			fail("time_zone", "%q is not a known IANA time zone", c.TimeZone)
+		}
+	}
+	if c.MetricsAddress != "" && !isListenAddress(c.MetricsAddress) {
+		fail("metrics_address", "%q must have the form host:port or :port", c.MetricsAddress)
+	}
+	for _, origin := range c.CORSOrigins() {
+		if origin != "*" && !isOrigin(origin) {
+			fail("cors_allowed_origins", "%q must be * or have the form scheme://host[:port]", origin)
+		}
+	}
+	if c.GatewayAddress != "" && !isListenAddress(c.GatewayAddress) {
+		fail("gateway_address", "%q must have the form host:port or :port", c.GatewayAddress)
+	}
+	if c.WebUIPath != "" && !isWebUIPath(c.WebUIPath) {
+		fail("web_ui_path", "%q must start and end with / and not be under /v1/, /calendar/ or /caldav/", c.WebUIPath)
+	}
+	if c.PublicURL != "" {
+		if u, err := url.Parse(c.PublicURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			fail("public_url", "%q must be an http or https URL such as https://tasks.example.com", c.PublicURL)
+		}
+	}
+
+	if !oneOf(c.LogFormat, "console", "json") {
+		fail("log_format", "%q must be console or json", c.LogFormat)
+	}
+	if !oneOf(strings.ToLower(c.LogLevel), "debug", "info", "warn", "error", "dpanic", "panic", "fatal") {
+		fail("log_level", "%q must be debug, info, warn or error", c.LogLevel)
+	}
+	if c.LogSamplingInitial < 0 || c.LogSamplingThereafter < 0 {
+		fail("log_sampling_initial", "sampling values must not be negative")
+	}
+	if c.LogFileMaxSizeMB < 0 || c.LogFileMaxBackups < 0 || c.LogFileMaxAgeDays < 0 {
+		fail("log_file_max_size_mb", "log file rotation values must not be negative")
+	}
+
+	if !oneOf(c.TracingExporter, "none", "otlp", "stdout", "file") {
+		fail("tracing_exporter", "%q must be none, otlp, stdout or file", c.TracingExporter)
+	}
+	if c.TracingExporter == "otlp" && c.OTLPEndpoint == "" {
+		fail("otlp_endpoint", "must be set when tracing_exporter is otlp")
+	}
+	if c.TracingExporter == "file" && c.TracingFile == "" {
+		fail("tracing_file", "must be set when tracing_exporter is file")
+	}
+
+	if !oneOf(c.SearchIndex, "mysql", "bleve") {
+		fail("search_index", "%q must be mysql or bleve", c.SearchIndex)
+	}
+
+	if c.WebhookMaxAttempts < 1 {
+		fail("webhook_max_attempts", "must be at least 1")
+	}
+	if c.WebhookTimeoutSeconds < 1 {
+		fail("webhook_timeout_seconds", "must be at least 1")
+	}
+
+	if !oneOf(c.EventBus, "inprocess", "file", "nats") {
+		fail("event_bus", "%q must be inprocess, file or nats", c.EventBus)
+	}
+	if c.EventBus == "file" && c.EventBusFile == "" {
+		fail("event_bus_file", "must be set when event_bus is file")
+	}
+	if c.EventBus == "nats" && c.NATSURL == "" && c.NATSEmbeddedAddress == "" {
+		fail("nats_url", "must be set when event_bus is nats and no embedded server is configured")
+	}
+	if c.NATSSubjectPrefix == "" || strings.ContainsAny(c.NATSSubjectPrefix, "*> \t") ||
+		strings.HasPrefix(c.NATSSubjectPrefix, ".") || strings.HasSuffix(c.NATSSubjectPrefix, ".") {
+		fail("nats_subject_prefix", "%q must be a NATS subject without wildcards", c.NATSSubjectPrefix)
+	}
+	if c.NATSEmbeddedAddress != "" && !isListenAddress(c.NATSEmbeddedAddress) {
+		fail("nats_embedded_address", "%q must have the form host:port or :port", c.NATSEmbeddedAddress)
+	}
+
+	if c.SMTPHost != "" {
+		if !isPort(c.SMTPPort) {
+			fail("smtp_port", "%q is not a valid port", c.SMTPPort)
+		}
+		if _, err := mail.ParseAddress(c.SMTPFrom); err != nil {
+			fail("smtp_from", "%q is not a valid email address", c.SMTPFrom)
+		}
+	}
+	if !oneOf(c.SMTPTLS, "starttls", "tls", "none") {
+		fail("smtp_tls", "%q must be starttls, tls or none", c.SMTPTLS)
+	}
+	if c.ReminderLeadMinutes < 0 {
+		fail("reminder_lead_minutes", "must not be negative")
+	}
+	if c.DigestHour < 0 || c.DigestHour > 23 {
+		fail("digest_hour", "%d must be between 0 and 23", c.DigestHour)
+	}
+
+	if c.InboundSMTPAddress != "" && !isListenAddress(c.InboundSMTPAddress) {
+		fail("inbound_smtp_address", "%q must have the form host:port or :port", c.InboundSMTPAddress)
+	}
+	if addr, err := mail.ParseAddress(c.InboundAddress); err != nil || addr.Address != c.InboundAddress || strings.Contains(c.InboundAddress, "+") {
+		fail("inbound_address", "%q must be a plain email address without a plus tag", c.InboundAddress)
+	}
+	if c.InboundMaxMessageSize < 1024 {
+		fail("inbound_max_message_size", "must be at least 1024")
+	}
+
+	if c.DBHost == "" {
+		fail("db_host", "must not be empty")
+	}
+	if !isPort(c.DBPort) {
+		fail("db_port", "%q is not a valid port", c.DBPort)
+	}
+	if c.DBUser == "" {
+		fail("db_user", "must not be empty")
+	}
+	if c.DBName == "" {
+		fail("db_name", "must not be empty")
+	}
+	return errs
+}
+
+func isPort(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0 && n < 65536
+}
+
+func isListenAddress(s string) bool {
+	_, port, err := net.SplitHostPort(s)
+	return err == nil && isPort(port)
+}
+
+// isWebUIPath reports whether s can be mounted next to the gateway routes.
+func isWebUIPath(s string) bool {
+	if s == "/" {
+		return true
+	}
+	for _, reserved := range []string{"/v1/", "/calendar/", "/caldav/"} {
+		if strings.HasPrefix(s, reserved) {
+			return false
+		}
+	}
+	return path.Clean(s)+"/" == s && strings.HasPrefix(s, "/") && !strings.ContainsAny(s, "{} \t")
+}
+
+// isOrigin reports whether s is a browser origin such as https://app.example.com:8443.
+func isOrigin(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+		u.Path == "" && u.RawQuery == "" && u.Fragment == "" && u.User == nil
+}
+
+func oneOf(s string, values ...string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -24,32 +24,33 @@ type DBConnectionParams struct {
 
 // NewDBConnection creates and manages a database connection lifecycle.
 func NewDBConnection(p DBConnectionParams) (*sql.DB, error) {
-	p.Logger.Info("Attempting to connect to database", zap.String("db_host", p.Config.DBHost), zap.String("db_name", p.Config.DBName))
+	logger := p.Logger.Named("database")
+	logger.Info("Attempting to connect to database", zap.String("db_host", p.Config.DBHost), zap.String("db_name", p.Config.DBName))
 
 	db, err := sql.Open("mysql", p.Config.DBDSN)
 	if err != nil {
-		p.Logger.Error("Failed to open database connection", zap.Error(err))
+		logger.Error("Failed to open database connection", zap.Error(err))
 		return nil, err
 	}
 
 	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			p.Logger.Info("Pinging database...")
+			logger.Info("Pinging database...")
 			if err := db.PingContext(ctx); err != nil {
-				p.Logger.Error("Failed to ping database on start", zap.Error(err))
+				logger.Error("Failed to ping database on start", zap.Error(err))
 				db.Close()
 				return err
 			}
-			p.Logger.Info("Database connection established and pinged successfully.")
+			logger.Info("Database connection established and pinged successfully.")
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			p.Logger.Info("Closing database connection")
+			logger.Info("Closing database connection")
 			if err := db.Close(); err != nil {
-				p.Logger.Error("Failed to close database connection", zap.Error(err))
+				logger.Error("Failed to close database connection", zap.Error(err))
 				return err
 			}
-			p.Logger.Info("Database connection closed.")
+			logger.Info("Database connection closed.")
 			return nil
 		},
 	})
@@ -0,0 +1,75 @@
+// Package events publishes task domain events.
+//
+// Task changes write a TaskEvent to the outbox_events table in the same
+// transaction as the change. The Relay reads the outbox in order and publishes
+// every event on the configured Bus: to subscribers in the same process, to a
+// JSON Lines file, or to NATS.
+package events
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"context"
+	"fmt"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the event Bus and the outbox Relay for FX.
+var Module = fx.Options(
+	fx.Provide(NewBus),
+	fx.Provide(NewRelay),
+)
+
+// Handler processes an event received from a Bus. The event is shared between
+// handlers and must not be modified.
+type Handler func(ctx context.Context, event *pb.TaskEvent)
+
+// Bus publishes task events to subscribers.
+type Bus interface {
+	// Publish returns once the bus has accepted the event; an error makes the relay
+	// publish it again later.
+	Publish(ctx context.Context, event *pb.TaskEvent) error
+	// Subscribe calls handler for every event published after it returns, until the
+	// returned function is called.
+	Subscribe(handler Handler) (unsubscribe func(), err error)
+}
+
+type BusParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *cfg.Config
+}
+
+// NewBus creates the bus selected by the event_bus setting and closes it when the
+// FX app stops.
+func NewBus(p BusParams) (Bus, error) {
+	logger := p.Logger.Named("events")
+	switch p.Config.EventBus {
+	case "inprocess":
+		logger.Info("Publishing task events in process")
+		return newInProcessBus(), nil
+	case "file":
+		bus, err := newFileBus(p.Config.EventBusFile)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Publishing task events to file", zap.String("file", p.Config.EventBusFile))
+		p.Lifecycle.Append(fx.StopHook(bus.Close))
+		return bus, nil
+	case "nats":
+		bus := newNATSBus(p.Config, logger)
+		p.Lifecycle.Append(fx.Hook{OnStart: bus.Start, OnStop: func(context.Context) error { return bus.Close() }})
+		return bus, nil
+	}
+	return nil, fmt.Errorf("unknown event bus %q", p.Config.EventBus)
+}
+
+// Subject returns the NATS subject of event: <prefix>.<workspace_id>.<event_type>.
+// Subscribers select events with wildcards, e.g. tasks.*.task.completed.
+func Subject(prefix string, event *pb.TaskEvent) string {
+	return prefix + "." + event.GetWorkspaceId() + "." + repo.EventType(event)
+}
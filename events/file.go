@@ -0,0 +1,51 @@
+package events
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fileBus appends every event as one line of JSON, in the protobuf JSON mapping, to
+// a file. Subscribers in the same process receive the events after they are written.
+type fileBus struct {
+	mu    sync.Mutex
+	file  *os.File
+	local *inProcessBus
+}
+
+func newFileBus(path string) (*fileBus, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event bus file: %w", err)
+	}
+	return &fileBus{file: file, local: newInProcessBus()}, nil
+}
+
+func (b *fileBus) Publish(ctx context.Context, event *pb.TaskEvent) error {
+	line, err := protojson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	b.mu.Lock()
+	_, err = b.file.Write(append(line, '\n'))
+	b.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to write event %s: %w", event.GetId(), err)
+	}
+	return b.local.Publish(ctx, event)
+}
+
+func (b *fileBus) Subscribe(handler Handler) (func(), error) {
+	return b.local.Subscribe(handler)
+}
+
+func (b *fileBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.file.Close()
+}
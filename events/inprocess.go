@@ -0,0 +1,41 @@
+package events
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"sync"
+)
+
+// inProcessBus calls the handlers of the process synchronously, in the order of
+// publication. Without subscribers events are dropped.
+type inProcessBus struct {
+	mu       sync.RWMutex
+	handlers map[int]Handler
+	next     int
+}
+
+func newInProcessBus() *inProcessBus {
+	return &inProcessBus{handlers: make(map[int]Handler)}
+}
+
+func (b *inProcessBus) Publish(ctx context.Context, event *pb.TaskEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, handler := range b.handlers {
+		handler(ctx, event)
+	}
+	return nil
+}
+
+func (b *inProcessBus) Subscribe(handler Handler) (func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.handlers[id] = handler
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}, nil
+}
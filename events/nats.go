@@ -0,0 +1,161 @@
+package events
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// contentType is sent with every NATS message; the data is the binary protobuf
+// encoding of a TaskEvent.
+const contentType = "application/x-protobuf; messageType=api.TaskEvent"
+
+// publishTimeout bounds the round trip that confirms the server received an event.
+const publishTimeout = 5 * time.Second
+
+// natsBus publishes events to core NATS. The message ID header carries the event
+// ID, so a JetStream stream bound to the subjects drops events published twice.
+type natsBus struct {
+	url             string
+	embeddedAddress string
+	prefix          string
+	logger          *zap.Logger
+	embedded        *server.Server
+	conn            *nats.Conn
+}
+
+// newNATSBus creates a bus that connects when Start is called.
+func newNATSBus(c *cfg.Config, logger *zap.Logger) *natsBus {
+	return &natsBus{url: c.NATSURL, embeddedAddress: c.NATSEmbeddedAddress, prefix: c.NATSSubjectPrefix, logger: logger}
+}
+
+// Start starts the embedded server, if configured, and connects to NATS.
+func (b *natsBus) Start(ctx context.Context) error {
+	opts := []nats.Option{
+		nats.Name("fx-grpc-app"),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			b.logger.Warn("Disconnected from NATS", zap.Error(err))
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			b.logger.Info("Reconnected to NATS", zap.String("url", conn.ConnectedUrl()))
+		}),
+	}
+	url := b.url
+	if b.embeddedAddress != "" {
+		srv, err := StartEmbeddedNATS(b.embeddedAddress)
+		if err != nil {
+			return err
+		}
+		b.embedded = srv
+		url = srv.ClientURL()
+		opts = append(opts, nats.InProcessServer(srv))
+		b.logger.Info("Started embedded NATS server", zap.String("url", url))
+	}
+	conn, err := nats.Connect(url, opts...)
+	if err != nil {
+		b.shutdownEmbedded()
+		return fmt.Errorf("failed to connect to NATS at %s: %w", url, err)
+	}
+	b.conn = conn
+	b.logger.Info("Publishing task events to NATS", zap.String("url", url), zap.String("subject_prefix", b.prefix))
+	return nil
+}
+
+func (b *natsBus) Publish(ctx context.Context, event *pb.TaskEvent) error {
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg := nats.NewMsg(Subject(b.prefix, event))
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, event.GetId())
+	msg.Header.Set("Content-Type", contentType)
+	if err := b.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.GetId(), err)
+	}
+	// Core NATS publishes are buffered; the flush reports a server that cannot be reached.
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if err := b.conn.FlushWithContext(ctx); err != nil {
+		return fmt.Errorf("failed to publish event %s: %w", event.GetId(), err)
+	}
+	return nil
+}
+
+// Subscribe receives the events of every workspace published to the NATS server,
+// by this or any other process.
+func (b *natsBus) Subscribe(handler Handler) (func(), error) {
+	if b.conn == nil {
+		return nil, errors.New("nats event bus is not started")
+	}
+	sub, err := b.conn.Subscribe(b.prefix+".>", func(msg *nats.Msg) {
+		var event pb.TaskEvent
+		if err := proto.Unmarshal(msg.Data, &event); err != nil {
+			b.logger.Warn("Dropping undecodable NATS message", zap.String("subject", msg.Subject), zap.Error(err))
+			return
+		}
+		handler(context.Background(), &event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return func() { sub.Unsubscribe() }, nil
+}
+
+// Close flushes buffered events, closes the connection and stops the embedded server.
+func (b *natsBus) Close() error {
+	var err error
+	if b.conn != nil {
+		err = b.conn.FlushTimeout(publishTimeout)
+		b.conn.Close()
+	}
+	b.shutdownEmbedded()
+	return err
+}
+
+func (b *natsBus) shutdownEmbedded() {
+	if b.embedded != nil {
+		b.embedded.Shutdown()
+		b.embedded.WaitForShutdown()
+		b.embedded = nil
+	}
+}
+
+// StartEmbeddedNATS runs a NATS server inside the process, listening on address
+// (host:port; port 0 picks a free port), and waits until it accepts connections.
+// It serves development setups and tests that need a NATS server without
+// installing one; call Shutdown on the result to stop it.
+func StartEmbeddedNATS(address string) (*server.Server, error) {
+	host, portText, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded NATS address %q: %w", address, err)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded NATS port %q: %w", portText, err)
+	}
+	if port == 0 {
+		port = server.RANDOM_PORT
+	}
+	srv, err := server.NewServer(&server.Options{Host: host, Port: port, NoSigs: true, NoLog: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedded NATS server: %w", err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		srv.Shutdown()
+		return nil, fmt.Errorf("embedded NATS server did not start on %s", address)
+	}
+	return srv, nil
+}
@@ -0,0 +1,172 @@
+package events
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryOutbox is an OutboxRepository over events kept in memory, in the order
+// they were written.
+type memoryOutbox struct {
+	mu          sync.Mutex
+	events      []*pb.TaskEvent
+	publishedAt map[string]time.Time
+}
+
+func newMemoryOutbox(n int) *memoryOutbox {
+	o := &memoryOutbox{publishedAt: make(map[string]time.Time)}
+	for i := range n {
+		id := strconv.Itoa(i + 1)
+		o.events = append(o.events, &pb.TaskEvent{
+			Id:          id,
+			WorkspaceId: "1",
+			OccurredAt:  "2026-10-19T08:15:00.000Z",
+			Event:       &pb.TaskEvent_TaskCreated{TaskCreated: &pb.TaskCreated{Task: &pb.Task{Id: id, Title: "Task " + id}}},
+		})
+	}
+	return o
+}
+
+func (o *memoryOutbox) PublishPending(ctx context.Context, limit int, publish func(context.Context, *pb.TaskEvent) error) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	published := 0
+	for _, event := range o.events {
+		if _, ok := o.publishedAt[event.GetId()]; ok {
+			continue
+		}
+		if published == limit {
+			break
+		}
+		if err := publish(ctx, event); err != nil {
+			return published, err
+		}
+		o.publishedAt[event.GetId()] = time.Now()
+		published++
+	}
+	return published, nil
+}
+
+func (o *memoryOutbox) isPublished(id string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.publishedAt[id]
+	return ok
+}
+
+var _ repo.OutboxRepository = (*memoryOutbox)(nil)
+
+// startTestNATSBus starts an embedded NATS server and a bus connected to it.
+func startTestNATSBus(t *testing.T) (*natsBus, string) {
+	t.Helper()
+	srv, err := StartEmbeddedNATS("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Shutdown)
+	bus := newNATSBus(&cfg.Config{NATSURL: srv.ClientURL(), NATSSubjectPrefix: "tasks"}, zap.NewNop())
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { bus.Close() })
+	return bus, srv.ClientURL()
+}
+
+func TestRelayPublishesOutboxToNATS(t *testing.T) {
+	bus, url := startTestNATSBus(t)
+	received := make(chan *pb.TaskEvent, 10)
+	unsubscribe, err := bus.Subscribe(func(ctx context.Context, event *pb.TaskEvent) { received <- event })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsubscribe()
+
+	// A plain NATS client sees the subject and headers that other processes rely on.
+	conn, err := nats.Connect(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	raw, err := conn.SubscribeSync("tasks.1.task.created")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := newMemoryOutbox(3)
+	lc := fxtest.NewLifecycle(t)
+	NewRelay(RelayParams{Lifecycle: lc, Logger: zap.NewNop(), Repository: outbox, Bus: bus})
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	for i, want := range outbox.events {
+		select {
+		case event := <-received:
+			if !proto.Equal(event, want) {
+				t.Errorf("event %d = %v, want %v", i, event, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("event %s was not delivered", want.GetId())
+		}
+	}
+	// The relay marks the events once Publish has returned, which can be just
+	// after the subscriber received them.
+	for deadline := time.Now().Add(5 * time.Second); !outbox.isPublished("3"); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("the delivered events were not marked as published")
+		}
+	}
+
+	msg, err := raw.NextMsg(5 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := msg.Header.Get(nats.MsgIdHdr); id != "1" {
+		t.Errorf("%s header = %q, want 1", nats.MsgIdHdr, id)
+	}
+	if ct := msg.Header.Get("Content-Type"); ct != contentType {
+		t.Errorf("Content-Type = %q, want %q", ct, contentType)
+	}
+}
+
+func TestNATSBusPublishFailsWithoutServer(t *testing.T) {
+	srv, err := StartEmbeddedNATS("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus := newNATSBus(&cfg.Config{NATSURL: srv.ClientURL(), NATSSubjectPrefix: "tasks"}, zap.NewNop())
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// Close would wait for the buffered events to be flushed.
+	defer bus.conn.Close()
+	srv.Shutdown()
+	srv.WaitForShutdown()
+
+	outbox := newMemoryOutbox(2)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	n, err := outbox.PublishPending(ctx, relayBatchSize, bus.Publish)
+	if err == nil || n != 0 {
+		t.Fatalf("PublishPending = %d, %v; want 0 and an error", n, err)
+	}
+	if outbox.isPublished("1") {
+		t.Error("an event that NATS did not receive was marked as published")
+	}
+}
@@ -0,0 +1,88 @@
+package events
+
+import (
+	repo "Go_Test/repository"
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// relayPollInterval is the pause between two reads of a drained outbox.
+	relayPollInterval = 500 * time.Millisecond
+	// relayRetryInterval is the pause after the bus rejected an event.
+	relayRetryInterval = 5 * time.Second
+	// relayBatchSize bounds the events published per outbox transaction.
+	relayBatchSize = 100
+)
+
+// Relay publishes the events of the outbox on the Bus, in the order they were
+// written and at least once.
+type Relay struct {
+	repo   repo.OutboxRepository
+	bus    Bus
+	logger *zap.Logger
+}
+
+type RelayParams struct {
+	fx.In
+	Lifecycle  fx.Lifecycle
+	Logger     *zap.Logger
+	Repository repo.OutboxRepository
+	Bus        Bus
+}
+
+// NewRelay creates the relay and runs it for the lifetime of the FX app. It is
+// stopped before the bus is closed.
+func NewRelay(p RelayParams) *Relay {
+	r := &Relay{repo: p.Repository, bus: p.Bus, logger: p.Logger.Named("events")}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			r.logger.Info("Starting event relay")
+			go func() {
+				defer close(done)
+				r.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			r.logger.Info("Stopping event relay")
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+	return r
+}
+
+func (r *Relay) run(ctx context.Context) {
+	for {
+		wait := relayPollInterval
+		n, err := r.repo.PublishPending(ctx, relayBatchSize, r.bus.Publish)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			r.logger.Warn("Failed to publish task events; retrying", zap.Int("published", n), zap.Duration("retry_in", relayRetryInterval), zap.Error(err))
+			wait = relayRetryInterval
+		case n > 0:
+			r.logger.Debug("Published task events", zap.Int("count", n))
+			if n == relayBatchSize {
+				continue
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
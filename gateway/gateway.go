@@ -0,0 +1,150 @@
+// Package gateway serves the TaskService as a REST/JSON API. Requests are
+// translated by grpc-gateway according to the google.api.http annotations in
+// api.proto and forwarded to the gRPC server of the same process, so that
+// authentication, logging and metrics apply to both protocols alike.
+package gateway
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/caldav"
+	cfg "Go_Test/config"
+	"Go_Test/ical"
+	"Go_Test/webui"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Module exports the REST/JSON gateway server for FX.
+var Module = fx.Options(
+	fx.Provide(NewServer),
+)
+
+// requestIDHeader is passed through in both directions, like the x-request-id metadata of gRPC calls.
+const requestIDHeader = "X-Request-Id"
+
+// Server is the HTTP server of the REST/JSON gateway.
+type Server struct {
+	*http.Server
+}
+
+type ServerParams struct {
+	fx.In
+	Lifecycle      fx.Lifecycle
+	Logger         *zap.Logger
+	Config         *cfg.Config
+	TracerProvider trace.TracerProvider
+	Feeds          *ical.FeedHandler
+	CalDAV         *caldav.Handler
+}
+
+// NewServer creates the gateway and ties it to the FX lifecycle. It serves the
+// TaskService routes under /v1/, the OpenAPI spec at /openapi.json, the calendar
+// feeds under ical.FeedPath, CalDAV under caldav.Path and the browser UI at
+// WebUIPath. When GatewayAddress is empty the server is created but never started.
+func NewServer(p ServerParams) (*Server, error) {
+	logger := p.Logger.Named("gateway")
+	gwMux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+	)
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", gwMux)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(pb.OpenAPISpec)
+	})
+	mux.Handle("GET "+ical.FeedPath+"{file}", p.Feeds)
+	// Patterns without a method would conflict with a browser UI at /.
+	for _, method := range caldav.Methods {
+		mux.Handle(method+" "+caldav.Path, p.CalDAV)
+		mux.Handle(method+" "+caldav.WellKnownPath, p.CalDAV)
+	}
+	if uiPath := p.Config.WebUIPath; uiPath != "" {
+		mux.Handle("GET "+uiPath, webui.Handler(uiPath))
+		if uiPath != "/" {
+			mux.Handle("GET /{$}", http.RedirectHandler(uiPath, http.StatusFound))
+		}
+	}
+	server := &Server{Server: &http.Server{Addr: p.Config.GatewayAddress, Handler: mux}}
+
+	if p.Config.GatewayAddress == "" {
+		logger.Info("REST gateway disabled")
+		return server, nil
+	}
+
+	target := p.Config.LocalGRPCTarget()
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(p.TracerProvider))),
+		// Task attachments are up to 16 MiB, above the default limit of 4 MiB.
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(32<<20)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gateway client for %s: %w", target, err)
+	}
+	if err := pb.RegisterTaskServiceHandlerClient(context.Background(), gwMux, pb.NewTaskServiceClient(conn)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to register TaskService gateway: %w", err)
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting REST gateway", zap.String("address", p.Config.GatewayAddress), zap.String("grpc_target", target))
+			lis, err := net.Listen("tcp", p.Config.GatewayAddress)
+			if err != nil {
+				logger.Error("Failed to listen for REST gateway", zap.Error(err))
+				return fmt.Errorf("failed to listen for REST gateway: %w", err)
+			}
+			go func() {
+				if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("REST gateway failed to serve", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping REST gateway")
+			err := server.Shutdown(ctx)
+			if closeErr := conn.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				logger.Error("Failed to stop REST gateway", zap.Error(err))
+				return err
+			}
+			logger.Info("REST gateway stopped")
+			return nil
+		},
+	})
+	return server, nil
+}
+
+// incomingHeader forwards X-Request-Id as x-request-id metadata, in addition to
+// the headers grpc-gateway forwards by default (among them Authorization).
+func incomingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == requestIDHeader {
+		return "x-request-id", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeader returns the x-request-id response header as X-Request-Id; other
+// metadata keeps the default Grpc-Metadata- prefix.
+func outgoingHeader(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == requestIDHeader {
+		return requestIDHeader, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,340 @@
+package ical
+
+import (
+	pb "Go_Test/api"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Component is a parsed iCalendar component, such as a VCALENDAR or a VTODO.
+type Component struct {
+	Name       string
+	Properties []Property
+	Components []*Component
+}
+
+// Property is a content line of a component. Value is still escaped.
+type Property struct {
+	Name string
+	// Params holds the first value of each parameter by upper-case name.
+	Params map[string]string
+	Value  string
+}
+
+// Property returns the first property with the given upper-case name, or nil.
+func (c *Component) Property(name string) *Property {
+	for i := range c.Properties {
+		if c.Properties[i].Name == name {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Text returns the unescaped value of the first property with the given name.
+func (c *Component) Text(name string) string {
+	if p := c.Property(name); p != nil {
+		return unescapeText(p.Value)
+	}
+	return ""
+}
+
+// maxDecodeSize limits the calendar objects read by Decode.
+const maxDecodeSize = 1 << 20
+
+// Decode reads an iCalendar object and returns its outermost component.
+func Decode(r io.Reader) (*Component, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDecodeSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDecodeSize {
+		return nil, fmt.Errorf("calendar object exceeds %d bytes", maxDecodeSize)
+	}
+	var root *Component
+	var stack []*Component
+	scanner := bufio.NewScanner(bytes.NewReader(unfold(data)))
+	scanner.Buffer(make([]byte, 0, 4096), maxDecodeSize)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		prop, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		switch prop.Name {
+		case "BEGIN":
+			c := &Component{Name: strings.ToUpper(prop.Value)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Components = append(parent.Components, c)
+			} else if root != nil {
+				return nil, fmt.Errorf("line %d: content after END:%s", n, root.Name)
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].Name != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", n, prop.Value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s outside of a component", n, prop.Name)
+			}
+			c := stack[len(stack)-1]
+			c.Properties = append(c.Properties, prop)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errors.New("no calendar component")
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	return root, nil
+}
+
+// unfold joins folded content lines: a line break followed by a space or a tab
+// continues the previous line.
+func unfold(data []byte) []byte {
+	data = bytes.ReplaceAll(data, []byte("\r\n "), nil)
+	data = bytes.ReplaceAll(data, []byte("\r\n\t"), nil)
+	data = bytes.ReplaceAll(data, []byte("\n "), nil)
+	return bytes.ReplaceAll(data, []byte("\n\t"), nil)
+}
+
+// parseLine splits a content line into its name, parameters and value. Parameter
+// values may be quoted, so that they can contain ':', ';' and ','.
+func parseLine(line string) (Property, error) {
+	end := strings.IndexAny(line, ";:")
+	if end <= 0 {
+		return Property{}, fmt.Errorf("malformed content line %q", line)
+	}
+	prop := Property{Name: strings.ToUpper(line[:end])}
+	rest := line[end:]
+	for strings.HasPrefix(rest, ";") {
+		rest = rest[1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return Property{}, fmt.Errorf("malformed parameter in %s", prop.Name)
+		}
+		name := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			closing := strings.IndexByte(rest[1:], '"')
+			if closing < 0 {
+				return Property{}, fmt.Errorf("unterminated quoted parameter %s in %s", name, prop.Name)
+			}
+			value, rest = rest[1:closing+1], rest[closing+2:]
+		} else {
+			stop := strings.IndexAny(rest, ";:,")
+			if stop < 0 {
+				return Property{}, fmt.Errorf("missing value of %s", prop.Name)
+			}
+			value, rest = rest[:stop], rest[stop:]
+		}
+		// Further values of a multi-valued parameter are skipped.
+		for strings.HasPrefix(rest, ",") {
+			stop := strings.IndexAny(rest[1:], ";:")
+			if stop < 0 {
+				return Property{}, fmt.Errorf("missing value of %s", prop.Name)
+			}
+			rest = rest[stop+1:]
+		}
+		if prop.Params == nil {
+			prop.Params = make(map[string]string)
+		}
+		if _, ok := prop.Params[name]; !ok {
+			prop.Params[name] = value
+		}
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return Property{}, fmt.Errorf("missing value of %s", prop.Name)
+	}
+	prop.Value = rest[1:]
+	return prop, nil
+}
+
+// unescapeText reverses escapeText.
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// splitList splits an escaped multi-valued TEXT value at its unescaped commas and
+// unescapes the values.
+func splitList(s string) []string {
+	var values []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			values = append(values, unescapeText(s[start:i]))
+			start = i + 1
+		}
+	}
+	return append(values, unescapeText(s[start:]))
+}
+
+var (
+	// ErrNoTodo is returned by ParseTodo for calendar objects without a VTODO.
+	ErrNoTodo = errors.New("calendar object contains no VTODO")
+	// ErrUnsupportedComponent is wrapped by ParseTodo for calendar objects with
+	// events, journals or other components besides the VTODO.
+	ErrUnsupportedComponent = errors.New("only VTODOs are supported")
+)
+
+// ParseTodo reads a calendar object with a single VTODO, as uploaded by CalDAV
+// clients, and returns its UID and the task it describes. Floating times and
+// dates are read in loc. Properties without a task field, such as alarms or
+// recurrence rules, are ignored.
+func ParseTodo(r io.Reader, loc *time.Location) (uid string, task *pb.Task, err error) {
+	cal, err := Decode(r)
+	if err != nil {
+		return "", nil, err
+	}
+	if cal.Name != "VCALENDAR" {
+		return "", nil, fmt.Errorf("expected VCALENDAR, got %s", cal.Name)
+	}
+	var todo *Component
+	for _, c := range cal.Components {
+		switch c.Name {
+		case "VTODO":
+			if todo != nil {
+				return "", nil, errors.New("calendar object contains more than one VTODO")
+			}
+			todo = c
+		case "VTIMEZONE":
+		default:
+			return "", nil, fmt.Errorf("calendar object contains a %s: %w", c.Name, ErrUnsupportedComponent)
+		}
+	}
+	if todo == nil {
+		return "", nil, ErrNoTodo
+	}
+
+	uid = todo.Text("UID")
+	if uid == "" {
+		return "", nil, errors.New("VTODO has no UID")
+	}
+	task = &pb.Task{
+		Title:       todo.Text("SUMMARY"),
+		Description: todo.Text("DESCRIPTION"),
+		Status:      taskStatus(todo),
+		Priority:    taskPriority(todo.Text("PRIORITY")),
+	}
+	if due := todo.Property("DUE"); due != nil {
+		t, err := parseTime(due, loc)
+		if err != nil {
+			return "", nil, fmt.Errorf("DUE: %w", err)
+		}
+		task.DueAt = t.UTC().Format(time.RFC3339)
+	}
+	for _, p := range todo.Properties {
+		if p.Name == "CATEGORIES" {
+			task.Tags = append(task.Tags, splitList(p.Value)...)
+		}
+	}
+	return uid, task, nil
+}
+
+// TodoStatus maps a task status to the STATUS of a VTODO. Statuses without an
+// equivalent become NEEDS-ACTION.
+func TodoStatus(status string) string {
+	switch status {
+	case "completed":
+		return "COMPLETED"
+	case "in_progress":
+		return "IN-PROCESS"
+	case "cancelled":
+		return "CANCELLED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// taskStatus maps the STATUS of a VTODO to a task status. Without STATUS, a
+// COMPLETED time or a PERCENT-COMPLETE of 100 marks the task completed.
+func taskStatus(todo *Component) string {
+	switch strings.ToUpper(todo.Text("STATUS")) {
+	case "COMPLETED":
+		return "completed"
+	case "IN-PROCESS":
+		return "in_progress"
+	case "CANCELLED":
+		return "cancelled"
+	case "NEEDS-ACTION":
+		return "pending"
+	}
+	if todo.Property("COMPLETED") != nil || todo.Text("PERCENT-COMPLETE") == "100" {
+		return "completed"
+	}
+	return "pending"
+}
+
+// taskPriority maps the PRIORITY of a VTODO, 1 (highest) to 9 (lowest) or 0 for
+// none, to a task priority; it reverses todoPriority.
+func taskPriority(value string) string {
+	priority, err := strconv.Atoi(strings.TrimSpace(value))
+	switch {
+	case err != nil || priority <= 0 || priority > 9:
+		return ""
+	case priority <= 2:
+		return "urgent"
+	case priority <= 4:
+		return "high"
+	case priority == 5:
+		return "medium"
+	}
+	return "low"
+}
+
+// parseTime reads a DATE or DATE-TIME value. UTC times end in Z; other times are
+// in the zone of the TZID parameter, if it is an IANA name known to the system,
+// and otherwise in loc. A DATE is the start of the day in loc.
+func parseTime(p *Property, loc *time.Location) (time.Time, error) {
+	value := strings.TrimSpace(p.Value)
+	if strings.EqualFold(p.Params["VALUE"], "DATE") || len(value) == len("20060102") {
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(utcFormat, value)
+	}
+	if tzid := strings.TrimPrefix(p.Params["TZID"], "/"); tzid != "" {
+		if zone, err := time.LoadLocation(tzid); err == nil {
+			loc = zone
+		}
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
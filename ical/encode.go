@@ -0,0 +1,269 @@
+// Package ical renders tasks as iCalendar (RFC 5545) data and serves them as
+// calendar feeds.
+//
+// Every task becomes a VTODO, which task apps show with its due date, status and
+// completion time, and every task with a due date can also become a VEVENT at that
+// date, for calendar apps that ignore VTODOs. Feeds are read by calendar apps
+// under a secret URL of the REST gateway; see FeedHandler.
+package ical
+
+import (
+	pb "Go_Test/api"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Components selects the calendar components written for each task.
+type Components int
+
+const (
+	// VTodo writes a VTODO for every task.
+	VTodo Components = 1 << iota
+	// VEvent writes a VEVENT at the due date of every task that has one.
+	VEvent
+)
+
+// ParseComponents parses a comma-separated list of "vtodo" and "vevent".
+func ParseComponents(s string) (Components, error) {
+	var c Components
+	for _, name := range strings.Split(s, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "vtodo":
+			c |= VTodo
+		case "vevent":
+			c |= VEvent
+		default:
+			return 0, fmt.Errorf("unknown calendar component %q; use vtodo, vevent or both", name)
+		}
+	}
+	return c, nil
+}
+
+const (
+	// prodID identifies the application that wrote a calendar.
+	prodID = "-//fx-grpc-app//Tasks//EN"
+	// uidDomain makes task UIDs globally unique. It must not change, or calendar
+	// apps would see every task as new.
+	uidDomain = "fx-grpc-app"
+	// maxLineLength is the longest content line in octets, without the CRLF.
+	maxLineLength = 75
+)
+
+// Calendar is the content of an iCalendar object.
+type Calendar struct {
+	// Name is shown by calendar apps for a subscribed feed.
+	Name  string
+	Tasks []*pb.Task
+	// Components defaults to VTodo.
+	Components Components
+	// RefreshInterval, when set, suggests how often apps poll a feed.
+	RefreshInterval time.Duration
+	// UIDs replaces the UIDs of the VTODOs of some tasks, by task ID: tasks created
+	// by CalDAV clients keep the UIDs the clients gave them.
+	UIDs map[string]string
+}
+
+// UID returns the UID of the VTODO of a task.
+func UID(taskID string) string {
+	return "task-" + taskID + "@" + uidDomain
+}
+
+// eventUID returns the UID of the VEVENT of a task, which must differ from the
+// UID of its VTODO.
+func eventUID(taskID string) string {
+	return "task-" + taskID + "-due@" + uidDomain
+}
+
+// Encode writes cal as an iCalendar object.
+func Encode(w io.Writer, cal *Calendar) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", prodID)
+	e.line("CALSCALE", "GREGORIAN")
+	if cal.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(cal.Name))
+	}
+	if cal.RefreshInterval > 0 {
+		e.line("REFRESH-INTERVAL;VALUE=DURATION", duration(cal.RefreshInterval))
+		e.line("X-PUBLISHED-TTL", duration(cal.RefreshInterval))
+	}
+	components := cal.Components
+	if components == 0 {
+		components = VTodo
+	}
+	for _, task := range cal.Tasks {
+		if components&VTodo != 0 {
+			uid, ok := cal.UIDs[task.GetId()]
+			if !ok {
+				uid = UID(task.GetId())
+			}
+			e.todo(task, uid)
+		}
+		if components&VEvent != 0 && task.GetDueAt() != "" {
+			e.event(task)
+		}
+	}
+	e.line("END", "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) todo(task *pb.Task, uid string) {
+	e.line("BEGIN", "VTODO")
+	e.line("UID", escapeText(uid))
+	e.common(task)
+	e.line("SUMMARY", escapeText(task.GetTitle()))
+	if task.GetDescription() != "" {
+		e.line("DESCRIPTION", escapeText(task.GetDescription()))
+	}
+	if due, ok := timestamp(task.GetDueAt()); ok {
+		e.line("DUE", due)
+	}
+	e.line("STATUS", TodoStatus(task.GetStatus()))
+	if task.GetStatus() == "completed" {
+		completedAt := task.GetCompletedAt()
+		if completedAt == "" {
+			completedAt = task.GetUpdatedAt()
+		}
+		if completed, ok := timestamp(completedAt); ok {
+			e.line("COMPLETED", completed)
+		}
+		e.line("PERCENT-COMPLETE", "100")
+	}
+	if priority := todoPriority(task.GetPriority()); priority != "" {
+		e.line("PRIORITY", priority)
+	}
+	e.categories(task)
+	e.line("END", "VTODO")
+}
+
+// event writes the due date of a task as an instant: a VEVENT with a DTSTART but
+// no DTEND or DURATION ends when it starts. It does not block time in free/busy
+// lookups.
+func (e *encoder) event(task *pb.Task) {
+	due, ok := timestamp(task.GetDueAt())
+	if !ok {
+		return
+	}
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", eventUID(task.GetId()))
+	e.common(task)
+	summary := task.GetTitle()
+	if task.GetStatus() == "completed" {
+		summary = "✓ " + summary
+	}
+	e.line("SUMMARY", escapeText(summary))
+	if task.GetDescription() != "" {
+		e.line("DESCRIPTION", escapeText(task.GetDescription()))
+	}
+	e.line("DTSTART", due)
+	e.line("TRANSP", "TRANSPARENT")
+	e.categories(task)
+	e.line("END", "VEVENT")
+}
+
+// common writes the timestamps shared by VTODO and VEVENT. Without a METHOD
+// property, DTSTAMP is the time the task was last changed, so that unchanged feeds
+// stay byte for byte the same.
+func (e *encoder) common(task *pb.Task) {
+	stamp, ok := timestamp(task.GetUpdatedAt())
+	if !ok {
+		stamp, ok = timestamp(task.GetCreatedAt())
+	}
+	if !ok {
+		stamp = time.Now().UTC().Format(utcFormat)
+	}
+	e.line("DTSTAMP", stamp)
+	if created, ok := timestamp(task.GetCreatedAt()); ok {
+		e.line("CREATED", created)
+	}
+	if modified, ok := timestamp(task.GetUpdatedAt()); ok {
+		e.line("LAST-MODIFIED", modified)
+	}
+}
+
+func (e *encoder) categories(task *pb.Task) {
+	if len(task.GetTags()) == 0 {
+		return
+	}
+	tags := make([]string, len(task.GetTags()))
+	for i, tag := range task.GetTags() {
+		tags[i] = escapeText(tag)
+	}
+	e.line("CATEGORIES", strings.Join(tags, ","))
+}
+
+// line writes a content line, folded after maxLineLength octets without splitting
+// a UTF-8 sequence. value must already be escaped.
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	s := name + ":" + value
+	limit := maxLineLength
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, e.err = e.w.WriteString(s[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		s = s[cut:]
+		// Continuation lines start with a space, which counts towards the limit.
+		limit = maxLineLength - 1
+	}
+	_, e.err = e.w.WriteString(s + "\r\n")
+}
+
+// utcFormat is the format of UTC DATE-TIME values.
+const utcFormat = "20060102T150405Z"
+
+// timestamp converts an RFC 3339 timestamp into a UTC DATE-TIME value.
+func timestamp(rfc3339 string) (string, bool) {
+	t, err := time.Parse(time.RFC3339, rfc3339)
+	if err != nil {
+		return "", false
+	}
+	return t.UTC().Format(utcFormat), true
+}
+
+// duration formats d as a DURATION value in whole minutes, at least one.
+func duration(d time.Duration) string {
+	return fmt.Sprintf("PT%dM", max(int(d/time.Minute), 1))
+}
+
+// escapeText escapes a TEXT value.
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// todoPriority maps a task priority to the PRIORITY of a VTODO, where 1 is the
+// highest and 9 the lowest priority.
+func todoPriority(priority string) string {
+	switch priority {
+	case "urgent":
+		return "1"
+	case "high":
+		return "3"
+	case "medium":
+		return "5"
+	case "low":
+		return "7"
+	}
+	return ""
+}
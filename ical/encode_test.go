@@ -0,0 +1,115 @@
+package ical
+
+import (
+	pb "Go_Test/api"
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func encodeString(t *testing.T, cal *Calendar) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Encode(&buf, cal); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return buf.String()
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	title := strings.Repeat("Prüfbericht für das Quartal ", 8) + "✓"
+	out := encodeString(t, &Calendar{Tasks: []*pb.Task{{Id: "1", Title: title, Status: "pending"}}})
+
+	if !strings.HasSuffix(out, "\r\n") || strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Fatal("content lines do not all end with CRLF")
+	}
+	folded := 0
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a UTF-8 sequence: %q", line)
+		}
+		if strings.HasPrefix(line, " ") {
+			folded++
+		}
+	}
+	if folded == 0 {
+		t.Fatal("the long SUMMARY was not folded")
+	}
+
+	cal, err := Decode(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(cal.Components) != 1 || cal.Components[0].Text("SUMMARY") != title {
+		t.Errorf("unfolded SUMMARY = %q, want %q", cal.Components[0].Text("SUMMARY"), title)
+	}
+}
+
+func TestEncodeEscapesText(t *testing.T) {
+	task := &pb.Task{
+		Id:          "7",
+		Title:       `Plan; budget, risks \ notes`,
+		Description: "Line one\r\nLine two\nLine three",
+		Status:      "completed",
+		CompletedAt: "2026-03-10T12:30:00+01:00",
+		DueAt:       "2026-03-12T09:00:00Z",
+		Tags:        []string{"a,b", "c;d"},
+		Priority:    "high",
+	}
+	out := encodeString(t, &Calendar{Name: "Tasks, mine", Tasks: []*pb.Task{task}, Components: VTodo | VEvent})
+	for _, want := range []string{
+		"X-WR-CALNAME:Tasks\\, mine\r\n",
+		"UID:task-7@fx-grpc-app\r\n",
+		"SUMMARY:Plan\\; budget\\, risks \\\\ notes\r\n",
+		"DESCRIPTION:Line one\\nLine two\\nLine three\r\n",
+		"CATEGORIES:a\\,b,c\\;d\r\n",
+		"DUE:20260312T090000Z\r\n",
+		"COMPLETED:20260310T113000Z\r\n",
+		"STATUS:COMPLETED\r\n",
+		"PRIORITY:3\r\n",
+		"UID:task-7-due@fx-grpc-app\r\n",
+		"SUMMARY:✓ Plan\\; budget\\, risks \\\\ notes\r\n",
+		"DTSTART:20260312T090000Z\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	cal, err := Decode(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	todo := cal.Components[0]
+	if todo.Text("SUMMARY") != task.GetTitle() || todo.Text("DESCRIPTION") != "Line one\nLine two\nLine three" {
+		t.Errorf("decoded SUMMARY %q and DESCRIPTION %q", todo.Text("SUMMARY"), todo.Text("DESCRIPTION"))
+	}
+}
+
+func TestEncodeComponents(t *testing.T) {
+	tasks := []*pb.Task{
+		{Id: "1", Title: "Due", Status: "pending", DueAt: "2026-03-12T09:00:00Z"},
+		{Id: "2", Title: "Someday", Status: "pending"},
+	}
+	tests := []struct {
+		components          Components
+		wantTodo, wantEvent int
+	}{
+		{components: 0, wantTodo: 2},
+		{components: VEvent, wantEvent: 1},
+		{components: VTodo | VEvent, wantTodo: 2, wantEvent: 1},
+	}
+	for _, tt := range tests {
+		out := encodeString(t, &Calendar{Tasks: tasks, Components: tt.components, UIDs: map[string]string{"2": "client-uid"}})
+		if todos, events := strings.Count(out, "BEGIN:VTODO"), strings.Count(out, "BEGIN:VEVENT"); todos != tt.wantTodo || events != tt.wantEvent {
+			t.Errorf("components %d: %d VTODOs and %d VEVENTs, want %d and %d", tt.components, todos, events, tt.wantTodo, tt.wantEvent)
+		}
+		if tt.wantTodo > 0 && !strings.Contains(out, "UID:client-uid\r\n") {
+			t.Errorf("components %d: the UID of task 2 was not replaced", tt.components)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ical
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the calendar feed handler for FX.
+var Module = fx.Options(
+	fx.Provide(NewFeedHandler),
+)
+
+const (
+	// FeedPath is the path prefix of the feed URLs on the REST gateway; the secret
+	// token and the suffix .ics follow it.
+	FeedPath = "/calendar/"
+	// feedRefreshInterval is the polling interval suggested to calendar apps.
+	feedRefreshInterval = 15 * time.Minute
+)
+
+// FeedURL returns the URL of the feed with the given token on the gateway at baseURL.
+func FeedURL(baseURL, token string) string {
+	return baseURL + FeedPath + token + ".ics"
+}
+
+// FeedHandler serves the calendar feeds. A feed lists the tasks with a due date of
+// its assignee or project, as a VTODO and a VEVENT each; the query parameter
+// components=vtodo or components=vevent limits it to one of them. The secret token
+// in the URL is the only credential, as calendar apps cannot send API tokens.
+type FeedHandler struct {
+	feeds      repo.CalendarRepository
+	tasks      repo.TaskRepository
+	workspaces repo.WorkspaceRepository
+	logger     *zap.Logger
+}
+
+type FeedHandlerParams struct {
+	fx.In
+	Logger     *zap.Logger
+	Feeds      repo.CalendarRepository
+	Tasks      repo.TaskRepository
+	Workspaces repo.WorkspaceRepository
+}
+
+// NewFeedHandler creates the handler of the calendar feeds, to be mounted at
+// "GET " + FeedPath + "{file}".
+func NewFeedHandler(p FeedHandlerParams) *FeedHandler {
+	return &FeedHandler{
+		feeds:      p.Feeds,
+		tasks:      p.Tasks,
+		workspaces: p.Workspaces,
+		logger:     p.Logger.Named("ical"),
+	}
+}
+
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := strings.TrimSuffix(r.PathValue("file"), ".ics")
+	components := VTodo | VEvent
+	if value := r.URL.Query().Get("components"); value != "" {
+		var err error
+		if components, err = ParseComponents(value); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tenantID, feed, err := h.feeds.FetchFeedByTokenHash(ctx, auth.HashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read calendar feed", http.StatusInternalServerError)
+		return
+	}
+	logger := h.logger.With(zap.String("tenant_id", tenantID), zap.String("feed_id", feed.GetId()))
+	workspace, err := h.workspaces.FetchWorkspaceByID(ctx, tenantID)
+	if err != nil {
+		logger.Error("Failed to fetch workspace of calendar feed", zap.Error(err))
+		http.Error(w, "failed to read calendar feed", http.StatusInternalServerError)
+		return
+	}
+	if workspace.GetStatus() != auth.WorkspaceStatusActive {
+		http.Error(w, "workspace is suspended", http.StatusForbidden)
+		return
+	}
+
+	tasks, err := h.tasks.FetchTasks(tenant.WithID(ctx, tenantID), repo.TaskFilter{Assignee: feed.GetAssignee(), Project: feed.GetProject()})
+	if err != nil {
+		logger.Error("Failed to fetch tasks of calendar feed", zap.Error(err))
+		http.Error(w, "failed to read calendar feed", http.StatusInternalServerError)
+		return
+	}
+	var due []*pb.Task
+	for _, task := range tasks {
+		if task.GetDueAt() != "" {
+			due = append(due, task)
+		}
+	}
+	var body bytes.Buffer
+	err = Encode(&body, &Calendar{Name: feedName(feed), Tasks: due, Components: components, RefreshInterval: feedRefreshInterval})
+	if err != nil {
+		logger.Error("Failed to encode calendar feed", zap.Error(err))
+		http.Error(w, "failed to encode calendar feed", http.StatusInternalServerError)
+		return
+	}
+	logger.Debug("Serving calendar feed", zap.Int("tasks", len(due)))
+
+	// Apps poll feeds often; the ETag lets them skip unchanged ones.
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Write(body.Bytes())
+}
+
+// feedName returns the calendar name of a feed shown by calendar apps.
+func feedName(feed *pb.CalendarFeed) string {
+	switch {
+	case feed.GetAssignee() != "" && feed.GetProject() != "":
+		return "Tasks of " + feed.GetAssignee() + " in " + feed.GetProject()
+	case feed.GetAssignee() != "":
+		return "Tasks of " + feed.GetAssignee()
+	}
+	return "Tasks in " + feed.GetProject()
+}
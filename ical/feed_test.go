@@ -0,0 +1,135 @@
+package ical
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeFeeds knows the token "alice-token" of workspace 1 and "suspended-token"
+// of the suspended workspace 2.
+type fakeFeeds struct {
+	repo.CalendarRepository
+}
+
+func (fakeFeeds) FetchFeedByTokenHash(ctx context.Context, tokenHash string) (string, *pb.CalendarFeed, error) {
+	switch tokenHash {
+	case auth.HashToken("alice-token"):
+		return "1", &pb.CalendarFeed{Id: "10", Assignee: "alice"}, nil
+	case auth.HashToken("suspended-token"):
+		return "2", &pb.CalendarFeed{Id: "20", Project: "ops"}, nil
+	}
+	return "", nil, sql.ErrNoRows
+}
+
+type fakeWorkspaces struct {
+	repo.WorkspaceRepository
+}
+
+func (fakeWorkspaces) FetchWorkspaceByID(ctx context.Context, workspaceID string) (*pb.Workspace, error) {
+	status := auth.WorkspaceStatusActive
+	if workspaceID == "2" {
+		status = auth.WorkspaceStatusSuspended
+	}
+	return &pb.Workspace{Id: workspaceID, Status: status}, nil
+}
+
+// fakeTasks returns its tasks for tenant 1 and alice only.
+type fakeTasks struct {
+	repo.TaskRepository
+	tasks []*pb.Task
+}
+
+func (f *fakeTasks) FetchTasks(ctx context.Context, filter repo.TaskFilter) ([]*pb.Task, error) {
+	if id, _ := tenant.IDFromContext(ctx); id != "1" || filter.Assignee != "alice" {
+		return nil, nil
+	}
+	return f.tasks, nil
+}
+
+func newTestFeedServer(tasks *fakeTasks) http.Handler {
+	h := NewFeedHandler(FeedHandlerParams{Logger: zap.NewNop(), Feeds: fakeFeeds{}, Tasks: tasks, Workspaces: fakeWorkspaces{}})
+	mux := http.NewServeMux()
+	mux.Handle("GET "+FeedPath+"{file}", h)
+	return mux
+}
+
+func getFeed(h http.Handler, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestFeedHandlerServesFeedWithETag(t *testing.T) {
+	tasks := &fakeTasks{tasks: []*pb.Task{
+		{Id: "1", Title: "Report", Status: "pending", DueAt: "2026-03-12T09:00:00Z", UpdatedAt: "2026-03-01T10:00:00Z"},
+		{Id: "2", Title: "No due date", Status: "pending", UpdatedAt: "2026-03-01T10:00:00Z"},
+	}}
+	h := newTestFeedServer(tasks)
+
+	rec := getFeed(h, FeedURL("", "alice-token"), "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/calendar; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "X-WR-CALNAME:Tasks of alice\r\n") || !strings.Contains(body, "UID:task-1@fx-grpc-app\r\n") ||
+		!strings.Contains(body, "UID:task-1-due@fx-grpc-app\r\n") || strings.Contains(body, "No due date") {
+		t.Errorf("feed does not hold exactly the VTODO and VEVENT of the task with a due date:\n%s", body)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag")
+	}
+
+	rec = getFeed(h, FeedURL("", "alice-token"), etag)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("unchanged feed: status %d with %d bytes, want 304 without body", rec.Code, rec.Body.Len())
+	}
+
+	// A change of a task changes the ETag.
+	tasks.tasks[0].Title = "Final report"
+	tasks.tasks[0].UpdatedAt = "2026-03-02T10:00:00Z"
+	rec = getFeed(h, FeedURL("", "alice-token"), etag)
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") == etag {
+		t.Errorf("changed feed: status %d, ETag %s; want 200 with a new ETag", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	rec = getFeed(h, FeedURL("", "alice-token")+"?components=vevent", "")
+	if body := rec.Body.String(); rec.Code != http.StatusOK || strings.Contains(body, "BEGIN:VTODO") || !strings.Contains(body, "BEGIN:VEVENT") {
+		t.Errorf("components=vevent: status %d:\n%s", rec.Code, body)
+	}
+}
+
+func TestFeedHandlerErrors(t *testing.T) {
+	h := newTestFeedServer(&fakeTasks{})
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"unknown token", FeedURL("", "unknown-token"), http.StatusNotFound},
+		{"suspended workspace", FeedURL("", "suspended-token"), http.StatusForbidden},
+		{"unknown component", FeedURL("", "alice-token") + "?components=vjournal", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if rec := getFeed(h, tt.path, ""); rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+}
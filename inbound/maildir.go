@@ -0,0 +1,163 @@
+package inbound
+
+import (
+	cfg "Go_Test/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// maildirPollInterval is the pause between two scans of the Maildir.
+const maildirPollInterval = 10 * time.Second
+
+// MaildirPoller creates tasks from the messages delivered to the new/ directory
+// of a Maildir. A processed message is moved to cur/ and flagged as seen; a
+// rejected one is also flagged, so that it stands out in a mail client. Messages
+// that fail for a transient reason stay in new/ and are retried on the next scan.
+//
+// Only one server should poll a given Maildir.
+type MaildirPoller struct {
+	processor *Processor
+	logger    *zap.Logger
+	dir       string
+	maxSize   int
+}
+
+type MaildirPollerParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *cfg.Config
+	Processor *Processor
+}
+
+// NewMaildirPoller creates the poller and, when inbound_maildir is set, runs it for
+// the lifetime of the FX app.
+func NewMaildirPoller(p MaildirPollerParams) (*MaildirPoller, error) {
+	m := &MaildirPoller{
+		processor: p.Processor,
+		logger:    p.Logger.Named("inbound.maildir"),
+		dir:       p.Config.InboundMaildir,
+		maxSize:   p.Config.InboundMaxMessageSize,
+	}
+	if m.dir == "" {
+		return m, nil
+	}
+	for _, sub := range []string{"new", "cur"} {
+		if info, err := os.Stat(filepath.Join(m.dir, sub)); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("inbound_maildir %s is not a Maildir: missing %s/ directory", m.dir, sub)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			m.logger.Info("Starting Maildir poller", zap.String("maildir", m.dir))
+			go func() {
+				defer close(done)
+				m.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			m.logger.Info("Stopping Maildir poller")
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+	return m, nil
+}
+
+func (m *MaildirPoller) run(ctx context.Context) {
+	for {
+		m.scan(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(maildirPollInterval):
+		}
+	}
+}
+
+// scan processes the messages in new/ in the order of their names, which start
+// with the delivery time.
+func (m *MaildirPoller) scan(ctx context.Context) {
+	entries, err := os.ReadDir(filepath.Join(m.dir, "new"))
+	if err != nil {
+		m.logger.Warn("Failed to read Maildir", zap.Error(err))
+		return
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		m.process(ctx, name)
+	}
+}
+
+func (m *MaildirPoller) process(ctx context.Context, name string) {
+	logger := m.logger.With(zap.String("message", name))
+	path := filepath.Join(m.dir, "new", name)
+	raw, err := m.read(path)
+	if err != nil && !errors.As(err, new(*RejectError)) {
+		logger.Warn("Failed to read message", zap.Error(err))
+		return
+	}
+	if err == nil {
+		_, err = m.processor.Deliver(ctx, nil, raw)
+	}
+	flags := "S"
+	var rejected *RejectError
+	switch {
+	case errors.As(err, &rejected):
+		logger.Info("Rejected inbound email", zap.String("reason", rejected.Reason))
+		flags = "FS"
+	case err != nil:
+		logger.Warn("Failed to create task from message; retrying later", zap.Error(err))
+		return
+	}
+	// The info suffix starts with ":2," and lists the flags in ASCII order.
+	base, _, _ := strings.Cut(name, ":")
+	if err := os.Rename(path, filepath.Join(m.dir, "cur", base+":2,"+flags)); err != nil {
+		logger.Error("Failed to move processed message to cur/", zap.Error(err))
+	}
+}
+
+// read returns the content of a message, rejecting messages above the size limit.
+func (m *MaildirPoller) read(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	raw, err := io.ReadAll(io.LimitReader(f, int64(m.maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > m.maxSize {
+		return nil, reject("message exceeds %d bytes", m.maxSize)
+	}
+	return raw, nil
+}
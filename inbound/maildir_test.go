@@ -0,0 +1,105 @@
+package inbound
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+// newTestMaildir creates a Maildir with the given messages in new/ and a poller
+// for it that is not started; the tests call scan directly.
+func newTestMaildir(t *testing.T, tasks *fakeTasks, maxSize int, messages map[string]string) (*MaildirPoller, string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"new", "cur", "tmp"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range messages {
+		if err := os.WriteFile(filepath.Join(dir, "new", name), []byte(crlf(content)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := testConfig(maxSize)
+	c.InboundMaildir = dir
+	m, err := NewMaildirPoller(MaildirPollerParams{Lifecycle: fxtest.NewLifecycle(t), Logger: zap.NewNop(), Config: c, Processor: newTestProcessor(tasks)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, dir
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestMaildirPollerMovesProcessedMessages(t *testing.T) {
+	tasks := &fakeTasks{}
+	m, dir := newTestMaildir(t, tasks, 1024, map[string]string{
+		"1000.a.host":      "From: alice@example.com\nDelivered-To: tasks+ops@example.com\nSubject: First\n\nBody\n",
+		"1001.b.host:2,":   "From: mallory@example.com\nTo: tasks@example.com\nSubject: Spam\n\nBody\n",
+		"1002.c.host":      "From: bob@example.com\nTo: tasks@example.com\nSubject: Suspended\n\nBody\n",
+		"1003.d.host":      "From: alice@example.com\nTo: tasks@example.com\nSubject: Large\n\n" + strings.Repeat("x", 2048) + "\n",
+		".1004.e.host.tmp": "From: alice@example.com\nTo: tasks@example.com\nSubject: Hidden\n\nBody\n",
+	})
+	m.scan(context.Background())
+
+	added := tasks.added()
+	if len(added) != 1 || added[0].GetTitle() != "First" || added[0].GetProject() != "ops" {
+		t.Fatalf("tasks = %v, want only the one of the first message, in project ops", added)
+	}
+	want := []string{"1000.a.host:2,S", "1001.b.host:2,FS", "1002.c.host:2,FS", "1003.d.host:2,FS"}
+	if got := listDir(t, filepath.Join(dir, "cur")); !slices.Equal(got, want) {
+		t.Errorf("cur/ = %v, want %v", got, want)
+	}
+	if got := listDir(t, filepath.Join(dir, "new")); !slices.Equal(got, []string{".1004.e.host.tmp"}) {
+		t.Errorf("new/ = %v, want only the hidden file", got)
+	}
+}
+
+func TestMaildirPollerRetriesTemporaryFailures(t *testing.T) {
+	tasks := &fakeTasks{err: errors.New("database is down")}
+	m, dir := newTestMaildir(t, tasks, 1024, map[string]string{
+		"1000.a.host": "From: alice@example.com\nTo: tasks@example.com\nSubject: First\n\nBody\n",
+	})
+	m.scan(context.Background())
+	if got := listDir(t, filepath.Join(dir, "new")); !slices.Equal(got, []string{"1000.a.host"}) {
+		t.Fatalf("new/ = %v, want the message kept for a retry", got)
+	}
+
+	tasks.err = nil
+	m.scan(context.Background())
+	if got := listDir(t, filepath.Join(dir, "cur")); !slices.Equal(got, []string{"1000.a.host:2,S"}) {
+		t.Errorf("cur/ = %v after the retry", got)
+	}
+	if len(tasks.added()) != 1 {
+		t.Errorf("%d tasks added, want 1", len(tasks.added()))
+	}
+}
+
+func TestNewMaildirPollerRequiresMaildir(t *testing.T) {
+	c := testConfig(1024)
+	c.InboundMaildir = t.TempDir()
+	_, err := NewMaildirPoller(MaildirPollerParams{Lifecycle: fxtest.NewLifecycle(t), Logger: zap.NewNop(), Config: c, Processor: newTestProcessor(&fakeTasks{})})
+	if err == nil {
+		t.Error("NewMaildirPoller accepted a directory without new/ and cur/")
+	}
+}
@@ -0,0 +1,290 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"path"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// maxParts bounds the MIME parts read from one message.
+const maxParts = 100
+
+// Message is the part of an email that becomes a task.
+type Message struct {
+	// From is the address of the From header.
+	From string
+	// Recipients are the addresses of the To, Cc and Delivered-To headers.
+	Recipients []string
+	Subject    string
+	// Body is the plain text of the message; HTML-only messages are converted to text.
+	Body        string
+	Attachments []Attachment
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charsetReader}
+
+// ParseMessage reads an RFC 5322 message with MIME parts.
+func ParseMessage(r io.Reader) (*Message, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("malformed message: %w", err)
+	}
+	from, err := addressList(msg.Header, "From")
+	if err != nil || len(from) == 0 {
+		return nil, fmt.Errorf("message has no valid From address")
+	}
+	m := &Message{From: from[0].Address}
+	for _, key := range []string{"To", "Cc", "Delivered-To"} {
+		addrs, _ := addressList(msg.Header, key)
+		for _, addr := range addrs {
+			m.Recipients = append(m.Recipients, addr.Address)
+		}
+	}
+	if m.Subject, err = wordDecoder.DecodeHeader(msg.Header.Get("Subject")); err != nil {
+		m.Subject = msg.Header.Get("Subject")
+	}
+
+	p := &parser{message: m}
+	if err := p.walk(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), "", msg.Body); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.text != "":
+		m.Body = p.text
+	case p.html != "":
+		m.Body = htmlToText(p.html)
+	}
+	m.Body = strings.TrimSpace(strings.ReplaceAll(m.Body, "\r\n", "\n"))
+	return m, nil
+}
+
+// addressList parses an address header, decoding encoded words in display names.
+func addressList(header mail.Header, key string) ([]*mail.Address, error) {
+	value := header.Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	parser := mail.AddressParser{WordDecoder: wordDecoder}
+	return parser.ParseList(value)
+}
+
+// parser collects the first text and HTML body and the attachments of a message.
+type parser struct {
+	message *Message
+	text    string
+	html    string
+	parts   int
+}
+
+// walk visits a MIME entity. transferEncoding is empty for the parts of a
+// multipart.Reader that already decoded quoted-printable.
+func (p *parser) walk(contentType, transferEncoding, disposition string, body io.Reader) error {
+	p.parts++
+	if p.parts > maxParts {
+		return fmt.Errorf("message has more than %d MIME parts", maxParts)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	body = decodeTransfer(transferEncoding, body)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("malformed MIME part: %w", err)
+			}
+			// NextPart decodes quoted-printable and removes the header.
+			encoding := part.Header.Get("Content-Transfer-Encoding")
+			if err := p.walk(part.Header.Get("Content-Type"), encoding, part.Header.Get("Content-Disposition"), part); err != nil {
+				return err
+			}
+		}
+	}
+
+	dispositionType, dispositionParams, _ := mime.ParseMediaType(disposition)
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if filename != "" || dispositionType == "attachment" || (mediaType != "text/plain" && mediaType != "text/html") {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			return fmt.Errorf("failed to read attachment: %w", err)
+		}
+		p.message.Attachments = append(p.message.Attachments, Attachment{
+			Filename:    attachmentName(filename, mediaType, len(p.message.Attachments)+1),
+			ContentType: mediaType,
+			Content:     content,
+		})
+		return nil
+	}
+
+	text, err := readText(body, params["charset"])
+	if err != nil {
+		return err
+	}
+	if mediaType == "text/html" {
+		if p.html == "" {
+			p.html = text
+		}
+	} else if p.text == "" {
+		p.text = text
+	}
+	return nil
+}
+
+// decodeTransfer undoes base64 and quoted-printable encodings.
+func decodeTransfer(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &whitespaceFilter{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// readText reads a text part and converts it from charset to UTF-8.
+func readText(body io.Reader, charset string) (string, error) {
+	reader, err := charsetReader(charset, body)
+	if err != nil {
+		reader = body
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read message text: %w", err)
+	}
+	return string(bytes.ToValidUTF8(data, []byte("�"))), nil
+}
+
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	charset = strings.ToLower(strings.TrimSpace(charset))
+	if charset == "" || charset == "utf-8" || charset == "us-ascii" {
+		return input, nil
+	}
+	encoding, err := htmlindex.Get(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	return encoding.NewDecoder().Reader(input), nil
+}
+
+// attachmentName returns a file name without directories, inventing one from the
+// media type for unnamed parts.
+func attachmentName(filename, mediaType string, n int) string {
+	if decoded, err := wordDecoder.DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	if filename == "." || filename == "/" || filename == "" {
+		filename = fmt.Sprintf("attachment-%d", n)
+		if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+			filename += exts[0]
+		}
+	}
+	if len(filename) > 255 {
+		filename = filename[len(filename)-255:]
+	}
+	return filename
+}
+
+// htmlToText returns the text of an HTML document with one line per block element.
+func htmlToText(document string) string {
+	var out strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// Runs of whitespace became single spaces; drop them around line breaks.
+			lines := strings.Split(out.String(), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimSpace(line)
+			}
+			return strings.Join(lines, "\n")
+		case html.TextToken:
+			if skip == 0 {
+				out.WriteString(collapseSpace(string(tokenizer.Text())))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "script", "style", "head":
+				if tt := tokenizer.Token().Type; tt == html.StartTagToken {
+					skip++
+				} else if tt == html.EndTagToken && skip > 0 {
+					skip--
+				}
+			case "br", "p", "div", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6":
+				out.WriteString("\n")
+			}
+		}
+	}
+}
+
+// collapseSpace replaces every run of whitespace in s with a single space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// whitespaceFilter drops the line breaks and spaces of base64 bodies, which the
+// decoder of encoding/base64 only partly tolerates.
+type whitespaceFilter struct {
+	r io.Reader
+}
+
+func (f *whitespaceFilter) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		kept := 0
+		for _, b := range p[:n] {
+			if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+				p[kept] = b
+				kept++
+			}
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Package inbound turns incoming emails into tasks.
+//
+// Messages arrive through the embedded SMTP receiver or a polled Maildir. The
+// sender must be on the allow-list of a workspace, which also names the assignee
+// of the task. The subject becomes the title, the text body the description and
+// every attachment a task attachment. A tag after a plus sign in the recipient
+// address, as in tasks+infra@example.com, selects the project.
+package inbound
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the processor and both message sources for FX.
+var Module = fx.Options(
+	fx.Provide(NewProcessor),
+	fx.Provide(NewSMTPReceiver),
+	fx.Provide(NewMaildirPoller),
+)
+
+const (
+	// maxTitleLength matches the width of tasks.title in characters.
+	maxTitleLength = 255
+	// maxDescriptionLength matches the size of tasks.description in bytes.
+	maxDescriptionLength = 65535
+	// maxProjectLength matches the width of tasks.project in characters.
+	maxProjectLength = 255
+)
+
+// RejectError reports a message that will never become a task, such as one from
+// an unknown sender. Delivering it again fails the same way.
+type RejectError struct {
+	Reason string
+}
+
+func (e *RejectError) Error() string {
+	return e.Reason
+}
+
+func reject(format string, args ...any) error {
+	return &RejectError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Processor creates tasks from raw messages.
+type Processor struct {
+	senders    repo.InboundRepository
+	tasks      repo.TaskRepository
+	workspaces repo.WorkspaceRepository
+	logger     *zap.Logger
+	local      string
+	domain     string
+}
+
+type ProcessorParams struct {
+	fx.In
+	Logger     *zap.Logger
+	Config     *cfg.Config
+	Senders    repo.InboundRepository
+	Tasks      repo.TaskRepository
+	Workspaces repo.WorkspaceRepository
+}
+
+// NewProcessor creates a processor for messages to the configured inbound address.
+func NewProcessor(p ProcessorParams) *Processor {
+	local, domain, _ := strings.Cut(p.Config.InboundAddress, "@")
+	return &Processor{
+		senders:    p.Senders,
+		tasks:      p.Tasks,
+		workspaces: p.Workspaces,
+		logger:     p.Logger.Named("inbound"),
+		local:      strings.ToLower(local),
+		domain:     strings.ToLower(domain),
+	}
+}
+
+// Accepts reports whether address is the inbound address, with or without a plus tag.
+func (p *Processor) Accepts(address string) bool {
+	_, ok := p.project(address)
+	return ok
+}
+
+// project returns the plus tag of address when address is the inbound address.
+func (p *Processor) project(address string) (string, bool) {
+	local, domain, ok := strings.Cut(address, "@")
+	if !ok || !strings.EqualFold(domain, p.domain) {
+		return "", false
+	}
+	local, tag, _ := strings.Cut(local, "+")
+	if !strings.EqualFold(local, p.local) {
+		return "", false
+	}
+	return tag, true
+}
+
+// Deliver creates a task from the raw message. recipients are the envelope
+// recipients; when empty, as for Maildir messages, the To, Cc and Delivered-To
+// headers are used instead. Permanent failures are returned as *RejectError.
+func (p *Processor) Deliver(ctx context.Context, recipients []string, raw []byte) (*pb.Task, error) {
+	msg, err := ParseMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, reject("%v", err)
+	}
+	logger := p.logger.With(zap.String("from", msg.From))
+
+	tenantID, sender, err := p.senders.LookupSender(ctx, msg.From)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reject("sender %s is not allowed to create tasks", msg.From)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up sender: %w", err)
+	}
+	workspace, err := p.workspaces.FetchWorkspaceByID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace: %w", err)
+	}
+	if workspace.GetStatus() != auth.WorkspaceStatusActive {
+		return nil, reject("workspace of sender %s is %s", msg.From, workspace.GetStatus())
+	}
+
+	if len(recipients) == 0 {
+		recipients = msg.Recipients
+	}
+	project := ""
+	for _, recipient := range recipients {
+		if tag, ok := p.project(recipient); ok {
+			project = tag
+			break
+		}
+	}
+	if utf8.RuneCountInString(project) > maxProjectLength {
+		return nil, reject("project %q is longer than %d characters", project, maxProjectLength)
+	}
+
+	task := &pb.Task{
+		Title:       taskTitle(msg.Subject),
+		Description: truncate(msg.Body, maxDescriptionLength),
+		Status:      "pending",
+		Assignee:    sender.GetAssignee(),
+		Project:     project,
+	}
+	ctx = tenant.WithID(ctx, tenantID)
+	var created *pb.Task
+	err = p.tasks.InTransaction(ctx, func(tx repo.TaskTx) error {
+		var err error
+		if created, err = tx.AddTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to add task: %w", err)
+		}
+		for _, attachment := range msg.Attachments {
+			_, err := tx.AddAttachment(ctx, &pb.Attachment{
+				TaskId:      created.GetId(),
+				Filename:    attachment.Filename,
+				ContentType: attachment.ContentType,
+				Content:     attachment.Content,
+			})
+			if errors.Is(err, repo.ErrAttachmentTooLarge) {
+				return reject("attachment %s is too large", attachment.Filename)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to add attachment %s: %w", attachment.Filename, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Created task from email", zap.String("tenant_id", tenantID), zap.String("task_id", created.GetId()),
+		zap.String("project", project), zap.Int("attachments", len(msg.Attachments)))
+	return created, nil
+}
+
+// taskTitle removes forwarding prefixes from subject and fits it into a title.
+func taskTitle(subject string) string {
+	subject = strings.Join(strings.Fields(subject), " ")
+	for {
+		lower := strings.ToLower(subject)
+		trimmed := false
+		for _, prefix := range []string{"fwd:", "fw:"} {
+			if strings.HasPrefix(lower, prefix) {
+				subject = strings.TrimSpace(subject[len(prefix):])
+				trimmed = true
+				break
+			}
+		}
+		if !trimmed {
+			break
+		}
+	}
+	if subject == "" {
+		return "(no subject)"
+	}
+	if utf8.RuneCountInString(subject) > maxTitleLength {
+		subject = string([]rune(subject)[:maxTitleLength])
+	}
+	return subject
+}
+
+// truncate shortens s to at most n bytes without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
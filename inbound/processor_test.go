@@ -0,0 +1,240 @@
+package inbound
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// fakeSenders allows alice@example.com in workspace 1 and bob@example.com in the
+// suspended workspace 2.
+type fakeSenders struct {
+	repo.InboundRepository
+}
+
+func (fakeSenders) LookupSender(ctx context.Context, email string) (string, *pb.InboundSender, error) {
+	switch strings.ToLower(email) {
+	case "alice@example.com":
+		return "1", &pb.InboundSender{Email: email, Assignee: "alice"}, nil
+	case "bob@example.com":
+		return "2", &pb.InboundSender{Email: email, Assignee: "bob"}, nil
+	}
+	return "", nil, sql.ErrNoRows
+}
+
+type fakeWorkspaces struct {
+	repo.WorkspaceRepository
+}
+
+func (fakeWorkspaces) FetchWorkspaceByID(ctx context.Context, workspaceID string) (*pb.Workspace, error) {
+	switch workspaceID {
+	case "1":
+		return &pb.Workspace{Id: "1", Name: "acme", Status: "active"}, nil
+	case "2":
+		return &pb.Workspace{Id: "2", Name: "globex", Status: "suspended"}, nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+// fakeTasks stores the tasks and attachments added in committed transactions.
+type fakeTasks struct {
+	repo.TaskRepository
+
+	mu          sync.Mutex
+	tasks       []*pb.Task
+	attachments []*pb.Attachment
+	// err, when set, fails every transaction.
+	err error
+}
+
+// fakeTaskTx collects the changes of one transaction.
+type fakeTaskTx struct {
+	repo.TaskTx
+	tenantID    string
+	tasks       []*pb.Task
+	attachments []*pb.Attachment
+}
+
+func (tx *fakeTaskTx) AddTask(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	if id, _ := tenant.IDFromContext(ctx); id != tx.tenantID {
+		return nil, errors.New("tenant changed within the transaction")
+	}
+	tx.tasks = append(tx.tasks, task)
+	return &pb.Task{Id: "t" + strconv.Itoa(len(tx.tasks)), Title: task.GetTitle()}, nil
+}
+
+func (tx *fakeTaskTx) AddAttachment(ctx context.Context, attachment *pb.Attachment) (*pb.Attachment, error) {
+	tx.attachments = append(tx.attachments, attachment)
+	return attachment, nil
+}
+
+func (r *fakeTasks) InTransaction(ctx context.Context, fn func(tx repo.TaskTx) error) error {
+	if r.err != nil {
+		return r.err
+	}
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return err
+	}
+	tx := &fakeTaskTx{tenantID: tenantID}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks = append(r.tasks, tx.tasks...)
+	r.attachments = append(r.attachments, tx.attachments...)
+	return nil
+}
+
+func (r *fakeTasks) added() []*pb.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*pb.Task(nil), r.tasks...)
+}
+
+// testConfig accepts mail to tasks@example.com of up to maxSize bytes.
+func testConfig(maxSize int) *cfg.Config {
+	return &cfg.Config{InboundAddress: "tasks@example.com", InboundMaxMessageSize: maxSize}
+}
+
+func newTestProcessor(tasks *fakeTasks) *Processor {
+	return NewProcessor(ProcessorParams{
+		Logger:     zap.NewNop(),
+		Config:     testConfig(1 << 20),
+		Senders:    fakeSenders{},
+		Tasks:      tasks,
+		Workspaces: fakeWorkspaces{},
+	})
+}
+
+// crlf converts the line endings of a message written in Go source to CRLF.
+func crlf(s string) string {
+	return strings.ReplaceAll(s, "\n", "\r\n")
+}
+
+const alternativeMessage = `From: =?UTF-8?Q?Alice_M=C3=BCller?= <alice@example.com>
+To: tasks+infra@example.com
+Subject: =?UTF-8?B?RndkOiBSZXBvcnQgw7xiZXJwcsO8ZmVu?=
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=iso-8859-1
+Content-Transfer-Encoding: quoted-printable
+
+Bitte bis Freitag pr=FCfen.
+--inner
+Content-Type: text/html; charset=utf-8
+
+<p>Bitte bis <b>Freitag</b> pr&uuml;fen.</p>
+--inner--
+
+--outer
+Content-Type: application/pdf; name="=?UTF-8?Q?Bericht_=C3=BC.pdf?="
+Content-Disposition: attachment; filename="../../Bericht.pdf"
+Content-Transfer-Encoding: base64
+
+JVBERi0xLjQK
+--outer--
+`
+
+func TestParseMessageMultipartAlternative(t *testing.T) {
+	msg, err := ParseMessage(strings.NewReader(crlf(alternativeMessage)))
+	if err != nil {
+		t.Fatalf("ParseMessage: %v", err)
+	}
+	if msg.From != "alice@example.com" {
+		t.Errorf("From = %q", msg.From)
+	}
+	if msg.Subject != "Fwd: Report überprüfen" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Body != "Bitte bis Freitag prüfen." {
+		t.Errorf("Body = %q, want the text/plain part in UTF-8", msg.Body)
+	}
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("%d attachments, want 1", len(msg.Attachments))
+	}
+	if a := msg.Attachments[0]; a.Filename != "Bericht.pdf" || a.ContentType != "application/pdf" || string(a.Content) != "%PDF-1.4\n" {
+		t.Errorf("attachment = %s (%s) %q", a.Filename, a.ContentType, a.Content)
+	}
+}
+
+func TestParseMessageEncodedWordsAndHTMLOnly(t *testing.T) {
+	raw := crlf(`From: alice@example.com
+To: tasks@example.com
+Subject: =?ISO-8859-1?Q?Caf=E9?= =?UTF-8?B?IOKAkyDDvGJlcg==?= Mittag
+Content-Type: text/html; charset=utf-8
+
+<html><body><h1>Agenda</h1><p>Punkt&nbsp;1</p><script>alert(1)</script></body></html>
+`)
+	msg, err := ParseMessage(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseMessage: %v", err)
+	}
+	if msg.Subject != "Café – über Mittag" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Body != "Agenda\n\nPunkt 1" {
+		t.Errorf("Body = %q, want the text of the HTML part", msg.Body)
+	}
+}
+
+func TestDeliverCreatesTask(t *testing.T) {
+	tasks := &fakeTasks{}
+	p := newTestProcessor(tasks)
+	if _, err := p.Deliver(context.Background(), nil, []byte(crlf(alternativeMessage))); err != nil {
+		t.Fatalf("Deliver: %v", err)
+	}
+	added := tasks.added()
+	if len(added) != 1 {
+		t.Fatalf("%d tasks added, want 1", len(added))
+	}
+	task := added[0]
+	if task.GetTitle() != "Report überprüfen" || task.GetAssignee() != "alice" || task.GetProject() != "infra" ||
+		task.GetStatus() != "pending" || task.GetDescription() != "Bitte bis Freitag prüfen." {
+		t.Errorf("task = %v", task)
+	}
+	if len(tasks.attachments) != 1 || tasks.attachments[0].GetTaskId() != "t1" {
+		t.Errorf("attachments = %v, want the PDF of task t1", tasks.attachments)
+	}
+}
+
+func TestDeliverRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"unknown sender", "From: mallory@example.com\nTo: tasks@example.com\nSubject: Hi\n\nBody\n"},
+		{"suspended workspace", "From: bob@example.com\nTo: tasks@example.com\nSubject: Hi\n\nBody\n"},
+		{"no From", "To: tasks@example.com\nSubject: Hi\n\nBody\n"},
+		{"long project", "From: alice@example.com\nTo: tasks+" + strings.Repeat("p", maxProjectLength+1) + "@example.com\nSubject: Hi\n\nBody\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &fakeTasks{}
+			_, err := newTestProcessor(tasks).Deliver(context.Background(), nil, []byte(crlf(tt.raw)))
+			var rejected *RejectError
+			if !errors.As(err, &rejected) {
+				t.Fatalf("Deliver returned %v, want a RejectError", err)
+			}
+			if len(tasks.added()) != 0 {
+				t.Error("a rejected message created a task")
+			}
+		})
+	}
+}
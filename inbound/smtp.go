@@ -0,0 +1,342 @@
+package inbound
+
+import (
+	cfg "Go_Test/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	// commandTimeout bounds the wait for the next SMTP command.
+	commandTimeout = 5 * time.Minute
+	// dataTimeout bounds the transfer of one message.
+	dataTimeout = 10 * time.Minute
+	// maxRecipients bounds the RCPT commands of one message.
+	maxRecipients = 100
+)
+
+// SMTPReceiver is a minimal SMTP server that accepts mail for the inbound address
+// only. It does not relay, authenticate or offer TLS; it is meant to receive mail
+// from a trusted MTA that checks SPF and DKIM.
+type SMTPReceiver struct {
+	processor *Processor
+	logger    *zap.Logger
+	hostname  string
+	maxSize   int
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+type SMTPReceiverParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *cfg.Config
+	Processor *Processor
+}
+
+// NewSMTPReceiver creates the SMTP receiver and, when inbound_smtp_address is set,
+// serves it for the lifetime of the FX app.
+func NewSMTPReceiver(p SMTPReceiverParams) *SMTPReceiver {
+	_, domain, _ := strings.Cut(p.Config.InboundAddress, "@")
+	s := &SMTPReceiver{
+		processor: p.Processor,
+		logger:    p.Logger.Named("inbound.smtp"),
+		hostname:  domain,
+		maxSize:   p.Config.InboundMaxMessageSize,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	if p.Config.InboundSMTPAddress == "" {
+		return s
+	}
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			listener, err := net.Listen("tcp", p.Config.InboundSMTPAddress)
+			if err != nil {
+				return fmt.Errorf("failed to listen for inbound email on %s: %w", p.Config.InboundSMTPAddress, err)
+			}
+			s.logger.Info("Starting SMTP receiver", zap.String("address", listener.Addr().String()),
+				zap.String("inbound_address", p.Config.InboundAddress))
+			s.mu.Lock()
+			s.listener = listener
+			s.mu.Unlock()
+			s.wg.Add(1)
+			go s.serve(listener)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			s.logger.Info("Stopping SMTP receiver")
+			s.close()
+			done := make(chan struct{})
+			go func() {
+				s.wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+	return s
+}
+
+// close stops accepting connections and closes the open ones.
+func (s *SMTPReceiver) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+func (s *SMTPReceiver) serve(listener net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.logger.Error("Failed to accept SMTP connection", zap.Error(err))
+			}
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			s.handle(conn)
+		}()
+	}
+}
+
+// session is the state of one SMTP connection.
+type session struct {
+	conn       net.Conn
+	text       *textproto.Conn
+	logger     *zap.Logger
+	greeted    bool
+	from       string
+	hasFrom    bool
+	recipients []string
+}
+
+func (s *SMTPReceiver) handle(conn net.Conn) {
+	sess := &session{
+		conn:   conn,
+		text:   textproto.NewConn(conn),
+		logger: s.logger.With(zap.String("remote", conn.RemoteAddr().String())),
+	}
+	if !sess.reply(220, "%s ESMTP task receiver ready", s.hostname) {
+		return
+	}
+	for {
+		conn.SetReadDeadline(time.Now().Add(commandTimeout))
+		line, err := sess.text.ReadLine()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				sess.logger.Debug("SMTP connection ended", zap.Error(err))
+			}
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		verb = strings.ToUpper(verb)
+		arg = strings.TrimSpace(arg)
+		var ok bool
+		switch verb {
+		case "EHLO":
+			sess.greeted = true
+			sess.resetTransaction()
+			ok = sess.replyLines(250, s.hostname, fmt.Sprintf("SIZE %d", s.maxSize), "8BITMIME", "PIPELINING")
+		case "HELO":
+			sess.greeted = true
+			sess.resetTransaction()
+			ok = sess.reply(250, "%s", s.hostname)
+		case "MAIL":
+			ok = s.mail(sess, arg)
+		case "RCPT":
+			ok = s.rcpt(sess, arg)
+		case "DATA":
+			ok = s.data(sess)
+		case "RSET":
+			sess.resetTransaction()
+			ok = sess.reply(250, "OK")
+		case "NOOP":
+			ok = sess.reply(250, "OK")
+		case "VRFY":
+			ok = sess.reply(252, "Cannot verify users")
+		case "QUIT":
+			sess.reply(221, "Bye")
+			return
+		default:
+			ok = sess.reply(502, "Command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+func (sess *session) resetTransaction() {
+	sess.from, sess.hasFrom, sess.recipients = "", false, nil
+}
+
+func (s *SMTPReceiver) mail(sess *session, arg string) bool {
+	switch {
+	case !sess.greeted:
+		return sess.reply(503, "Send EHLO first")
+	case sess.hasFrom:
+		return sess.reply(503, "Sender already given")
+	}
+	path, params, ok := parsePath(arg, "FROM:")
+	if !ok {
+		return sess.reply(501, "Syntax: MAIL FROM:<address>")
+	}
+	for _, param := range params {
+		name, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(name, "SIZE") {
+			var size int
+			if _, err := fmt.Sscan(value, &size); err == nil && size > s.maxSize {
+				return sess.reply(552, "Message exceeds %d bytes", s.maxSize)
+			}
+		}
+	}
+	sess.from, sess.hasFrom = path, true
+	return sess.reply(250, "OK")
+}
+
+func (s *SMTPReceiver) rcpt(sess *session, arg string) bool {
+	if !sess.hasFrom {
+		return sess.reply(503, "Send MAIL first")
+	}
+	path, _, ok := parsePath(arg, "TO:")
+	if !ok || path == "" {
+		return sess.reply(501, "Syntax: RCPT TO:<address>")
+	}
+	if !s.processor.Accepts(path) {
+		return sess.reply(550, "No such mailbox: %s", path)
+	}
+	if len(sess.recipients) >= maxRecipients {
+		return sess.reply(452, "Too many recipients")
+	}
+	sess.recipients = append(sess.recipients, path)
+	return sess.reply(250, "OK")
+}
+
+func (s *SMTPReceiver) data(sess *session) bool {
+	if len(sess.recipients) == 0 {
+		return sess.reply(503, "Send RCPT first")
+	}
+	if !sess.reply(354, "End data with <CR><LF>.<CR><LF>") {
+		return false
+	}
+	sess.conn.SetReadDeadline(time.Now().Add(dataTimeout))
+	reader := sess.text.DotReader()
+	raw, err := io.ReadAll(io.LimitReader(reader, int64(s.maxSize)+1))
+	if err != nil {
+		return false
+	}
+	from, recipients := sess.from, sess.recipients
+	sess.resetTransaction()
+	if len(raw) > s.maxSize {
+		// Drain the rest of the message so the connection stays in sync.
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			return false
+		}
+		return sess.reply(552, "Message exceeds %d bytes", s.maxSize)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	task, err := s.processor.Deliver(ctx, recipients, raw)
+	var rejected *RejectError
+	switch {
+	case errors.As(err, &rejected):
+		sess.logger.Info("Rejected inbound email", zap.String("mail_from", from), zap.String("reason", rejected.Reason))
+		return sess.reply(550, "%s", rejected.Reason)
+	case err != nil:
+		sess.logger.Error("Failed to create task from email", zap.String("mail_from", from), zap.Error(err))
+		return sess.reply(451, "Temporary failure; try again later")
+	}
+	return sess.reply(250, "Created task %s", task.GetId())
+}
+
+// parsePath parses the argument of MAIL and RCPT, "FROM:<path> params".
+func parsePath(arg, prefix string) (string, []string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	fields := strings.Fields(strings.TrimSpace(arg[len(prefix):]))
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "<") || !strings.HasSuffix(fields[0], ">") {
+		return "", nil, false
+	}
+	path := fields[0][1 : len(fields[0])-1]
+	if path == "" {
+		return "", fields[1:], true
+	}
+	addr, err := mail.ParseAddress("<" + path + ">")
+	if err != nil {
+		return "", nil, false
+	}
+	return addr.Address, fields[1:], true
+}
+
+func (sess *session) reply(code int, format string, args ...any) bool {
+	sess.conn.SetWriteDeadline(time.Now().Add(commandTimeout))
+	if err := sess.text.PrintfLine("%d %s", code, fmt.Sprintf(format, args...)); err != nil {
+		sess.logger.Debug("Failed to write SMTP reply", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// replyLines writes a multiline reply.
+func (sess *session) replyLines(code int, lines ...string) bool {
+	sess.conn.SetWriteDeadline(time.Now().Add(commandTimeout))
+	w := sess.text.W
+	for i, line := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		fmt.Fprintf(w, "%d%s%s\r\n", code, sep, line)
+	}
+	if err := w.Flush(); err != nil {
+		sess.logger.Debug("Failed to write SMTP reply", zap.Error(err))
+		return false
+	}
+	return true
+}
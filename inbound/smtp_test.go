@@ -0,0 +1,148 @@
+package inbound
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/zap"
+)
+
+// startTestReceiver serves an SMTPReceiver on a loopback port for the test.
+func startTestReceiver(t *testing.T, tasks *fakeTasks, maxSize int) string {
+	t.Helper()
+	c := testConfig(maxSize)
+	c.InboundSMTPAddress = "127.0.0.1:0"
+	lc := fxtest.NewLifecycle(t)
+	processor := newTestProcessor(tasks)
+	s := NewSMTPReceiver(SMTPReceiverParams{Lifecycle: lc, Logger: zap.NewNop(), Config: c, Processor: processor})
+	lc.RequireStart()
+	t.Cleanup(lc.RequireStop)
+	return s.listener.Addr().String()
+}
+
+// smtpCode returns the reply code of an SMTP error, or 0.
+func smtpCode(err error) int {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code
+	}
+	return 0
+}
+
+func TestSMTPReceiverCreatesTask(t *testing.T) {
+	tasks := &fakeTasks{}
+	addr := startTestReceiver(t, tasks, 1<<20)
+	err := smtp.SendMail(addr, nil, "alice@example.com", []string{"tasks+infra@example.com"}, []byte(crlf(alternativeMessage)))
+	if err != nil {
+		t.Fatalf("SendMail: %v", err)
+	}
+	added := tasks.added()
+	if len(added) != 1 || added[0].GetProject() != "infra" || added[0].GetTitle() != "Report überprüfen" {
+		t.Fatalf("tasks = %v, want the task of the message in project infra", added)
+	}
+}
+
+func TestSMTPReceiverRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		body     string
+		wantCode int
+	}{
+		{name: "other recipient", from: "alice@example.com", to: "someone@example.com", wantCode: 550},
+		{name: "unknown sender", from: "mallory@example.com", to: "tasks@example.com", wantCode: 550},
+		{name: "suspended workspace", from: "bob@example.com", to: "tasks@example.com", wantCode: 550},
+		{name: "oversized message", from: "alice@example.com", to: "tasks@example.com", body: strings.Repeat("x", 2048), wantCode: 552},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &fakeTasks{}
+			addr := startTestReceiver(t, tasks, 1024)
+			raw := crlf(fmt.Sprintf("From: %s\nTo: tasks@example.com\nSubject: Hi\n\n%s\n", tt.from, tt.body))
+			err := smtp.SendMail(addr, nil, tt.from, []string{tt.to}, []byte(raw))
+			if code := smtpCode(err); code != tt.wantCode {
+				t.Fatalf("SendMail returned %v, want code %d", err, tt.wantCode)
+			}
+			if len(tasks.added()) != 0 {
+				t.Error("a rejected message created a task")
+			}
+		})
+	}
+}
+
+func TestSMTPReceiverTemporaryFailure(t *testing.T) {
+	tasks := &fakeTasks{err: errors.New("database is down")}
+	addr := startTestReceiver(t, tasks, 1<<20)
+	err := smtp.SendMail(addr, nil, "alice@example.com", []string{"tasks@example.com"},
+		[]byte(crlf("From: alice@example.com\nTo: tasks@example.com\nSubject: Hi\n\nBody\n")))
+	if code := smtpCode(err); code != 451 {
+		t.Fatalf("SendMail returned %v, want code 451 so that the sender retries", err)
+	}
+}
+
+func TestSMTPReceiverSizeParameter(t *testing.T) {
+	addr := startTestReceiver(t, &fakeTasks{}, 1024)
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if err := client.Hello("client.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, size := client.Extension("SIZE"); !ok || size != "1024" {
+		t.Errorf("SIZE extension = %v %q, want 1024", ok, size)
+	}
+	id, err := client.Text.Cmd("MAIL FROM:<alice@example.com> SIZE=4096")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Text.StartResponse(id)
+	code, _, _ := client.Text.ReadResponse(250)
+	client.Text.EndResponse(id)
+	if code != 552 {
+		t.Errorf("MAIL with SIZE=4096: code %d, want 552", code)
+	}
+}
+
+func TestSMTPReceiverContinuesAfterOversizedMessage(t *testing.T) {
+	tasks := &fakeTasks{}
+	addr := startTestReceiver(t, tasks, 1024)
+	client, err := smtp.Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	send := func(body string) error {
+		if err := client.Mail("alice@example.com"); err != nil {
+			return err
+		}
+		if err := client.Rcpt("tasks@example.com"); err != nil {
+			return err
+		}
+		w, err := client.Data()
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprint(w, crlf("From: alice@example.com\nTo: tasks@example.com\nSubject: Hi\n\n"+body+"\n")); err != nil {
+			return err
+		}
+		return w.Close()
+	}
+	if code := smtpCode(send(strings.Repeat("line of text\n", 200))); code != 552 {
+		t.Fatalf("oversized message: code %d, want 552", code)
+	}
+	// The rest of the oversized message was read, so the session continues.
+	if err := send("Small"); err != nil {
+		t.Fatalf("message after the oversized one: %v", err)
+	}
+	if len(tasks.added()) != 1 {
+		t.Errorf("%d tasks added, want 1", len(tasks.added()))
+	}
+}
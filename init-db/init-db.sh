@@ -4,19 +4,185 @@ set -e
 SQL_COMMANDS=$(cat <<-END
 USE ${MYSQL_DATABASE};
 
+CREATE TABLE IF NOT EXISTS workspaces (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(50) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    workspace_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
 CREATE TABLE IF NOT EXISTS tasks (
     id INT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id INT NOT NULL,
     title VARCHAR(255) NOT NULL,
     description TEXT,
     status VARCHAR(50) DEFAULT 'pending',
+    due_at DATETIME NULL,
+    priority VARCHAR(20) NOT NULL DEFAULT '',
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    project VARCHAR(255) NOT NULL DEFAULT '',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    completed_at DATETIME NULL,
+    version INT NOT NULL DEFAULT 1,
+    INDEX idx_tasks_tenant_created (tenant_id, created_at),
+    INDEX idx_tasks_tenant_due (tenant_id, due_at),
+    FULLTEXT INDEX ft_tasks_title_description (title, description),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id INT NOT NULL,
+    tag VARCHAR(100) NOT NULL,
+    PRIMARY KEY (task_id, tag),
+    INDEX idx_task_tags_tag (tag),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS task_attachments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    task_id INT NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size INT NOT NULL,
+    content MEDIUMBLOB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_task_attachments_task (task_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- outbox_events is written in the transaction of every task change. The event relay
+-- publishes events to the event bus (published_at) and the webhook dispatcher fans
+-- them out to webhook_deliveries (dispatched_at).
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    task_id INT NOT NULL,
+    payload JSON NOT NULL,
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    dispatched_at TIMESTAMP(3) NULL,
+    published_at TIMESTAMP(3) NULL,
+    INDEX idx_outbox_events_dispatched (dispatched_at, id),
+    INDEX idx_outbox_events_published (published_at, id),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_webhooks_tenant (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id INT NOT NULL,
+    event_id BIGINT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_webhook_deliveries_next_attempt (next_attempt_at),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES outbox_events(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id INT NOT NULL,
+    event_id BIGINT NOT NULL,
+    attempts INT NOT NULL,
+    last_error TEXT,
+    failed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE,
+    FOREIGN KEY (event_id) REFERENCES outbox_events(id) ON DELETE CASCADE
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
-INSERT INTO tasks (title, description, status) VALUES
-('Setup Docker environment', 'Configure Dockerfile and Docker Compose for the project', 'completed'),
-('Implement gRPC User Service', 'Create gRPC service for user management', 'pending'),
-('Write unit tests', 'Add unit tests for critical components', 'todo')
+-- notification_preferences holds the email address and the enabled emails of each
+-- assignee. last_digest_on is the day, in time_zone, of the last daily digest.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    tenant_id INT NOT NULL,
+    assignee VARCHAR(255) NOT NULL,
+    email VARCHAR(320) NOT NULL,
+    reminders BOOLEAN NOT NULL DEFAULT TRUE,
+    digest BOOLEAN NOT NULL DEFAULT FALSE,
+    time_zone VARCHAR(64) NOT NULL DEFAULT '',
+    last_digest_on DATE NULL,
+    PRIMARY KEY (tenant_id, assignee),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- task_reminders records the reminders sent, one per task and due date, so that a
+-- task whose due date moves is reminded again.
+CREATE TABLE IF NOT EXISTS task_reminders (
+    task_id INT NOT NULL,
+    due_at DATETIME NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, due_at),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- caldav_objects keeps the resource names and UIDs of the tasks that CalDAV clients
+-- created. Other tasks are served as task-<id>.ics with a UID derived from their ID.
+CREATE TABLE IF NOT EXISTS caldav_objects (
+    task_id INT PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    name VARCHAR(255) COLLATE utf8mb4_bin NOT NULL,
+    uid VARCHAR(1024) NOT NULL,
+    UNIQUE INDEX idx_caldav_objects_name (tenant_id, name),
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- inbound_senders is the allow-list of the inbound email receiver. The sender's
+-- address, stored in lower case, selects the workspace and the assignee of the task.
+CREATE TABLE IF NOT EXISTS inbound_senders (
+    email VARCHAR(320) NOT NULL PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_inbound_senders_tenant (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- calendar_feeds are the iCalendar feeds served by the REST gateway. Like API
+-- tokens, the secret of a feed URL is stored as its SHA-256 hash only.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    tenant_id INT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    assignee VARCHAR(255) NOT NULL DEFAULT '',
+    project VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_calendar_feeds_token (token_hash),
+    INDEX idx_calendar_feeds_tenant (tenant_id),
+    FOREIGN KEY (tenant_id) REFERENCES workspaces(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- The default workspace is reachable with the development token "dev-token".
+INSERT INTO workspaces (id, name, status) VALUES (1, 'default', 'active')
+ON DUPLICATE KEY UPDATE name=VALUES(name);
+
+INSERT INTO api_tokens (workspace_id, token_hash) VALUES (1, SHA2('dev-token', 256))
+ON DUPLICATE KEY UPDATE workspace_id=VALUES(workspace_id);
+
+INSERT INTO tasks (tenant_id, title, description, status, completed_at) VALUES
+(1, 'Setup Docker environment', 'Configure Dockerfile and Docker Compose for the project', 'completed', CURRENT_TIMESTAMP),
+(1, 'Implement gRPC User Service', 'Create gRPC service for user management', 'pending', NULL),
+(1, 'Write unit tests', 'Add unit tests for critical components', 'todo', NULL)
 ON DUPLICATE KEY UPDATE title=VALUES(title);
 
 END
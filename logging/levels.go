@@ -0,0 +1,156 @@
+package logging
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultLevel is the level that removes the override of a logger, which then
+// follows the default level again.
+const DefaultLevel = "default"
+
+// Levels holds the default log level and per-package overrides.
+// Packages are identified by the name of their logger (see zap.Logger.Named);
+// an override for "repository" also applies to "repository.sub".
+// All methods are safe for concurrent use, so levels can be changed at runtime.
+type Levels struct {
+	mu   sync.RWMutex
+	root zap.AtomicLevel
+	// overrides is sorted by descending length of the name, so that the first
+	// match is the most specific; Set keeps it sorted for the checks of every entry.
+	overrides []override
+}
+
+// override is the level of a named logger and the loggers below it.
+type override struct {
+	name  string
+	level zapcore.Level
+}
+
+// NewLevels creates Levels from a default level and a comma-separated
+// list of "name=level" overrides.
+func NewLevels(defaultLevel string, overrides string) (*Levels, error) {
+	root, err := zap.ParseAtomicLevel(defaultLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", defaultLevel, err)
+	}
+	l := &Levels{root: root}
+	for _, pair := range strings.Split(overrides, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, level, found := strings.Cut(pair, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid log level override %q, expected name=level", pair)
+		}
+		if err := l.Set(strings.TrimSpace(name), strings.TrimSpace(level)); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Set changes the level of the named logger, or the default level when name is
+// empty. An empty level or DefaultLevel removes the override of the named logger.
+func (l *Levels) Set(name string, level string) error {
+	reset := level == "" || level == DefaultLevel
+	if reset && name == "" {
+		return fmt.Errorf("invalid log level %q: the default level cannot be removed", level)
+	}
+	var parsed zapcore.Level
+	if !reset {
+		var err error
+		if parsed, err = zapcore.ParseLevel(level); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if name == "" {
+		l.root.SetLevel(parsed)
+		return nil
+	}
+	overrides := slices.DeleteFunc(slices.Clone(l.overrides), func(o override) bool { return o.name == name })
+	if !reset {
+		overrides = append(overrides, override{name: name, level: parsed})
+	}
+	slices.SortFunc(overrides, func(a, b override) int {
+		if n := cmp.Compare(len(b.name), len(a.name)); n != 0 {
+			return n
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	l.overrides = overrides
+	return nil
+}
+
+// Snapshot returns the effective levels, keyed by logger name; the default level uses the key "".
+func (l *Levels) Snapshot() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := map[string]string{"": l.root.Level().String()}
+	for _, o := range l.overrides {
+		levels[o.name] = o.level.String()
+	}
+	return levels
+}
+
+// Enabled reports whether an entry at level should be written by the named logger.
+func (l *Levels) Enabled(name string, level zapcore.Level) bool {
+	return level >= l.levelFor(name)
+}
+
+// minLevel returns the lowest level that any logger may write.
+func (l *Levels) minLevel() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	lowest := l.root.Level()
+	for _, o := range l.overrides {
+		if o.level < lowest {
+			lowest = o.level
+		}
+	}
+	return lowest
+}
+
+// levelFor returns the level of the most specific override matching name.
+func (l *Levels) levelFor(name string) zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if name != "" {
+		for _, o := range l.overrides {
+			if name == o.name || strings.HasPrefix(name, o.name+".") {
+				return o.level
+			}
+		}
+	}
+	return l.root.Level()
+}
+
+// levelsCore filters entries by the level configured for their logger name.
+type levelsCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelsCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.minLevel()
+}
+
+func (c *levelsCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelsCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelsCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.Enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
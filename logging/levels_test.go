@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelsMostSpecificOverride(t *testing.T) {
+	levels, err := NewLevels("info", "repository=debug, repository.search=error, server=warn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want zapcore.Level
+	}{
+		{"", zapcore.InfoLevel},
+		{"auth", zapcore.InfoLevel},
+		{"repository", zapcore.DebugLevel},
+		{"repository.tx", zapcore.DebugLevel},
+		{"repository.search", zapcore.ErrorLevel},
+		{"repository.search.bleve", zapcore.ErrorLevel},
+		{"repositoryx", zapcore.InfoLevel},
+		{"server", zapcore.WarnLevel},
+	}
+	for _, tt := range tests {
+		if got := levels.levelFor(tt.name); got != tt.want {
+			t.Errorf("levelFor(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := levels.minLevel(); got != zapcore.DebugLevel {
+		t.Errorf("minLevel() = %v, want debug", got)
+	}
+}
+
+func TestLevelsSetAndReset(t *testing.T) {
+	levels, err := NewLevels("info", "repository=debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := levels.Set("repository.search", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	if err := levels.Set("repository", "error"); err != nil {
+		t.Fatal(err)
+	}
+	if got := levels.levelFor("repository.search"); got != zapcore.WarnLevel {
+		t.Errorf("levelFor(repository.search) = %v, want warn", got)
+	}
+	if got := levels.levelFor("repository"); got != zapcore.ErrorLevel {
+		t.Errorf("levelFor(repository) = %v after Set, want error", got)
+	}
+
+	if err := levels.Set("repository.search", DefaultLevel); err != nil {
+		t.Fatalf("Set(%q): %v", DefaultLevel, err)
+	}
+	if got := levels.levelFor("repository.search"); got != zapcore.ErrorLevel {
+		t.Errorf("levelFor(repository.search) = %v after the reset, want the error level of repository", got)
+	}
+	if err := levels.Set("repository", ""); err != nil {
+		t.Fatalf("Set with an empty level: %v", err)
+	}
+	if got := levels.levelFor("repository"); got != zapcore.InfoLevel {
+		t.Errorf("levelFor(repository) = %v after the reset, want the default info", got)
+	}
+	if snapshot := levels.Snapshot(); len(snapshot) != 1 || snapshot[""] != "info" {
+		t.Errorf("Snapshot() = %v, want only the default level", snapshot)
+	}
+	// Removing a level that was never set changes nothing.
+	if err := levels.Set("server", DefaultLevel); err != nil {
+		t.Errorf("Set of an unknown logger to %q: %v", DefaultLevel, err)
+	}
+
+	if err := levels.Set("", DefaultLevel); err == nil {
+		t.Errorf("Set of the default level to %q succeeded, want an error", DefaultLevel)
+	}
+	if err := levels.Set("server", "loud"); err == nil {
+		t.Error("Set with an invalid level succeeded")
+	}
+	if err := levels.Set("", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	if got := levels.levelFor("server"); got != zapcore.WarnLevel {
+		t.Errorf("levelFor(server) = %v, want the new default warn", got)
+	}
+}
@@ -0,0 +1,94 @@
+package logging
+
+import (
+	cfg "Go_Test/config"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Module exports the logger and its runtime-adjustable levels for FX,
+// and routes FX's own event log through the logger at debug level.
+var Module = fx.Options(
+	fx.Provide(NewLogger),
+	fx.WithLogger(func(logger *zap.Logger) fxevent.Logger {
+		fxLogger := &fxevent.ZapLogger{Logger: logger.Named("fx")}
+		fxLogger.UseLogLevel(zapcore.DebugLevel)
+		return fxLogger
+	}),
+)
+
+type LoggerParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Config    *cfg.Config
+}
+
+type LoggerResult struct {
+	fx.Out
+	Logger *zap.Logger
+	Levels *Levels
+}
+
+// NewLogger builds a zap logger from the logging configuration: encoding, default and
+// per-package levels, sampling and an optional rotated output file.
+func NewLogger(p LoggerParams) (LoggerResult, error) {
+	levels, err := NewLevels(p.Config.LogLevel, p.Config.LogLevels)
+	if err != nil {
+		return LoggerResult{}, err
+	}
+
+	var encoder zapcore.Encoder
+	switch p.Config.LogFormat {
+	case "json":
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+	case "console", "":
+		encoderConfig := zap.NewDevelopmentEncoderConfig()
+		if p.Config.LogFile == "" {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+	default:
+		return LoggerResult{}, fmt.Errorf("unknown log format %q (want console or json)", p.Config.LogFormat)
+	}
+
+	var sink zapcore.WriteSyncer = zapcore.Lock(os.Stderr)
+	if p.Config.LogFile != "" {
+		rotator := &lumberjack.Logger{
+			Filename:   p.Config.LogFile,
+			MaxSize:    p.Config.LogFileMaxSizeMB,
+			MaxBackups: p.Config.LogFileMaxBackups,
+			MaxAge:     p.Config.LogFileMaxAgeDays,
+		}
+		sink = zapcore.AddSync(rotator)
+		p.Lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return rotator.Close()
+			},
+		})
+	}
+
+	core := zapcore.NewCore(encoder, sink, zap.DebugLevel)
+	if p.Config.LogSamplingInitial > 0 {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, p.Config.LogSamplingInitial, p.Config.LogSamplingThereafter)
+	}
+	core = &levelsCore{Core: core, levels: levels}
+
+	logger := zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel))
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			_ = logger.Sync()
+			return nil
+		},
+	})
+	return LoggerResult{Logger: logger, Levels: levels}, nil
+}
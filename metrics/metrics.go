@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports providers for the Prometheus registry, gRPC metrics and the /metrics HTTP server for FX.
+var Module = fx.Options(
+	fx.Provide(NewRegistry),
+	fx.Provide(NewGRPCMetrics),
+	fx.Provide(NewServer),
+	fx.Invoke(RegisterDBCollectors),
+)
+
+// NewRegistry creates a Prometheus registry with the standard Go runtime and process collectors.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+type DBCollectorParams struct {
+	fx.In
+	Registry  *prometheus.Registry
+	DB        *sql.DB
+	Config    *cfg.Config
+	StatsRepo repo.StatsRepository
+	Logger    *zap.Logger
+}
+
+// RegisterDBCollectors registers the database/sql pool statistics and the task domain gauges.
+func RegisterDBCollectors(p DBCollectorParams) error {
+	if err := p.Registry.Register(collectors.NewDBStatsCollector(p.DB, p.Config.DBName)); err != nil {
+		return fmt.Errorf("failed to register database stats collector: %w", err)
+	}
+	if err := p.Registry.Register(newTaskCollector(p.StatsRepo, p.Logger.Named("metrics"))); err != nil {
+		return fmt.Errorf("failed to register task collector: %w", err)
+	}
+	return nil
+}
+
+// Server is the HTTP server that exposes the /metrics endpoint.
+type Server struct {
+	*http.Server
+}
+
+type ServerParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *cfg.Config
+	Registry  *prometheus.Registry
+}
+
+// NewServer creates the /metrics HTTP server and ties it to the FX lifecycle.
+// When MetricsAddress is empty the server is created but never started.
+func NewServer(p ServerParams) *Server {
+	logger := p.Logger.Named("metrics")
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(p.Registry, promhttp.HandlerOpts{Registry: p.Registry}))
+	server := &Server{Server: &http.Server{Addr: p.Config.MetricsAddress, Handler: mux}}
+
+	if p.Config.MetricsAddress == "" {
+		logger.Info("Metrics endpoint disabled")
+		return server
+	}
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting metrics server", zap.String("address", p.Config.MetricsAddress))
+			lis, err := net.Listen("tcp", p.Config.MetricsAddress)
+			if err != nil {
+				logger.Error("Failed to listen for metrics", zap.Error(err))
+				return fmt.Errorf("failed to listen for metrics: %w", err)
+			}
+			go func() {
+				if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("Metrics server failed to serve", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Stopping metrics server")
+			if err := server.Shutdown(ctx); err != nil {
+				logger.Error("Failed to stop metrics server", zap.Error(err))
+				return err
+			}
+			logger.Info("Metrics server stopped")
+			return nil
+		},
+	})
+	return server
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	repo "Go_Test/repository"
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// taskCollector exports the number of tasks per tenant and status at scrape time.
+type taskCollector struct {
+	statsRepo repo.StatsRepository
+	logger    *zap.Logger
+	tasks     *prometheus.Desc
+	up        *prometheus.Desc
+}
+
+func newTaskCollector(statsRepo repo.StatsRepository, logger *zap.Logger) prometheus.Collector {
+	return &taskCollector{
+		statsRepo: statsRepo,
+		logger:    logger,
+		tasks: prometheus.NewDesc("tasks",
+			"Number of tasks by tenant and status.",
+			[]string{"tenant_id", "status"}, nil),
+		up: prometheus.NewDesc("tasks_scrape_success",
+			"Whether the last task count query succeeded (1) or failed (0).",
+			nil, nil),
+	}
+}
+
+func (c *taskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.up
+}
+
+func (c *taskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	counts, err := c.statsRepo.CountTasksByStatus(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to collect task metrics", zap.Error(err))
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0)
+		return
+	}
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(count.Count), count.TenantID, count.Status)
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1)
+}
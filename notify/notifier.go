@@ -0,0 +1,238 @@
+// Package notify sends task notifications by email.
+//
+// Assignees receive a reminder shortly before the due date of each of their open
+// tasks and, once a day, a digest of their open and overdue tasks. Both are opt-in
+// per assignee through the notification preferences of the workspace, and both are
+// rendered from the text and HTML templates embedded from templates/.
+package notify
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the Notifier for FX.
+var Module = fx.Options(
+	fx.Provide(NewNotifier),
+)
+
+const (
+	// pollInterval is the pause between two checks for due reminders and digests.
+	pollInterval = 30 * time.Second
+	// reminderBatchSize bounds the reminders sent per check.
+	reminderBatchSize = 100
+)
+
+// ErrDisabled is returned by SendDigest when no SMTP server is configured.
+var ErrDisabled = errors.New("email notifications are disabled; set smtp_host to enable them")
+
+// Notifier sends reminders and digests. Several servers can run one against the
+// same database: every reminder and digest is claimed in the database before it
+// is sent.
+type Notifier struct {
+	repo       repo.NotificationRepository
+	tasks      repo.TaskRepository
+	mailer     Mailer
+	logger     *zap.Logger
+	lead       time.Duration
+	digestHour int
+}
+
+type NotifierParams struct {
+	fx.In
+	Lifecycle  fx.Lifecycle
+	Logger     *zap.Logger
+	Config     *cfg.Config
+	Repository repo.NotificationRepository
+	Tasks      repo.TaskRepository
+}
+
+// NewNotifier creates the notifier and, when smtp_host is set, runs its schedule
+// for the lifetime of the FX app.
+func NewNotifier(p NotifierParams) (*Notifier, error) {
+	n := &Notifier{
+		repo:       p.Repository,
+		tasks:      p.Tasks,
+		logger:     p.Logger.Named("notify"),
+		lead:       time.Duration(p.Config.ReminderLeadMinutes) * time.Minute,
+		digestHour: p.Config.DigestHour,
+	}
+	if p.Config.SMTPHost == "" {
+		n.logger.Info("Email notifications are disabled; smtp_host is not set")
+		return n, nil
+	}
+	mailer, err := NewSMTPMailer(p.Config)
+	if err != nil {
+		return nil, err
+	}
+	n.mailer = mailer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			n.logger.Info("Starting email notifier", zap.String("smtp_host", p.Config.SMTPHost),
+				zap.Duration("reminder_lead", n.lead), zap.Int("digest_hour", n.digestHour))
+			go func() {
+				defer close(done)
+				n.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			n.logger.Info("Stopping email notifier")
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+	return n, nil
+}
+
+// Enabled reports whether an SMTP server is configured.
+func (n *Notifier) Enabled() bool {
+	return n.mailer != nil
+}
+
+func (n *Notifier) run(ctx context.Context) {
+	for {
+		now := time.Now()
+		n.sendReminders(ctx, now)
+		n.sendDigests(ctx, now)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// sendReminders sends the reminders of the tasks due within the lead time. Tasks
+// that are already overdue are left to the digest.
+func (n *Notifier) sendReminders(ctx context.Context, now time.Time) {
+	reminders, err := n.repo.DueReminders(ctx, now, now.Add(n.lead), reminderBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			n.logger.Warn("Failed to query due reminders", zap.Error(err))
+		}
+		return
+	}
+	for _, reminder := range reminders {
+		if ctx.Err() != nil {
+			return
+		}
+		logger := n.logger.With(zap.String("tenant_id", reminder.TenantID), zap.String("task_id", reminder.Task.GetId()))
+		tctx := tenant.WithID(ctx, reminder.TenantID)
+		prefs, err := n.repo.GetPreferences(tctx, reminder.Task.GetAssignee())
+		if err != nil {
+			logger.Warn("Failed to read notification preferences", zap.Error(err))
+			continue
+		}
+		claimed, err := n.repo.ClaimReminder(ctx, reminder)
+		if err != nil || !claimed {
+			if err != nil {
+				logger.Warn("Failed to claim reminder", zap.Error(err))
+			}
+			continue
+		}
+		msg, err := reminderMessage(prefs, reminder.Task, location(prefs))
+		if err == nil {
+			err = n.mailer.Send(ctx, msg)
+		}
+		if err != nil {
+			logger.Warn("Failed to send reminder; retrying later", zap.Error(err))
+			if err := n.repo.ReleaseReminder(context.WithoutCancel(ctx), reminder); err != nil {
+				logger.Error("Failed to release reminder", zap.Error(err))
+			}
+			continue
+		}
+		logger.Info("Sent reminder", zap.String("assignee", prefs.GetAssignee()))
+	}
+}
+
+// sendDigests sends the digest of the current day to every recipient whose local
+// time is past the digest hour. Recipients without open tasks get no digest that day.
+func (n *Notifier) sendDigests(ctx context.Context, now time.Time) {
+	recipients, err := n.repo.DigestRecipients(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			n.logger.Warn("Failed to query digest recipients", zap.Error(err))
+		}
+		return
+	}
+	for _, recipient := range recipients {
+		if ctx.Err() != nil {
+			return
+		}
+		local := now.In(location(recipient.Preferences))
+		day := local.Format(time.DateOnly)
+		if local.Hour() < n.digestHour || recipient.LastDigestOn >= day {
+			continue
+		}
+		logger := n.logger.With(zap.String("tenant_id", recipient.TenantID), zap.String("assignee", recipient.Preferences.GetAssignee()))
+		claimed, err := n.repo.ClaimDigest(ctx, recipient, day)
+		if err != nil || !claimed {
+			if err != nil {
+				logger.Warn("Failed to claim digest", zap.Error(err))
+			}
+			continue
+		}
+		open, overdue, err := n.sendDigest(tenant.WithID(ctx, recipient.TenantID), recipient.Preferences, now, true)
+		if err != nil {
+			logger.Warn("Failed to send digest; retrying later", zap.Error(err))
+			if err := n.repo.ReleaseDigest(context.WithoutCancel(ctx), recipient, day); err != nil {
+				logger.Error("Failed to release digest", zap.Error(err))
+			}
+			continue
+		}
+		if open > 0 {
+			logger.Info("Sent digest", zap.Int("open_tasks", open), zap.Int("overdue_tasks", overdue))
+		}
+	}
+}
+
+// SendDigest sends the digest of the assignee of prefs right away, even when it
+// lists no tasks. ctx must carry the tenant of prefs.
+func (n *Notifier) SendDigest(ctx context.Context, prefs *pb.NotificationPreferences) (open, overdue int, err error) {
+	if !n.Enabled() {
+		return 0, 0, ErrDisabled
+	}
+	return n.sendDigest(ctx, prefs, time.Now(), false)
+}
+
+func (n *Notifier) sendDigest(ctx context.Context, prefs *pb.NotificationPreferences, now time.Time, skipEmpty bool) (int, int, error) {
+	tasks, err := n.tasks.FetchTasks(ctx, repo.TaskFilter{Assignee: prefs.GetAssignee()})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch tasks: %w", err)
+	}
+	var open []*pb.Task
+	for _, task := range tasks {
+		if task.GetStatus() != "completed" {
+			open = append(open, task)
+		}
+	}
+	if len(open) == 0 && skipEmpty {
+		return 0, 0, nil
+	}
+	msg, data, err := digestMessage(prefs, open, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := n.mailer.Send(ctx, msg); err != nil {
+		return 0, 0, err
+	}
+	return data.Open, data.Overdue, nil
+}
@@ -0,0 +1,267 @@
+package notify
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeNotificationRepository keeps claims in memory for the reminders and digest
+// recipients of one workspace.
+type fakeNotificationRepository struct {
+	repo.NotificationRepository
+
+	mu         sync.Mutex
+	prefs      *pb.NotificationPreferences
+	reminders  []repo.Reminder
+	claimed    map[string]bool
+	released   int
+	lastDigest string
+}
+
+func (r *fakeNotificationRepository) GetPreferences(ctx context.Context, assignee string) (*pb.NotificationPreferences, error) {
+	if id, _ := tenant.IDFromContext(ctx); id != "1" || assignee != r.prefs.GetAssignee() {
+		return nil, sql.ErrNoRows
+	}
+	return r.prefs, nil
+}
+
+func (r *fakeNotificationRepository) DueReminders(ctx context.Context, from, to time.Time, limit int) ([]repo.Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var due []repo.Reminder
+	for _, reminder := range r.reminders {
+		if reminder.DueAt.After(from) && !reminder.DueAt.After(to) && !r.claimed[reminder.Task.GetId()] {
+			due = append(due, reminder)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeNotificationRepository) ClaimReminder(ctx context.Context, reminder repo.Reminder) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.claimed[reminder.Task.GetId()] {
+		return false, nil
+	}
+	r.claimed[reminder.Task.GetId()] = true
+	return true, nil
+}
+
+func (r *fakeNotificationRepository) ReleaseReminder(ctx context.Context, reminder repo.Reminder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.claimed, reminder.Task.GetId())
+	r.released++
+	return nil
+}
+
+func (r *fakeNotificationRepository) DigestRecipients(ctx context.Context) ([]repo.DigestRecipient, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.prefs.GetDigest() {
+		return nil, nil
+	}
+	return []repo.DigestRecipient{{TenantID: "1", Preferences: r.prefs, LastDigestOn: r.lastDigest}}, nil
+}
+
+func (r *fakeNotificationRepository) ClaimDigest(ctx context.Context, recipient repo.DigestRecipient, day string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastDigest >= day {
+		return false, nil
+	}
+	r.lastDigest = day
+	return true, nil
+}
+
+func (r *fakeNotificationRepository) ReleaseDigest(ctx context.Context, recipient repo.DigestRecipient, day string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastDigest == day {
+		r.lastDigest = recipient.LastDigestOn
+	}
+	r.released++
+	return nil
+}
+
+// fakeTaskRepository returns the same tasks for every filter.
+type fakeTaskRepository struct {
+	repo.TaskRepository
+	tasks []*pb.Task
+}
+
+func (r *fakeTaskRepository) FetchTasks(ctx context.Context, filter repo.TaskFilter) ([]*pb.Task, error) {
+	return r.tasks, nil
+}
+
+// newTestNotifier returns a notifier that sends through smtp to the preferences of
+// alice in workspace 1.
+func newTestNotifier(t *testing.T, smtp *fakeSMTPServer, tasks ...*pb.Task) (*Notifier, *fakeNotificationRepository) {
+	t.Helper()
+	mailer, err := NewSMTPMailer(&cfg.Config{SMTPHost: "127.0.0.1", SMTPPort: smtp.port(), SMTPFrom: "Tasks <tasks@example.com>", SMTPTLS: "none"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	notifications := &fakeNotificationRepository{
+		prefs:   &pb.NotificationPreferences{Assignee: "alice", Email: "alice@example.com", Reminders: true, Digest: true},
+		claimed: make(map[string]bool),
+	}
+	n := &Notifier{
+		repo:       notifications,
+		tasks:      &fakeTaskRepository{tasks: tasks},
+		mailer:     mailer,
+		logger:     zap.NewNop(),
+		lead:       time.Hour,
+		digestHour: 8,
+	}
+	return n, notifications
+}
+
+// readMail parses a received message and returns its decoded subject and the
+// content of its text/plain and text/html parts.
+func readMail(t *testing.T, received receivedMail) (subject string, parts map[string]string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(strings.NewReader(string(received.data)))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	subject, err = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("invalid subject %q: %v", msg.Header.Get("Subject"), err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", msg.Header.Get("Content-Type"))
+	}
+	parts = make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		parts[contentType] = string(content)
+	}
+	return subject, parts
+}
+
+func TestSMTPMailerSendsMultipartMessage(t *testing.T) {
+	smtp := startFakeSMTPServer(t)
+	n, _ := newTestNotifier(t, smtp)
+	err := n.mailer.Send(context.Background(), Message{
+		To:      "Alice <alice@example.com>",
+		Subject: "Fällig: Bericht",
+		Text:    "Bitte prüfen.\nZeile zwei.",
+		HTML:    "<p>Bitte prüfen.</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	received := smtp.received()
+	if len(received) != 1 {
+		t.Fatalf("received %d messages, want 1", len(received))
+	}
+	if received[0].from != "tasks@example.com" || len(received[0].to) != 1 || received[0].to[0] != "alice@example.com" {
+		t.Errorf("envelope from %s to %v, want tasks@example.com to alice@example.com", received[0].from, received[0].to)
+	}
+	subject, parts := readMail(t, received[0])
+	if subject != "Fällig: Bericht" {
+		t.Errorf("subject = %q", subject)
+	}
+	if parts["text/plain"] != "Bitte prüfen.\nZeile zwei." || parts["text/html"] != "<p>Bitte prüfen.</p>" {
+		t.Errorf("parts = %q", parts)
+	}
+}
+
+func TestSendRemindersSendsEachReminderOnce(t *testing.T) {
+	smtp := startFakeSMTPServer(t)
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	n, notifications := newTestNotifier(t, smtp)
+	due := now.Add(30 * time.Minute)
+	notifications.reminders = []repo.Reminder{
+		{TenantID: "1", DueAt: due, Task: &pb.Task{Id: "7", Title: "Write report", Assignee: "alice", DueAt: due.Format(time.RFC3339)}},
+		{TenantID: "1", DueAt: now.Add(2 * time.Hour), Task: &pb.Task{Id: "8", Title: "Later", Assignee: "alice"}},
+	}
+
+	n.sendReminders(context.Background(), now)
+	n.sendReminders(context.Background(), now)
+	received := smtp.received()
+	if len(received) != 1 {
+		t.Fatalf("received %d messages, want the reminder of task 7 once", len(received))
+	}
+	subject, parts := readMail(t, received[0])
+	if !strings.HasPrefix(subject, "Reminder: Write report is due") {
+		t.Errorf("subject = %q", subject)
+	}
+	if !strings.Contains(parts["text/plain"], "complete-task --id 7") {
+		t.Errorf("text part lacks the task:\n%s", parts["text/plain"])
+	}
+}
+
+func TestSendRemindersReleasesFailedReminder(t *testing.T) {
+	smtp := startFakeSMTPServer(t, "alice@example.com")
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	n, notifications := newTestNotifier(t, smtp)
+	notifications.reminders = []repo.Reminder{
+		{TenantID: "1", DueAt: now.Add(time.Minute), Task: &pb.Task{Id: "7", Title: "Write report", Assignee: "alice"}},
+	}
+
+	n.sendReminders(context.Background(), now)
+	if len(smtp.received()) != 0 {
+		t.Fatal("a rejected reminder was delivered")
+	}
+	if notifications.claimed["7"] || notifications.released != 1 {
+		t.Errorf("claimed = %v, released = %d; want the reminder released for a retry", notifications.claimed, notifications.released)
+	}
+}
+
+func TestSendDigestsOncePerDayAfterDigestHour(t *testing.T) {
+	smtp := startFakeSMTPServer(t)
+	morning := time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC)
+	n, _ := newTestNotifier(t, smtp,
+		&pb.Task{Id: "1", Title: "Overdue report", Status: "pending", DueAt: "2026-10-18T12:00:00Z"},
+		&pb.Task{Id: "2", Title: "Weekly review", Status: "pending"},
+		&pb.Task{Id: "3", Title: "Done already", Status: "completed"},
+	)
+
+	n.sendDigests(context.Background(), morning)
+	if len(smtp.received()) != 0 {
+		t.Fatal("a digest was sent before the digest hour")
+	}
+	n.sendDigests(context.Background(), morning.Add(2*time.Hour))
+	n.sendDigests(context.Background(), morning.Add(3*time.Hour))
+	received := smtp.received()
+	if len(received) != 1 {
+		t.Fatalf("received %d digests, want 1", len(received))
+	}
+	subject, parts := readMail(t, received[0])
+	if subject != "Your tasks for Oct 19: 2 open, 1 overdue" {
+		t.Errorf("subject = %q", subject)
+	}
+	text := parts["text/plain"]
+	if !strings.Contains(text, "Overdue report") || !strings.Contains(text, "Weekly review") || strings.Contains(text, "Done already") {
+		t.Errorf("digest does not list exactly the open tasks:\n%s", text)
+	}
+}
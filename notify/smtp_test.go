@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// receivedMail is a message accepted by fakeSMTPServer.
+type receivedMail struct {
+	from string
+	to   []string
+	data []byte
+}
+
+// fakeSMTPServer accepts mail over plain SMTP on a loopback port, without
+// authentication or STARTTLS, and keeps it in memory.
+type fakeSMTPServer struct {
+	listener net.Listener
+	// rejected lists recipient addresses answered with 550.
+	rejected []string
+
+	mu   sync.Mutex
+	mail []receivedMail
+}
+
+func startFakeSMTPServer(t *testing.T, rejected ...string) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeSMTPServer{listener: listener, rejected: rejected}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				s.serve(conn)
+			}()
+		}
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		wg.Wait()
+	})
+	return s
+}
+
+func (s *fakeSMTPServer) port() string {
+	return strings.TrimPrefix(s.listener.Addr().String(), "127.0.0.1:")
+}
+
+// received returns the messages accepted so far.
+func (s *fakeSMTPServer) received() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.mail)
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 fake ESMTP")
+	var mail receivedMail
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 fake")
+		case "MAIL":
+			mail = receivedMail{from: envelopeAddress(arg)}
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			if to := envelopeAddress(arg); slices.Contains(s.rejected, to) {
+				tp.PrintfLine("550 no such user")
+			} else {
+				mail.to = append(mail.to, to)
+				tp.PrintfLine("250 OK")
+			}
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			mail.data = data
+			s.mu.Lock()
+			s.mail = append(s.mail, mail)
+			s.mu.Unlock()
+			tp.PrintfLine("250 OK")
+		case "RSET", "NOOP":
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+// envelopeAddress returns the address of a MAIL FROM:<a> or RCPT TO:<a> argument.
+func envelopeAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, "<")
+	addr, _, _ = strings.Cut(addr, ">")
+	return addr
+}
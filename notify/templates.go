@@ -0,0 +1,156 @@
+package notify
+
+import (
+	pb "Go_Test/api"
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt.tmpl"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html.tmpl"))
+)
+
+// dateTimeLayout formats due dates in emails, in the time zone of the recipient.
+const dateTimeLayout = "Mon, Jan 2 2006 15:04 MST"
+
+// taskView is a task as shown in an email.
+type taskView struct {
+	ID          string
+	Title       string
+	Description string
+	// Due is the formatted due date, or empty.
+	Due string
+	// Details joins the project, priority and tags.
+	Details string
+}
+
+func newTaskView(task *pb.Task, loc *time.Location) taskView {
+	view := taskView{ID: task.GetId(), Title: task.GetTitle(), Description: task.GetDescription()}
+	if due, err := time.Parse(time.RFC3339, task.GetDueAt()); err == nil {
+		view.Due = due.In(loc).Format(dateTimeLayout)
+	}
+	var details []string
+	if task.GetProject() != "" {
+		details = append(details, "project "+task.GetProject())
+	}
+	if task.GetPriority() != "" {
+		details = append(details, task.GetPriority()+" priority")
+	}
+	for _, tag := range task.GetTags() {
+		details = append(details, "#"+tag)
+	}
+	view.Details = strings.Join(details, " · ")
+	return view
+}
+
+type reminderData struct {
+	Assignee string
+	Task     taskView
+}
+
+// digestSection is a group of tasks in the digest.
+type digestSection struct {
+	Title string
+	// Highlight marks the section of overdue tasks.
+	Highlight bool
+	Tasks     []taskView
+}
+
+type digestData struct {
+	Assignee string
+	Date     string
+	Open     int
+	Overdue  int
+	Sections []digestSection
+}
+
+// reminderMessage renders the reminder of a task.
+func reminderMessage(prefs *pb.NotificationPreferences, task *pb.Task, loc *time.Location) (Message, error) {
+	data := reminderData{Assignee: prefs.GetAssignee(), Task: newTaskView(task, loc)}
+	return render(prefs.GetEmail(), fmt.Sprintf("Reminder: %s is due %s", task.GetTitle(), data.Task.Due), "reminder", data)
+}
+
+// digestMessage renders the digest of the open tasks of an assignee on the day of
+// now, grouped into overdue tasks, tasks due today, later tasks and tasks without a
+// due date.
+func digestMessage(prefs *pb.NotificationPreferences, tasks []*pb.Task, now time.Time) (Message, digestData, error) {
+	now = now.In(location(prefs))
+	year, month, day := now.Date()
+	tomorrow := time.Date(year, month, day+1, 0, 0, 0, 0, now.Location())
+
+	type dueTask struct {
+		task *pb.Task
+		due  time.Time
+	}
+	var sorted []dueTask
+	for _, task := range tasks {
+		due, _ := time.Parse(time.RFC3339, task.GetDueAt())
+		sorted = append(sorted, dueTask{task, due})
+	}
+	// Tasks without a due date (zero time) go last.
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].due, sorted[j].due
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+
+	sections := []digestSection{{Title: "Overdue", Highlight: true}, {Title: "Due today"}, {Title: "Upcoming"}, {Title: "No due date"}}
+	for _, t := range sorted {
+		i := 3
+		switch {
+		case t.due.IsZero():
+		case t.due.Before(now):
+			i = 0
+		case t.due.Before(tomorrow):
+			i = 1
+		default:
+			i = 2
+		}
+		sections[i].Tasks = append(sections[i].Tasks, newTaskView(t.task, now.Location()))
+	}
+	data := digestData{Assignee: prefs.GetAssignee(), Date: now.Format("Monday, January 2 2006"), Open: len(tasks), Overdue: len(sections[0].Tasks)}
+	for _, section := range sections {
+		if len(section.Tasks) > 0 {
+			data.Sections = append(data.Sections, section)
+		}
+	}
+
+	subject := fmt.Sprintf("Your tasks for %s: %d open", now.Format("Jan 2"), data.Open)
+	if data.Overdue > 0 {
+		subject += fmt.Sprintf(", %d overdue", data.Overdue)
+	}
+	msg, err := render(prefs.GetEmail(), subject, "digest", data)
+	return msg, data, err
+}
+
+// render executes the text and HTML templates named name.
+func render(to, subject, name string, data any) (Message, error) {
+	var text, html bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&text, name+".txt.tmpl", data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+	if err := htmlTemplates.ExecuteTemplate(&html, name+".html.tmpl", data); err != nil {
+		return Message{}, fmt.Errorf("failed to render %s email: %w", name, err)
+	}
+	return Message{To: to, Subject: subject, Text: text.String(), HTML: html.String()}, nil
+}
+
+// location returns the time zone of prefs, or UTC when it is empty or unknown.
+func location(prefs *pb.NotificationPreferences) *time.Location {
+	if l, err := time.LoadLocation(prefs.GetTimeZone()); err == nil {
+		return l
+	}
+	return time.UTC
+}
@@ -0,0 +1,242 @@
+// Package output renders CLI results in the formats selected with --output:
+// human-readable tables and machine-readable JSON, JSON Lines, YAML, CSV or Go templates.
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format accepted by --output.
+type Format string
+
+const (
+	Table      Format = "table"
+	Wide       Format = "wide"
+	JSON       Format = "json"
+	JSONL      Format = "jsonl"
+	YAML       Format = "yaml"
+	CSV        Format = "csv"
+	GoTemplate Format = "go-template"
+)
+
+// Formats lists the accepted --output values for help texts.
+const Formats = "table, wide, json, jsonl, yaml, csv or go-template=<template>"
+
+// Column describes one column of a table, wide or CSV rendering.
+type Column[T any] struct {
+	Header string
+	// Wide columns are only shown with the wide and csv formats.
+	Wide  bool
+	Value func(T) string
+}
+
+// TableSpec describes how items of type T are rendered as table rows.
+type TableSpec[T any] struct {
+	Columns []Column[T]
+	// Empty is printed instead of an empty table, e.g. "No tasks found.".
+	Empty string
+}
+
+// Printer writes results to w in the selected format.
+type Printer struct {
+	format   Format
+	template *template.Template
+	w        io.Writer
+}
+
+// NewPrinter parses an --output value. An empty value selects the table format.
+func NewPrinter(value string, w io.Writer) (*Printer, error) {
+	p := &Printer{w: w}
+	name, text, hasTemplate := strings.Cut(value, "=")
+	switch Format(name) {
+	case "":
+		p.format = Table
+	case Table, Wide, JSON, JSONL, YAML, CSV:
+		if hasTemplate {
+			return nil, fmt.Errorf("output format %q does not take a value", name)
+		}
+		p.format = Format(name)
+	case GoTemplate:
+		if text == "" {
+			return nil, fmt.Errorf("output format go-template requires a template, e.g. go-template='{{.id}}'")
+		}
+		tmpl, err := template.New("output").Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid go-template: %w", err)
+		}
+		p.format, p.template = GoTemplate, tmpl
+	default:
+		return nil, fmt.Errorf("unknown output format %q: must be %s", value, Formats)
+	}
+	return p, nil
+}
+
+// Format returns the selected format.
+func (p *Printer) Format() Format {
+	return p.format
+}
+
+// Human reports whether the output is meant for people rather than scripts.
+func (p *Printer) Human() bool {
+	return p.format == Table || p.format == Wide
+}
+
+// PrintList renders a list of items. JSON and YAML produce an array;
+// JSON Lines and Go templates produce one line per item.
+func PrintList[T any](p *Printer, spec TableSpec[T], items []T) error {
+	return render(p, spec, items, false)
+}
+
+// PrintItem renders a single item. JSON and YAML produce a single object.
+func PrintItem[T any](p *Printer, spec TableSpec[T], item T) error {
+	return render(p, spec, []T{item}, true)
+}
+
+func render[T any](p *Printer, spec TableSpec[T], items []T, single bool) error {
+	switch p.format {
+	case Table, Wide:
+		if len(items) == 0 && spec.Empty != "" {
+			_, err := fmt.Fprintln(p.w, spec.Empty)
+			return err
+		}
+		return writeTable(p.w, spec, items, p.format == Wide)
+	case CSV:
+		return writeCSV(p.w, spec, items)
+	}
+
+	values := make([]any, 0, len(items))
+	for _, item := range items {
+		value, err := toValue(item)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+	switch p.format {
+	case JSON:
+		var data any = values
+		if single {
+			data = values[0]
+		}
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		_, err = fmt.Fprintln(p.w, string(encoded))
+		return err
+	case JSONL:
+		for _, value := range values {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return fmt.Errorf("failed to encode JSON output: %w", err)
+			}
+			if _, err := fmt.Fprintln(p.w, string(encoded)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case YAML:
+		var data any = values
+		if single {
+			data = values[0]
+		}
+		encoded, err := yaml.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encode YAML output: %w", err)
+		}
+		_, err = p.w.Write(encoded)
+		return err
+	case GoTemplate:
+		for _, value := range values {
+			var buf bytes.Buffer
+			if err := p.template.Execute(&buf, value); err != nil {
+				return fmt.Errorf("failed to execute go-template: %w", err)
+			}
+			if _, err := fmt.Fprintln(p.w, buf.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported output format %q", p.format)
+}
+
+// writeTable renders items as aligned columns; wide columns are included only when wide is set.
+func writeTable[T any](w io.Writer, spec TableSpec[T], items []T, wide bool) error {
+	var columns []Column[T]
+	for _, column := range spec.Columns {
+		if wide || !column.Wide {
+			columns = append(columns, column)
+		}
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	for _, item := range items {
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			// Tabs and newlines would break the column layout.
+			cells[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(column.Value(item))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeCSV renders items as RFC 4180 CSV with a header row and all columns.
+func writeCSV[T any](w io.Writer, spec TableSpec[T], items []T) error {
+	cw := csv.NewWriter(w)
+	headers := make([]string, len(spec.Columns))
+	for i, column := range spec.Columns {
+		headers[i] = strings.ToLower(strings.ReplaceAll(column.Header, " ", "_"))
+	}
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	for _, item := range items {
+		record := make([]string, len(spec.Columns))
+		for i, column := range spec.Columns {
+			record[i] = column.Value(item)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// toValue converts an item into generic JSON data. Protobuf messages are encoded
+// with protojson so that field names and well-known types match the API; other
+// values go through encoding/json.
+func toValue(item any) (any, error) {
+	var encoded []byte
+	var err error
+	if message, ok := item.(proto.Message); ok {
+		encoded, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(message)
+	} else {
+		encoded, err = json.Marshal(item)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode output: %w", err)
+	}
+	var value any
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return nil, fmt.Errorf("failed to decode output: %w", err)
+	}
+	return value, nil
+}
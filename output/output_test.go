@@ -0,0 +1,161 @@
+package output
+
+import (
+	pb "Go_Test/api"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var taskSpec = TableSpec[*pb.Task]{
+	Columns: []Column[*pb.Task]{
+		{Header: "ID", Value: func(t *pb.Task) string { return t.GetId() }},
+		{Header: "Title", Value: func(t *pb.Task) string { return t.GetTitle() }},
+		{Header: "Due At", Wide: true, Value: func(t *pb.Task) string { return t.GetDueAt() }},
+		{Header: "Tags", Wide: true, Value: func(t *pb.Task) string { return strings.Join(t.GetTags(), ",") }},
+	},
+	Empty: "No tasks found.",
+}
+
+func testTasks() []*pb.Task {
+	return []*pb.Task{
+		{Id: "1", Title: "Buy milk, eggs", Status: "pending", DueAt: "2025-01-31T18:00:00Z", Tags: []string{"home", "errands"}, Version: 2},
+		{Id: "2", Title: "Write \"notes\"\nsecond line", Status: "completed", Version: 1},
+	}
+}
+
+func printed(t *testing.T, format string, print func(p *Printer) error) string {
+	t.Helper()
+	var buf bytes.Buffer
+	p, err := NewPrinter(format, &buf)
+	if err != nil {
+		t.Fatalf("NewPrinter(%q): %v", format, err)
+	}
+	if err := print(p); err != nil {
+		t.Fatalf("%s: %v", format, err)
+	}
+	return buf.String()
+}
+
+func TestPrintList(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"", "" +
+			"ID   Title\n" +
+			"1    Buy milk, eggs\n" +
+			"2    Write \"notes\" second line\n"},
+		{"wide", "" +
+			"ID   Title                       Due At                 Tags\n" +
+			"1    Buy milk, eggs              2025-01-31T18:00:00Z   home,errands\n" +
+			"2    Write \"notes\" second line                          \n"},
+		{"csv", "" +
+			"id,title,due_at,tags\n" +
+			"1,\"Buy milk, eggs\",2025-01-31T18:00:00Z,\"home,errands\"\n" +
+			"2,\"Write \"\"notes\"\"\nsecond line\",,\n"},
+		{"jsonl", "" +
+			`{"assignee":"","completedAt":"","createdAt":"","description":"","dueAt":"2025-01-31T18:00:00Z","id":"1","priority":"","project":"","status":"pending","tags":["home","errands"],"title":"Buy milk, eggs","updatedAt":"","version":2}` + "\n" +
+			`{"assignee":"","completedAt":"","createdAt":"","description":"","dueAt":"","id":"2","priority":"","project":"","status":"completed","tags":[],"title":"Write \"notes\"\nsecond line","updatedAt":"","version":1}` + "\n"},
+		{"go-template={{.id}}|{{.dueAt}}|{{len .tags}}", "" +
+			"1|2025-01-31T18:00:00Z|2\n" +
+			"2||0\n"},
+	}
+	for _, tt := range tests {
+		got := printed(t, tt.format, func(p *Printer) error { return PrintList(p, taskSpec, testTasks()) })
+		if got != tt.want {
+			t.Errorf("%q:\n%s\nwant:\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestPrintItem(t *testing.T) {
+	task := &pb.Task{Id: "7", Title: "Plan sprint", CreatedAt: "2025-01-02T09:00:00Z", Tags: []string{"work"}, Version: 3}
+	tests := []struct {
+		format, want string
+	}{
+		{"json", `{
+  "assignee": "",
+  "completedAt": "",
+  "createdAt": "2025-01-02T09:00:00Z",
+  "description": "",
+  "dueAt": "",
+  "id": "7",
+  "priority": "",
+  "project": "",
+  "status": "",
+  "tags": [
+    "work"
+  ],
+  "title": "Plan sprint",
+  "updatedAt": "",
+  "version": 3
+}
+`},
+		{"yaml", `assignee: ""
+completedAt: ""
+createdAt: "2025-01-02T09:00:00Z"
+description: ""
+dueAt: ""
+id: "7"
+priority: ""
+project: ""
+status: ""
+tags:
+    - work
+title: Plan sprint
+updatedAt: ""
+version: 3
+`},
+	}
+	for _, tt := range tests {
+		got := printed(t, tt.format, func(p *Printer) error { return PrintItem(p, taskSpec, task) })
+		if got != tt.want {
+			t.Errorf("%q:\n%s\nwant:\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestPrintEmptyList(t *testing.T) {
+	tests := []struct {
+		format, want string
+	}{
+		{"table", "No tasks found.\n"},
+		{"json", "[]\n"},
+		{"yaml", "[]\n"},
+		{"jsonl", ""},
+		{"csv", "id,title,due_at,tags\n"},
+	}
+	for _, tt := range tests {
+		got := printed(t, tt.format, func(p *Printer) error { return PrintList(p, taskSpec, nil) })
+		if got != tt.want {
+			t.Errorf("%q: %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestPrintNonProtoValues(t *testing.T) {
+	type entry struct {
+		Name     string `json:"name"`
+		HasToken bool   `json:"has_token"`
+	}
+	spec := TableSpec[entry]{Columns: []Column[entry]{{Header: "Name", Value: func(e entry) string { return e.Name }}}}
+	got := printed(t, "jsonl", func(p *Printer) error { return PrintList(p, spec, []entry{{"prod", true}}) })
+	if want := `{"has_token":true,"name":"prod"}` + "\n"; got != want {
+		t.Errorf("jsonl: %q, want %q", got, want)
+	}
+}
+
+func TestNewPrinterRejectsInvalidFormats(t *testing.T) {
+	for _, value := range []string{"xml", "json=x", "go-template", "go-template=", "go-template={{.id"} {
+		if _, err := NewPrinter(value, &bytes.Buffer{}); err == nil {
+			t.Errorf("NewPrinter(%q) succeeded", value)
+		}
+	}
+	p, err := NewPrinter("go-template={{.missing}}", &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := PrintItem(p, taskSpec, &pb.Task{Id: "1"}); err == nil {
+		t.Errorf("a template naming a missing field succeeded")
+	}
+}
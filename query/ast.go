@@ -0,0 +1,196 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Node is a node of a parsed query.
+type Node interface {
+	// Pos returns the byte offset of the node in the query text.
+	Pos() int
+	// String renders the node in query syntax.
+	String() string
+}
+
+// And matches tasks that match all of its terms.
+type And struct {
+	Terms []Node
+}
+
+// Or matches tasks that match any of its terms.
+type Or struct {
+	Terms []Node
+}
+
+// Not matches tasks that do not match its term.
+type Not struct {
+	Term  Node
+	Start int
+}
+
+// Text matches tasks whose title or description contains Value, ignoring case.
+type Text struct {
+	Value string
+	Start int
+}
+
+// Comparison matches tasks whose Field compares to the value with Op.
+type Comparison struct {
+	Field Field
+	Op    Op
+	// Value is the value as written, unquoted.
+	Value string
+	// None is set for the value "none", which matches an empty or missing field.
+	None bool
+	// Time is the parsed value of date fields. Day is set when the value was a
+	// date without a time of day; Time is then midnight at the start of that day.
+	Time time.Time
+	Day  bool
+	// Priorities holds the priorities selected by a priority comparison, e.g.
+	// high and urgent for priority>=high.
+	Priorities []string
+	Start      int
+}
+
+func (n *And) Pos() int        { return n.Terms[0].Pos() }
+func (n *Or) Pos() int         { return n.Terms[0].Pos() }
+func (n *Not) Pos() int        { return n.Start }
+func (n *Text) Pos() int       { return n.Start }
+func (n *Comparison) Pos() int { return n.Start }
+
+func (n *And) String() string { return joinNodes(n.Terms, " ") }
+func (n *Or) String() string  { return "(" + joinNodes(n.Terms, " OR ") + ")" }
+
+func (n *Not) String() string {
+	// An And needs parentheses to be negated as a whole; an Or brings its own.
+	if and, ok := n.Term.(*And); ok {
+		return "-(" + and.String() + ")"
+	}
+	return "-" + n.Term.String()
+}
+
+func (n *Text) String() string { return quote(n.Value) }
+
+func (n *Comparison) String() string {
+	return n.Field.String() + n.Op.String() + quote(n.Value)
+}
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		parts[i] = node.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// quote returns s as a bare word when that parses back to the same value, and
+// otherwise as a double-quoted string with the escapes the lexer understands.
+func quote(s string) string {
+	if s != "" && !strings.ContainsFunc(s, needsQuote) && !strings.HasPrefix(s, "-") && s != "OR" && s != "AND" && s != "NOT" {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func needsQuote(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("\"():<>=", r)
+}
+
+// Field is a task field that can be searched.
+type Field int
+
+const (
+	FieldStatus Field = iota + 1
+	FieldTag
+	FieldPriority
+	FieldAssignee
+	FieldProject
+	FieldTitle
+	FieldDescription
+	FieldID
+	FieldDue
+	FieldCreated
+	FieldUpdated
+)
+
+// fieldNames maps the names used in queries to fields.
+var fieldNames = map[string]Field{
+	"status":      FieldStatus,
+	"tag":         FieldTag,
+	"priority":    FieldPriority,
+	"assignee":    FieldAssignee,
+	"project":     FieldProject,
+	"title":       FieldTitle,
+	"description": FieldDescription,
+	"id":          FieldID,
+	"due":         FieldDue,
+	"created":     FieldCreated,
+	"updated":     FieldUpdated,
+}
+
+// FieldNames lists the searchable fields for help and error messages.
+const FieldNames = "status, tag, priority, assignee, project, title, description, id, due, created, updated"
+
+func (f Field) String() string {
+	for name, field := range fieldNames {
+		if field == f {
+			return name
+		}
+	}
+	return "field(" + strconv.Itoa(int(f)) + ")"
+}
+
+// IsDate reports whether the field holds a timestamp and supports <, <=, > and >=.
+func (f Field) IsDate() bool {
+	return f == FieldDue || f == FieldCreated || f == FieldUpdated
+}
+
+// Op is the operator of a comparison.
+type Op int
+
+const (
+	OpEq Op = iota + 1 // field:value
+	OpLt               // field<value
+	OpLe               // field<=value
+	OpGt               // field>value
+	OpGe               // field>=value
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpEq:
+		return ":"
+	case OpLt:
+		return "<"
+	case OpLe:
+		return "<="
+	case OpGt:
+		return ">"
+	case OpGe:
+		return ">="
+	}
+	return "?"
+}
+
+// Priorities lists the task priorities in ascending order.
+var Priorities = []string{"low", "medium", "high", "urgent"}
+
+// Status aliases accepted by status:, besides the literal statuses.
+const (
+	// StatusOpen matches every task that is not completed.
+	StatusOpen = "open"
+	// StatusDone matches completed tasks.
+	StatusDone = "done"
+)
@@ -0,0 +1,160 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF    tokenKind = iota
+	tokWord             // bare word
+	tokString           // double-quoted string, unquoted in text
+	tokField            // field name with operator, e.g. "due<"; text is the name
+	tokLParen
+	tokRParen
+	tokNot // NOT or a leading "-"
+	tokAnd
+	tokOr
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	op   Op
+	pos  int
+}
+
+// lexer splits a query into tokens. A field token is always directly followed by
+// its value, without whitespace in between.
+type lexer struct {
+	input string
+	pos   int
+}
+
+// next returns the next token. Unterminated strings are reported as *Error.
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.pos += size
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF, pos: l.pos}, nil
+	}
+	start := l.pos
+	switch c := l.input[l.pos]; {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, pos: start}, nil
+	case c == '"':
+		text, err := l.readString()
+		return token{kind: tokString, text: text, pos: start}, err
+	case c == '-' && l.pos+1 < len(l.input) && !unicode.IsSpace(rune(l.input[l.pos+1])) && l.input[l.pos+1] != ')':
+		l.pos++
+		return token{kind: tokNot, pos: start}, nil
+	}
+
+	if name, op, ok := l.readFieldPrefix(); ok {
+		return token{kind: tokField, text: name, op: op, pos: start}, nil
+	}
+	word := l.readWord()
+	switch word {
+	case "AND":
+		return token{kind: tokAnd, pos: start}, nil
+	case "OR":
+		return token{kind: tokOr, pos: start}, nil
+	case "NOT":
+		return token{kind: tokNot, pos: start}, nil
+	}
+	return token{kind: tokWord, text: word, pos: start}, nil
+}
+
+// value reads the value directly following a field token. An empty value is
+// returned as an empty word.
+func (l *lexer) value() (token, error) {
+	start := l.pos
+	if l.pos < len(l.input) && l.input[l.pos] == '"' {
+		text, err := l.readString()
+		return token{kind: tokString, text: text, pos: start}, err
+	}
+	return token{kind: tokWord, text: l.readWord(), pos: start}, nil
+}
+
+// readFieldPrefix consumes "name:" or "name<" etc. when the input continues with
+// a field name and an operator.
+func (l *lexer) readFieldPrefix() (string, Op, bool) {
+	end := l.pos
+	for end < len(l.input) && (isLetter(l.input[end]) || l.input[end] == '_') {
+		end++
+	}
+	if end == l.pos || end >= len(l.input) {
+		return "", 0, false
+	}
+	var op Op
+	width := 1
+	switch rest := l.input[end:]; {
+	case strings.HasPrefix(rest, "<="):
+		op, width = OpLe, 2
+	case strings.HasPrefix(rest, ">="):
+		op, width = OpGe, 2
+	case rest[0] == '<':
+		op = OpLt
+	case rest[0] == '>':
+		op = OpGt
+	case rest[0] == ':' || rest[0] == '=':
+		op = OpEq
+	default:
+		return "", 0, false
+	}
+	name := l.input[l.pos:end]
+	l.pos = end + width
+	return strings.ToLower(name), op, true
+}
+
+// readWord consumes characters up to whitespace, a parenthesis or a quote.
+func (l *lexer) readWord() string {
+	start := l.pos
+	for l.pos < len(l.input) && !isDelimiter(l.input[l.pos]) {
+		_, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		l.pos += size
+	}
+	return l.input[start:l.pos]
+}
+
+// readString consumes a double-quoted string; \" and \\ are escapes.
+func (l *lexer) readString() (string, error) {
+	start := l.pos
+	l.pos++ // opening quote
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			return b.String(), nil
+		case c == '\\' && l.pos+1 < len(l.input):
+			b.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return "", &Error{Pos: start, Msg: "unterminated string"}
+}
+
+func isDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')' || c == '"'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
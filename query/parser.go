@@ -0,0 +1,259 @@
+// Package query parses the task search language into a typed syntax tree.
+//
+// A query is a list of terms that must all match:
+//
+//	status:open tag:infra due<2026-11-01 "login bug"
+//
+// A term is either free text, a bare word or a double-quoted phrase that must
+// occur in the title or description, or a comparison field:value, field<value,
+// field<=value, field>value or field>=value. Terms can be combined with OR,
+// negated with NOT or a leading "-", and grouped with parentheses; AND may be
+// written explicitly. Keywords are upper case.
+//
+// Fields are status (with the aliases open and done), tag, priority (which also
+// supports ordering, e.g. priority>=high), assignee, project, title, description
+// and id, and the dates due, created and updated. Dates are YYYY-MM-DD, RFC 3339
+// timestamps, or today, tomorrow and yesterday. The value none matches an empty
+// assignee, project or priority and a missing due date.
+package query
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Error is a syntax or validation error at a byte offset of the query.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+// Parse parses and validates a query. Relative dates and dates without a time of
+// day are resolved against now, in the location of now. Errors are *Error.
+func Parse(input string, now time.Time) (Node, error) {
+	p := &parser{lexer: lexer{input: input}, now: now}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF {
+		return nil, &Error{Pos: 0, Msg: "query is empty"}
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		// Only an unmatched closing parenthesis ends parseOr early.
+		return nil, &Error{Pos: p.tok.pos, Msg: "unexpected ')'"}
+	}
+	return node, nil
+}
+
+type parser struct {
+	lexer lexer
+	tok   token
+	now   time.Time
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	terms := []Node{first}
+	for p.tok.kind == tokOr {
+		orPos := p.tok.pos
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokEOF || p.tok.kind == tokRParen || p.tok.kind == tokOr {
+			return nil, &Error{Pos: orPos, Msg: "OR must be followed by a term"}
+		}
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, next)
+	}
+	if len(terms) == 1 {
+		return first, nil
+	}
+	return &Or{Terms: terms}, nil
+}
+
+// parseAnd parses: unary (["AND"] unary)*
+func (p *parser) parseAnd() (Node, error) {
+	var terms []Node
+	for {
+		switch p.tok.kind {
+		case tokEOF, tokRParen, tokOr:
+			if len(terms) == 0 {
+				return nil, &Error{Pos: p.tok.pos, Msg: "expected a term"}
+			}
+			if len(terms) == 1 {
+				return terms[0], nil
+			}
+			return &And{Terms: terms}, nil
+		case tokAnd:
+			if len(terms) == 0 {
+				return nil, &Error{Pos: p.tok.pos, Msg: "AND must follow a term"}
+			}
+			andPos := p.tok.pos
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind == tokEOF || p.tok.kind == tokRParen || p.tok.kind == tokOr || p.tok.kind == tokAnd {
+				return nil, &Error{Pos: andPos, Msg: "AND must be followed by a term"}
+			}
+		}
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+}
+
+// parseUnary parses: ("NOT" | "-") unary | primary
+func (p *parser) parseUnary() (Node, error) {
+	if p.tok.kind != tokNot {
+		return p.parsePrimary()
+	}
+	start := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokEOF || p.tok.kind == tokRParen || p.tok.kind == tokOr || p.tok.kind == tokAnd {
+		return nil, &Error{Pos: start, Msg: "NOT must be followed by a term"}
+	}
+	term, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Term: term, Start: start}, nil
+}
+
+// parsePrimary parses: "(" or ")" | comparison | text
+func (p *parser) parsePrimary() (Node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "empty parentheses"}
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "unclosed '('"}
+		}
+		return node, p.advance()
+	case tokWord, tokString:
+		return &Text{Value: tok.text, Start: tok.pos}, p.advance()
+	case tokField:
+		value, err := p.lexer.value()
+		if err != nil {
+			return nil, err
+		}
+		node, err := p.comparison(tok, value)
+		if err != nil {
+			return nil, err
+		}
+		return node, p.advance()
+	}
+	return nil, &Error{Pos: tok.pos, Msg: "expected a term"}
+}
+
+// comparison validates a field term and resolves its value.
+func (p *parser) comparison(field, value token) (*Comparison, error) {
+	f, ok := fieldNames[field.text]
+	if !ok {
+		return nil, &Error{Pos: field.pos, Msg: fmt.Sprintf("unknown field %q; fields are %s (quote text that contains ':', '<' or '>')", field.text, FieldNames)}
+	}
+	if value.text == "" && value.kind == tokWord {
+		return nil, &Error{Pos: value.pos, Msg: fmt.Sprintf("missing value after %s%s", field.text, field.op)}
+	}
+	c := &Comparison{Field: f, Op: field.op, Value: value.text, Start: field.pos}
+	if field.op != OpEq && !f.IsDate() && f != FieldPriority {
+		return nil, &Error{Pos: field.pos, Msg: fmt.Sprintf("field %s only supports ':'", f)}
+	}
+
+	switch {
+	case value.text == "none" && (f == FieldAssignee || f == FieldProject || f == FieldPriority || f == FieldDue):
+		if field.op != OpEq {
+			return nil, &Error{Pos: value.pos, Msg: fmt.Sprintf("none can only be used with %s:", f)}
+		}
+		c.None = true
+	case f.IsDate():
+		t, day, err := p.parseDate(value.text)
+		if err != nil {
+			return nil, &Error{Pos: value.pos, Msg: err.Error()}
+		}
+		if field.op == OpEq && !day {
+			return nil, &Error{Pos: value.pos, Msg: fmt.Sprintf("%s: needs a date; use < or > to compare with a time", f)}
+		}
+		c.Time, c.Day = t, day
+	case f == FieldPriority:
+		i := slices.Index(Priorities, value.text)
+		if i < 0 {
+			return nil, &Error{Pos: value.pos, Msg: fmt.Sprintf("unknown priority %q; priorities are low, medium, high and urgent", value.text)}
+		}
+		switch field.op {
+		case OpEq:
+			c.Priorities = Priorities[i : i+1]
+		case OpLt:
+			c.Priorities = Priorities[:i]
+		case OpLe:
+			c.Priorities = Priorities[:i+1]
+		case OpGt:
+			c.Priorities = Priorities[i+1:]
+		case OpGe:
+			c.Priorities = Priorities[i:]
+		}
+	case f == FieldID:
+		if _, err := strconv.ParseUint(value.text, 10, 64); err != nil {
+			return nil, &Error{Pos: value.pos, Msg: fmt.Sprintf("id must be a number, not %q", value.text)}
+		}
+	}
+	return c, nil
+}
+
+// parseDate parses a date value. day reports that it names a whole day.
+func (p *parser) parseDate(value string) (t time.Time, day bool, err error) {
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	switch value {
+	case "today":
+		return today, true, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, p.now.Location()); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q; use YYYY-MM-DD, an RFC 3339 timestamp, today, tomorrow or yesterday", value)
+}
@@ -0,0 +1,123 @@
+package query
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+
+func TestParseErrorColumns(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+		msg    string
+	}{
+		{"(status:open tag:infra", 1, "unclosed '('"},
+		{"a (b (c)", 3, "unclosed '('"},
+		{"status:open)", 12, "unexpected ')'"},
+		{"()", 1, "empty parentheses"},
+		{"login colour:red", 7, `unknown field "colour"`},
+		{"due<2026-13-01", 5, `invalid date "2026-13-01"`},
+		{"created>=yesterdayish", 10, `invalid date "yesterdayish"`},
+		{"due:2026-11-01T10:00:00Z", 5, "due: needs a date"},
+		{"priority>=highest", 11, `unknown priority "highest"`},
+		{"status<open", 1, "field status only supports ':'"},
+		{"id:seven", 4, `id must be a number, not "seven"`},
+		{"tag: infra", 5, "missing value after tag:"},
+		{"due>none", 5, "none can only be used with due:"},
+		{`title:"unterminated`, 7, "unterminated string"},
+		{"bug OR", 5, "OR must be followed by a term"},
+		{"AND bug", 1, "AND must follow a term"},
+		{"bug NOT", 5, "NOT must be followed by a term"},
+		{"   ", 1, "query is empty"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.query, testNow)
+		var qerr *Error
+		if !errors.As(err, &qerr) {
+			t.Errorf("Parse(%q) error = %v, want *Error", tt.query, err)
+			continue
+		}
+		if qerr.Pos+1 != tt.column || !strings.Contains(qerr.Msg, tt.msg) {
+			t.Errorf("Parse(%q) error = %q at column %d, want %q at column %d", tt.query, qerr.Msg, qerr.Pos+1, tt.msg, tt.column)
+		}
+	}
+}
+
+func TestStringParsesBackToSameQuery(t *testing.T) {
+	queries := []string{
+		`status:open tag:infra due<2026-11-01 "login bug"`,
+		"a OR b c",
+		"(a OR b) c",
+		"-(e f)",
+		"NOT (e f) g",
+		"-(a OR b)",
+		"- -a",
+		"-(a (b OR -(c d)))",
+		"priority>=high assignee:none",
+		`title:"release: v2" due>2026-11-01T10:00:00Z`,
+		`"say \"hi\"" "back\\slash" "-dash" "OR" "tab	here"`,
+		"due:today OR updated<=yesterday",
+		"id:42 project:none",
+	}
+	for _, input := range queries {
+		q, err := Parse(input, testNow)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", input, err)
+			continue
+		}
+		rendered := q.String()
+		again, err := Parse(rendered, testNow)
+		if err != nil {
+			t.Errorf("Parse(%q), the rendering of %q: %v", rendered, input, err)
+			continue
+		}
+		if !reflect.DeepEqual(withoutPositions(again), withoutPositions(q)) {
+			t.Errorf("%q renders as %q, which parses to a different query", input, rendered)
+		}
+	}
+}
+
+func TestNotStringParenthesizesAnd(t *testing.T) {
+	q, err := Parse("-(e f)", testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := q.String(), "-(e f)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// withoutPositions returns a copy of node with all offsets set to zero, so that
+// trees parsed from differently formatted text compare equal.
+func withoutPositions(node Node) Node {
+	switch n := node.(type) {
+	case *And:
+		return &And{Terms: termsWithoutPositions(n.Terms)}
+	case *Or:
+		return &Or{Terms: termsWithoutPositions(n.Terms)}
+	case *Not:
+		return &Not{Term: withoutPositions(n.Term)}
+	case *Text:
+		c := *n
+		c.Start = 0
+		return &c
+	case *Comparison:
+		c := *n
+		c.Start = 0
+		return &c
+	}
+	return node
+}
+
+func termsWithoutPositions(terms []Node) []Node {
+	out := make([]Node, len(terms))
+	for i, term := range terms {
+		out[i] = withoutPositions(term)
+	}
+	return out
+}
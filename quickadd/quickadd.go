@@ -0,0 +1,435 @@
+// Package quickadd parses free-form task descriptions such as
+// "Fix login bug tomorrow 5pm #backend !high @alice +ProjectX" into task fields.
+//
+// Recognised tokens are removed from the text; the remaining words form the title:
+//
+//	#tag          adds a tag (repeatable)
+//	!priority     sets the priority: low, medium (or med), high or urgent
+//	@name         sets the assignee
+//	+Project      sets the project
+//	date          today, tonight, tomorrow, [on|next] <weekday>, next week, next month,
+//	              in N days|weeks, [on] YYYY-MM-DD
+//	time          [at] 5pm, 5:30pm, 5 pm, 17:00, noon; in N minutes|hours sets date and time
+//
+// Words in double quotes are always kept as title text, e.g. "Call \"next friday\" team".
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Task holds the fields parsed from a quick-add text.
+type Task struct {
+	Title string
+	// Due is the zero time when the text contains no date or time.
+	Due      time.Time
+	Tags     []string
+	Priority string
+	Assignee string
+	Project  string
+}
+
+// defaultHour and defaultMinute are used for a date given without a time:
+// the task is due at the end of that day.
+const (
+	defaultHour   = 23
+	defaultMinute = 59
+	// tonightHour is used for "tonight" without a time.
+	tonightHour = 20
+)
+
+// priorities maps the accepted !priority spellings to the API values.
+var priorities = map[string]string{
+	"low":    "low",
+	"medium": "medium",
+	"med":    "medium",
+	"high":   "high",
+	"urgent": "urgent",
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// word is one whitespace-separated part of the input.
+type word struct {
+	text string
+	// quoted words were enclosed in double quotes and are never interpreted.
+	quoted bool
+}
+
+// key returns the lower-cased text without trailing punctuation, used for matching keywords.
+func (w word) key() string {
+	if w.quoted {
+		return ""
+	}
+	return strings.ToLower(strings.TrimRight(w.text, ",.;"))
+}
+
+// Parse parses text into a Task. Relative dates and times are resolved against now,
+// in the location of now.
+func Parse(text string, now time.Time) (*Task, error) {
+	words, err := split(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{words: words, now: now, loc: now.Location()}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return p.task()
+}
+
+type parser struct {
+	words []word
+	now   time.Time
+	loc   *time.Location
+
+	title    []string
+	result   Task
+	date     *time.Time // midnight of the due date, if given
+	clock    *time.Duration
+	absolute *time.Time // set by "in N minutes|hours"
+	tonight  bool       // "tonight" without a time means tonightHour
+}
+
+func (p *parser) parse() error {
+	for i := 0; i < len(p.words); {
+		w := p.words[i]
+		if w.quoted {
+			p.title = append(p.title, w.text)
+			i++
+			continue
+		}
+		consumed, err := p.token(i)
+		if err != nil {
+			return err
+		}
+		if consumed == 0 {
+			p.title = append(p.title, w.text)
+			consumed = 1
+		}
+		i += consumed
+	}
+	return nil
+}
+
+// token interprets the words starting at i and returns how many it consumed;
+// zero means the word is part of the title.
+func (p *parser) token(i int) (int, error) {
+	w := p.words[i]
+	if value, ok := prefixed(w.text, '#'); ok {
+		p.addTag(value)
+		return 1, nil
+	}
+	if value, ok := prefixed(w.text, '@'); ok {
+		return 1, setOnce(&p.result.Assignee, value, "assignee")
+	}
+	if value, ok := prefixed(w.text, '+'); ok {
+		return 1, setOnce(&p.result.Project, value, "project")
+	}
+	if value, ok := prefixed(w.text, '!'); ok {
+		priority, known := priorities[strings.ToLower(value)]
+		if !known {
+			return 0, fmt.Errorf("unknown priority %q: use !low, !medium, !high or !urgent", w.text)
+		}
+		return 1, setOnce(&p.result.Priority, priority, "priority")
+	}
+	if n, err := p.relative(i); n > 0 || err != nil {
+		return n, err
+	}
+	if n, err := p.dateAt(i); n > 0 || err != nil {
+		return n, err
+	}
+	return p.timeAt(i)
+}
+
+// prefixed reports whether s is the prefix followed by a name that starts with a
+// letter, and returns the name without trailing punctuation.
+func prefixed(s string, prefix byte) (string, bool) {
+	if len(s) < 2 || s[0] != prefix {
+		return "", false
+	}
+	name := strings.TrimRight(s[1:], ",.;")
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		return "", false
+	}
+	return name, true
+}
+
+func setOnce(field *string, value, name string) error {
+	if *field != "" && *field != value {
+		return fmt.Errorf("more than one %s given: %q and %q", name, *field, value)
+	}
+	*field = value
+	return nil
+}
+
+func (p *parser) addTag(tag string) {
+	for _, existing := range p.result.Tags {
+		if existing == tag {
+			return
+		}
+	}
+	p.result.Tags = append(p.result.Tags, tag)
+}
+
+// relative matches "in N <unit>".
+func (p *parser) relative(i int) (int, error) {
+	if p.words[i].key() != "in" || i+2 >= len(p.words) {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(p.words[i+1].key())
+	if err != nil || n < 0 {
+		return 0, nil
+	}
+	var due time.Time
+	switch strings.TrimSuffix(p.words[i+2].key(), "s") {
+	case "minute", "min":
+		due = p.now.Add(time.Duration(n) * time.Minute)
+	case "hour", "hr", "h":
+		due = p.now.Add(time.Duration(n) * time.Hour)
+	case "day":
+		return 3, p.setDate(midnight(p.now).AddDate(0, 0, n))
+	case "week":
+		return 3, p.setDate(midnight(p.now).AddDate(0, 0, 7*n))
+	default:
+		return 0, nil
+	}
+	if p.absolute != nil || p.date != nil || p.clock != nil {
+		return 0, errors.New("\"in N minutes/hours\" cannot be combined with another date or time")
+	}
+	due = due.Truncate(time.Minute)
+	p.absolute = &due
+	return 3, nil
+}
+
+// dateAt matches a date expression, optionally preceded by "on".
+func (p *parser) dateAt(i int) (int, error) {
+	start := i
+	if p.words[i].key() == "on" {
+		i++
+		if i >= len(p.words) {
+			return 0, nil
+		}
+	}
+	key := p.words[i].key()
+	today := midnight(p.now)
+	var date time.Time
+	n := 1
+	switch {
+	case key == "":
+		return 0, nil
+	case key == "today" && start == i:
+		date = today
+	case key == "tonight" && start == i:
+		date = today
+		p.tonight = true
+	case key == "tomorrow" && start == i:
+		date = today.AddDate(0, 0, 1)
+	case key == "next" && start == i && i+1 < len(p.words):
+		next := p.words[i+1].key()
+		n = 2
+		if day, ok := weekdays[next]; ok {
+			date = nextWeekday(today, day)
+		} else if next == "week" {
+			date = today.AddDate(0, 0, 7)
+		} else if next == "month" {
+			date = today.AddDate(0, 1, 0)
+		} else {
+			return 0, nil
+		}
+	default:
+		if day, ok := weekdays[key]; ok {
+			date = nextWeekday(today, day)
+		} else if t, err := time.ParseInLocation("2006-01-02", key, p.loc); err == nil {
+			date = t
+		} else {
+			return 0, nil
+		}
+	}
+	return i - start + n, p.setDate(date)
+}
+
+func (p *parser) setDate(date time.Time) error {
+	if p.date != nil {
+		return fmt.Errorf("more than one date given: %s and %s", p.date.Format("2006-01-02"), date.Format("2006-01-02"))
+	}
+	if p.absolute != nil {
+		return errors.New("\"in N minutes/hours\" cannot be combined with another date or time")
+	}
+	p.date = &date
+	return nil
+}
+
+// timeAt matches a time of day, optionally preceded by "at".
+func (p *parser) timeAt(i int) (int, error) {
+	start := i
+	if p.words[i].key() == "at" {
+		i++
+		if i >= len(p.words) {
+			return 0, nil
+		}
+	}
+	next := ""
+	if i+1 < len(p.words) {
+		next = p.words[i+1].key()
+	}
+	clock, n, ok := parseClock(p.words[i].key(), next)
+	if !ok {
+		return 0, nil
+	}
+	if p.absolute != nil {
+		return 0, errors.New("\"in N minutes/hours\" cannot be combined with another date or time")
+	}
+	if p.clock != nil {
+		return 0, fmt.Errorf("more than one time given: %q", p.words[i].text)
+	}
+	p.clock = &clock
+	return i - start + n, nil
+}
+
+// parseClock parses a time of day such as 5pm, 5:30pm, 17:00 or noon. When word is a
+// bare hour and next is am or pm, both words are consumed.
+func parseClock(word, next string) (time.Duration, int, bool) {
+	if word == "noon" {
+		return 12 * time.Hour, 1, true
+	}
+	n := 1
+	suffix := ""
+	switch {
+	case strings.HasSuffix(word, "am"), strings.HasSuffix(word, "pm"):
+		suffix = word[len(word)-2:]
+		word = word[:len(word)-2]
+	case next == "am" || next == "pm":
+		suffix = next
+		n = 2
+	}
+	hourText, minuteText, hasMinutes := strings.Cut(word, ":")
+	if suffix == "" && !hasMinutes {
+		// A bare number is a title word, as in "Buy 5 apples".
+		return 0, 0, false
+	}
+	hour, err := strconv.Atoi(hourText)
+	if err != nil || len(hourText) > 2 {
+		return 0, 0, false
+	}
+	minute := 0
+	if hasMinutes {
+		if len(minuteText) != 2 {
+			return 0, 0, false
+		}
+		if minute, err = strconv.Atoi(minuteText); err != nil || minute > 59 {
+			return 0, 0, false
+		}
+	}
+	switch suffix {
+	case "":
+		if hour > 23 {
+			return 0, 0, false
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if suffix == "pm" {
+			hour += 12
+		}
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, n, true
+}
+
+// task assembles the parsed fields and resolves the due date.
+func (p *parser) task() (*Task, error) {
+	p.result.Title = strings.Join(p.title, " ")
+	if p.result.Title == "" {
+		return nil, errors.New("no title left after removing dates, tags and other tokens")
+	}
+	switch {
+	case p.absolute != nil:
+		p.result.Due = *p.absolute
+	case p.date != nil && p.clock != nil:
+		p.result.Due = atClock(*p.date, *p.clock)
+	case p.date != nil && p.tonight:
+		p.result.Due = atClock(*p.date, tonightHour*time.Hour)
+	case p.date != nil:
+		p.result.Due = atClock(*p.date, defaultHour*time.Hour+defaultMinute*time.Minute)
+	case p.clock != nil:
+		// A time alone means the next occurrence of that time.
+		due := atClock(p.now, *p.clock)
+		if !due.After(p.now) {
+			due = atClock(p.now.AddDate(0, 0, 1), *p.clock)
+		}
+		p.result.Due = due
+	}
+	return &p.result, nil
+}
+
+// midnight returns the start of the day of t in its location.
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// atClock returns the given time of day on the day of date. Unlike adding the clock to
+// midnight it is correct on days when daylight saving time begins or ends.
+func atClock(date time.Time, clock time.Duration) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, date.Location())
+}
+
+// nextWeekday returns the first day after today that falls on day.
+func nextWeekday(today time.Time, day time.Weekday) time.Time {
+	days := (int(day) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// split breaks text into words at whitespace. Double-quoted sections form single
+// quoted words; an unterminated quote is an error.
+func split(text string) ([]word, error) {
+	var words []word
+	var current strings.Builder
+	inQuotes, quoted := false, false
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, word{text: current.String(), quoted: quoted})
+		}
+		current.Reset()
+		quoted = false
+	}
+	for _, r := range text {
+		switch {
+		case r == '"':
+			if !inQuotes {
+				flush()
+			}
+			inQuotes = !inQuotes
+			quoted = true
+			if !inQuotes {
+				flush()
+			}
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if inQuotes {
+		return nil, errors.New("unterminated double quote")
+	}
+	flush()
+	return words, nil
+}
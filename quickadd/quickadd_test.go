@@ -0,0 +1,125 @@
+package quickadd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata" // America/New_York on hosts without a zoneinfo database
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	loc := newYork(t)
+	// Saturday morning before daylight saving time begins on Sunday, March 8, 2026,
+	// and Saturday noon before it ends on Sunday, November 1, 2026.
+	spring := time.Date(2026, 3, 7, 10, 0, 0, 0, loc)
+	fall := time.Date(2026, 10, 31, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		text string
+		now  time.Time
+		// due is the RFC 3339 due time, empty for none.
+		due   string
+		title string
+	}{
+		{"Pay rent today", spring, "2026-03-07T23:59:00-05:00", "Pay rent"},
+		{"Pay rent tomorrow", spring, "2026-03-08T23:59:00-04:00", "Pay rent"},
+		{"Pay rent tomorrow 5pm", fall, "2026-11-01T17:00:00-05:00", "Pay rent"},
+		{"Call mom tonight", spring, "2026-03-07T20:00:00-05:00", "Call mom"},
+		{"Water plants in 3 days", spring, "2026-03-10T23:59:00-04:00", "Water plants"},
+		{"Water plants in 2 weeks", spring, "2026-03-21T23:59:00-04:00", "Water plants"},
+		// Minutes and hours are elapsed time, so a day is 23 or 25 hours of wall clock.
+		{"Check oven in 24 hours", spring, "2026-03-08T11:00:00-04:00", "Check oven"},
+		{"Check oven in 24 hours", fall, "2026-11-01T11:00:00-05:00", "Check oven"},
+		{"Check oven in 90 minutes", spring, "2026-03-07T11:30:00-05:00", "Check oven"},
+		{"Standup next friday", spring, "2026-03-13T23:59:00-04:00", "Standup"},
+		{"Standup friday", spring, "2026-03-13T23:59:00-04:00", "Standup"},
+		// A weekday is the next one, a week ahead on that day itself.
+		{"Standup on Sat.", spring, "2026-03-14T23:59:00-04:00", "Standup"},
+		{"Review next week", spring, "2026-03-14T23:59:00-04:00", "Review"},
+		{"Review next month", spring, "2026-04-07T23:59:00-04:00", "Review"},
+		{"Renew passport on 2026-07-04", spring, "2026-07-04T23:59:00-04:00", "Renew passport"},
+		// A time alone is its next occurrence.
+		{"Lunch at noon", spring, "2026-03-07T12:00:00-05:00", "Lunch"},
+		{"Lunch 12pm", spring, "2026-03-07T12:00:00-05:00", "Lunch"},
+		{"Backup 12am", spring, "2026-03-08T00:00:00-05:00", "Backup"},
+		{"Stretch 9am", spring, "2026-03-08T09:00:00-04:00", "Stretch"},
+		{"Stretch 9:15 am", fall, "2026-11-01T09:15:00-05:00", "Stretch"},
+		{"Deploy tomorrow at 17:30", spring, "2026-03-08T17:30:00-04:00", "Deploy"},
+		{"Deploy 12am tomorrow", spring, "2026-03-08T00:00:00-05:00", "Deploy"},
+		{"Buy 5 apples", spring, "", "Buy 5 apples"},
+		{"Read 13pm 25:00 9:5", spring, "", "Read 13pm 25:00 9:5"},
+		{`Call "next friday" team`, spring, "", "Call next friday team"},
+		{`Plan "tomorrow" tomorrow`, spring, "2026-03-08T23:59:00-04:00", "Plan tomorrow"},
+		{"Say today's news at", spring, "", "Say today's news at"},
+	}
+	for _, tt := range tests {
+		task, err := Parse(tt.text, tt.now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.text, err)
+			continue
+		}
+		due := ""
+		if !task.Due.IsZero() {
+			due = task.Due.Format(time.RFC3339)
+		}
+		if task.Title != tt.title || due != tt.due {
+			t.Errorf("Parse(%q) at %s = title %q due %q, want %q due %q", tt.text, tt.now.Format(time.RFC3339), task.Title, due, tt.title, tt.due)
+		}
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	now := time.Date(2026, 3, 7, 10, 0, 0, 0, newYork(t))
+	task, err := Parse("Fix login, #backend !HIGH @alice +Website #urgent-fix #backend issue #1 a+b", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Title != "Fix login, issue #1 a+b" || !slices.Equal(task.Tags, []string{"backend", "urgent-fix"}) ||
+		task.Priority != "high" || task.Assignee != "alice" || task.Project != "Website" || !task.Due.IsZero() {
+		t.Errorf("parsed %+v", task)
+	}
+
+	task, err = Parse("Ship it !med @bob @bob", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Priority != "medium" || task.Assignee != "bob" {
+		t.Errorf("!med @bob @bob parsed as priority %q, assignee %q", task.Priority, task.Assignee)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	now := time.Date(2026, 3, 7, 10, 0, 0, 0, newYork(t))
+	tests := []struct {
+		text, want string
+	}{
+		{"Pay rent tomorrow friday", "more than one date"},
+		{"Pay rent 2026-04-01 next week", "more than one date"},
+		{"Pay rent 5pm at 6pm", "more than one time"},
+		{"Pay rent in 2 hours tomorrow", "cannot be combined"},
+		{"Pay rent tomorrow in 30 minutes", "cannot be combined"},
+		{"Pay rent 9am in 1 hour", "cannot be combined"},
+		{"Pay rent @alice @bob", "more than one assignee"},
+		{"Pay rent +home +work", "more than one project"},
+		{"Pay rent !low !high", "more than one priority"},
+		{"Pay rent !asap", "unknown priority"},
+		{`Pay "rent tomorrow`, "unterminated"},
+		{"#home tomorrow 5pm @alice", "no title"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.text, now)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) error %v, want %q", tt.text, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxAttachmentSize is the largest attachment the task_attachments table can hold.
+const maxAttachmentSize = 1<<24 - 1
+
+// ErrAttachmentTooLarge is returned for attachments larger than the MEDIUMBLOB column.
+var ErrAttachmentTooLarge = fmt.Errorf("attachment exceeds %d bytes", maxAttachmentSize)
+
+// attachmentColumns is the select list read by scanAttachment, without the content.
+const attachmentColumns = "a.id, a.task_id, a.filename, a.content_type, a.size, a.created_at"
+
+func scanAttachment(row rowScanner, extra ...any) (*pb.Attachment, error) {
+	var attachment pb.Attachment
+	var createdAt sql.NullTime
+	dest := append([]any{&attachment.Id, &attachment.TaskId, &attachment.Filename, &attachment.ContentType, &attachment.Size, &createdAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		attachment.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	return &attachment, nil
+}
+
+// AddAttachment inserts the attachment within the transaction of the repository,
+// if any, so that a task and its attachments can be created together.
+func (r *sqlTaskRepository) AddAttachment(ctx context.Context, attachment *pb.Attachment) (_ *pb.Attachment, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachment.GetContent()) > maxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	taskID := attachment.GetTaskId()
+	r.logger.Debug("Adding attachment", zap.String("tenantID", tenantID), zap.String("taskID", taskID), zap.String("filename", attachment.GetFilename()))
+	query := `INSERT INTO task_attachments (task_id, filename, content_type, size, content)
+		SELECT id, ?, ?, ?, ? FROM tasks WHERE id = ? AND tenant_id = ?`
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.AddAttachment", query)
+	defer func() { endSpan(span, err) }()
+	result, err := r.conn().ExecContext(ctx, query, attachment.GetFilename(), attachment.GetContentType(),
+		len(attachment.GetContent()), attachment.GetContent(), taskID, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to insert attachment", zap.String("taskID", taskID), zap.Error(err))
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get last insert ID for attachment", zap.Error(err))
+		return nil, err
+	}
+	created, err := scanAttachment(r.conn().QueryRowContext(ctx,
+		"SELECT "+attachmentColumns+" FROM task_attachments a WHERE a.id = ?", id))
+	if err != nil {
+		r.logger.Error("Failed to read inserted attachment", zap.Int64("attachmentID", id), zap.Error(err))
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListAttachments returns the attachments of a task of the current tenant in the
+// order they were added. A task without attachments, or of another tenant, has none.
+func (r *sqlTaskRepository) ListAttachments(ctx context.Context, taskID string) (_ []*pb.Attachment, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT " + attachmentColumns + ` FROM task_attachments a JOIN tasks t ON t.id = a.task_id
+		WHERE a.task_id = ? AND t.tenant_id = ? ORDER BY a.id`
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.ListAttachments", query)
+	defer func() { endSpan(span, err) }()
+	rows, err := r.conn().QueryContext(ctx, query, taskID, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query attachments", zap.String("taskID", taskID), zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var attachments []*pb.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan attachment row", zap.Error(err))
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for attachments", zap.Error(err))
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *sqlTaskRepository) FetchAttachment(ctx context.Context, taskID, attachmentID string) (_ *pb.Attachment, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT " + attachmentColumns + `, a.content FROM task_attachments a JOIN tasks t ON t.id = a.task_id
+		WHERE a.id = ? AND a.task_id = ? AND t.tenant_id = ?`
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.FetchAttachment", query)
+	defer func() { endSpan(span, err) }()
+	var content []byte
+	attachment, err := scanAttachment(r.conn().QueryRowContext(ctx, query, attachmentID, taskID, tenantID), &content)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to fetch attachment", zap.String("attachmentID", attachmentID), zap.Error(err))
+		}
+		return nil, err
+	}
+	attachment.Content = content
+	return attachment, nil
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+
+	"go.uber.org/zap"
+)
+
+// CalendarObject is the CalDAV resource under which a client created a task.
+type CalendarObject struct {
+	TaskID string
+	// Name is the last segment of the resource URL chosen by the client.
+	Name string
+	UID  string
+}
+
+// AddCalendarObject records the resource of a task within the transaction of the
+// repository, if any, so that a task and its resource are created together.
+func (r *sqlTaskRepository) AddCalendarObject(ctx context.Context, object CalendarObject) (err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("Adding CalDAV object", zap.String("tenantID", tenantID), zap.String("taskID", object.TaskID), zap.String("name", object.Name))
+	query := `INSERT INTO caldav_objects (task_id, tenant_id, name, uid)
+		SELECT id, tenant_id, ?, ? FROM tasks WHERE id = ? AND tenant_id = ?`
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.AddCalendarObject", query)
+	defer func() { endSpan(span, err) }()
+	result, err := r.conn().ExecContext(ctx, query, object.Name, object.UID, object.TaskID, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to insert CalDAV object", zap.String("taskID", object.TaskID), zap.Error(err))
+		return err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListCalendarObjects returns the recorded resources of the current tenant by task ID.
+func (r *sqlTaskRepository) ListCalendarObjects(ctx context.Context) (_ map[string]CalendarObject, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := "SELECT task_id, name, uid FROM caldav_objects WHERE tenant_id = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.ListCalendarObjects", query)
+	defer func() { endSpan(span, err) }()
+	rows, err := r.conn().QueryContext(ctx, query, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query CalDAV objects", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	objects := make(map[string]CalendarObject)
+	for rows.Next() {
+		var object CalendarObject
+		if err := rows.Scan(&object.TaskID, &object.Name, &object.UID); err != nil {
+			r.logger.Error("Failed to scan CalDAV object row", zap.Error(err))
+			return nil, err
+		}
+		objects[object.TaskID] = object
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for CalDAV objects", zap.Error(err))
+		return nil, err
+	}
+	return objects, nil
+}
+
+// FetchCalendarObjectByName returns the recorded resource with the given name in
+// the current tenant, or sql.ErrNoRows.
+func (r *sqlTaskRepository) FetchCalendarObjectByName(ctx context.Context, name string) (_ CalendarObject, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return CalendarObject{}, err
+	}
+	query := "SELECT task_id, name, uid FROM caldav_objects WHERE tenant_id = ? AND name = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.FetchCalendarObjectByName", query)
+	defer func() { endSpan(span, err) }()
+	var object CalendarObject
+	err = r.conn().QueryRowContext(ctx, query, tenantID, name).Scan(&object.TaskID, &object.Name, &object.UID)
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to fetch CalDAV object", zap.String("name", name), zap.Error(err))
+	}
+	return object, err
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CalendarRepository stores the iCalendar feeds of the workspaces. The management
+// methods are scoped to the tenant carried by ctx; FetchFeedByTokenHash serves the
+// feed handler, which has only the secret of the URL, and works across all tenants.
+type CalendarRepository interface {
+	// CreateFeed inserts a feed of the tasks of assignee and project, identified by
+	// the hash of its secret token.
+	CreateFeed(ctx context.Context, tokenHash, assignee, project string) (*pb.CalendarFeed, error)
+	ListFeeds(ctx context.Context) ([]*pb.CalendarFeed, error)
+	// DeleteFeed deletes a feed of the current tenant, or returns sql.ErrNoRows.
+	DeleteFeed(ctx context.Context, feedID string) (*pb.CalendarFeed, error)
+
+	// FetchFeedByTokenHash returns the tenant and the feed with the given token hash,
+	// or sql.ErrNoRows.
+	FetchFeedByTokenHash(ctx context.Context, tokenHash string) (tenantID string, feed *pb.CalendarFeed, err error)
+}
+
+type sqlCalendarRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLCalendarRepository creates a new SQL-based calendar feed repository.
+func NewSQLCalendarRepository(db *sql.DB, logger *zap.Logger) CalendarRepository {
+	return &sqlCalendarRepository{db: db, logger: logger.Named("repository")}
+}
+
+func scanCalendarFeed(row rowScanner, extra ...any) (*pb.CalendarFeed, error) {
+	var feed pb.CalendarFeed
+	var createdAt sql.NullTime
+	if err := row.Scan(append([]any{&feed.Id, &feed.Assignee, &feed.Project, &createdAt}, extra...)...); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		feed.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	return &feed, nil
+}
+
+func (r *sqlCalendarRepository) CreateFeed(ctx context.Context, tokenHash, assignee, project string) (*pb.CalendarFeed, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Creating calendar feed", zap.String("tenantID", tenantID), zap.String("assignee", assignee), zap.String("project", project))
+	result, err := r.db.ExecContext(ctx, "INSERT INTO calendar_feeds (tenant_id, token_hash, assignee, project) VALUES (?, ?, ?, ?)",
+		tenantID, tokenHash, assignee, project)
+	if err != nil {
+		r.logger.Error("Failed to insert calendar feed", zap.Error(err))
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get last insert ID for calendar feed", zap.Error(err))
+		return nil, err
+	}
+	return r.fetchFeed(ctx, tenantID, fmt.Sprintf("%d", id))
+}
+
+// ListFeeds returns the feeds of the current tenant, oldest first.
+func (r *sqlCalendarRepository) ListFeeds(ctx context.Context) ([]*pb.CalendarFeed, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT id, assignee, project, created_at FROM calendar_feeds WHERE tenant_id = ? ORDER BY id", tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query calendar feeds", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var feeds []*pb.CalendarFeed
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan calendar feed row", zap.Error(err))
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for calendar feeds", zap.Error(err))
+		return nil, err
+	}
+	return feeds, nil
+}
+
+func (r *sqlCalendarRepository) DeleteFeed(ctx context.Context, feedID string) (*pb.CalendarFeed, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Deleting calendar feed", zap.String("tenantID", tenantID), zap.String("feedID", feedID))
+	feed, err := r.fetchFeed(ctx, tenantID, feedID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM calendar_feeds WHERE id = ? AND tenant_id = ?", feedID, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to delete calendar feed", zap.String("feedID", feedID), zap.Error(err))
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return feed, nil
+}
+
+func (r *sqlCalendarRepository) FetchFeedByTokenHash(ctx context.Context, tokenHash string) (string, *pb.CalendarFeed, error) {
+	var tenantID string
+	feed, err := scanCalendarFeed(r.db.QueryRowContext(ctx,
+		"SELECT id, assignee, project, created_at, tenant_id FROM calendar_feeds WHERE token_hash = ?", tokenHash), &tenantID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to fetch calendar feed by token", zap.Error(err))
+		}
+		return "", nil, err
+	}
+	return tenantID, feed, nil
+}
+
+func (r *sqlCalendarRepository) fetchFeed(ctx context.Context, tenantID, feedID string) (*pb.CalendarFeed, error) {
+	feed, err := scanCalendarFeed(r.db.QueryRowContext(ctx,
+		"SELECT id, assignee, project, created_at FROM calendar_feeds WHERE id = ? AND tenant_id = ?", feedID, tenantID))
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to fetch calendar feed", zap.String("feedID", feedID), zap.Error(err))
+	}
+	return feed, err
+}
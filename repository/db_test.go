@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"Go_Test/tenant"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// testDSNVariable names the environment variable with the DSN of a MySQL server
+// for the tests of this package, e.g. root:secret@tcp(localhost:3306)/. Each test
+// creates a database of its own there and drops it again; without the variable
+// the tests are skipped.
+const testDSNVariable = "TEST_MYSQL_DSN"
+
+// openTestDB creates a database with the schema of init-db/init-db.sh and returns
+// a connection to it.
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv(testDSNVariable)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNVariable)
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("invalid %s: %v", testDSNVariable, err)
+	}
+	cfg.ParseTime = true
+	cfg.MultiStatements = false
+
+	admin, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+	name := "tasks_test_" + randomHex(t, 6)
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP DATABASE " + name) })
+
+	cfg.DBName = name
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, statement := range schemaStatements(t) {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("%v in:\n%s", err, statement)
+		}
+	}
+	return db
+}
+
+// schemaStatements returns the SQL statements of init-db/init-db.sh, the schema
+// that the Docker setup creates.
+func schemaStatements(t *testing.T) []string {
+	t.Helper()
+	script, err := os.ReadFile("../init-db/init-db.sh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, sqlText, ok := strings.Cut(string(script), "<<-END\n")
+	if ok {
+		sqlText, _, ok = strings.Cut(sqlText, "\nEND\n")
+	}
+	if !ok {
+		t.Fatal("init-db.sh has no SQL_COMMANDS heredoc")
+	}
+	var lines []string
+	for _, line := range strings.Split(sqlText, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "--") || strings.HasPrefix(trimmed, "USE ") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	var statements []string
+	for _, statement := range strings.Split(strings.Join(lines, "\n"), ";\n") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, strings.TrimSuffix(statement, ";"))
+		}
+	}
+	return statements
+}
+
+// seedWorkspace inserts a workspace with the given status and returns a context
+// carrying its ID as the tenant.
+func seedWorkspace(t *testing.T, db *sql.DB, name, status string) context.Context {
+	t.Helper()
+	result, err := db.Exec("INSERT INTO workspaces (name, status) VALUES (?, ?)", name, status)
+	if err != nil {
+		t.Fatalf("insert workspace %s: %v", name, err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tenant.WithID(context.Background(), strconv.FormatInt(id, 10))
+}
+
+func randomHex(t *testing.T, n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// newTestTaskRepository returns a task repository over db that searches with the
+// FULLTEXT index of MySQL.
+func newTestTaskRepository(db *sql.DB) (TaskRepository, SearchIndex) {
+	tracer := noop.NewTracerProvider().Tracer("test")
+	index := &mysqlSearchIndex{db: db, logger: zap.NewNop(), tracer: tracer}
+	return NewSQLTaskRepository(db, zap.NewNop(), noop.NewTracerProvider(), index), index
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrSenderTaken is returned by AddSender for an address that another tenant allows.
+var ErrSenderTaken = errors.New("sender belongs to another workspace")
+
+// InboundRepository stores the allow-list of the inbound email receiver. The
+// management methods are scoped to the tenant carried by ctx; LookupSender serves
+// the receiver and works across all tenants.
+type InboundRepository interface {
+	// AddSender allows email for the current tenant or, when it is already allowed,
+	// changes its assignee.
+	AddSender(ctx context.Context, email, assignee string) (*pb.InboundSender, error)
+	ListSenders(ctx context.Context) ([]*pb.InboundSender, error)
+	// RemoveSender removes email from the current tenant, or returns sql.ErrNoRows.
+	RemoveSender(ctx context.Context, email string) (*pb.InboundSender, error)
+
+	// LookupSender returns the tenant and the sender entry of email, or sql.ErrNoRows.
+	LookupSender(ctx context.Context, email string) (tenantID string, sender *pb.InboundSender, err error)
+}
+
+type sqlInboundRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLInboundRepository creates a new SQL-based inbound sender repository.
+func NewSQLInboundRepository(db *sql.DB, logger *zap.Logger) InboundRepository {
+	return &sqlInboundRepository{db: db, logger: logger.Named("repository")}
+}
+
+func scanInboundSender(row rowScanner, extra ...any) (*pb.InboundSender, error) {
+	var sender pb.InboundSender
+	var createdAt sql.NullTime
+	if err := row.Scan(append([]any{&sender.Email, &sender.Assignee, &createdAt}, extra...)...); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		sender.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	return &sender, nil
+}
+
+// AddSender only updates the assignee of an address the current tenant already
+// owns; an address owned by another tenant is left alone and reported as taken.
+func (r *sqlInboundRepository) AddSender(ctx context.Context, email, assignee string) (*pb.InboundSender, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	email = strings.ToLower(email)
+	r.logger.Debug("Adding inbound sender", zap.String("tenantID", tenantID), zap.String("email", email))
+	_, err = r.db.ExecContext(ctx, `INSERT INTO inbound_senders (email, tenant_id, assignee) VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE assignee = IF(tenant_id = VALUES(tenant_id), VALUES(assignee), assignee)`,
+		email, tenantID, assignee)
+	if err != nil {
+		r.logger.Error("Failed to upsert inbound sender", zap.Error(err))
+		return nil, err
+	}
+	owner, sender, err := r.LookupSender(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if owner != tenantID {
+		return nil, ErrSenderTaken
+	}
+	return sender, nil
+}
+
+// ListSenders returns the allowed senders of the current tenant ordered by address.
+func (r *sqlInboundRepository) ListSenders(ctx context.Context) ([]*pb.InboundSender, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT email, assignee, created_at FROM inbound_senders WHERE tenant_id = ? ORDER BY email", tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query inbound senders", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var senders []*pb.InboundSender
+	for rows.Next() {
+		sender, err := scanInboundSender(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan inbound sender row", zap.Error(err))
+			return nil, err
+		}
+		senders = append(senders, sender)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for inbound senders", zap.Error(err))
+		return nil, err
+	}
+	return senders, nil
+}
+
+func (r *sqlInboundRepository) RemoveSender(ctx context.Context, email string) (*pb.InboundSender, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	email = strings.ToLower(email)
+	r.logger.Debug("Removing inbound sender", zap.String("tenantID", tenantID), zap.String("email", email))
+	owner, sender, err := r.LookupSender(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if owner != tenantID {
+		return nil, sql.ErrNoRows
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM inbound_senders WHERE email = ? AND tenant_id = ?", email, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to delete inbound sender", zap.String("email", email), zap.Error(err))
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return sender, nil
+}
+
+func (r *sqlInboundRepository) LookupSender(ctx context.Context, email string) (string, *pb.InboundSender, error) {
+	var tenantID string
+	sender, err := scanInboundSender(r.db.QueryRowContext(ctx,
+		"SELECT email, assignee, created_at, tenant_id FROM inbound_senders WHERE email = ?", strings.ToLower(email)), &tenantID)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to look up inbound sender", zap.String("email", email), zap.Error(err))
+		}
+		return "", nil, err
+	}
+	return tenantID, sender, nil
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NotificationRepository stores the email preferences of assignees and records the
+// reminders and digests sent to them. The preference methods are scoped to the
+// tenant carried by ctx, like TaskRepository; the scheduling methods serve the
+// notifier and work across all tenants.
+type NotificationRepository interface {
+	// SetPreferences creates or replaces the preferences of prefs.Assignee.
+	SetPreferences(ctx context.Context, prefs *pb.NotificationPreferences) (*pb.NotificationPreferences, error)
+	// GetPreferences returns the preferences of an assignee, or sql.ErrNoRows.
+	GetPreferences(ctx context.Context, assignee string) (*pb.NotificationPreferences, error)
+	ListPreferences(ctx context.Context) ([]*pb.NotificationPreferences, error)
+	// DeletePreferences deletes the preferences of an assignee, or returns sql.ErrNoRows.
+	DeletePreferences(ctx context.Context, assignee string) (*pb.NotificationPreferences, error)
+
+	// DueReminders returns up to limit open tasks due within (from, to] whose
+	// assignee enabled reminders and that were not reminded of their due date yet.
+	DueReminders(ctx context.Context, from, to time.Time, limit int) ([]Reminder, error)
+	// ClaimReminder records the reminder of a task's due date and reports whether
+	// it was not recorded before, so that only one server sends it.
+	ClaimReminder(ctx context.Context, reminder Reminder) (bool, error)
+	// ReleaseReminder forgets a claimed reminder that could not be sent, so that it
+	// is sent again.
+	ReleaseReminder(ctx context.Context, reminder Reminder) error
+	// DigestRecipients returns the preferences of every assignee that enabled the digest.
+	DigestRecipients(ctx context.Context) ([]DigestRecipient, error)
+	// ClaimDigest records that the digest of day (YYYY-MM-DD) is sent to a recipient
+	// and reports whether it was not recorded for that day or a later one before.
+	ClaimDigest(ctx context.Context, recipient DigestRecipient, day string) (bool, error)
+	// ReleaseDigest undoes ClaimDigest after the digest could not be sent.
+	ReleaseDigest(ctx context.Context, recipient DigestRecipient, day string) error
+}
+
+// Reminder is a task whose due date is close.
+type Reminder struct {
+	TenantID string
+	Task     *pb.Task
+	// DueAt is the due date the reminder is sent for.
+	DueAt time.Time
+}
+
+// DigestRecipient is an assignee that receives the daily digest.
+type DigestRecipient struct {
+	TenantID    string
+	Preferences *pb.NotificationPreferences
+	// LastDigestOn is the day of the last digest sent, or empty.
+	LastDigestOn string
+}
+
+type sqlNotificationRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLNotificationRepository creates a new SQL-based notification repository.
+func NewSQLNotificationRepository(db *sql.DB, logger *zap.Logger) NotificationRepository {
+	return &sqlNotificationRepository{db: db, logger: logger.Named("repository")}
+}
+
+const preferenceColumns = "assignee, email, reminders, digest, time_zone"
+
+func scanPreferences(row rowScanner, extra ...any) (*pb.NotificationPreferences, error) {
+	var prefs pb.NotificationPreferences
+	dest := append([]any{&prefs.Assignee, &prefs.Email, &prefs.Reminders, &prefs.Digest, &prefs.TimeZone}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SetPreferences keeps the day of the last digest, so that changing the
+// preferences does not send a second digest on the same day.
+func (r *sqlNotificationRepository) SetPreferences(ctx context.Context, prefs *pb.NotificationPreferences) (*pb.NotificationPreferences, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Setting notification preferences", zap.String("tenantID", tenantID), zap.String("assignee", prefs.GetAssignee()))
+	_, err = r.db.ExecContext(ctx, `INSERT INTO notification_preferences (tenant_id, assignee, email, reminders, digest, time_zone)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE email = VALUES(email), reminders = VALUES(reminders), digest = VALUES(digest), time_zone = VALUES(time_zone)`,
+		tenantID, prefs.GetAssignee(), prefs.GetEmail(), prefs.GetReminders(), prefs.GetDigest(), prefs.GetTimeZone())
+	if err != nil {
+		r.logger.Error("Failed to upsert notification preferences", zap.Error(err))
+		return nil, err
+	}
+	return r.GetPreferences(ctx, prefs.GetAssignee())
+}
+
+func (r *sqlNotificationRepository) GetPreferences(ctx context.Context, assignee string) (*pb.NotificationPreferences, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := scanPreferences(r.db.QueryRowContext(ctx,
+		"SELECT "+preferenceColumns+" FROM notification_preferences WHERE tenant_id = ? AND assignee = ?", tenantID, assignee))
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to fetch notification preferences", zap.String("assignee", assignee), zap.Error(err))
+	}
+	return prefs, err
+}
+
+// ListPreferences returns the preferences of the current tenant ordered by assignee.
+func (r *sqlNotificationRepository) ListPreferences(ctx context.Context) ([]*pb.NotificationPreferences, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT "+preferenceColumns+" FROM notification_preferences WHERE tenant_id = ? ORDER BY assignee", tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query notification preferences", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var list []*pb.NotificationPreferences
+	for rows.Next() {
+		prefs, err := scanPreferences(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan notification preferences row", zap.Error(err))
+			return nil, err
+		}
+		list = append(list, prefs)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for notification preferences", zap.Error(err))
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *sqlNotificationRepository) DeletePreferences(ctx context.Context, assignee string) (*pb.NotificationPreferences, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Deleting notification preferences", zap.String("tenantID", tenantID), zap.String("assignee", assignee))
+	prefs, err := r.GetPreferences(ctx, assignee)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM notification_preferences WHERE tenant_id = ? AND assignee = ?", tenantID, assignee)
+	if err != nil {
+		r.logger.Error("Failed to delete notification preferences", zap.String("assignee", assignee), zap.Error(err))
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return prefs, nil
+}
+
+// activeTenant returns the condition that the workspace of the rows of table is
+// active: the members of suspended workspaces receive no emails.
+func activeTenant(table string) string {
+	return "EXISTS (SELECT 1 FROM workspaces w WHERE w.id = " + table + ".tenant_id AND w.status = 'active')"
+}
+
+// DueReminders selects the tasks with EXISTS conditions rather than joins, so that
+// taskColumns can be read unqualified.
+func (r *sqlNotificationRepository) DueReminders(ctx context.Context, from, to time.Time, limit int) ([]Reminder, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tenant_id, due_at, `+taskColumns+` FROM tasks
+		WHERE due_at > ? AND due_at <= ? AND COALESCE(status, '') <> 'completed' AND assignee <> ''
+		AND `+activeTenant("tasks")+`
+		AND EXISTS (SELECT 1 FROM notification_preferences p
+			WHERE p.tenant_id = tasks.tenant_id AND p.assignee = tasks.assignee AND p.reminders AND p.email <> '')
+		AND NOT EXISTS (SELECT 1 FROM task_reminders r WHERE r.task_id = tasks.id AND r.due_at = tasks.due_at)
+		ORDER BY due_at LIMIT ?`, from.UTC(), to.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var reminders []Reminder
+	for rows.Next() {
+		var reminder Reminder
+		task, err := scanTask(prefixScanner{rows, []any{&reminder.TenantID, &reminder.DueAt}})
+		if err != nil {
+			return nil, err
+		}
+		reminder.Task = task
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (r *sqlNotificationRepository) ClaimReminder(ctx context.Context, reminder Reminder) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "INSERT IGNORE INTO task_reminders (task_id, due_at) VALUES (?, ?)",
+		reminder.Task.GetId(), reminder.DueAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+func (r *sqlNotificationRepository) ReleaseReminder(ctx context.Context, reminder Reminder) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM task_reminders WHERE task_id = ? AND due_at = ?",
+		reminder.Task.GetId(), reminder.DueAt)
+	return err
+}
+
+func (r *sqlNotificationRepository) DigestRecipients(ctx context.Context) ([]DigestRecipient, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT tenant_id, `+preferenceColumns+`, last_digest_on
+		FROM notification_preferences WHERE digest AND email <> '' AND `+activeTenant("notification_preferences")+`
+		ORDER BY tenant_id, assignee`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var recipients []DigestRecipient
+	for rows.Next() {
+		var recipient DigestRecipient
+		var lastDigestOn sql.NullTime
+		prefs, err := scanPreferences(prefixScanner{rows, []any{&recipient.TenantID}}, &lastDigestOn)
+		if err != nil {
+			return nil, err
+		}
+		recipient.Preferences = prefs
+		if lastDigestOn.Valid {
+			recipient.LastDigestOn = lastDigestOn.Time.Format(time.DateOnly)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, rows.Err()
+}
+
+func (r *sqlNotificationRepository) ClaimDigest(ctx context.Context, recipient DigestRecipient, day string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE notification_preferences SET last_digest_on = ?
+		WHERE tenant_id = ? AND assignee = ? AND (last_digest_on IS NULL OR last_digest_on < ?)`,
+		day, recipient.TenantID, recipient.Preferences.GetAssignee(), day)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n == 1, err
+}
+
+func (r *sqlNotificationRepository) ReleaseDigest(ctx context.Context, recipient DigestRecipient, day string) error {
+	previous := sql.NullString{String: recipient.LastDigestOn, Valid: recipient.LastDigestOn != ""}
+	_, err := r.db.ExecContext(ctx, `UPDATE notification_preferences SET last_digest_on = ?
+		WHERE tenant_id = ? AND assignee = ? AND last_digest_on = ?`,
+		previous, recipient.TenantID, recipient.Preferences.GetAssignee(), day)
+	return err
+}
+
+// prefixScanner reads leading columns into prefix before handing the remaining
+// columns to dest, so that scanTask and scanPreferences can read rows with extra
+// columns in front.
+type prefixScanner struct {
+	row    rowScanner
+	prefix []any
+}
+
+func (s prefixScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(s.prefix, dest...)...)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestNotificationSchedulingSkipsSuspendedWorkspaces(t *testing.T) {
+	db := openTestDB(t)
+	tasks, _ := newTestTaskRepository(db)
+	notifications := NewSQLNotificationRepository(db, zap.NewNop())
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	tenants := make(map[string]string)
+	for _, name := range []string{"active", "suspended"} {
+		ctx := seedWorkspace(t, db, name, name)
+		tenantID, _ := tenant.IDFromContext(ctx)
+		tenants[tenantID] = name
+		if _, err := notifications.SetPreferences(ctx, &pb.NotificationPreferences{
+			Assignee: "alice", Email: "alice@" + name + ".example.com", Reminders: true, Digest: true,
+		}); err != nil {
+			t.Fatalf("SetPreferences: %v", err)
+		}
+		if _, err := tasks.AddTask(ctx, &pb.Task{Title: "Due soon", Status: "pending", Assignee: "alice",
+			DueAt: now.Add(30 * time.Minute).Format(time.RFC3339)}); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+	}
+
+	reminders, err := notifications.DueReminders(context.Background(), now, now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("DueReminders: %v", err)
+	}
+	if len(reminders) != 1 || tenants[reminders[0].TenantID] != "active" {
+		t.Errorf("DueReminders returned %d reminders (%v), want the one of the active workspace", len(reminders), reminders)
+	}
+
+	recipients, err := notifications.DigestRecipients(context.Background())
+	if err != nil {
+		t.Fatalf("DigestRecipients: %v", err)
+	}
+	if len(recipients) != 1 || tenants[recipients[0].TenantID] != "active" {
+		t.Errorf("DigestRecipients returned %d recipients (%v), want the one of the active workspace", len(recipients), recipients)
+	}
+}
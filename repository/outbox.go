@@ -0,0 +1,203 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Event types of the outbox_events table, one per TaskEvent variant.
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+)
+
+// EventTypes lists every event type, in the order of the task lifecycle.
+var EventTypes = []string{EventTaskCreated, EventTaskUpdated, EventTaskCompleted, EventTaskDeleted}
+
+// EventType returns the event type of event, e.g. task.completed for a TaskCompleted.
+func EventType(event *pb.TaskEvent) string {
+	switch event.GetEvent().(type) {
+	case *pb.TaskEvent_TaskCreated:
+		return EventTaskCreated
+	case *pb.TaskEvent_TaskUpdated:
+		return EventTaskUpdated
+	case *pb.TaskEvent_TaskCompleted:
+		return EventTaskCompleted
+	case *pb.TaskEvent_TaskDeleted:
+		return EventTaskDeleted
+	}
+	return ""
+}
+
+// EventTask returns the task an event is about: its state after the change or,
+// for TaskDeleted, before the deletion.
+func EventTask(event *pb.TaskEvent) *pb.Task {
+	switch e := event.GetEvent().(type) {
+	case *pb.TaskEvent_TaskCreated:
+		return e.TaskCreated.GetTask()
+	case *pb.TaskEvent_TaskUpdated:
+		return e.TaskUpdated.GetTask()
+	case *pb.TaskEvent_TaskCompleted:
+		return e.TaskCompleted.GetTask()
+	case *pb.TaskEvent_TaskDeleted:
+		return e.TaskDeleted.GetTask()
+	}
+	return nil
+}
+
+func taskCreated(task *pb.Task) *pb.TaskEvent {
+	return &pb.TaskEvent{Event: &pb.TaskEvent_TaskCreated{TaskCreated: &pb.TaskCreated{Task: task}}}
+}
+
+func taskDeleted(task *pb.Task) *pb.TaskEvent {
+	return &pb.TaskEvent{Event: &pb.TaskEvent_TaskDeleted{TaskDeleted: &pb.TaskDeleted{Task: task}}}
+}
+
+// taskChanged returns the event of a change from previous to task: TaskCompleted
+// when the status changed to completed, TaskUpdated otherwise.
+func taskChanged(previous, task *pb.Task) *pb.TaskEvent {
+	if task.GetStatus() == "completed" && previous.GetStatus() != "completed" {
+		return &pb.TaskEvent{Event: &pb.TaskEvent_TaskCompleted{TaskCompleted: &pb.TaskCompleted{
+			Task: task, PreviousStatus: previous.GetStatus(),
+		}}}
+	}
+	return &pb.TaskEvent{Event: &pb.TaskEvent_TaskUpdated{TaskUpdated: &pb.TaskUpdated{
+		Task: task, Previous: previous, ChangedFields: changedFields(previous, task),
+	}}}
+}
+
+// changedFields names the editable fields that differ between a and b.
+func changedFields(a, b *pb.Task) []string {
+	var fields []string
+	for _, f := range []struct {
+		name    string
+		changed bool
+	}{
+		{"title", a.GetTitle() != b.GetTitle()},
+		{"description", a.GetDescription() != b.GetDescription()},
+		{"status", a.GetStatus() != b.GetStatus()},
+		{"due_at", a.GetDueAt() != b.GetDueAt()},
+		{"tags", !slices.Equal(a.GetTags(), b.GetTags())},
+		{"priority", a.GetPriority() != b.GetPriority()},
+		{"assignee", a.GetAssignee() != b.GetAssignee()},
+		{"project", a.GetProject() != b.GetProject()},
+	} {
+		if f.changed {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// writeEvent records event in the outbox within tx, so that the event exists if and
+// only if the change it describes is committed. The ID, workspace and time of the
+// event are the columns of its row and filled in by decodeEvent.
+func writeEvent(ctx context.Context, tx *sql.Tx, tenantID string, event *pb.TaskEvent) error {
+	payload, err := protojson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO outbox_events (tenant_id, event_type, task_id, payload) VALUES (?, ?, ?, ?)",
+		tenantID, EventType(event), EventTask(event).GetId(), payload)
+	return err
+}
+
+// decodeEvent rebuilds an event from the columns of its outbox row.
+func decodeEvent(id, tenantID string, occurredAt time.Time, payload []byte) (*pb.TaskEvent, error) {
+	var event pb.TaskEvent
+	if err := protojson.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox event %s: %w", id, err)
+	}
+	event.Id = id
+	event.WorkspaceId = tenantID
+	event.OccurredAt = occurredAt.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	return &event, nil
+}
+
+// OutboxRepository reads the outbox for the event relay. Unlike the webhook
+// dispatcher, which keeps its own progress in dispatched_at, the relay marks
+// events in published_at.
+type OutboxRepository interface {
+	// PublishPending passes up to limit unpublished events to publish, oldest first,
+	// and marks the ones it accepted as published. It stops at the first error of
+	// publish and returns it together with the number of published events.
+	PublishPending(ctx context.Context, limit int, publish func(context.Context, *pb.TaskEvent) error) (int, error)
+}
+
+type sqlOutboxRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLOutboxRepository creates a new SQL-based outbox repository.
+func NewSQLOutboxRepository(db *sql.DB, logger *zap.Logger) OutboxRepository {
+	return &sqlOutboxRepository{db: db, logger: logger.Named("repository")}
+}
+
+// PublishPending holds row locks on the batch while publishing without SKIP LOCKED,
+// so that relays of several servers take turns and events keep their order.
+// Events are published at least once: should the commit fail, the next call
+// publishes them again.
+//
+// The locks also cover dispatched_at, so FanOutEvents skips the batch until the
+// commit and may queue later events first: the order holds for the event bus only.
+func (r *sqlOutboxRepository) PublishPending(ctx context.Context, limit int, publish func(context.Context, *pb.TaskEvent) error) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, tenant_id, created_at, payload FROM outbox_events
+		WHERE published_at IS NULL ORDER BY id LIMIT ? FOR UPDATE`, limit)
+	if err != nil {
+		return 0, err
+	}
+	var events []*pb.TaskEvent
+	for rows.Next() {
+		var id, tenantID string
+		var createdAt time.Time
+		var payload []byte
+		if err := rows.Scan(&id, &tenantID, &createdAt, &payload); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		event, err := decodeEvent(id, tenantID, createdAt, payload)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		events = append(events, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var published []any
+	var publishErr error
+	for _, event := range events {
+		if publishErr = publish(ctx, event); publishErr != nil {
+			break
+		}
+		published = append(published, event.GetId())
+	}
+	if len(published) > 0 {
+		if _, err := tx.ExecContext(ctx, "UPDATE outbox_events SET published_at = CURRENT_TIMESTAMP(3) WHERE id IN ("+placeholders(len(published))+")", published...); err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	return len(published), publishErr
+}
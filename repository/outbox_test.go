@@ -0,0 +1,70 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestPublishPendingMarksAcceptedEvents(t *testing.T) {
+	db := openTestDB(t)
+	ctx := seedWorkspace(t, db, "alpha", "active")
+	tasks, _ := newTestTaskRepository(db)
+	var taskIDs []string
+	for _, title := range []string{"First", "Second", "Third"} {
+		added, err := tasks.AddTask(ctx, &pb.Task{Title: title, Status: "pending"})
+		if err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+		taskIDs = append(taskIDs, added.GetId())
+	}
+	outbox := NewSQLOutboxRepository(db, zap.NewNop())
+
+	// The bus rejects the second event: only the first is marked as published.
+	errRejected := errors.New("rejected")
+	var published []string
+	n, err := outbox.PublishPending(context.Background(), 10, func(ctx context.Context, event *pb.TaskEvent) error {
+		if len(published) == 1 {
+			return errRejected
+		}
+		published = append(published, EventTask(event).GetId())
+		return nil
+	})
+	if n != 1 || !errors.Is(err, errRejected) {
+		t.Fatalf("PublishPending = %d, %v; want 1 and the error of publish", n, err)
+	}
+	wantPublished(t, db, map[string]bool{taskIDs[0]: true, taskIDs[1]: false, taskIDs[2]: false})
+
+	// The next call starts at the rejected event and keeps the order.
+	published = nil
+	n, err = outbox.PublishPending(context.Background(), 10, func(ctx context.Context, event *pb.TaskEvent) error {
+		published = append(published, EventTask(event).GetId())
+		return nil
+	})
+	if err != nil || n != 2 {
+		t.Fatalf("PublishPending = %d, %v; want 2", n, err)
+	}
+	if len(published) != 2 || published[0] != taskIDs[1] || published[1] != taskIDs[2] {
+		t.Errorf("published the events of tasks %v, want %v", published, taskIDs[1:])
+	}
+	wantPublished(t, db, map[string]bool{taskIDs[0]: true, taskIDs[1]: true, taskIDs[2]: true})
+}
+
+// wantPublished checks which task.created events have published_at set.
+func wantPublished(t *testing.T, db *sql.DB, want map[string]bool) {
+	t.Helper()
+	for taskID, wantSet := range want {
+		var published bool
+		err := db.QueryRow("SELECT published_at IS NOT NULL FROM outbox_events WHERE task_id = ? AND event_type = ?", taskID, EventTaskCreated).Scan(&published)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if published != wantSet {
+			t.Errorf("event of task %s: published_at set = %v, want %v", taskID, published, wantSet)
+		}
+	}
+}
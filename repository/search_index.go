@@ -0,0 +1,198 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	cfg "Go_Test/config"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Search index backends selectable with the search_index setting.
+const (
+	SearchIndexMySQL = "mysql"
+	SearchIndexBleve = "bleve"
+)
+
+// SearchIndex is a full-text index over the titles and descriptions of tasks.
+// Search, IndexTask and RemoveTask are scoped to the tenant carried by ctx.
+type SearchIndex interface {
+	// Backend names the implementation, SearchIndexMySQL or SearchIndexBleve.
+	Backend() string
+	// Search returns up to limit tasks that match the words of text, best match first.
+	Search(ctx context.Context, text string, limit int) ([]SearchHit, error)
+	// IndexTask adds task to the index or replaces its previous entry.
+	IndexTask(ctx context.Context, task *pb.Task) error
+	// RemoveTask removes a task from the index.
+	RemoveTask(ctx context.Context, taskID string) error
+	// Rebuild discards the index and rebuilds it from the tasks of all tenants.
+	// It returns the number of indexed tasks.
+	Rebuild(ctx context.Context) (int64, error)
+}
+
+// SearchHit is a task found by SearchIndex.Search.
+type SearchHit struct {
+	TaskID string
+	// Score is the relevance reported by the backend; it is only comparable within one search.
+	Score float64
+	// Highlights holds a snippet of each field that matched.
+	Highlights []Highlight
+}
+
+// Highlight is a snippet of a task field with the byte ranges of the matched words.
+type Highlight struct {
+	Field    string
+	Fragment string
+	Matches  [][2]int
+}
+
+type SearchIndexParams struct {
+	fx.In
+	Lifecycle      fx.Lifecycle
+	Config         *cfg.Config
+	DB             *sql.DB
+	Logger         *zap.Logger
+	TracerProvider trace.TracerProvider
+}
+
+// NewSearchIndex creates the search index selected by the search_index setting.
+func NewSearchIndex(p SearchIndexParams) (SearchIndex, error) {
+	logger := p.Logger.Named("repository")
+	tracer := p.TracerProvider.Tracer("Go_Test/repository")
+	switch p.Config.SearchIndex {
+	case SearchIndexMySQL:
+		return &mysqlSearchIndex{db: p.DB, logger: logger, tracer: tracer}, nil
+	case SearchIndexBleve:
+		return newBleveSearchIndex(p.Lifecycle, p.Config.SearchIndexPath, p.DB, logger, tracer)
+	}
+	return nil, fmt.Errorf("unknown search index %q", p.Config.SearchIndex)
+}
+
+const (
+	// snippetLength is the approximate length in bytes of description snippets.
+	snippetLength = 160
+	// snippetContext is how far before the first match a snippet starts.
+	snippetContext = 40
+	ellipsis       = "…"
+)
+
+// highlight returns a snippet of text with the given matches. Titles are kept
+// whole; longer descriptions are cut around the first match. It returns false
+// when there is no match.
+func highlight(field, text string, matches [][2]int) (Highlight, bool) {
+	if len(matches) == 0 {
+		return Highlight{}, false
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+	merged := matches[:1]
+	for _, m := range matches[1:] {
+		last := &merged[len(merged)-1]
+		if m[0] <= last[1] {
+			last[1] = max(last[1], m[1])
+			continue
+		}
+		merged = append(merged, m)
+	}
+	if field == "title" || len(text) <= snippetLength {
+		return Highlight{Field: field, Fragment: oneLine(text), Matches: merged}, true
+	}
+
+	start := wordBoundary(text, max(merged[0][0]-snippetContext, 0), true)
+	end := wordBoundary(text, min(start+snippetLength, len(text)), false)
+	end = max(end, merged[0][1])
+	prefix, suffix := "", ""
+	if start > 0 {
+		prefix = ellipsis
+	}
+	if end < len(text) {
+		suffix = ellipsis
+	}
+	h := Highlight{Field: field, Fragment: prefix + oneLine(text[start:end]) + suffix}
+	for _, m := range merged {
+		if m[0] >= start && m[1] <= end {
+			h.Matches = append(h.Matches, [2]int{m[0] - start + len(prefix), m[1] - start + len(prefix)})
+		}
+	}
+	return h, true
+}
+
+// wordBoundary moves i to the start of a rune and, within a short distance, to
+// the next (forward) or previous whitespace so that snippets do not cut words.
+func wordBoundary(text string, i int, forward bool) int {
+	if i <= 0 || i >= len(text) {
+		return i
+	}
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	const reach = 15
+	for d := 0; d < reach; d++ {
+		j := i - d
+		if forward {
+			j = i + d
+		}
+		if j <= 0 || j >= len(text) {
+			break
+		}
+		if text[j] == ' ' || text[j] == '\n' || text[j] == '\t' {
+			if forward {
+				return j + 1
+			}
+			return j
+		}
+	}
+	return i
+}
+
+// oneLine replaces line breaks and tabs by spaces, keeping byte offsets intact.
+func oneLine(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// searchTerms splits text into words, the way both backends tokenize fields.
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+}
+
+// termMatches returns the byte ranges of the words of text that equal one of
+// terms, ignoring case.
+func termMatches(text string, terms []string) [][2]int {
+	var matches [][2]int
+	start := -1
+	check := func(end int) {
+		word := text[start:end]
+		for _, term := range terms {
+			if strings.EqualFold(word, term) {
+				matches = append(matches, [2]int{start, end})
+				break
+			}
+		}
+		start = -1
+	}
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			check(i)
+		}
+	}
+	if start >= 0 {
+		check(len(text))
+	}
+	return matches
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// bleveBatchSize is the number of tasks written per batch while rebuilding.
+const bleveBatchSize = 500
+
+// bleveSearchIndex is an embedded Bleve index, kept in memory or, with a path, on
+// disk. It is fed by the task repository after every committed write and rebuilt
+// from the tasks table when it is created.
+type bleveSearchIndex struct {
+	path   string
+	db     *sql.DB
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	// mu guards index, which Rebuild replaces.
+	mu    sync.RWMutex
+	index bleve.Index
+	// writeMu serializes writes with Rebuild, so that no write is lost in the
+	// replaced index.
+	writeMu sync.Mutex
+}
+
+func newBleveSearchIndex(lc fx.Lifecycle, path string, db *sql.DB, logger *zap.Logger, tracer trace.Tracer) (SearchIndex, error) {
+	i := &bleveSearchIndex{path: path, db: db, logger: logger, tracer: tracer}
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if path != "" {
+				index, err := bleve.Open(path)
+				if err == nil {
+					i.index = index
+					logger.Info("Opened search index", zap.String("path", path))
+					return nil
+				}
+				if !errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+					return fmt.Errorf("failed to open search index %s: %w", path, err)
+				}
+			}
+			_, err := i.Rebuild(ctx)
+			return err
+		},
+		OnStop: func(ctx context.Context) error {
+			i.mu.Lock()
+			defer i.mu.Unlock()
+			if i.index == nil {
+				return nil
+			}
+			return i.index.Close()
+		},
+	})
+	return i, nil
+}
+
+func (i *bleveSearchIndex) Backend() string { return SearchIndexBleve }
+
+// bleveMapping indexes title and description with the standard analyzer and the
+// tenant as a single keyword.
+func bleveMapping() mapping.IndexMapping {
+	text := bleve.NewTextFieldMapping()
+	text.Analyzer = standard.Name
+	tenantField := bleve.NewKeywordFieldMapping()
+	tenantField.Store = false
+	tenantField.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	doc.AddFieldMappingsAt("title", text)
+	doc.AddFieldMappingsAt("description", text)
+	doc.AddFieldMappingsAt("tenant_id", tenantField)
+
+	m := bleve.NewIndexMapping()
+	m.DefaultMapping = doc
+	m.DefaultAnalyzer = standard.Name
+	return m
+}
+
+// bleveDocID identifies a task across tenants.
+func bleveDocID(tenantID, taskID string) string {
+	return tenantID + "/" + taskID
+}
+
+func bleveDocument(tenantID, title, description string) map[string]any {
+	return map[string]any{"tenant_id": tenantID, "title": title, "description": description}
+}
+
+// Search matches the words of text against title and description of the tasks
+// of the current tenant, ranked by Bleve's TF-IDF score.
+func (i *bleveSearchIndex) Search(ctx context.Context, text string, limit int) (_ []SearchHit, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := i.tracer.Start(ctx, "bleveSearchIndex.Search")
+	defer func() { endSpan(span, err) }()
+	i.logger.Debug("Searching full-text index", zap.String("tenantID", tenantID), zap.String("text", text))
+
+	title := bleve.NewMatchQuery(text)
+	title.SetField("title")
+	description := bleve.NewMatchQuery(text)
+	description.SetField("description")
+	tenantQuery := bleve.NewTermQuery(tenantID)
+	tenantQuery.SetField("tenant_id")
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(tenantQuery, bleve.NewDisjunctionQuery(title, description)), limit, 0, false)
+	req.Fields = []string{"title", "description"}
+	req.IncludeLocations = true
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.index == nil {
+		return nil, errors.New("search index is not open")
+	}
+	result, err := i.index.SearchInContext(ctx, req)
+	if err != nil {
+		i.logger.Error("Failed to search full-text index", zap.Error(err))
+		return nil, err
+	}
+	hits := make([]SearchHit, 0, len(result.Hits))
+	for _, match := range result.Hits {
+		hit := SearchHit{TaskID: strings.TrimPrefix(match.ID, tenantID+"/"), Score: match.Score}
+		for _, field := range []string{"title", "description"} {
+			value, _ := match.Fields[field].(string)
+			var matches [][2]int
+			for _, locations := range match.Locations[field] {
+				for _, loc := range locations {
+					matches = append(matches, [2]int{int(loc.Start), int(loc.End)})
+				}
+			}
+			if h, ok := highlight(field, value, matches); ok {
+				hit.Highlights = append(hit.Highlights, h)
+			}
+		}
+		hits = append(hits, hit)
+	}
+	return hits, nil
+}
+
+// IndexTask indexes the title and description of task for the current tenant.
+func (i *bleveSearchIndex) IndexTask(ctx context.Context, task *pb.Task) error {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return err
+	}
+	i.writeMu.Lock()
+	defer i.writeMu.Unlock()
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.index == nil {
+		return errors.New("search index is not open")
+	}
+	return i.index.Index(bleveDocID(tenantID, task.GetId()), bleveDocument(tenantID, task.GetTitle(), task.GetDescription()))
+}
+
+// RemoveTask removes a task of the current tenant from the index.
+func (i *bleveSearchIndex) RemoveTask(ctx context.Context, taskID string) error {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return err
+	}
+	i.writeMu.Lock()
+	defer i.writeMu.Unlock()
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.index == nil {
+		return errors.New("search index is not open")
+	}
+	return i.index.Delete(bleveDocID(tenantID, taskID))
+}
+
+// Rebuild builds a new index from the tasks table and then replaces the current
+// one. Searches keep using the old index until the new one is complete; writes
+// wait and go to the new index.
+func (i *bleveSearchIndex) Rebuild(ctx context.Context) (_ int64, err error) {
+	ctx, span := i.tracer.Start(ctx, "bleveSearchIndex.Rebuild")
+	defer func() { endSpan(span, err) }()
+	i.writeMu.Lock()
+	defer i.writeMu.Unlock()
+	i.logger.Info("Rebuilding search index", zap.String("path", i.path))
+
+	buildPath := ""
+	if i.path != "" {
+		buildPath = i.path + ".rebuild"
+		if err := os.RemoveAll(buildPath); err != nil {
+			return 0, err
+		}
+	}
+	index, count, err := i.build(ctx, buildPath)
+	if err != nil {
+		i.logger.Error("Failed to rebuild search index", zap.Error(err))
+		if buildPath != "" {
+			os.RemoveAll(buildPath)
+		}
+		return 0, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.index != nil {
+		if err := i.index.Close(); err != nil {
+			i.logger.Warn("Failed to close replaced search index", zap.Error(err))
+		}
+		i.index = nil
+	}
+	if i.path != "" {
+		// Bleve indexes cannot be moved while open; reopen from the final path.
+		if err := index.Close(); err != nil {
+			return 0, err
+		}
+		if err := os.RemoveAll(i.path); err != nil {
+			return 0, err
+		}
+		if err := os.Rename(buildPath, i.path); err != nil {
+			return 0, err
+		}
+		if index, err = bleve.Open(i.path); err != nil {
+			return 0, err
+		}
+	}
+	i.index = index
+	i.logger.Info("Search index rebuilt", zap.Int64("tasks", count))
+	return count, nil
+}
+
+// build creates an index at path, or in memory without one, holding every task.
+func (i *bleveSearchIndex) build(ctx context.Context, path string) (bleve.Index, int64, error) {
+	var index bleve.Index
+	var err error
+	if path == "" {
+		index, err = bleve.NewMemOnly(bleveMapping())
+	} else {
+		index, err = bleve.New(path, bleveMapping())
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := i.fill(ctx, index)
+	if err != nil {
+		index.Close()
+		return nil, 0, err
+	}
+	return index, count, nil
+}
+
+func (i *bleveSearchIndex) fill(ctx context.Context, index bleve.Index) (int64, error) {
+	rows, err := i.db.QueryContext(ctx, "SELECT id, tenant_id, title, COALESCE(description, '') FROM tasks")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	batch := index.NewBatch()
+	for rows.Next() {
+		var taskID, tenantID, title, description string
+		if err := rows.Scan(&taskID, &tenantID, &title, &description); err != nil {
+			return 0, err
+		}
+		if err := batch.Index(bleveDocID(tenantID, taskID), bleveDocument(tenantID, title, description)); err != nil {
+			return 0, err
+		}
+		count++
+		if batch.Size() >= bleveBatchSize {
+			if err := index.Batch(batch); err != nil {
+				return 0, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if err := index.Batch(batch); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
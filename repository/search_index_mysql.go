@@ -0,0 +1,115 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// fullTextIndexName is the FULLTEXT index over tasks(title, description).
+const fullTextIndexName = "ft_tasks_title_description"
+
+// mysqlSearchIndex searches the FULLTEXT index of the tasks table. InnoDB keeps the
+// index up to date with every write, so IndexTask and RemoveTask do nothing.
+type mysqlSearchIndex struct {
+	db     *sql.DB
+	logger *zap.Logger
+	tracer trace.Tracer
+}
+
+func (i *mysqlSearchIndex) Backend() string { return SearchIndexMySQL }
+
+func (i *mysqlSearchIndex) startSpan(ctx context.Context, name string, query string) (context.Context, trace.Span) {
+	return i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.statement", query),
+		))
+}
+
+// Search ranks the tasks of the current tenant with MATCH ... AGAINST in natural
+// language mode. Words shorter than innodb_ft_min_token_size and stopwords are ignored.
+func (i *mysqlSearchIndex) Search(ctx context.Context, text string, limit int) (_ []SearchHit, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i.logger.Debug("Searching full-text index", zap.String("tenantID", tenantID), zap.String("text", text))
+	query := `SELECT id, title, COALESCE(description, ''), MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE) AS score
+		FROM tasks WHERE tenant_id = ? AND MATCH(title, description) AGAINST (? IN NATURAL LANGUAGE MODE)
+		ORDER BY score DESC, id DESC LIMIT ?`
+	ctx, span := i.startSpan(ctx, "mysqlSearchIndex.Search", query)
+	defer func() { endSpan(span, err) }()
+	rows, err := i.db.QueryContext(ctx, query, text, tenantID, text, limit)
+	if err != nil {
+		i.logger.Error("Failed to query full-text index", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	terms := searchTerms(text)
+	var hits []SearchHit
+	for rows.Next() {
+		var hit SearchHit
+		var title, description string
+		if err := rows.Scan(&hit.TaskID, &title, &description, &hit.Score); err != nil {
+			i.logger.Error("Failed to scan full-text search row", zap.Error(err))
+			return nil, err
+		}
+		if h, ok := highlight("title", title, termMatches(title, terms)); ok {
+			hit.Highlights = append(hit.Highlights, h)
+		}
+		if h, ok := highlight("description", description, termMatches(description, terms)); ok {
+			hit.Highlights = append(hit.Highlights, h)
+		}
+		hits = append(hits, hit)
+	}
+	if err = rows.Err(); err != nil {
+		i.logger.Error("Error during rows iteration for full-text search", zap.Error(err))
+		return nil, err
+	}
+	return hits, nil
+}
+
+func (i *mysqlSearchIndex) IndexTask(ctx context.Context, task *pb.Task) error { return nil }
+
+func (i *mysqlSearchIndex) RemoveTask(ctx context.Context, taskID string) error { return nil }
+
+// Rebuild drops the FULLTEXT index, if present, and creates it again. This also
+// creates the index on databases initialized before it existed.
+func (i *mysqlSearchIndex) Rebuild(ctx context.Context) (_ int64, err error) {
+	ctx, span := i.tracer.Start(ctx, "mysqlSearchIndex.Rebuild")
+	defer func() { endSpan(span, err) }()
+	i.logger.Info("Rebuilding full-text index", zap.String("index", fullTextIndexName))
+
+	var exists int
+	err = i.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'tasks' AND INDEX_NAME = ?`, fullTextIndexName).Scan(&exists)
+	if err != nil {
+		i.logger.Error("Failed to look up full-text index", zap.Error(err))
+		return 0, err
+	}
+	if exists > 0 {
+		if _, err = i.db.ExecContext(ctx, "ALTER TABLE tasks DROP INDEX "+fullTextIndexName); err != nil {
+			i.logger.Error("Failed to drop full-text index", zap.Error(err))
+			return 0, err
+		}
+	}
+	if _, err = i.db.ExecContext(ctx, "ALTER TABLE tasks ADD FULLTEXT INDEX "+fullTextIndexName+" (title, description)"); err != nil {
+		i.logger.Error("Failed to create full-text index", zap.Error(err))
+		return 0, err
+	}
+	var count int64
+	if err = i.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks").Scan(&count); err != nil {
+		i.logger.Error("Failed to count indexed tasks", zap.Error(err))
+		return 0, err
+	}
+	i.logger.Info("Full-text index rebuilt", zap.Int64("tasks", count))
+	return count, nil
+}
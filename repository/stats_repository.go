@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"go.uber.org/zap"
+)
+
+// TaskCount is the number of tasks with a given status in one tenant.
+type TaskCount struct {
+	TenantID string
+	Status   string
+	Count    int64
+}
+
+// StatsRepository defines read-only aggregate queries used for operational metrics.
+// It deliberately spans all tenants and must never be exposed through the API.
+type StatsRepository interface {
+	CountTasksByStatus(ctx context.Context) ([]TaskCount, error)
+}
+
+type sqlStatsRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLStatsRepository creates a new SQL-based stats repository.
+func NewSQLStatsRepository(db *sql.DB, logger *zap.Logger) StatsRepository {
+	return &sqlStatsRepository{db: db, logger: logger.Named("repository")}
+}
+
+// CountTasksByStatus returns the number of tasks per tenant and status. A NULL
+// status is counted together with the empty one, so that each pair is returned
+// once; Prometheus rejects a scrape with duplicate label sets.
+func (r *sqlStatsRepository) CountTasksByStatus(ctx context.Context) ([]TaskCount, error) {
+	query := "SELECT tenant_id, COALESCE(status, '') AS task_status, COUNT(*) FROM tasks GROUP BY tenant_id, task_status"
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.Error("Failed to count tasks by status", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []TaskCount
+	for rows.Next() {
+		var count TaskCount
+		if err := rows.Scan(&count.TenantID, &count.Status, &count.Count); err != nil {
+			r.logger.Error("Failed to scan task count row", zap.Error(err))
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for task counts", zap.Error(err))
+		return nil, err
+	}
+	return counts, nil
+}
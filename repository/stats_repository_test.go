@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"Go_Test/tenant"
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCountTasksByStatusMergesNullAndEmptyStatus(t *testing.T) {
+	db := openTestDB(t)
+	ctx := seedWorkspace(t, db, "stats", "active")
+	tenantID, _ := tenant.IDFromContext(ctx)
+	for _, status := range []any{nil, "", "pending"} {
+		if _, err := db.Exec("INSERT INTO tasks (tenant_id, title, status) VALUES (?, 'Task', ?)", tenantID, status); err != nil {
+			t.Fatalf("insert task with status %v: %v", status, err)
+		}
+	}
+
+	counts, err := NewSQLStatsRepository(db, zap.NewNop()).CountTasksByStatus(context.Background())
+	if err != nil {
+		t.Fatalf("CountTasksByStatus: %v", err)
+	}
+	got := make(map[string]int64)
+	for _, count := range counts {
+		if _, ok := got[count.Status]; ok {
+			t.Errorf("status %q returned twice", count.Status)
+		}
+		got[count.Status] = count.Count
+	}
+	if len(got) != 2 || got[""] != 2 || got["pending"] != 1 {
+		t.Errorf("CountTasksByStatus = %v, want 2 tasks without status and 1 pending", counts)
+	}
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"strings"
+	"time"
+)
+
+// TaskFilter restricts FetchTasks to the tasks that match all of its set fields.
+// The zero value matches every task.
+type TaskFilter struct {
+	// Statuses matches tasks with any of the given statuses.
+	Statuses []string
+	// Tags matches tasks that carry all of the given tags.
+	Tags     []string
+	Priority string
+	Assignee string
+	Project  string
+	// TitleContains matches titles containing the text; case follows the column collation.
+	TitleContains string
+	// CreatedBefore and DueBefore are exclusive, CreatedAfter and DueAfter inclusive bounds.
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+	DueBefore     time.Time
+	DueAfter      time.Time
+}
+
+// where returns the SQL conditions of the filter, joined with AND and each preceded
+// by " AND ", together with their arguments. The tasks table must not be aliased.
+func (f TaskFilter) where() (string, []any) {
+	var conditions []string
+	var args []any
+	add := func(condition string, values ...any) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+	if len(f.Statuses) > 0 {
+		add("status IN (?"+strings.Repeat(", ?", len(f.Statuses)-1)+")", toArgs(f.Statuses)...)
+	}
+	for _, tag := range f.Tags {
+		add("EXISTS (SELECT 1 FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.tag = ?)", tag)
+	}
+	if f.Priority != "" {
+		add("priority = ?", f.Priority)
+	}
+	if f.Assignee != "" {
+		add("assignee = ?", f.Assignee)
+	}
+	if f.Project != "" {
+		add("project = ?", f.Project)
+	}
+	if f.TitleContains != "" {
+		// Backslash is the default LIKE escape character of MySQL.
+		add("title LIKE ?", "%"+escapeLike(f.TitleContains)+"%")
+	}
+	if !f.CreatedBefore.IsZero() {
+		add("created_at < ?", f.CreatedBefore)
+	}
+	if !f.CreatedAfter.IsZero() {
+		add("created_at >= ?", f.CreatedAfter)
+	}
+	if !f.DueBefore.IsZero() {
+		add("due_at < ?", f.DueBefore)
+	}
+	if !f.DueAfter.IsZero() {
+		add("due_at >= ?", f.DueAfter)
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
+func toArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
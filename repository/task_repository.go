@@ -2,11 +2,19 @@ package repository
 
 import (
 	pb "Go_Test/api"
+	"Go_Test/query"
+	"Go_Test/tenant"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
@@ -14,31 +22,295 @@ import (
 // Module exports the TaskRepository provider for FX.
 var Module = fx.Options(
 	fx.Provide(NewSQLTaskRepository),
+	fx.Provide(NewSearchIndex),
+	fx.Provide(NewSQLWorkspaceRepository),
+	fx.Provide(NewSQLStatsRepository),
+	fx.Provide(NewSQLWebhookRepository),
+	fx.Provide(NewSQLOutboxRepository),
+	fx.Provide(NewSQLNotificationRepository),
+	fx.Provide(NewSQLInboundRepository),
+	fx.Provide(NewSQLCalendarRepository),
 )
 
 // TaskRepository defines the interface for task data persistence operations.
+// Every operation is scoped to the tenant carried by ctx (see tenant.WithID)
+// and fails with tenant.ErrMissing when no tenant is present.
 type TaskRepository interface {
-	FetchTasks(ctx context.Context) ([]*pb.Task, error)
-	AddTask(ctx context.Context, title string, description string, status string) (*pb.Task, error)
+	FetchTasks(ctx context.Context, filter TaskFilter) ([]*pb.Task, error)
+	// FetchTaskPage returns up to limit tasks that match filter and have an ID above
+	// afterID ("" for the first page), in the order of their IDs.
+	FetchTaskPage(ctx context.Context, filter TaskFilter, afterID string, limit int) ([]*pb.Task, error)
+	// CountTasks returns the number of tasks that match filter.
+	CountTasks(ctx context.Context, filter TaskFilter) (int, error)
+	SearchTasks(ctx context.Context, q query.Node, limit int) ([]*pb.Task, error)
+	AddTask(ctx context.Context, task *pb.Task) (*pb.Task, error)
 	FetchTaskByID(ctx context.Context, taskID string) (*pb.Task, error)
 	UpdateTaskStatus(ctx context.Context, taskID string, newStatus string) (*pb.Task, error)
+	UpdateTask(ctx context.Context, task *pb.Task) (*pb.Task, error)
+	// DeleteTask deletes a task; a non-zero version must match the current one.
+	DeleteTask(ctx context.Context, taskID string, version int32) error
+	// AddAttachment stores a file with the task attachment.TaskId and returns it
+	// without its content. A task of another tenant is reported as sql.ErrNoRows.
+	AddAttachment(ctx context.Context, attachment *pb.Attachment) (*pb.Attachment, error)
+	// ListAttachments returns the attachments of a task without their content.
+	ListAttachments(ctx context.Context, taskID string) ([]*pb.Attachment, error)
+	// FetchAttachment returns an attachment of a task with its content, or sql.ErrNoRows.
+	FetchAttachment(ctx context.Context, taskID, attachmentID string) (*pb.Attachment, error)
+	// AddCalendarObject records the resource name and UID under which a CalDAV client
+	// created a task. A task of another tenant is reported as sql.ErrNoRows.
+	AddCalendarObject(ctx context.Context, object CalendarObject) error
+	// ListCalendarObjects returns the recorded CalDAV resources by task ID.
+	ListCalendarObjects(ctx context.Context) (map[string]CalendarObject, error)
+	// FetchCalendarObjectByName returns the CalDAV resource with the given name, or sql.ErrNoRows.
+	FetchCalendarObjectByName(ctx context.Context, name string) (CalendarObject, error)
+	// InTransaction runs fn with a repository whose operations share one database
+	// transaction. The transaction is committed when fn returns nil and rolled back
+	// otherwise. Called on a TaskTx, it joins the existing transaction.
+	InTransaction(ctx context.Context, fn func(tx TaskTx) error) error
+}
+
+// TaskTx is a TaskRepository bound to a database transaction.
+type TaskTx interface {
+	TaskRepository
+	// Savepoint runs fn and, when fn fails, rolls back only the changes made by fn;
+	// the transaction itself stays usable.
+	Savepoint(ctx context.Context, fn func() error) error
+}
+
+// ErrVersionConflict is returned by UpdateTask and DeleteTask when the task no
+// longer has the version the caller expected.
+var ErrVersionConflict = errors.New("task has been changed concurrently")
+
+// errNoTransaction is returned by Savepoint outside of InTransaction.
+var errNoTransaction = errors.New("savepoint requires a transaction")
+
+// querier is implemented by *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
 type sqlTaskRepository struct {
 	db     *sql.DB
 	logger *zap.Logger
+	tracer trace.Tracer
+	index  SearchIndex
+
+	// tx is set on repositories created by InTransaction.
+	tx *sql.Tx
+	// savepoints counts the savepoints of tx, to give each a unique name.
+	savepoints int
+	// indexUpdates are the search index changes of tx, applied once it commits.
+	indexUpdates []func() error
 }
 
-// NewSQLTaskRepository creates a new SQL-based task repository.
-func NewSQLTaskRepository(db *sql.DB, logger *zap.Logger) TaskRepository {
-	return &sqlTaskRepository{db: db, logger: logger}
+// NewSQLTaskRepository creates a new SQL-based task repository that keeps index
+// up to date with the titles and descriptions of tasks.
+func NewSQLTaskRepository(db *sql.DB, logger *zap.Logger, tp trace.TracerProvider, index SearchIndex) TaskRepository {
+	return &sqlTaskRepository{db: db, logger: logger.Named("repository"), tracer: tp.Tracer("Go_Test/repository"), index: index}
 }
 
-// FetchTasks retrieves all tasks from the database.
-func (r *sqlTaskRepository) FetchTasks(ctx context.Context) ([]*pb.Task, error) {
-	r.logger.Debug("Fetching tasks from database")
-	query := "SELECT id, title, description, status, created_at, updated_at FROM tasks ORDER BY created_at DESC"
-	rows, err := r.db.QueryContext(ctx, query)
+// startSpan starts a client span for a single SQL statement.
+func (r *sqlTaskRepository) startSpan(ctx context.Context, name string, query string) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "mysql"),
+			attribute.String("db.statement", query),
+		))
+}
+
+// conn returns the transaction of the repository, if any, or the database.
+func (r *sqlTaskRepository) conn() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+// inTx runs fn in the transaction of the repository or, outside of InTransaction,
+// in a new transaction that is committed when fn succeeds.
+func (r *sqlTaskRepository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if r.tx != nil {
+		return fn(r.tx)
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// updateIndex applies a search index change at once or, within a transaction,
+// after the transaction commits. The database is the source of truth: a failed
+// index update is logged and repaired by rebuilding the index.
+func (r *sqlTaskRepository) updateIndex(update func() error) {
+	if r.tx != nil {
+		r.indexUpdates = append(r.indexUpdates, update)
+		return
+	}
+	if err := update(); err != nil {
+		r.logger.Warn("Failed to update search index", zap.Error(err))
+	}
+}
+
+// endSpan records err, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// taskColumns is the select list read by scanTask. Tags are aggregated from
+// task_tags into a comma-separated, sorted list.
+const taskColumns = `id, title, description, status, due_at, priority, assignee, project, created_at, updated_at, completed_at, version,
+	(SELECT GROUP_CONCAT(tag ORDER BY tag SEPARATOR ',') FROM task_tags WHERE task_tags.task_id = tasks.id)`
+
+// completedAtAssignment keeps tasks.completed_at in step with the status set before
+// it in the same UPDATE: MySQL assigns single-table UPDATE columns from left to
+// right, so status already holds the new value. Completing a completed task again
+// keeps the original time.
+const completedAtAssignment = "completed_at = IF(status = 'completed', COALESCE(completed_at, CURRENT_TIMESTAMP), NULL)"
+
+// versionAssignment counts the changes of a task; every UPDATE of tasks sets it.
+const versionAssignment = "version = version + 1"
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads one row selected with taskColumns.
+func scanTask(row rowScanner) (*pb.Task, error) {
+	var task pb.Task
+	var description, tags sql.NullString
+	var dueAt, createdAt, updatedAt, completedAt sql.NullTime
+	if err := row.Scan(&task.Id, &task.Title, &description, &task.Status, &dueAt, &task.Priority, &task.Assignee, &task.Project,
+		&createdAt, &updatedAt, &completedAt, &task.Version, &tags); err != nil {
+		return nil, err
+	}
+	task.Description = description.String
+	if dueAt.Valid {
+		task.DueAt = dueAt.Time.Format(time.RFC3339)
+	}
+	if createdAt.Valid {
+		task.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	if updatedAt.Valid {
+		task.UpdatedAt = updatedAt.Time.Format(time.RFC3339)
+	}
+	if completedAt.Valid {
+		task.CompletedAt = completedAt.Time.Format(time.RFC3339)
+	}
+	if tags.String != "" {
+		task.Tags = strings.Split(tags.String, ",")
+	}
+	return &task, nil
+}
+
+// fetchTaskTx reads a task of tenantID within tx, optionally locking its row.
+func fetchTaskTx(ctx context.Context, tx *sql.Tx, tenantID, taskID string, forUpdate bool) (*pb.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ? AND tenant_id = ?"
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
+	return scanTask(tx.QueryRowContext(ctx, query, taskID, tenantID))
+}
+
+// dueAtValue converts the RFC 3339 due date of a task into a nullable column value.
+// The service validates the format; an empty string clears the due date.
+func dueAtValue(dueAt string) (sql.NullTime, error) {
+	return timestampValue("due date", dueAt)
+}
+
+// timestampValue converts an optional RFC 3339 timestamp of a task for the database.
+func timestampValue(name, value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}, nil
+}
+
+// replaceTags replaces the tags of a task within tx.
+func replaceTags(ctx context.Context, tx *sql.Tx, taskID string, tags []string) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO task_tags (task_id, tag) VALUES (?, ?)", taskID, tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchTasks retrieves the tasks of the current tenant that match filter, newest first.
+func (r *sqlTaskRepository) FetchTasks(ctx context.Context, filter TaskFilter) (_ []*pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Fetching tasks from database", zap.String("tenantID", tenantID))
+	conditions, args := filter.where()
+	query := "SELECT " + taskColumns + " FROM tasks WHERE tenant_id = ?" + conditions + " ORDER BY created_at DESC, id DESC"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.FetchTasks", query)
+	defer func() { endSpan(span, err) }()
+	return r.queryTasks(ctx, query, append([]any{tenantID}, args...))
+}
+
+// FetchTaskPage retrieves a page of the tasks of the current tenant that match filter.
+func (r *sqlTaskRepository) FetchTaskPage(ctx context.Context, filter TaskFilter, afterID string, limit int) (_ []*pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	after := int64(0)
+	if afterID != "" {
+		if after, err = strconv.ParseInt(afterID, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid task ID %q: %w", afterID, err)
+		}
+	}
+	r.logger.Debug("Fetching page of tasks from database", zap.String("tenantID", tenantID), zap.Int64("afterID", after))
+	conditions, args := filter.where()
+	query := "SELECT " + taskColumns + " FROM tasks WHERE tenant_id = ? AND id > ?" + conditions + " ORDER BY id LIMIT ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.FetchTaskPage", query)
+	defer func() { endSpan(span, err) }()
+	args = append([]any{tenantID, after}, args...)
+	return r.queryTasks(ctx, query, append(args, limit))
+}
+
+// CountTasks counts the tasks of the current tenant that match filter.
+func (r *sqlTaskRepository) CountTasks(ctx context.Context, filter TaskFilter) (_ int, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	conditions, args := filter.where()
+	query := "SELECT COUNT(*) FROM tasks WHERE tenant_id = ?" + conditions
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.CountTasks", query)
+	defer func() { endSpan(span, err) }()
+	var count int
+	if err = r.conn().QueryRowContext(ctx, query, append([]any{tenantID}, args...)...).Scan(&count); err != nil {
+		r.logger.Error("Failed to count tasks", zap.Error(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// queryTasks runs a query that selects taskColumns and scans its rows.
+func (r *sqlTaskRepository) queryTasks(ctx context.Context, query string, args []any) ([]*pb.Task, error) {
+	rows, err := r.conn().QueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.Error("Failed to query tasks", zap.Error(err))
 		return nil, err
@@ -47,25 +319,12 @@ func (r *sqlTaskRepository) FetchTasks(ctx context.Context) ([]*pb.Task, error)
 
 	var tasks []*pb.Task
 	for rows.Next() {
-		var task pb.Task
-		var createdAt, updatedAt sql.NullTime
-		var description sql.NullString
-		if err := rows.Scan(&task.Id, &task.Title, &description, &task.Status, &createdAt, &updatedAt); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			r.logger.Error("Failed to scan task row", zap.Error(err))
 			return nil, err
 		}
-		if description.Valid {
-			task.Description = description.String
-		} else {
-			task.Description = ""
-		}
-		if createdAt.Valid {
-			task.CreatedAt = createdAt.Time.Format(time.RFC3339)
-		}
-		if updatedAt.Valid {
-			task.UpdatedAt = updatedAt.Time.Format(time.RFC3339)
-		}
-		tasks = append(tasks, &task)
+		tasks = append(tasks, task)
 	}
 	if err = rows.Err(); err != nil {
 		r.logger.Error("Error during rows iteration for tasks", zap.Error(err))
@@ -75,70 +334,284 @@ func (r *sqlTaskRepository) FetchTasks(ctx context.Context) ([]*pb.Task, error)
 	return tasks, nil
 }
 
-// AddTask inserts a new task into the database and returns the created task.
-func (r *sqlTaskRepository) AddTask(ctx context.Context, title string, description string, status string) (*pb.Task, error) {
-	r.logger.Debug("Adding new task to database", zap.String("title", title))
-	query := "INSERT INTO tasks (title, description, status) VALUES (?, ?, ?)"
-	result, err := r.db.ExecContext(ctx, query, title, sql.NullString{String: description, Valid: description != ""}, status)
+// AddTask inserts a new task together with its tags and a TaskCreated event and
+// returns the created task. The ID, updated_at and version of task are ignored;
+// created_at and completed_at default to the current time, e.g. unless an import
+// restores them.
+func (r *sqlTaskRepository) AddTask(ctx context.Context, task *pb.Task) (_ *pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
 	if err != nil {
-		r.logger.Error("Failed to insert task", zap.Error(err))
 		return nil, err
 	}
-	id, err := result.LastInsertId()
+	r.logger.Debug("Adding new task to database", zap.String("tenantID", tenantID), zap.String("title", task.GetTitle()))
+	dueAt, err := dueAtValue(task.GetDueAt())
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := timestampValue("creation time", task.GetCreatedAt())
+	if err != nil {
+		return nil, err
+	}
+	completedAt, err := timestampValue("completion time", task.GetCompletedAt())
+	if err != nil {
+		return nil, err
+	}
+	// The value of completed_at refers to the status given earlier in the value list.
+	query := `INSERT INTO tasks (tenant_id, title, description, status, due_at, priority, assignee, project, created_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP), IF(status = 'completed', COALESCE(?, CURRENT_TIMESTAMP), NULL))`
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.AddTask", query)
+	defer func() { endSpan(span, err) }()
+	var created *pb.Task
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, query, tenantID, task.GetTitle(),
+			sql.NullString{String: task.GetDescription(), Valid: task.GetDescription() != ""},
+			task.GetStatus(), dueAt, task.GetPriority(), task.GetAssignee(), task.GetProject(), createdAt, completedAt)
+		if err != nil {
+			r.logger.Error("Failed to insert task", zap.Error(err))
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			r.logger.Error("Failed to get last insert ID for task", zap.Error(err))
+			return err
+		}
+		taskID := fmt.Sprintf("%d", id)
+		if err := replaceTags(ctx, tx, taskID, task.GetTags()); err != nil {
+			r.logger.Error("Failed to insert task tags", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if created, err = fetchTaskTx(ctx, tx, tenantID, taskID, false); err != nil {
+			r.logger.Error("Failed to read inserted task", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if err := writeEvent(ctx, tx, tenantID, taskCreated(created)); err != nil {
+			r.logger.Error("Failed to write task event", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		r.logger.Error("Failed to get last insert ID for task", zap.Error(err))
 		return nil, err
 	}
-	return r.FetchTaskByID(ctx, fmt.Sprintf("%d", id))
+	r.updateIndex(func() error { return r.index.IndexTask(ctx, created) })
+	return created, nil
 }
 
-// FetchTaskByID retrieves a single task by its ID.
-func (r *sqlTaskRepository) FetchTaskByID(ctx context.Context, taskID string) (*pb.Task, error) {
-	r.logger.Debug("Fetching task by ID", zap.String("taskID", taskID))
-	query := "SELECT id, title, description, status, created_at, updated_at FROM tasks WHERE id = ?"
-	var task pb.Task
-	var createdAt, updatedAt sql.NullTime
-	var description sql.NullString
-	err := r.db.QueryRowContext(ctx, query, taskID).Scan(
-		&task.Id, &task.Title, &description, &task.Status, &createdAt, &updatedAt,
-	)
+// FetchTaskByID retrieves a single task of the current tenant by its ID.
+// Tasks belonging to other tenants are reported as sql.ErrNoRows.
+func (r *sqlTaskRepository) FetchTaskByID(ctx context.Context, taskID string) (_ *pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Fetching task by ID", zap.String("tenantID", tenantID), zap.String("taskID", taskID))
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ? AND tenant_id = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.FetchTaskByID", query)
+	defer func() { endSpan(span, err) }()
+	task, err := scanTask(r.conn().QueryRowContext(ctx, query, taskID, tenantID))
 	if err != nil {
 		if err != sql.ErrNoRows {
 			r.logger.Error("Failed to fetch task by ID", zap.String("taskID", taskID), zap.Error(err))
 		}
 		return nil, err
 	}
-	if description.Valid {
-		task.Description = description.String
-	} else {
-		task.Description = ""
-	}
-	if createdAt.Valid {
-		task.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	r.logger.Debug("Successfully fetched task by ID", zap.String("taskID", taskID))
+	return task, nil
+}
+
+// UpdateTaskStatus updates the status of a task, records a TaskCompleted or
+// TaskUpdated event and returns the updated task.
+func (r *sqlTaskRepository) UpdateTaskStatus(ctx context.Context, taskID string, newStatus string) (_ *pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if updatedAt.Valid {
-		task.UpdatedAt = updatedAt.Time.Format(time.RFC3339)
+	r.logger.Debug("Updating task status", zap.String("tenantID", tenantID), zap.String("taskID", taskID), zap.String("newStatus", newStatus))
+	query := "UPDATE tasks SET status = ?, " + completedAtAssignment + ", " + versionAssignment +
+		", updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.UpdateTaskStatus", query)
+	defer func() { endSpan(span, err) }()
+	var updated *pb.Task
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		previous, err := fetchTaskTx(ctx, tx, tenantID, taskID, true)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				r.logger.Error("Failed to lock task for status update", zap.String("taskID", taskID), zap.Error(err))
+			}
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, newStatus, taskID, tenantID); err != nil {
+			r.logger.Error("Failed to update task status", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if updated, err = fetchTaskTx(ctx, tx, tenantID, taskID, false); err != nil {
+			r.logger.Error("Failed to read updated task", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if err := writeEvent(ctx, tx, tenantID, taskChanged(previous, updated)); err != nil {
+			r.logger.Error("Failed to write task event", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	r.logger.Debug("Successfully fetched task by ID", zap.String("taskID", taskID))
-	return &task, nil
+	return updated, nil
 }
 
-// UpdateTaskStatus updates the status of a task and returns the updated task.
-func (r *sqlTaskRepository) UpdateTaskStatus(ctx context.Context, taskID string, newStatus string) (*pb.Task, error) {
-	r.logger.Debug("Updating task status", zap.String("taskID", taskID), zap.String("newStatus", newStatus))
-	query := "UPDATE tasks SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
-	result, err := r.db.ExecContext(ctx, query, newStatus, taskID)
+// UpdateTask replaces all editable fields of the task identified by task.Id,
+// including its tags, records a TaskUpdated or TaskCompleted event and returns
+// the updated task. A non-zero task.Version must match the current version, or
+// ErrVersionConflict is returned.
+func (r *sqlTaskRepository) UpdateTask(ctx context.Context, task *pb.Task) (_ *pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
 	if err != nil {
-		r.logger.Error("Failed to update task status", zap.String("taskID", taskID), zap.Error(err))
 		return nil, err
 	}
-	rowsAffected, err := result.RowsAffected()
+	taskID := task.GetId()
+	r.logger.Debug("Updating task", zap.String("tenantID", tenantID), zap.String("taskID", taskID))
+	dueAt, err := dueAtValue(task.GetDueAt())
 	if err != nil {
-		r.logger.Error("Failed to get rows affected after status update", zap.String("taskID", taskID), zap.Error(err))
 		return nil, err
 	}
-	if rowsAffected == 0 {
-		return nil, sql.ErrNoRows
+	query := "UPDATE tasks SET title = ?, description = ?, status = ?, " + completedAtAssignment +
+		", due_at = ?, priority = ?, assignee = ?, project = ?, " + versionAssignment + ", updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.UpdateTask", query)
+	defer func() { endSpan(span, err) }()
+	var updated *pb.Task
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		// Lock the row first: MySQL reports zero affected rows for an UPDATE that
+		// changes nothing, which must not be mistaken for a missing task.
+		previous, err := fetchTaskTx(ctx, tx, tenantID, taskID, true)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				r.logger.Error("Failed to lock task for update", zap.String("taskID", taskID), zap.Error(err))
+			}
+			return err
+		}
+		if task.GetVersion() != 0 && task.GetVersion() != previous.GetVersion() {
+			return ErrVersionConflict
+		}
+		_, err = tx.ExecContext(ctx, query, task.GetTitle(),
+			sql.NullString{String: task.GetDescription(), Valid: task.GetDescription() != ""},
+			task.GetStatus(), dueAt, task.GetPriority(), task.GetAssignee(), task.GetProject(), taskID, tenantID)
+		if err != nil {
+			r.logger.Error("Failed to update task", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if err := replaceTags(ctx, tx, taskID, task.GetTags()); err != nil {
+			r.logger.Error("Failed to replace task tags", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if updated, err = fetchTaskTx(ctx, tx, tenantID, taskID, false); err != nil {
+			r.logger.Error("Failed to read updated task", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if err := writeEvent(ctx, tx, tenantID, taskChanged(previous, updated)); err != nil {
+			r.logger.Error("Failed to write task event", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.updateIndex(func() error { return r.index.IndexTask(ctx, updated) })
+	return updated, nil
+}
+
+// DeleteTask deletes a task and, through the foreign key, its tags, and records a
+// TaskDeleted event. A task that does not exist in the current tenant is reported
+// as sql.ErrNoRows, a version other than the current one as ErrVersionConflict.
+func (r *sqlTaskRepository) DeleteTask(ctx context.Context, taskID string, version int32) (err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("Deleting task", zap.String("tenantID", tenantID), zap.String("taskID", taskID))
+	query := "DELETE FROM tasks WHERE id = ? AND tenant_id = ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.DeleteTask", query)
+	defer func() { endSpan(span, err) }()
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		deleted, err := fetchTaskTx(ctx, tx, tenantID, taskID, true)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				r.logger.Error("Failed to lock task for deletion", zap.String("taskID", taskID), zap.Error(err))
+			}
+			return err
+		}
+		if version != 0 && version != deleted.GetVersion() {
+			return ErrVersionConflict
+		}
+		if _, err := tx.ExecContext(ctx, query, taskID, tenantID); err != nil {
+			r.logger.Error("Failed to delete task", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		if err := writeEvent(ctx, tx, tenantID, taskDeleted(deleted)); err != nil {
+			r.logger.Error("Failed to write task event", zap.String("taskID", taskID), zap.Error(err))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.updateIndex(func() error { return r.index.RemoveTask(ctx, taskID) })
+	return nil
+}
+
+// InTransaction runs fn with a repository bound to a new transaction.
+func (r *sqlTaskRepository) InTransaction(ctx context.Context, fn func(tx TaskTx) error) (err error) {
+	if r.tx != nil {
+		return fn(r)
+	}
+	ctx, span := r.tracer.Start(ctx, "sqlTaskRepository.InTransaction")
+	defer func() { endSpan(span, err) }()
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Failed to begin transaction", zap.Error(err))
+		return err
+	}
+	defer tx.Rollback()
+	txRepo := &sqlTaskRepository{db: r.db, logger: r.logger, tracer: r.tracer, index: r.index, tx: tx}
+	if err = fn(txRepo); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit transaction", zap.Error(err))
+		return err
+	}
+	for _, update := range txRepo.indexUpdates {
+		r.updateIndex(update)
+	}
+	return nil
+}
+
+// Savepoint runs fn between SAVEPOINT and RELEASE SAVEPOINT, rolling back to the
+// savepoint when fn fails. The error of fn is returned unchanged.
+func (r *sqlTaskRepository) Savepoint(ctx context.Context, fn func() error) error {
+	if r.tx == nil {
+		return errNoTransaction
+	}
+	r.savepoints++
+	name := fmt.Sprintf("sp_%d", r.savepoints)
+	if _, err := r.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		r.logger.Error("Failed to create savepoint", zap.String("savepoint", name), zap.Error(err))
+		return err
+	}
+	indexUpdates := len(r.indexUpdates)
+	if err := fn(); err != nil {
+		r.indexUpdates = r.indexUpdates[:indexUpdates]
+		if _, rollbackErr := r.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			r.logger.Error("Failed to roll back to savepoint", zap.String("savepoint", name), zap.Error(rollbackErr))
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+	if _, err := r.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		r.logger.Error("Failed to release savepoint", zap.String("savepoint", name), zap.Error(err))
+		return err
 	}
-	return r.FetchTaskByID(ctx, taskID)
+	return nil
 }
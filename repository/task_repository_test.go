@@ -0,0 +1,46 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"testing"
+)
+
+func TestCountTasks(t *testing.T) {
+	db := openTestDB(t)
+	ctx := seedWorkspace(t, db, "alpha", "active")
+	other := seedWorkspace(t, db, "beta", "active")
+	tasks, _ := newTestTaskRepository(db)
+	for _, task := range []*pb.Task{
+		{Title: "Fix login", Status: "pending", Assignee: "alice", Tags: []string{"backend"}},
+		{Title: "Fix logout", Status: "pending", Assignee: "alice"},
+		{Title: "Write docs", Status: "completed", Assignee: "bob", Tags: []string{"backend"}},
+	} {
+		if _, err := tasks.AddTask(ctx, task); err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+	}
+	if _, err := tasks.AddTask(other, &pb.Task{Title: "Fix login", Status: "pending", Assignee: "alice"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+
+	tests := []struct {
+		filter TaskFilter
+		want   int
+	}{
+		{TaskFilter{}, 3},
+		{TaskFilter{Assignee: "alice"}, 2},
+		{TaskFilter{Tags: []string{"backend"}}, 2},
+		{TaskFilter{Statuses: []string{"pending"}, Tags: []string{"backend"}}, 1},
+		{TaskFilter{TitleContains: "Fix"}, 2},
+		{TaskFilter{Assignee: "carol"}, 0},
+	}
+	for _, tt := range tests {
+		got, err := tasks.CountTasks(ctx, tt.filter)
+		if err != nil {
+			t.Fatalf("CountTasks(%+v): %v", tt.filter, err)
+		}
+		if got != tt.want {
+			t.Errorf("CountTasks(%+v) = %d, want %d", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/query"
+	"Go_Test/tenant"
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// SearchTasks retrieves up to limit tasks of the current tenant that match q, newest first.
+func (r *sqlTaskRepository) SearchTasks(ctx context.Context, q query.Node, limit int) (_ []*pb.Task, err error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Searching tasks", zap.String("tenantID", tenantID), zap.Stringer("query", q))
+	condition, args, err := compileQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	sqlQuery := "SELECT " + taskColumns + " FROM tasks WHERE tenant_id = ? AND " + condition + " ORDER BY created_at DESC, id DESC LIMIT ?"
+	ctx, span := r.startSpan(ctx, "sqlTaskRepository.SearchTasks", sqlQuery)
+	defer func() { endSpan(span, err) }()
+	args = append(append([]any{tenantID}, args...), limit)
+	rows, err := r.conn().QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		r.logger.Error("Failed to search tasks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []*pb.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan task row", zap.Error(err))
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err = rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for task search", zap.Error(err))
+		return nil, err
+	}
+	r.logger.Debug("Successfully searched tasks", zap.Int("count", len(tasks)))
+	return tasks, nil
+}
+
+// dateColumns maps the date fields of the query language to their columns.
+var dateColumns = map[query.Field]string{
+	query.FieldDue:     "due_at",
+	query.FieldCreated: "created_at",
+	query.FieldUpdated: "updated_at",
+}
+
+// compileQuery translates a parsed query into a SQL condition on the tasks table.
+// Every value becomes a placeholder argument; nothing of the query text is
+// interpolated into the SQL.
+//
+// Every condition must be TRUE or FALSE, never NULL, for NOT to select exactly the
+// other tasks: nullable columns such as description are compared through COALESCE.
+func compileQuery(node query.Node) (string, []any, error) {
+	switch n := node.(type) {
+	case *query.And:
+		return compileList(n.Terms, " AND ")
+	case *query.Or:
+		return compileList(n.Terms, " OR ")
+	case *query.Not:
+		condition, args, err := compileQuery(n.Term)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT " + condition, args, nil
+	case *query.Text:
+		pattern := "%" + escapeLike(n.Value) + "%"
+		return "(title LIKE ? OR COALESCE(description, '') LIKE ?)", []any{pattern, pattern}, nil
+	case *query.Comparison:
+		return compileComparison(n)
+	}
+	return "", nil, fmt.Errorf("unsupported query node %T", node)
+}
+
+func compileList(nodes []query.Node, sep string) (string, []any, error) {
+	conditions := make([]string, len(nodes))
+	var args []any
+	for i, node := range nodes {
+		condition, nodeArgs, err := compileQuery(node)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions[i] = condition
+		args = append(args, nodeArgs...)
+	}
+	return "(" + strings.Join(conditions, sep) + ")", args, nil
+}
+
+func compileComparison(c *query.Comparison) (string, []any, error) {
+	switch c.Field {
+	case query.FieldStatus:
+		switch c.Value {
+		case query.StatusOpen:
+			return "COALESCE(status, '') <> ?", []any{"completed"}, nil
+		case query.StatusDone:
+			return "COALESCE(status, '') = ?", []any{"completed"}, nil
+		}
+		return "COALESCE(status, '') = ?", []any{c.Value}, nil
+	case query.FieldTag:
+		return "EXISTS (SELECT 1 FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.tag = ?)", []any{c.Value}, nil
+	case query.FieldPriority:
+		if c.None {
+			return "priority = ''", nil, nil
+		}
+		if len(c.Priorities) == 0 {
+			return "FALSE", nil, nil
+		}
+		return "priority IN (?" + strings.Repeat(", ?", len(c.Priorities)-1) + ")", toArgs(c.Priorities), nil
+	case query.FieldAssignee, query.FieldProject:
+		column := "assignee"
+		if c.Field == query.FieldProject {
+			column = "project"
+		}
+		if c.None {
+			return column + " = ''", nil, nil
+		}
+		return column + " = ?", []any{c.Value}, nil
+	case query.FieldTitle:
+		return "title LIKE ?", []any{"%" + escapeLike(c.Value) + "%"}, nil
+	case query.FieldDescription:
+		return "COALESCE(description, '') LIKE ?", []any{"%" + escapeLike(c.Value) + "%"}, nil
+	case query.FieldID:
+		return "id = ?", []any{c.Value}, nil
+	}
+
+	column, ok := dateColumns[c.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported query field %s", c.Field)
+	}
+	if c.None {
+		return column + " IS NULL", nil, nil
+	}
+	condition, args, err := compileDateComparison(column, c)
+	if err != nil {
+		return "", nil, err
+	}
+	return "(" + column + " IS NOT NULL AND " + condition + ")", args, nil
+}
+
+// compileDateComparison compares a date column with the time of c; the caller
+// excludes tasks without the date.
+func compileDateComparison(column string, c *query.Comparison) (string, []any, error) {
+	if !c.Day {
+		ops := map[query.Op]string{query.OpLt: "<", query.OpLe: "<=", query.OpGt: ">", query.OpGe: ">="}
+		return column + " " + ops[c.Op] + " ?", []any{c.Time}, nil
+	}
+	// A date names the whole day from c.Time up to the next midnight.
+	start, end := c.Time, c.Time.AddDate(0, 0, 1)
+	switch c.Op {
+	case query.OpEq:
+		return "(" + column + " >= ? AND " + column + " < ?)", []any{start, end}, nil
+	case query.OpLt:
+		return column + " < ?", []any{start}, nil
+	case query.OpLe:
+		return column + " < ?", []any{end}, nil
+	case query.OpGt:
+		return column + " >= ?", []any{end}, nil
+	case query.OpGe:
+		return column + " >= ?", []any{start}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported operator %s for field %s", c.Op, c.Field)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/query"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSearchTasksNegationKeepsNullColumns(t *testing.T) {
+	db := openTestDB(t)
+	ctx := seedWorkspace(t, db, "alpha", "active")
+	tasks, _ := newTestTaskRepository(db)
+	titles := make(map[string]string)
+	for _, task := range []*pb.Task{
+		{Title: "Deploy", Description: "Run the release script", Status: "pending", DueAt: "2026-03-10T12:00:00Z"},
+		{Title: "Review", Status: "pending"},
+		{Title: "Triage", Status: "completed", DueAt: "2026-03-20T12:00:00Z"},
+	} {
+		added, err := tasks.AddTask(ctx, task)
+		if err != nil {
+			t.Fatalf("AddTask: %v", err)
+		}
+		titles[added.GetId()] = task.GetTitle()
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"release", []string{"Deploy"}},
+		{"-release", []string{"Review", "Triage"}},
+		{"NOT description:script", []string{"Review", "Triage"}},
+		{"-status:done", []string{"Deploy", "Review"}},
+		{"-status:pending", []string{"Triage"}},
+		{"-due<2026-03-15", []string{"Review", "Triage"}},
+		{"-due:2026-03-20", []string{"Deploy", "Review"}},
+		{"NOT (due>=2026-03-01 OR release)", []string{"Review"}},
+	}
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		q, err := query.Parse(tt.query, now)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.query, err)
+		}
+		found, err := tasks.SearchTasks(ctx, q, 100)
+		if err != nil {
+			t.Fatalf("SearchTasks(%q): %v", tt.query, err)
+		}
+		var got []string
+		for _, task := range found {
+			got = append(got, titles[task.GetId()])
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SearchTasks(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/query"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// tenantFixture holds the rows of the tenant that the tests must not see.
+type tenantFixture struct {
+	task       *pb.Task
+	attachment *pb.Attachment
+	object     CalendarObject
+	webhook    *pb.Webhook
+	feed       *pb.CalendarFeed
+}
+
+// seedOtherTenant adds a task with a tag, an attachment and a CalDAV object, a
+// webhook with a dead letter, notification preferences, a calendar feed and an
+// inbound sender to the tenant of ctx.
+func seedOtherTenant(t *testing.T, db *sql.DB, ctx context.Context) tenantFixture {
+	t.Helper()
+	tasks, _ := newTestTaskRepository(db)
+	var f tenantFixture
+	var err error
+	f.task, err = tasks.AddTask(ctx, &pb.Task{Title: "Secret roadmap", Description: "Launch plans", Status: "pending",
+		Tags: []string{"confidential"}, Assignee: "alice", Project: "website", DueAt: "2030-01-31T17:00:00Z"})
+	if err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	f.attachment, err = tasks.AddAttachment(ctx, &pb.Attachment{TaskId: f.task.GetId(), Filename: "plan.txt",
+		ContentType: "text/plain", Content: []byte("launch")})
+	if err != nil {
+		t.Fatalf("AddAttachment: %v", err)
+	}
+	f.object = CalendarObject{TaskID: f.task.GetId(), Name: "roadmap.ics", UID: "roadmap@example.com"}
+	if err := tasks.AddCalendarObject(ctx, f.object); err != nil {
+		t.Fatalf("AddCalendarObject: %v", err)
+	}
+
+	webhooks := NewSQLWebhookRepository(db, zap.NewNop())
+	if f.webhook, err = webhooks.CreateWebhook(ctx, "https://b.example.com/hook", nil, "secret"); err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO webhook_dead_letters (webhook_id, event_id, attempts, last_error)
+		SELECT ?, id, 5, 'gone' FROM outbox_events WHERE task_id = ?`, f.webhook.GetId(), f.task.GetId()); err != nil {
+		t.Fatalf("insert dead letter: %v", err)
+	}
+
+	notifications := NewSQLNotificationRepository(db, zap.NewNop())
+	if _, err := notifications.SetPreferences(ctx, &pb.NotificationPreferences{Assignee: "alice", Email: "alice@b.example.com",
+		Reminders: true, Digest: true}); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+	if f.feed, err = NewSQLCalendarRepository(db, zap.NewNop()).CreateFeed(ctx, "feed-token-hash", "alice", ""); err != nil {
+		t.Fatalf("CreateFeed: %v", err)
+	}
+	if _, err := NewSQLInboundRepository(db, zap.NewNop()).AddSender(ctx, "alice@b.example.com", "alice"); err != nil {
+		t.Fatalf("AddSender: %v", err)
+	}
+	return f
+}
+
+func wantNoRows(t *testing.T, name string, err error) {
+	t.Helper()
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("%s returned %v, want sql.ErrNoRows", name, err)
+	}
+}
+
+func TestTaskRepositoryTenantIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctxA := seedWorkspace(t, db, "alpha", "active")
+	ctxB := seedWorkspace(t, db, "beta", "active")
+	b := seedOtherTenant(t, db, ctxB)
+	tasks, index := newTestTaskRepository(db)
+	own, err := tasks.AddTask(ctxA, &pb.Task{Title: "Own roadmap", Status: "pending", Assignee: "alice", Project: "website"})
+	if err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+
+	all, err := tasks.FetchTasks(ctxA, TaskFilter{})
+	if err != nil {
+		t.Fatalf("FetchTasks: %v", err)
+	}
+	if len(all) != 1 || all[0].GetId() != own.GetId() {
+		t.Errorf("FetchTasks returned %v, want only task %s", all, own.GetId())
+	}
+	filtered, err := tasks.FetchTasks(ctxA, TaskFilter{Tags: []string{"confidential"}, Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("FetchTasks: %v", err)
+	}
+	if len(filtered) != 0 {
+		t.Errorf("FetchTasks by tag returned %v, want none", filtered)
+	}
+	if count, err := tasks.CountTasks(ctxA, TaskFilter{Assignee: "alice"}); err != nil || count != 1 {
+		t.Errorf("CountTasks returned %d, %v; want 1", count, err)
+	}
+	page, err := tasks.FetchTaskPage(ctxA, TaskFilter{}, "", 100)
+	if err != nil {
+		t.Fatalf("FetchTaskPage: %v", err)
+	}
+	if len(page) != 1 || page[0].GetId() != own.GetId() {
+		t.Errorf("FetchTaskPage returned %v, want only task %s", page, own.GetId())
+	}
+	q, err := query.Parse("roadmap OR tag:confidential OR id:"+b.task.GetId(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := tasks.SearchTasks(ctxA, q, 100)
+	if err != nil {
+		t.Fatalf("SearchTasks: %v", err)
+	}
+	if len(found) != 1 || found[0].GetId() != own.GetId() {
+		t.Errorf("SearchTasks returned %v, want only task %s", found, own.GetId())
+	}
+	hits, err := index.Search(ctxA, "secret launch", 100)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("Search returned %v, want no hits", hits)
+	}
+
+	_, err = tasks.FetchTaskByID(ctxA, b.task.GetId())
+	wantNoRows(t, "FetchTaskByID", err)
+	_, err = tasks.UpdateTaskStatus(ctxA, b.task.GetId(), "completed")
+	wantNoRows(t, "UpdateTaskStatus", err)
+	_, err = tasks.UpdateTask(ctxA, &pb.Task{Id: b.task.GetId(), Title: "Taken over", Status: "cancelled"})
+	wantNoRows(t, "UpdateTask", err)
+	wantNoRows(t, "DeleteTask", tasks.DeleteTask(ctxA, b.task.GetId(), 0))
+
+	_, err = tasks.AddAttachment(ctxA, &pb.Attachment{TaskId: b.task.GetId(), Filename: "x.txt", ContentType: "text/plain", Content: []byte("x")})
+	wantNoRows(t, "AddAttachment", err)
+	attachments, err := tasks.ListAttachments(ctxA, b.task.GetId())
+	if err != nil {
+		t.Fatalf("ListAttachments: %v", err)
+	}
+	if len(attachments) != 0 {
+		t.Errorf("ListAttachments returned %v, want none", attachments)
+	}
+	_, err = tasks.FetchAttachment(ctxA, b.task.GetId(), b.attachment.GetId())
+	wantNoRows(t, "FetchAttachment", err)
+
+	err = tasks.AddCalendarObject(ctxA, CalendarObject{TaskID: b.task.GetId(), Name: "stolen.ics", UID: "stolen"})
+	wantNoRows(t, "AddCalendarObject", err)
+	objects, err := tasks.ListCalendarObjects(ctxA)
+	if err != nil {
+		t.Fatalf("ListCalendarObjects: %v", err)
+	}
+	if len(objects) != 0 {
+		t.Errorf("ListCalendarObjects returned %v, want none", objects)
+	}
+	_, err = tasks.FetchCalendarObjectByName(ctxA, b.object.Name)
+	wantNoRows(t, "FetchCalendarObjectByName", err)
+	// Tenant A may use the same resource name as tenant B.
+	err = tasks.AddCalendarObject(ctxA, CalendarObject{TaskID: own.GetId(), Name: b.object.Name, UID: "own"})
+	if err != nil {
+		t.Errorf("AddCalendarObject with the name of another tenant's object: %v", err)
+	}
+
+	// Tenant B's rows are unchanged.
+	task, err := tasks.FetchTaskByID(ctxB, b.task.GetId())
+	if err != nil {
+		t.Fatalf("FetchTaskByID as tenant B: %v", err)
+	}
+	if task.GetTitle() != b.task.GetTitle() || task.GetStatus() != b.task.GetStatus() || task.GetVersion() != b.task.GetVersion() {
+		t.Errorf("tenant B's task changed to %v, want %v", task, b.task)
+	}
+	attachment, err := tasks.FetchAttachment(ctxB, b.task.GetId(), b.attachment.GetId())
+	if err != nil || string(attachment.GetContent()) != "launch" {
+		t.Errorf("FetchAttachment as tenant B returned %v, %v", attachment, err)
+	}
+	attachments, err = tasks.ListAttachments(ctxB, b.task.GetId())
+	if err != nil || len(attachments) != 1 {
+		t.Errorf("ListAttachments as tenant B returned %v, %v; want 1 attachment", attachments, err)
+	}
+	object, err := tasks.FetchCalendarObjectByName(ctxB, b.object.Name)
+	if err != nil || object != b.object {
+		t.Errorf("FetchCalendarObjectByName as tenant B returned %v, %v; want %v", object, err, b.object)
+	}
+}
+
+func TestWebhookRepositoryTenantIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctxA := seedWorkspace(t, db, "alpha", "active")
+	ctxB := seedWorkspace(t, db, "beta", "active")
+	b := seedOtherTenant(t, db, ctxB)
+	webhooks := NewSQLWebhookRepository(db, zap.NewNop())
+
+	list, err := webhooks.ListWebhooks(ctxA)
+	if err != nil {
+		t.Fatalf("ListWebhooks: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListWebhooks returned %v, want none", list)
+	}
+	deadLetters, err := webhooks.ListDeadLetters(ctxA, "")
+	if err != nil {
+		t.Fatalf("ListDeadLetters: %v", err)
+	}
+	if len(deadLetters) != 0 {
+		t.Errorf("ListDeadLetters returned %v, want none", deadLetters)
+	}
+	deadLetters, err = webhooks.ListDeadLetters(ctxA, b.webhook.GetId())
+	if err != nil {
+		t.Fatalf("ListDeadLetters: %v", err)
+	}
+	if len(deadLetters) != 0 {
+		t.Errorf("ListDeadLetters of tenant B's webhook returned %v, want none", deadLetters)
+	}
+	for _, webhookID := range []string{"", b.webhook.GetId()} {
+		n, err := webhooks.Redeliver(ctxA, webhookID, nil)
+		if err != nil || n != 0 {
+			t.Errorf("Redeliver(%q) returned %d, %v; want 0", webhookID, n, err)
+		}
+	}
+	_, err = webhooks.DeleteWebhook(ctxA, b.webhook.GetId())
+	wantNoRows(t, "DeleteWebhook", err)
+
+	list, err = webhooks.ListWebhooks(ctxB)
+	if err != nil || len(list) != 1 {
+		t.Errorf("ListWebhooks as tenant B returned %v, %v; want 1 webhook", list, err)
+	}
+	deadLetters, err = webhooks.ListDeadLetters(ctxB, "")
+	if err != nil || len(deadLetters) != 1 {
+		t.Errorf("ListDeadLetters as tenant B returned %v, %v; want 1 dead letter", deadLetters, err)
+	}
+}
+
+func TestNotificationRepositoryTenantIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctxA := seedWorkspace(t, db, "alpha", "active")
+	ctxB := seedWorkspace(t, db, "beta", "active")
+	seedOtherTenant(t, db, ctxB)
+	notifications := NewSQLNotificationRepository(db, zap.NewNop())
+
+	_, err := notifications.GetPreferences(ctxA, "alice")
+	wantNoRows(t, "GetPreferences", err)
+	list, err := notifications.ListPreferences(ctxA)
+	if err != nil {
+		t.Fatalf("ListPreferences: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("ListPreferences returned %v, want none", list)
+	}
+	_, err = notifications.DeletePreferences(ctxA, "alice")
+	wantNoRows(t, "DeletePreferences", err)
+	// The same assignee in tenant A has preferences of its own.
+	if _, err := notifications.SetPreferences(ctxA, &pb.NotificationPreferences{Assignee: "alice", Email: "alice@a.example.com"}); err != nil {
+		t.Fatalf("SetPreferences: %v", err)
+	}
+
+	prefs, err := notifications.GetPreferences(ctxB, "alice")
+	if err != nil || prefs.GetEmail() != "alice@b.example.com" || !prefs.GetDigest() {
+		t.Errorf("GetPreferences as tenant B returned %v, %v; want the preferences of tenant B", prefs, err)
+	}
+}
+
+func TestCalendarRepositoryTenantIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctxA := seedWorkspace(t, db, "alpha", "active")
+	ctxB := seedWorkspace(t, db, "beta", "active")
+	b := seedOtherTenant(t, db, ctxB)
+	calendars := NewSQLCalendarRepository(db, zap.NewNop())
+
+	feeds, err := calendars.ListFeeds(ctxA)
+	if err != nil {
+		t.Fatalf("ListFeeds: %v", err)
+	}
+	if len(feeds) != 0 {
+		t.Errorf("ListFeeds returned %v, want none", feeds)
+	}
+	_, err = calendars.DeleteFeed(ctxA, b.feed.GetId())
+	wantNoRows(t, "DeleteFeed", err)
+
+	tenantID, feed, err := calendars.FetchFeedByTokenHash(context.Background(), "feed-token-hash")
+	if err != nil || feed.GetId() != b.feed.GetId() {
+		t.Fatalf("FetchFeedByTokenHash returned %v, %v; want feed %s", feed, err, b.feed.GetId())
+	}
+	if want, _ := tenant.IDFromContext(ctxB); tenantID != want {
+		t.Errorf("FetchFeedByTokenHash returned tenant %s, want %s", tenantID, want)
+	}
+}
+
+func TestInboundRepositoryTenantIsolation(t *testing.T) {
+	db := openTestDB(t)
+	ctxA := seedWorkspace(t, db, "alpha", "active")
+	ctxB := seedWorkspace(t, db, "beta", "active")
+	seedOtherTenant(t, db, ctxB)
+	inbound := NewSQLInboundRepository(db, zap.NewNop())
+
+	senders, err := inbound.ListSenders(ctxA)
+	if err != nil {
+		t.Fatalf("ListSenders: %v", err)
+	}
+	if len(senders) != 0 {
+		t.Errorf("ListSenders returned %v, want none", senders)
+	}
+	_, err = inbound.RemoveSender(ctxA, "alice@b.example.com")
+	wantNoRows(t, "RemoveSender", err)
+	if _, err := inbound.AddSender(ctxA, "Alice@B.example.com", "mallory"); !errors.Is(err, ErrSenderTaken) {
+		t.Errorf("AddSender of tenant B's sender returned %v, want ErrSenderTaken", err)
+	}
+
+	tenantID, sender, err := inbound.LookupSender(context.Background(), "alice@b.example.com")
+	if err != nil {
+		t.Fatalf("LookupSender: %v", err)
+	}
+	if want, _ := tenant.IDFromContext(ctxB); tenantID != want || sender.GetAssignee() != "alice" {
+		t.Errorf("LookupSender returned tenant %s and %v, want tenant %s and assignee alice", tenantID, sender, want)
+	}
+}
@@ -0,0 +1,455 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WebhookRepository stores webhook subscriptions and the queue of their deliveries.
+// The subscription methods are scoped to the tenant carried by ctx, like
+// TaskRepository; the delivery methods serve the webhook dispatcher and work
+// across all tenants.
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, url string, eventTypes []string, secret string) (*pb.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*pb.Webhook, error)
+	// DeleteWebhook deletes a webhook together with its deliveries and dead letters.
+	DeleteWebhook(ctx context.Context, webhookID string) (*pb.Webhook, error)
+	// ListDeadLetters returns the dead letters of the tenant, or of one webhook when webhookID is set.
+	ListDeadLetters(ctx context.Context, webhookID string) ([]*pb.DeadLetter, error)
+	// Redeliver moves dead letters back to the delivery queue with a fresh attempt count:
+	// the given ones or, when deadLetterIDs is empty, all of the tenant or of webhookID.
+	// It returns the number of requeued dead letters.
+	Redeliver(ctx context.Context, webhookID string, deadLetterIDs []string) (int64, error)
+
+	// FanOutEvents marks up to limit outbox events as dispatched and queues one delivery
+	// per webhook of the event's tenant that subscribes to its type. It returns the
+	// number of dispatched events.
+	FanOutEvents(ctx context.Context, limit int) (int, error)
+	// ClaimDeliveries returns up to limit due deliveries, counts the attempt and hides
+	// them from other claims for lease, so that several servers can share the queue.
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	// CompleteDelivery removes a successful delivery from the queue.
+	CompleteDelivery(ctx context.Context, deliveryID int64) error
+	// RetryDelivery schedules the next attempt of a failed delivery.
+	RetryDelivery(ctx context.Context, deliveryID int64, lastError string, next time.Time) error
+	// DeadLetterDelivery moves a delivery that failed for the last time to the dead letters.
+	DeadLetterDelivery(ctx context.Context, delivery WebhookDelivery, lastError string) error
+	// PruneEvents deletes up to limit events dispatched and published before the given
+	// time that no delivery or dead letter refers to any more.
+	PruneEvents(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// WebhookDelivery is one attempt to post an outbox event to a webhook.
+type WebhookDelivery struct {
+	ID int64
+	// Attempts counts the attempts including the current one.
+	Attempts  int
+	WebhookID string
+	URL       string
+	Secret    string
+	Event     *pb.TaskEvent
+}
+
+type sqlWebhookRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLWebhookRepository creates a new SQL-based webhook repository.
+func NewSQLWebhookRepository(db *sql.DB, logger *zap.Logger) WebhookRepository {
+	return &sqlWebhookRepository{db: db, logger: logger.Named("repository")}
+}
+
+// CreateWebhook inserts a webhook for the current tenant. An empty eventTypes
+// subscribes to every event.
+func (r *sqlWebhookRepository) CreateWebhook(ctx context.Context, url string, eventTypes []string, secret string) (*pb.Webhook, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Creating webhook", zap.String("tenantID", tenantID), zap.String("url", url))
+	result, err := r.db.ExecContext(ctx, "INSERT INTO webhooks (tenant_id, url, secret, event_types) VALUES (?, ?, ?, ?)",
+		tenantID, url, secret, strings.Join(eventTypes, ","))
+	if err != nil {
+		r.logger.Error("Failed to insert webhook", zap.Error(err))
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get last insert ID for webhook", zap.Error(err))
+		return nil, err
+	}
+	return r.fetchWebhook(ctx, tenantID, fmt.Sprintf("%d", id))
+}
+
+// ListWebhooks returns the webhooks of the current tenant, oldest first.
+func (r *sqlWebhookRepository) ListWebhooks(ctx context.Context) ([]*pb.Webhook, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, "SELECT id, url, event_types, created_at FROM webhooks WHERE tenant_id = ? ORDER BY id", tenantID)
+	if err != nil {
+		r.logger.Error("Failed to query webhooks", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var webhooks []*pb.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			r.logger.Error("Failed to scan webhook row", zap.Error(err))
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for webhooks", zap.Error(err))
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// DeleteWebhook deletes a webhook of the current tenant; the foreign keys remove its
+// deliveries and dead letters. A missing webhook is reported as sql.ErrNoRows.
+func (r *sqlWebhookRepository) DeleteWebhook(ctx context.Context, webhookID string) (*pb.Webhook, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.logger.Debug("Deleting webhook", zap.String("tenantID", tenantID), zap.String("webhookID", webhookID))
+	webhook, err := r.fetchWebhook(ctx, tenantID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.db.ExecContext(ctx, "DELETE FROM webhooks WHERE id = ? AND tenant_id = ?", webhookID, tenantID)
+	if err != nil {
+		r.logger.Error("Failed to delete webhook", zap.String("webhookID", webhookID), zap.Error(err))
+		return nil, err
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return webhook, nil
+}
+
+func (r *sqlWebhookRepository) fetchWebhook(ctx context.Context, tenantID, webhookID string) (*pb.Webhook, error) {
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx,
+		"SELECT id, url, event_types, created_at FROM webhooks WHERE id = ? AND tenant_id = ?", webhookID, tenantID))
+	if err != nil && err != sql.ErrNoRows {
+		r.logger.Error("Failed to fetch webhook", zap.String("webhookID", webhookID), zap.Error(err))
+	}
+	return webhook, err
+}
+
+func scanWebhook(row rowScanner) (*pb.Webhook, error) {
+	var webhook pb.Webhook
+	var eventTypes string
+	var createdAt sql.NullTime
+	if err := row.Scan(&webhook.Id, &webhook.Url, &eventTypes, &createdAt); err != nil {
+		return nil, err
+	}
+	if eventTypes != "" {
+		webhook.EventTypes = strings.Split(eventTypes, ",")
+	}
+	if createdAt.Valid {
+		webhook.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	return &webhook, nil
+}
+
+// ListDeadLetters returns the dead letters of the current tenant, newest first.
+func (r *sqlWebhookRepository) ListDeadLetters(ctx context.Context, webhookID string) ([]*pb.DeadLetter, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	query := `SELECT d.id, w.id, w.url, e.id, e.event_type, d.attempts, d.last_error, d.failed_at
+		FROM webhook_dead_letters d
+		JOIN webhooks w ON w.id = d.webhook_id
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE w.tenant_id = ?`
+	args := []any{tenantID}
+	if webhookID != "" {
+		query += " AND w.id = ?"
+		args = append(args, webhookID)
+	}
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY d.id DESC", args...)
+	if err != nil {
+		r.logger.Error("Failed to query dead letters", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var deadLetters []*pb.DeadLetter
+	for rows.Next() {
+		var d pb.DeadLetter
+		var failedAt sql.NullTime
+		if err := rows.Scan(&d.Id, &d.WebhookId, &d.Url, &d.EventId, &d.EventType, &d.Attempts, &d.LastError, &failedAt); err != nil {
+			r.logger.Error("Failed to scan dead letter row", zap.Error(err))
+			return nil, err
+		}
+		if failedAt.Valid {
+			d.FailedAt = failedAt.Time.Format(time.RFC3339)
+		}
+		deadLetters = append(deadLetters, &d)
+	}
+	if err := rows.Err(); err != nil {
+		r.logger.Error("Error during rows iteration for dead letters", zap.Error(err))
+		return nil, err
+	}
+	return deadLetters, nil
+}
+
+// Redeliver requeues dead letters of the current tenant in one transaction.
+func (r *sqlWebhookRepository) Redeliver(ctx context.Context, webhookID string, deadLetterIDs []string) (int64, error) {
+	tenantID, err := tenant.RequireID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r.logger.Debug("Redelivering dead letters", zap.String("tenantID", tenantID), zap.String("webhookID", webhookID), zap.Strings("deadLetterIDs", deadLetterIDs))
+	query := `SELECT d.id FROM webhook_dead_letters d JOIN webhooks w ON w.id = d.webhook_id WHERE w.tenant_id = ?`
+	args := []any{tenantID}
+	if webhookID != "" {
+		query += " AND w.id = ?"
+		args = append(args, webhookID)
+	}
+	if len(deadLetterIDs) > 0 {
+		query += " AND d.id IN (" + placeholders(len(deadLetterIDs)) + ")"
+		for _, id := range deadLetterIDs {
+			args = append(args, id)
+		}
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Failed to begin redelivery transaction", zap.Error(err))
+		return 0, err
+	}
+	defer tx.Rollback()
+	ids, err := queryIDs(ctx, tx, query+" FOR UPDATE", args...)
+	if err != nil {
+		r.logger.Error("Failed to select dead letters", zap.Error(err))
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	in := placeholders(len(ids))
+	if _, err := tx.ExecContext(ctx, `INSERT INTO webhook_deliveries (webhook_id, event_id, attempts, next_attempt_at)
+		SELECT webhook_id, event_id, 0, CURRENT_TIMESTAMP(3) FROM webhook_dead_letters WHERE id IN (`+in+`)`, ids...); err != nil {
+		r.logger.Error("Failed to requeue dead letters", zap.Error(err))
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_dead_letters WHERE id IN ("+in+")", ids...); err != nil {
+		r.logger.Error("Failed to delete requeued dead letters", zap.Error(err))
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit redelivery transaction", zap.Error(err))
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// FanOutEvents locks the oldest undispatched events with SKIP LOCKED, so that
+// concurrent dispatchers split the outbox instead of queueing events twice.
+// Events locked by another dispatcher or by the relay of PublishPending are
+// skipped until a later call, after newer events: the queue is not in event order.
+func (r *sqlWebhookRepository) FanOutEvents(ctx context.Context, limit int) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, tenant_id, event_type FROM outbox_events
+		WHERE dispatched_at IS NULL ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, err
+	}
+	type event struct{ id, tenantID, eventType string }
+	var events []event
+	for rows.Next() {
+		var e event
+		if err := rows.Scan(&e.id, &e.tenantID, &e.eventType); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	subscriptions := make(map[string][]*pb.Webhook)
+	eventIDs := make([]any, 0, len(events))
+	for _, e := range events {
+		webhooks, ok := subscriptions[e.tenantID]
+		if !ok {
+			if webhooks, err = tenantWebhooks(ctx, tx, e.tenantID); err != nil {
+				return 0, err
+			}
+			subscriptions[e.tenantID] = webhooks
+		}
+		for _, webhook := range webhooks {
+			if len(webhook.GetEventTypes()) > 0 && !slices.Contains(webhook.GetEventTypes(), e.eventType) {
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO webhook_deliveries (webhook_id, event_id, attempts, next_attempt_at)
+				VALUES (?, ?, 0, CURRENT_TIMESTAMP(3))`, webhook.GetId(), e.id); err != nil {
+				return 0, err
+			}
+		}
+		eventIDs = append(eventIDs, e.id)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE outbox_events SET dispatched_at = CURRENT_TIMESTAMP(3) WHERE id IN ("+placeholders(len(eventIDs))+")", eventIDs...); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+func tenantWebhooks(ctx context.Context, tx *sql.Tx, tenantID string) ([]*pb.Webhook, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT id, url, event_types, created_at FROM webhooks WHERE tenant_id = ?", tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var webhooks []*pb.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	return webhooks, rows.Err()
+}
+
+// ClaimDeliveries moves next_attempt_at of the claimed deliveries past the lease;
+// a server that stops mid-delivery thus leaves them to be retried by another.
+func (r *sqlWebhookRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids, err := queryIDs(ctx, tx, `SELECT id FROM webhook_deliveries WHERE next_attempt_at <= CURRENT_TIMESTAMP(3)
+		ORDER BY next_attempt_at LIMIT ? FOR UPDATE SKIP LOCKED`, limit)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	in := placeholders(len(ids))
+	if _, err := tx.ExecContext(ctx, `UPDATE webhook_deliveries SET attempts = attempts + 1,
+		next_attempt_at = CURRENT_TIMESTAMP(3) + INTERVAL ? MICROSECOND WHERE id IN (`+in+`)`,
+		append([]any{lease.Microseconds()}, ids...)...); err != nil {
+		return nil, err
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT d.id, d.attempts, w.id, w.url, w.secret, e.id, e.tenant_id, e.created_at, e.payload
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		JOIN outbox_events e ON e.id = d.event_id
+		WHERE d.id IN (`+in+`) ORDER BY d.id`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		var eventID, tenantID string
+		var occurredAt time.Time
+		var payload []byte
+		if err := rows.Scan(&d.ID, &d.Attempts, &d.WebhookID, &d.URL, &d.Secret, &eventID, &tenantID, &occurredAt, &payload); err != nil {
+			return nil, err
+		}
+		if d.Event, err = decodeEvent(eventID, tenantID, occurredAt, payload); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *sqlWebhookRepository) CompleteDelivery(ctx context.Context, deliveryID int64) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE id = ?", deliveryID)
+	return err
+}
+
+func (r *sqlWebhookRepository) RetryDelivery(ctx context.Context, deliveryID int64, lastError string, next time.Time) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE webhook_deliveries SET next_attempt_at = ?, last_error = ? WHERE id = ?",
+		next.UTC(), lastError, deliveryID)
+	return err
+}
+
+func (r *sqlWebhookRepository) DeadLetterDelivery(ctx context.Context, delivery WebhookDelivery, lastError string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, "INSERT INTO webhook_dead_letters (webhook_id, event_id, attempts, last_error) VALUES (?, ?, ?, ?)",
+		delivery.WebhookID, delivery.Event.GetId(), delivery.Attempts, lastError); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM webhook_deliveries WHERE id = ?", delivery.ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *sqlWebhookRepository) PruneEvents(ctx context.Context, before time.Time, limit int) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM outbox_events WHERE dispatched_at < ? AND published_at < ?
+		AND NOT EXISTS (SELECT 1 FROM webhook_deliveries d WHERE d.event_id = outbox_events.id)
+		AND NOT EXISTS (SELECT 1 FROM webhook_dead_letters l WHERE l.event_id = outbox_events.id)
+		LIMIT ?`, before.UTC(), before.UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// placeholders returns n comma-separated question marks for an IN list.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// queryIDs runs a query selecting one ID column and returns the IDs as arguments
+// for a following IN list.
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]any, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.uber.org/zap"
+)
+
+// ErrWorkspaceExists is returned by CreateWorkspace for a name that is already taken.
+var ErrWorkspaceExists = errors.New("workspace name is already taken")
+
+// mysqlDuplicateEntry is the MySQL error number of a violated unique key.
+const mysqlDuplicateEntry = 1062
+
+// isDuplicateKey reports whether err is MySQL's error for a violated unique key.
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
+
+// WorkspaceRepository defines the interface for workspace (tenant) persistence operations.
+// Unlike TaskRepository it is not tenant-scoped; it is used by the auth layer to
+// resolve tokens and by the AdminService to manage workspaces.
+type WorkspaceRepository interface {
+	CreateWorkspace(ctx context.Context, name string, tokenHash string) (*pb.Workspace, error)
+	FetchWorkspaceByID(ctx context.Context, workspaceID string) (*pb.Workspace, error)
+	FetchWorkspaceByTokenHash(ctx context.Context, tokenHash string) (*pb.Workspace, error)
+	UpdateWorkspaceStatus(ctx context.Context, workspaceID string, newStatus string) (*pb.Workspace, error)
+}
+
+type sqlWorkspaceRepository struct {
+	db     *sql.DB
+	logger *zap.Logger
+}
+
+// NewSQLWorkspaceRepository creates a new SQL-based workspace repository.
+func NewSQLWorkspaceRepository(db *sql.DB, logger *zap.Logger) WorkspaceRepository {
+	return &sqlWorkspaceRepository{db: db, logger: logger.Named("repository")}
+}
+
+// CreateWorkspace inserts a new workspace and its first API token in a single
+// transaction. A name that is already taken is reported as ErrWorkspaceExists.
+func (r *sqlWorkspaceRepository) CreateWorkspace(ctx context.Context, name string, tokenHash string) (*pb.Workspace, error) {
+	r.logger.Debug("Creating workspace", zap.String("name", name))
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		r.logger.Error("Failed to begin workspace transaction", zap.Error(err))
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO workspaces (name, status) VALUES (?, 'active')", name)
+	if isDuplicateKey(err) {
+		return nil, ErrWorkspaceExists
+	}
+	if err != nil {
+		r.logger.Error("Failed to insert workspace", zap.Error(err))
+		return nil, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		r.logger.Error("Failed to get last insert ID for workspace", zap.Error(err))
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO api_tokens (workspace_id, token_hash) VALUES (?, ?)", id, tokenHash); err != nil {
+		r.logger.Error("Failed to insert workspace token", zap.Error(err))
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		r.logger.Error("Failed to commit workspace transaction", zap.Error(err))
+		return nil, err
+	}
+	return r.FetchWorkspaceByID(ctx, fmt.Sprintf("%d", id))
+}
+
+// FetchWorkspaceByID retrieves a single workspace by its ID.
+func (r *sqlWorkspaceRepository) FetchWorkspaceByID(ctx context.Context, workspaceID string) (*pb.Workspace, error) {
+	r.logger.Debug("Fetching workspace by ID", zap.String("workspaceID", workspaceID))
+	query := "SELECT id, name, status, created_at, updated_at FROM workspaces WHERE id = ?"
+	return r.scanWorkspace(r.db.QueryRowContext(ctx, query, workspaceID))
+}
+
+// FetchWorkspaceByTokenHash retrieves the workspace that owns the given API token hash.
+func (r *sqlWorkspaceRepository) FetchWorkspaceByTokenHash(ctx context.Context, tokenHash string) (*pb.Workspace, error) {
+	query := `SELECT w.id, w.name, w.status, w.created_at, w.updated_at
+		FROM workspaces w JOIN api_tokens t ON t.workspace_id = w.id
+		WHERE t.token_hash = ?`
+	return r.scanWorkspace(r.db.QueryRowContext(ctx, query, tokenHash))
+}
+
+// UpdateWorkspaceStatus updates the status of a workspace and returns the updated workspace.
+func (r *sqlWorkspaceRepository) UpdateWorkspaceStatus(ctx context.Context, workspaceID string, newStatus string) (*pb.Workspace, error) {
+	r.logger.Debug("Updating workspace status", zap.String("workspaceID", workspaceID), zap.String("newStatus", newStatus))
+	query := "UPDATE workspaces SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	result, err := r.db.ExecContext(ctx, query, newStatus, workspaceID)
+	if err != nil {
+		r.logger.Error("Failed to update workspace status", zap.String("workspaceID", workspaceID), zap.Error(err))
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		r.logger.Error("Failed to get rows affected after workspace status update", zap.String("workspaceID", workspaceID), zap.Error(err))
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return r.FetchWorkspaceByID(ctx, workspaceID)
+}
+
+func (r *sqlWorkspaceRepository) scanWorkspace(row *sql.Row) (*pb.Workspace, error) {
+	var workspace pb.Workspace
+	var createdAt, updatedAt sql.NullTime
+	if err := row.Scan(&workspace.Id, &workspace.Name, &workspace.Status, &createdAt, &updatedAt); err != nil {
+		if err != sql.ErrNoRows {
+			r.logger.Error("Failed to scan workspace row", zap.Error(err))
+		}
+		return nil, err
+	}
+	if createdAt.Valid {
+		workspace.CreatedAt = createdAt.Time.Format(time.RFC3339)
+	}
+	if updatedAt.Valid {
+		workspace.UpdatedAt = updatedAt.Time.Format(time.RFC3339)
+	}
+	return &workspace, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestCreateWorkspaceDuplicateName(t *testing.T) {
+	db := openTestDB(t)
+	workspaces := NewSQLWorkspaceRepository(db, zap.NewNop())
+	if _, err := workspaces.CreateWorkspace(t.Context(), "acme", "hash-1"); err != nil {
+		t.Fatalf("CreateWorkspace: %v", err)
+	}
+	if _, err := workspaces.CreateWorkspace(t.Context(), "acme", "hash-2"); !errors.Is(err, ErrWorkspaceExists) {
+		t.Errorf("CreateWorkspace with a taken name returned %v, want ErrWorkspaceExists", err)
+	}
+	if _, err := workspaces.FetchWorkspaceByTokenHash(t.Context(), "hash-2"); err == nil {
+		t.Error("the token of the rejected workspace was stored")
+	}
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"Go_Test/tenant"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminServiceImpl implements the proto.AdminServiceServer interface for workspace management.
+// Admin authentication is enforced by the auth interceptor before any method is reached.
+type AdminServiceImpl struct {
+	pb.UnimplementedAdminServiceServer
+	logger        *zap.Logger
+	workspaceRepo repo.WorkspaceRepository
+	taskRepo      repo.TaskRepository
+	searchIndex   repo.SearchIndex
+	logLevels     *logging.Levels
+}
+
+// NewAdminServiceImpl creates a new AdminServiceImpl.
+func NewAdminServiceImpl(logger *zap.Logger, workspaceRepo repo.WorkspaceRepository, taskRepo repo.TaskRepository, searchIndex repo.SearchIndex, logLevels *logging.Levels) pb.AdminServiceServer {
+	return &AdminServiceImpl{logger: logger.Named("server"), workspaceRepo: workspaceRepo, taskRepo: taskRepo, searchIndex: searchIndex, logLevels: logLevels}
+}
+
+// CreateWorkspace handles the RPC call to create a new workspace and its first API token.
+func (s *AdminServiceImpl) CreateWorkspace(ctx context.Context, req *pb.CreateWorkspaceRequest) (*pb.CreateWorkspaceReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("AdminServiceImpl: CreateWorkspace called", zap.String("name", req.GetName()))
+	if req.GetName() == "" {
+		return nil, invalidArgument("name", "cannot be empty")
+	}
+	token, tokenHash, err := auth.GenerateToken()
+	if err != nil {
+		logger.Error("Failed to generate workspace token", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+	}
+	workspace, err := s.workspaceRepo.CreateWorkspace(ctx, req.GetName(), tokenHash)
+	if err != nil {
+		if errors.Is(err, repo.ErrWorkspaceExists) {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("workspace '%s' already exists", req.GetName()))
+		}
+		// The error of the database stays in the log; it may quote the request.
+		logger.Error("Failed to create workspace in service", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to create workspace")
+	}
+	logger.Info("AdminServiceImpl: Workspace created", zap.String("workspace_id", workspace.GetId()))
+	return &pb.CreateWorkspaceReply{Workspace: workspace, Token: token}, nil
+}
+
+// SuspendWorkspace handles the RPC call to suspend a workspace.
+func (s *AdminServiceImpl) SuspendWorkspace(ctx context.Context, req *pb.SuspendWorkspaceRequest) (*pb.SuspendWorkspaceReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("AdminServiceImpl: SuspendWorkspace called", zap.String("workspace_id", req.GetWorkspaceId()))
+	if req.GetWorkspaceId() == "" {
+		return nil, invalidArgument("workspace_id", "cannot be empty")
+	}
+	workspace, err := s.workspaceRepo.UpdateWorkspaceStatus(ctx, req.GetWorkspaceId(), auth.WorkspaceStatusSuspended)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("workspace", req.GetWorkspaceId(), "")
+		}
+		logger.Error("Failed to suspend workspace in service", zap.String("workspace_id", req.GetWorkspaceId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to suspend workspace: %v", err)
+	}
+	return &pb.SuspendWorkspaceReply{Workspace: workspace}, nil
+}
+
+// ExportWorkspace handles the RPC call to export a workspace and all of its tasks.
+// The task query runs through the regular tenant-scoped repository.
+func (s *AdminServiceImpl) ExportWorkspace(ctx context.Context, req *pb.ExportWorkspaceRequest) (*pb.ExportWorkspaceReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("AdminServiceImpl: ExportWorkspace called", zap.String("workspace_id", req.GetWorkspaceId()))
+	if req.GetWorkspaceId() == "" {
+		return nil, invalidArgument("workspace_id", "cannot be empty")
+	}
+	workspace, err := s.workspaceRepo.FetchWorkspaceByID(ctx, req.GetWorkspaceId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("workspace", req.GetWorkspaceId(), "")
+		}
+		logger.Error("Failed to fetch workspace for export", zap.String("workspace_id", req.GetWorkspaceId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to fetch workspace: %v", err)
+	}
+	tasks, err := s.taskRepo.FetchTasks(tenant.WithID(ctx, workspace.GetId()), repo.TaskFilter{})
+	if err != nil {
+		logger.Error("Failed to fetch tasks for export", zap.String("workspace_id", workspace.GetId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
+	}
+	return &pb.ExportWorkspaceReply{Workspace: workspace, Tasks: tasks}, nil
+}
+
+// SetLogLevel handles the RPC call to change the log level without restarting the server.
+func (s *AdminServiceImpl) SetLogLevel(ctx context.Context, req *pb.SetLogLevelRequest) (*pb.SetLogLevelReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("AdminServiceImpl: SetLogLevel called", zap.String("level", req.GetLevel()), zap.String("logger", req.GetLogger()))
+	if err := s.logLevels.Set(req.GetLogger(), req.GetLevel()); err != nil {
+		logger.Debug("Rejected log level", zap.Error(err))
+		return nil, invalidArgument("level", fmt.Sprintf("%q must be debug, info, warn or error, or default to remove the level of a logger", req.GetLevel()))
+	}
+	return &pb.SetLogLevelReply{Levels: s.logLevels.Snapshot()}, nil
+}
+
+// RebuildSearchIndex handles the RPC call to rebuild the full-text search index from scratch.
+func (s *AdminServiceImpl) RebuildSearchIndex(ctx context.Context, req *pb.RebuildSearchIndexRequest) (*pb.RebuildSearchIndexReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("AdminServiceImpl: RebuildSearchIndex called", zap.String("backend", s.searchIndex.Backend()))
+	start := time.Now()
+	count, err := s.searchIndex.Rebuild(ctx)
+	if err != nil {
+		logger.Error("Failed to rebuild search index", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to rebuild search index: %v", err)
+	}
+	return &pb.RebuildSearchIndexReply{
+		Backend:      s.searchIndex.Backend(),
+		IndexedTasks: count,
+		DurationMs:   time.Since(start).Milliseconds(),
+	}, nil
+}
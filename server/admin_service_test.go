@@ -0,0 +1,48 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	repo "Go_Test/repository"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWorkspaceRepository fails CreateWorkspace with err.
+type fakeWorkspaceRepository struct {
+	repo.WorkspaceRepository
+	err error
+}
+
+func (f *fakeWorkspaceRepository) CreateWorkspace(ctx context.Context, name string, tokenHash string) (*pb.Workspace, error) {
+	return nil, f.err
+}
+
+func TestCreateWorkspaceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "name taken", err: repo.ErrWorkspaceExists, wantCode: codes.AlreadyExists},
+		{name: "database error", err: errors.New("Error 1406: Data too long for column 'name' near 'SELECT secret'"), wantCode: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAdminServiceImpl(zap.NewNop(), &fakeWorkspaceRepository{err: tt.err}, nil, nil, nil)
+			_, err := s.CreateWorkspace(context.Background(), &pb.CreateWorkspaceRequest{Name: "acme"})
+			st := status.Convert(err)
+			if st.Code() != tt.wantCode {
+				t.Fatalf("code = %v, want %v", st.Code(), tt.wantCode)
+			}
+			if strings.Contains(st.Message(), "Error 1") {
+				t.Errorf("message %q reveals the database error", st.Message())
+			}
+		})
+	}
+}
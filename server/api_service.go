@@ -2,10 +2,18 @@ package server
 
 import (
 	pb "Go_Test/api"
+	"Go_Test/logging"
 	repo "Go_Test/repository"
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -14,21 +22,30 @@ import (
 // TaskServiceImpl implements the proto.TaskServiceServer interface for task-related RPC calls.
 type TaskServiceImpl struct {
 	pb.UnimplementedTaskServiceServer
-	logger   *zap.Logger
-	taskRepo repo.TaskRepository
+	logger      *zap.Logger
+	taskRepo    repo.TaskRepository
+	searchIndex repo.SearchIndex
+	tracer      trace.Tracer
 }
 
 // NewTaskServiceImpl creates a new TaskServiceImpl.
-func NewTaskServiceImpl(logger *zap.Logger, taskRepo repo.TaskRepository) pb.TaskServiceServer {
-	return &TaskServiceImpl{logger: logger, taskRepo: taskRepo}
+func NewTaskServiceImpl(logger *zap.Logger, taskRepo repo.TaskRepository, searchIndex repo.SearchIndex, tp trace.TracerProvider) pb.TaskServiceServer {
+	return &TaskServiceImpl{logger: logger.Named("server"), taskRepo: taskRepo, searchIndex: searchIndex, tracer: tp.Tracer("Go_Test/server")}
 }
 
-// GetTasks handles the RPC call to fetch all tasks.
+// GetTasks handles the RPC call to fetch the tasks that match the request filter.
 func (s *TaskServiceImpl) GetTasks(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksReply, error) {
-	s.logger.Info("TaskServiceImpl: GetTasks called")
-	tasks, err := s.taskRepo.FetchTasks(ctx)
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.GetTasks")
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: GetTasks called")
+	filter, err := taskFilter(req.GetFilter())
 	if err != nil {
-		s.logger.Error("Failed to fetch tasks in service", zap.Error(err))
+		return nil, err
+	}
+	tasks, err := s.taskRepo.FetchTasks(ctx, filter)
+	if err != nil {
+		logger.Error("Failed to fetch tasks in service", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
 	}
 	return &pb.GetTasksReply{Tasks: tasks}, nil
@@ -36,55 +53,275 @@ func (s *TaskServiceImpl) GetTasks(ctx context.Context, req *pb.GetTasksRequest)
 
 // AddTask handles the RPC call to add a new task.
 func (s *TaskServiceImpl) AddTask(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskReply, error) {
-	s.logger.Info("TaskServiceImpl: AddTask called", zap.String("title", req.GetTitle()))
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.AddTask")
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: AddTask called", zap.String("title", req.GetTitle()))
+	createdTask, err := s.addTask(ctx, logger, s.taskRepo, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddTaskReply{Task: createdTask}, nil
+}
+
+// addTask validates req and adds the task through taskRepo.
+func (s *TaskServiceImpl) addTask(ctx context.Context, logger *zap.Logger, taskRepo repo.TaskRepository, req *pb.AddTaskRequest) (*pb.Task, error) {
 	if req.GetTitle() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "title cannot be empty")
+		return nil, invalidArgument("title", "cannot be empty")
+	}
+	task := &pb.Task{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Status:      req.GetStatus(),
+		DueAt:       req.GetDueAt(),
+		Tags:        req.GetTags(),
+		Priority:    req.GetPriority(),
+		Assignee:    req.GetAssignee(),
+		Project:     req.GetProject(),
+	}
+	if task.Status == "" {
+		task.Status = "pending"
 	}
-	taskStatus := req.GetStatus()
-	if taskStatus == "" {
-		taskStatus = "pending"
+	if err := normalizeTaskFields(task); err != nil {
+		return nil, err
 	}
-	createdTask, err := s.taskRepo.AddTask(ctx, req.GetTitle(), req.GetDescription(), taskStatus)
+	createdTask, err := taskRepo.AddTask(ctx, task)
 	if err != nil {
-		s.logger.Error("Failed to add task in service", zap.Error(err))
+		logger.Error("Failed to add task in service", zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "failed to add task: %v", err)
 	}
-	return &pb.AddTaskReply{Task: createdTask}, nil
+	return createdTask, nil
 }
 
 // CompleteTask handles the RPC call to mark a task as completed.
 // It includes error handling for non-existent tasks or tasks already completed.
 func (s *TaskServiceImpl) CompleteTask(ctx context.Context, req *pb.CompleteTaskRequest) (*pb.CompleteTaskReply, error) {
-	s.logger.Info("TaskServiceImpl: CompleteTask called", zap.String("task_id", req.GetTaskId()))
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.CompleteTask", trace.WithAttributes(attribute.String("task.id", req.GetTaskId())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: CompleteTask called", zap.String("task_id", req.GetTaskId()))
+	updatedTask, err := s.completeTask(ctx, logger, s.taskRepo, req)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("TaskServiceImpl: Task completed successfully", zap.String("task_id", updatedTask.GetId()))
+	return &pb.CompleteTaskReply{Task: updatedTask}, nil
+}
+
+// completeTask marks the task named by req as completed through taskRepo.
+func (s *TaskServiceImpl) completeTask(ctx context.Context, logger *zap.Logger, taskRepo repo.TaskRepository, req *pb.CompleteTaskRequest) (*pb.Task, error) {
 	if req.GetTaskId() == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "task_id cannot be empty")
+		return nil, invalidArgument("task_id", "cannot be empty")
 	}
 
-	existingTask, err := s.taskRepo.FetchTaskByID(ctx, req.GetTaskId())
+	existingTask, err := taskRepo.FetchTaskByID(ctx, req.GetTaskId())
 	if err != nil {
 		if err == sql.ErrNoRows {
-			s.logger.Warn("CompleteTask: Task not found", zap.String("task_id", req.GetTaskId()))
-			return nil, status.Errorf(codes.NotFound, "task with ID '%s' not found", req.GetTaskId())
+			logger.Warn("CompleteTask: Task not found", zap.String("task_id", req.GetTaskId()))
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
 		}
-		s.logger.Error("CompleteTask: Failed to fetch task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		logger.Error("CompleteTask: Failed to fetch task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "failed to retrieve task details: %v", err)
 	}
 
 	if existingTask.GetStatus() == "completed" {
-		s.logger.Info("CompleteTask: Task already completed", zap.String("task_id", req.GetTaskId()))
-		return nil, status.Errorf(codes.FailedPrecondition, "task with ID '%s' is already completed", req.GetTaskId())
+		logger.Info("CompleteTask: Task already completed", zap.String("task_id", req.GetTaskId()))
+		return nil, failedPrecondition(fmt.Sprintf("task with ID '%s' is already completed", req.GetTaskId()),
+			"TASK_STATUS", "task/"+req.GetTaskId(), "task must not be completed already")
 	}
 
-	updatedTask, err := s.taskRepo.UpdateTaskStatus(ctx, req.GetTaskId(), "completed")
+	updatedTask, err := taskRepo.UpdateTaskStatus(ctx, req.GetTaskId(), "completed")
 	if err != nil {
 		if err == sql.ErrNoRows {
-			s.logger.Warn("CompleteTask: Task disappeared before update", zap.String("task_id", req.GetTaskId()))
-			return nil, status.Errorf(codes.NotFound, "task with ID '%s' not found for update", req.GetTaskId())
+			logger.Warn("CompleteTask: Task disappeared before update", zap.String("task_id", req.GetTaskId()))
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
 		}
-		s.logger.Error("CompleteTask: Failed to update task status", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		logger.Error("CompleteTask: Failed to update task status", zap.String("task_id", req.GetTaskId()), zap.Error(err))
 		return nil, status.Errorf(codes.Internal, "failed to complete task: %v", err)
 	}
+	return updatedTask, nil
+}
 
-	s.logger.Info("TaskServiceImpl: Task completed successfully", zap.String("task_id", updatedTask.GetId()))
-	return &pb.CompleteTaskReply{Task: updatedTask}, nil
+// UpdateTask handles the RPC call to change the editable fields of a task.
+// Fields not named in the update mask keep their current values.
+func (s *TaskServiceImpl) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.UpdateTaskReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.UpdateTask", trace.WithAttributes(attribute.String("task.id", req.GetTaskId())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: UpdateTask called", zap.String("task_id", req.GetTaskId()), zap.Strings("update_mask", req.GetUpdateMask().GetPaths()))
+	updatedTask, err := s.updateTask(ctx, logger, s.taskRepo, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateTaskReply{Task: updatedTask}, nil
+}
+
+// updateTask applies the update mask of req to the task through taskRepo.
+func (s *TaskServiceImpl) updateTask(ctx context.Context, logger *zap.Logger, taskRepo repo.TaskRepository, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	if req.GetTaskId() == "" {
+		return nil, invalidArgument("task_id", "cannot be empty")
+	}
+
+	existingTask, err := taskRepo.FetchTaskByID(ctx, req.GetTaskId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
+		}
+		logger.Error("UpdateTask: Failed to fetch task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve task details: %v", err)
+	}
+	if req.GetVersion() != 0 && req.GetVersion() != existingTask.GetVersion() {
+		return nil, versionConflict(req.GetTaskId(), req.GetVersion())
+	}
+
+	task := existingTask
+	// The repository checks the version again, under the row lock.
+	task.Version = req.GetVersion()
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		paths = updatableTaskFields
+	}
+	for _, path := range paths {
+		switch path {
+		case "title":
+			task.Title = req.GetTitle()
+		case "description":
+			task.Description = req.GetDescription()
+		case "status":
+			task.Status = req.GetStatus()
+		case "due_at":
+			task.DueAt = req.GetDueAt()
+		case "tags":
+			task.Tags = req.GetTags()
+		case "priority":
+			task.Priority = req.GetPriority()
+		case "assignee":
+			task.Assignee = req.GetAssignee()
+		case "project":
+			task.Project = req.GetProject()
+		default:
+			return nil, invalidArgument("update_mask", fmt.Sprintf("contains unknown field %q", path))
+		}
+	}
+	if task.GetTitle() == "" {
+		return nil, invalidArgument("title", "cannot be empty")
+	}
+	if task.GetStatus() == "" {
+		return nil, invalidArgument("status", "cannot be empty")
+	}
+	if err := normalizeTaskFields(task); err != nil {
+		return nil, err
+	}
+
+	updatedTask, err := taskRepo.UpdateTask(ctx, task)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
+		}
+		if errors.Is(err, repo.ErrVersionConflict) {
+			return nil, versionConflict(req.GetTaskId(), req.GetVersion())
+		}
+		logger.Error("UpdateTask: Failed to update task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to update task: %v", err)
+	}
+	return updatedTask, nil
+}
+
+// deleteTask deletes the task named by req through taskRepo and returns it as it was before.
+func (s *TaskServiceImpl) deleteTask(ctx context.Context, logger *zap.Logger, taskRepo repo.TaskRepository, req *pb.DeleteTaskRequest) (*pb.Task, error) {
+	if req.GetTaskId() == "" {
+		return nil, invalidArgument("task_id", "cannot be empty")
+	}
+	existingTask, err := taskRepo.FetchTaskByID(ctx, req.GetTaskId())
+	if err == nil {
+		err = taskRepo.DeleteTask(ctx, req.GetTaskId(), req.GetVersion())
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
+		}
+		if errors.Is(err, repo.ErrVersionConflict) {
+			return nil, versionConflict(req.GetTaskId(), req.GetVersion())
+		}
+		logger.Error("DeleteTask: Failed to delete task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete task: %v", err)
+	}
+	return existingTask, nil
+}
+
+// updatableTaskFields are the update mask paths accepted by UpdateTask, in the
+// order applied when the mask is empty.
+var updatableTaskFields = []string{"title", "description", "status", "due_at", "tags", "priority", "assignee", "project"}
+
+// taskPriorities are the accepted priorities; the empty string means no priority.
+var taskPriorities = map[string]bool{"": true, "low": true, "medium": true, "high": true, "urgent": true}
+
+// maxTagLength matches the width of task_tags.tag.
+const maxTagLength = 100
+
+// normalizeTaskFields validates the optional fields of task and brings them into
+// their stored form: the due date in UTC, tags trimmed, deduplicated and sorted.
+func normalizeTaskFields(task *pb.Task) error {
+	if !taskPriorities[task.GetPriority()] {
+		return invalidArgument("priority", "must be one of low, medium, high or urgent")
+	}
+	if task.GetDueAt() != "" {
+		dueAt, err := time.Parse(time.RFC3339, task.GetDueAt())
+		if err != nil {
+			return invalidArgument("due_at", "must be an RFC 3339 timestamp, e.g. 2025-01-31T17:00:00Z")
+		}
+		task.DueAt = dueAt.UTC().Format(time.RFC3339)
+	}
+	seen := make(map[string]bool, len(task.GetTags()))
+	tags := make([]string, 0, len(task.GetTags()))
+	for _, tag := range task.GetTags() {
+		tag = strings.TrimSpace(tag)
+		switch {
+		case tag == "":
+			return invalidArgument("tags", "must not contain empty tags")
+		case strings.ContainsAny(tag, ", \t\n"):
+			return invalidArgument("tags", fmt.Sprintf("tag %q must not contain commas or whitespace", tag))
+		case len(tag) > maxTagLength:
+			return invalidArgument("tags", fmt.Sprintf("tag %q is longer than %d characters", tag, maxTagLength))
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	task.Tags = tags
+	return nil
+}
+
+// taskFilter converts the API filter into a repository filter, validating its timestamps.
+func taskFilter(f *pb.TaskFilter) (repo.TaskFilter, error) {
+	filter := repo.TaskFilter{
+		Statuses:      f.GetStatuses(),
+		Tags:          f.GetTags(),
+		Priority:      f.GetPriority(),
+		Assignee:      f.GetAssignee(),
+		Project:       f.GetProject(),
+		TitleContains: f.GetTitleContains(),
+	}
+	for _, bound := range []struct {
+		field string
+		value string
+		dest  *time.Time
+	}{
+		{"filter.created_before", f.GetCreatedBefore(), &filter.CreatedBefore},
+		{"filter.created_after", f.GetCreatedAfter(), &filter.CreatedAfter},
+		{"filter.due_before", f.GetDueBefore(), &filter.DueBefore},
+		{"filter.due_after", f.GetDueAfter(), &filter.DueAfter},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return repo.TaskFilter{}, invalidArgument(bound.field, "must be an RFC 3339 timestamp, e.g. 2025-01-31T17:00:00Z")
+		}
+		*bound.dest = t
+	}
+	return filter, nil
 }
@@ -0,0 +1,62 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAttachments handles the RPC call to list the attachments of a task without their content.
+func (s *TaskServiceImpl) ListAttachments(ctx context.Context, req *pb.ListAttachmentsRequest) (*pb.ListAttachmentsReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.ListAttachments", trace.WithAttributes(attribute.String("task.id", req.GetTaskId())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: ListAttachments called", zap.String("task_id", req.GetTaskId()))
+	if req.GetTaskId() == "" {
+		return nil, invalidArgument("task_id", "cannot be empty")
+	}
+	if _, err := s.taskRepo.FetchTaskByID(ctx, req.GetTaskId()); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("task", req.GetTaskId(), workspaceOwner(ctx))
+		}
+		logger.Error("ListAttachments: Failed to fetch task", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to retrieve task details: %v", err)
+	}
+	attachments, err := s.taskRepo.ListAttachments(ctx, req.GetTaskId())
+	if err != nil {
+		logger.Error("Failed to list attachments in service", zap.String("task_id", req.GetTaskId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list attachments: %v", err)
+	}
+	return &pb.ListAttachmentsReply{Attachments: attachments}, nil
+}
+
+// GetAttachment handles the RPC call to download an attachment of a task.
+func (s *TaskServiceImpl) GetAttachment(ctx context.Context, req *pb.GetAttachmentRequest) (*pb.GetAttachmentReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.GetAttachment", trace.WithAttributes(
+		attribute.String("task.id", req.GetTaskId()), attribute.String("attachment.id", req.GetAttachmentId())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: GetAttachment called", zap.String("task_id", req.GetTaskId()), zap.String("attachment_id", req.GetAttachmentId()))
+	if req.GetTaskId() == "" {
+		return nil, invalidArgument("task_id", "cannot be empty")
+	}
+	if req.GetAttachmentId() == "" {
+		return nil, invalidArgument("attachment_id", "cannot be empty")
+	}
+	attachment, err := s.taskRepo.FetchAttachment(ctx, req.GetTaskId(), req.GetAttachmentId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("attachment", req.GetAttachmentId(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to fetch attachment in service", zap.String("attachment_id", req.GetAttachmentId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to fetch attachment: %v", err)
+	}
+	return &pb.GetAttachmentReply{Attachment: attachment}, nil
+}
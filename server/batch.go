@@ -0,0 +1,112 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchMutations limits the size of a BatchMutate call, and with it the time
+// the transaction holds its row locks.
+const maxBatchMutations = 1000
+
+// errBatchAborted rolls back the transaction of an atomic batch after a failed mutation.
+var errBatchAborted = errors.New("batch aborted")
+
+// BatchMutate handles the RPC call to apply several mutations in one transaction.
+// In atomic mode the first failing mutation rolls back the whole batch; in best-effort
+// mode each mutation runs within a savepoint and only failing mutations are undone.
+// Failures of individual mutations are reported in the results, not as an RPC error.
+func (s *TaskServiceImpl) BatchMutate(ctx context.Context, req *pb.BatchMutateRequest) (*pb.BatchMutateReply, error) {
+	mode := req.GetMode()
+	if mode == pb.BatchMode_BATCH_MODE_UNSPECIFIED {
+		mode = pb.BatchMode_BATCH_MODE_ATOMIC
+	}
+	mutations := req.GetMutations()
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.BatchMutate", trace.WithAttributes(
+		attribute.Int("batch.size", len(mutations)),
+		attribute.String("batch.mode", mode.String())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: BatchMutate called", zap.Int("mutations", len(mutations)), zap.Stringer("mode", mode))
+
+	switch {
+	case len(mutations) == 0:
+		return nil, invalidArgument("mutations", "cannot be empty")
+	case len(mutations) > maxBatchMutations:
+		return nil, invalidArgument("mutations", fmt.Sprintf("cannot contain more than %d mutations", maxBatchMutations))
+	case mode != pb.BatchMode_BATCH_MODE_ATOMIC && mode != pb.BatchMode_BATCH_MODE_BEST_EFFORT:
+		return nil, invalidArgument("mode", fmt.Sprintf("unknown batch mode %d", mode))
+	}
+
+	results := make([]*pb.MutationResult, len(mutations))
+	failed := -1
+	err := s.taskRepo.InTransaction(ctx, func(tx repo.TaskTx) error {
+		for i, mutation := range mutations {
+			var task *pb.Task
+			err := tx.Savepoint(ctx, func() error {
+				var err error
+				task, err = s.applyMutation(ctx, logger, tx, i, mutation)
+				return err
+			})
+			results[i] = mutationResult(i, task, err)
+			if err != nil && mode == pb.BatchMode_BATCH_MODE_ATOMIC {
+				failed = i
+				return errBatchAborted
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBatchAborted) {
+		logger.Error("BatchMutate: Transaction failed", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to apply batch: %v", err)
+	}
+
+	if failed >= 0 {
+		logger.Info("BatchMutate: Batch rolled back", zap.Int("failed_index", failed))
+		for i := range results {
+			switch {
+			case i < failed:
+				results[i] = &pb.MutationResult{Index: int32(i), Code: int32(codes.Aborted),
+					Message: fmt.Sprintf("rolled back because mutation %d failed", failed)}
+			case i > failed:
+				results[i] = &pb.MutationResult{Index: int32(i), Code: int32(codes.Aborted),
+					Message: fmt.Sprintf("not applied because mutation %d failed", failed)}
+			}
+		}
+	}
+	return &pb.BatchMutateReply{Results: results, Committed: failed < 0}, nil
+}
+
+// applyMutation performs one mutation of a batch through the transaction tx.
+func (s *TaskServiceImpl) applyMutation(ctx context.Context, logger *zap.Logger, tx repo.TaskTx, index int, mutation *pb.Mutation) (*pb.Task, error) {
+	switch op := mutation.GetOperation().(type) {
+	case *pb.Mutation_Add:
+		return s.addTask(ctx, logger, tx, op.Add)
+	case *pb.Mutation_Update:
+		return s.updateTask(ctx, logger, tx, op.Update)
+	case *pb.Mutation_Complete:
+		return s.completeTask(ctx, logger, tx, op.Complete)
+	case *pb.Mutation_Delete:
+		return s.deleteTask(ctx, logger, tx, op.Delete)
+	}
+	return nil, invalidArgument(fmt.Sprintf("mutations[%d]", index), "must set one of add, update, complete or delete")
+}
+
+// mutationResult reports the outcome of mutation index; err is converted into its gRPC status.
+func mutationResult(index int, task *pb.Task, err error) *pb.MutationResult {
+	if err == nil {
+		return &pb.MutationResult{Index: int32(index), Task: task}
+	}
+	st := status.Convert(err).Proto()
+	return &pb.MutationResult{Index: int32(index), Code: st.GetCode(), Message: st.GetMessage(), Details: st.GetDetails()}
+}
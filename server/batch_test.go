@@ -0,0 +1,158 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func addMutation(title string) *pb.Mutation {
+	return &pb.Mutation{Operation: &pb.Mutation_Add{Add: &pb.AddTaskRequest{Title: title}}}
+}
+
+func completeMutation(id string) *pb.Mutation {
+	return &pb.Mutation{Operation: &pb.Mutation_Complete{Complete: &pb.CompleteTaskRequest{TaskId: id}}}
+}
+
+// mixedMutations adds, updates, completes and deletes tasks; the fourth mutation
+// completes task 99, which does not exist.
+func mixedMutations() []*pb.Mutation {
+	return []*pb.Mutation{
+		addMutation("Write report"),
+		{Operation: &pb.Mutation_Update{Update: &pb.UpdateTaskRequest{TaskId: "1", Title: "Renamed", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}}}}},
+		completeMutation("2"),
+		completeMutation("99"),
+		{Operation: &pb.Mutation_Delete{Delete: &pb.DeleteTaskRequest{TaskId: "1"}}},
+	}
+}
+
+// newBatchTestService returns a service whose repository holds the pending tasks 1 and 2.
+func newBatchTestService(t *testing.T) (*TaskServiceImpl, *memoryTaskRepository, context.Context) {
+	t.Helper()
+	s, tasks, ctx := newImportExportTestService()
+	for _, title := range []string{"Plan sprint", "Fix login"} {
+		if _, err := tasks.AddTask(ctx, &pb.Task{Title: title, Status: "pending"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s, tasks, ctx
+}
+
+func checkCodes(t *testing.T, results []*pb.MutationResult, want []codes.Code) {
+	t.Helper()
+	if len(results) != len(want) {
+		t.Fatalf("%d results, want %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if result.GetIndex() != int32(i) || codes.Code(result.GetCode()) != want[i] {
+			t.Errorf("result %d: index %d, code %v (%s), want %v", i, result.GetIndex(), codes.Code(result.GetCode()), result.GetMessage(), want[i])
+		}
+	}
+}
+
+func TestBatchMutateAtomicRollsBack(t *testing.T) {
+	for _, mode := range []pb.BatchMode{pb.BatchMode_BATCH_MODE_UNSPECIFIED, pb.BatchMode_BATCH_MODE_ATOMIC} {
+		s, tasks, ctx := newBatchTestService(t)
+		reply, err := s.BatchMutate(ctx, &pb.BatchMutateRequest{Mutations: mixedMutations(), Mode: mode})
+		if err != nil {
+			t.Fatalf("%v: BatchMutate: %v", mode, err)
+		}
+		if reply.GetCommitted() {
+			t.Errorf("%v: batch with a failed mutation committed", mode)
+		}
+		checkCodes(t, reply.GetResults(), []codes.Code{codes.Aborted, codes.Aborted, codes.Aborted, codes.NotFound, codes.Aborted})
+		results := reply.GetResults()
+		if msg := results[0].GetMessage(); msg != "rolled back because mutation 3 failed" || results[0].GetTask() != nil {
+			t.Errorf("%v: mutation before the failure: %q with task %v", mode, msg, results[0].GetTask())
+		}
+		if msg := results[4].GetMessage(); msg != "not applied because mutation 3 failed" {
+			t.Errorf("%v: mutation after the failure: %q", mode, msg)
+		}
+		if len(results[3].GetDetails()) == 0 {
+			t.Errorf("%v: the failed mutation has no error details", mode)
+		}
+
+		if len(tasks.tasks) != 2 || tasks.tasks[0].GetTitle() != "Plan sprint" || tasks.tasks[1].GetStatus() != "pending" {
+			t.Errorf("%v: rolled-back batch left tasks %v", mode, tasks.tasks)
+		}
+	}
+}
+
+func TestBatchMutateBestEffortSkipsFailures(t *testing.T) {
+	s, tasks, ctx := newBatchTestService(t)
+	mutations := append(mixedMutations(), &pb.Mutation{}, addMutation(""))
+	reply, err := s.BatchMutate(ctx, &pb.BatchMutateRequest{Mutations: mutations, Mode: pb.BatchMode_BATCH_MODE_BEST_EFFORT})
+	if err != nil {
+		t.Fatalf("BatchMutate: %v", err)
+	}
+	if !reply.GetCommitted() {
+		t.Errorf("best-effort batch did not commit")
+	}
+	checkCodes(t, reply.GetResults(), []codes.Code{codes.OK, codes.OK, codes.OK, codes.NotFound, codes.OK, codes.InvalidArgument, codes.InvalidArgument})
+	results := reply.GetResults()
+	if results[0].GetTask().GetId() != "3" || results[1].GetTask().GetTitle() != "Renamed" ||
+		results[2].GetTask().GetStatus() != "completed" || results[4].GetTask().GetId() != "1" {
+		t.Errorf("results carry tasks %v, %v, %v and %v", results[0].GetTask(), results[1].GetTask(), results[2].GetTask(), results[4].GetTask())
+	}
+	if !strings.Contains(results[5].GetMessage(), "mutations[5]") {
+		t.Errorf("empty mutation failed with %q, want it to name mutations[5]", results[5].GetMessage())
+	}
+
+	var ids []string
+	for _, task := range tasks.tasks {
+		ids = append(ids, task.GetId()+" "+task.GetStatus())
+	}
+	if got := strings.Join(ids, ", "); got != "2 completed, 3 pending" {
+		t.Errorf("tasks after the batch: %s, want 2 completed, 3 pending", got)
+	}
+}
+
+func TestBatchMutateBestEffortRollsBackSavepoint(t *testing.T) {
+	s, tasks, ctx := newBatchTestService(t)
+	tasks.statusErr = errors.New("outbox unavailable")
+	mutations := []*pb.Mutation{completeMutation("1"), addMutation("Write report")}
+	reply, err := s.BatchMutate(ctx, &pb.BatchMutateRequest{Mutations: mutations, Mode: pb.BatchMode_BATCH_MODE_BEST_EFFORT})
+	if err != nil {
+		t.Fatalf("BatchMutate: %v", err)
+	}
+	checkCodes(t, reply.GetResults(), []codes.Code{codes.Internal, codes.OK})
+	if !reply.GetCommitted() || len(tasks.tasks) != 3 || tasks.tasks[0].GetStatus() != "pending" || tasks.tasks[0].GetVersion() != 1 {
+		t.Errorf("committed %v with tasks %v, want task 1 unchanged and task 3 added", reply.GetCommitted(), tasks.tasks)
+	}
+}
+
+func TestBatchMutateValidatesRequest(t *testing.T) {
+	s, _, ctx := newBatchTestService(t)
+	tooMany := make([]*pb.Mutation, maxBatchMutations+1)
+	for i := range tooMany {
+		tooMany[i] = addMutation(fmt.Sprintf("Task %d", i))
+	}
+	tests := []struct {
+		name string
+		req  *pb.BatchMutateRequest
+	}{
+		{"no mutations", &pb.BatchMutateRequest{}},
+		{"too many mutations", &pb.BatchMutateRequest{Mutations: tooMany}},
+		{"unknown mode", &pb.BatchMutateRequest{Mutations: tooMany[:1], Mode: pb.BatchMode(7)}},
+	}
+	for _, tt := range tests {
+		if _, err := s.BatchMutate(ctx, tt.req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: %v, want InvalidArgument", tt.name, err)
+		}
+	}
+
+	reply, err := s.BatchMutate(ctx, &pb.BatchMutateRequest{Mutations: tooMany[:maxBatchMutations]})
+	if err != nil {
+		t.Fatalf("BatchMutate of %d mutations: %v", maxBatchMutations, err)
+	}
+	if !reply.GetCommitted() || len(reply.GetResults()) != maxBatchMutations {
+		t.Errorf("batch of %d mutations: committed %v with %d results", maxBatchMutations, reply.GetCommitted(), len(reply.GetResults()))
+	}
+}
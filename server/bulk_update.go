@@ -0,0 +1,133 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBulkUpdate limits the number of tasks one UpdateTasksByQuery call may change.
+	maxBulkUpdate = 10000
+	// defaultSampleSize and maxSampleSize bound the sample of tasks in the reply.
+	defaultSampleSize = 10
+	maxSampleSize     = 100
+)
+
+// UpdateTasksByQuery handles the RPC call to patch every task that matches a filter.
+// All matching tasks are updated in one transaction; if any update fails, none is applied.
+// The reply carries the IDs of the updated tasks and a sample of sample_size tasks.
+func (s *TaskServiceImpl) UpdateTasksByQuery(ctx context.Context, req *pb.UpdateTasksByQueryRequest) (*pb.UpdateTasksByQueryReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.UpdateTasksByQuery")
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	patch := req.GetPatch()
+	logger.Info("TaskServiceImpl: UpdateTasksByQuery called",
+		zap.Bool("dry_run", req.GetDryRun()),
+		zap.Strings("update_mask", patch.GetUpdateMask().GetPaths()))
+
+	if len(patch.GetUpdateMask().GetPaths()) == 0 {
+		return nil, invalidArgument("patch.update_mask", "cannot be empty")
+	}
+	for _, path := range patch.GetUpdateMask().GetPaths() {
+		if !slices.Contains(updatableTaskFields, path) {
+			return nil, invalidArgument("patch.update_mask", fmt.Sprintf("contains unknown field %q", path))
+		}
+	}
+	filter, err := taskFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	sampleSize := int(req.GetSampleSize())
+	switch {
+	case sampleSize < 0 || sampleSize > maxSampleSize:
+		return nil, invalidArgument("sample_size", fmt.Sprintf("must be between 0 and %d", maxSampleSize))
+	case sampleSize == 0:
+		sampleSize = defaultSampleSize
+	}
+
+	if req.GetDryRun() {
+		// Only the count and the sample are read; the filter may match far more tasks
+		// than maxBulkUpdate.
+		count, err := s.taskRepo.CountTasks(ctx, filter)
+		if err != nil {
+			logger.Error("UpdateTasksByQuery: Failed to count matching tasks", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to count tasks: %v", err)
+		}
+		sample, err := s.taskRepo.FetchTaskPage(ctx, filter, "", sampleSize)
+		if err != nil {
+			logger.Error("UpdateTasksByQuery: Failed to fetch matching tasks", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
+		}
+		return &pb.UpdateTasksByQueryReply{MatchedCount: int32(count), Tasks: sample, DryRun: true}, nil
+	}
+
+	reply := &pb.UpdateTasksByQueryReply{}
+	err = s.taskRepo.InTransaction(ctx, func(tx repo.TaskTx) error {
+		count, err := tx.CountTasks(ctx, filter)
+		if err != nil {
+			logger.Error("UpdateTasksByQuery: Failed to count matching tasks", zap.Error(err))
+			return status.Errorf(codes.Internal, "failed to count tasks: %v", err)
+		}
+		if count > maxBulkUpdate {
+			return failedPrecondition(fmt.Sprintf("filter matches %d tasks, more than the limit of %d", count, maxBulkUpdate),
+				"BULK_LIMIT", "filter", "narrow the filter to match fewer tasks")
+		}
+		if expected := int(req.GetExpectedCount()); expected > 0 && expected != count {
+			return statusWithDetails(codes.Aborted, fmt.Sprintf("filter matches %d tasks, expected %d; the tasks changed since the dry run", count, expected),
+				&errdetails.ErrorInfo{
+					Reason:   "MATCH_COUNT_CHANGED",
+					Domain:   "fx-grpc-app",
+					Metadata: map[string]string{"expected_count": strconv.Itoa(expected), "matched_count": strconv.Itoa(count)},
+				})
+		}
+		tasks, err := tx.FetchTasks(ctx, filter)
+		if err != nil {
+			logger.Error("UpdateTasksByQuery: Failed to fetch matching tasks", zap.Error(err))
+			return status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
+		}
+		reply.TaskIds = make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			updatedTask, err := s.updateTask(ctx, logger, tx, &pb.UpdateTaskRequest{
+				TaskId:      task.GetId(),
+				Title:       patch.GetTitle(),
+				Description: patch.GetDescription(),
+				Status:      patch.GetStatus(),
+				DueAt:       patch.GetDueAt(),
+				Tags:        patch.GetTags(),
+				Priority:    patch.GetPriority(),
+				Assignee:    patch.GetAssignee(),
+				Project:     patch.GetProject(),
+				UpdateMask:  patch.GetUpdateMask(),
+			})
+			if err != nil {
+				// Validation errors name UpdateTaskRequest fields; they equally apply to the patch.
+				return err
+			}
+			reply.TaskIds = append(reply.TaskIds, updatedTask.GetId())
+			if len(reply.Tasks) < sampleSize {
+				reply.Tasks = append(reply.Tasks, updatedTask)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		logger.Error("UpdateTasksByQuery: Transaction failed", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to update tasks: %v", err)
+	}
+	reply.MatchedCount = int32(len(reply.TaskIds))
+	logger.Info("TaskServiceImpl: Tasks updated by query", zap.Int("count", len(reply.TaskIds)))
+	return reply, nil
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// newBulkTestService returns a TaskServiceImpl over a repository with n pending
+// tasks of alice and one of bob.
+func newBulkTestService(t *testing.T, n int) (pb.TaskServiceServer, *memoryTaskRepository, context.Context) {
+	t.Helper()
+	ctx := tenant.WithID(context.Background(), "1")
+	tasks := &memoryTaskRepository{}
+	for i := range n {
+		if _, err := tasks.AddTask(ctx, &pb.Task{Title: fmt.Sprintf("Task %d", i), Status: "pending", Assignee: "alice"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tasks.AddTask(ctx, &pb.Task{Title: "Other", Status: "pending", Assignee: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	tasks.fetched = 0
+	return NewTaskServiceImpl(zap.NewNop(), tasks, nil, noop.NewTracerProvider()), tasks, ctx
+}
+
+func completeAlicesTasks(dryRun bool, sampleSize int32) *pb.UpdateTasksByQueryRequest {
+	return &pb.UpdateTasksByQueryRequest{
+		Filter:     &pb.TaskFilter{Assignee: "alice"},
+		Patch:      &pb.TaskPatch{Status: "completed", UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}}},
+		DryRun:     dryRun,
+		SampleSize: sampleSize,
+	}
+}
+
+func TestUpdateTasksByQueryDryRunReadsOnlySample(t *testing.T) {
+	s, tasks, ctx := newBulkTestService(t, 30)
+	reply, err := s.UpdateTasksByQuery(ctx, completeAlicesTasks(true, 5))
+	if err != nil {
+		t.Fatalf("UpdateTasksByQuery: %v", err)
+	}
+	if reply.GetMatchedCount() != 30 || len(reply.GetTasks()) != 5 || !reply.GetDryRun() || len(reply.GetTaskIds()) != 0 {
+		t.Errorf("reply has matched_count %d, %d tasks, %d task IDs, dry_run %v; want 30, 5, 0, true",
+			reply.GetMatchedCount(), len(reply.GetTasks()), len(reply.GetTaskIds()), reply.GetDryRun())
+	}
+	if tasks.fetched != 5 {
+		t.Errorf("the dry run read %d tasks, want only the 5 of the sample", tasks.fetched)
+	}
+	for _, task := range tasks.tasks {
+		if task.GetStatus() != "pending" {
+			t.Fatalf("the dry run changed task %s", task.GetId())
+		}
+	}
+}
+
+func TestUpdateTasksByQueryReturnsIDsAndSample(t *testing.T) {
+	s, tasks, ctx := newBulkTestService(t, 30)
+	req := completeAlicesTasks(false, 0)
+	req.ExpectedCount = 30
+	reply, err := s.UpdateTasksByQuery(ctx, req)
+	if err != nil {
+		t.Fatalf("UpdateTasksByQuery: %v", err)
+	}
+	if reply.GetMatchedCount() != 30 || len(reply.GetTaskIds()) != 30 || len(reply.GetTasks()) != defaultSampleSize {
+		t.Errorf("reply has matched_count %d, %d task IDs and %d tasks; want 30, 30 and %d",
+			reply.GetMatchedCount(), len(reply.GetTaskIds()), len(reply.GetTasks()), defaultSampleSize)
+	}
+	for _, task := range tasks.tasks {
+		if want := map[string]string{"alice": "completed", "bob": "pending"}[task.GetAssignee()]; task.GetStatus() != want {
+			t.Errorf("task %s of %s has status %s, want %s", task.GetId(), task.GetAssignee(), task.GetStatus(), want)
+		}
+	}
+}
+
+func TestUpdateTasksByQueryLimits(t *testing.T) {
+	s, tasks, ctx := newBulkTestService(t, maxBulkUpdate+1)
+	_, err := s.UpdateTasksByQuery(ctx, completeAlicesTasks(false, 0))
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Fatalf("code = %v, want FailedPrecondition", code)
+	}
+	if tasks.fetched != 0 {
+		t.Errorf("the rejected update read %d tasks, want none", tasks.fetched)
+	}
+
+	req := completeAlicesTasks(false, 0)
+	req.Filter.Assignee = "bob"
+	req.ExpectedCount = 2
+	if _, err := s.UpdateTasksByQuery(ctx, req); status.Code(err) != codes.Aborted {
+		t.Errorf("code = %v for a changed match count, want Aborted", status.Code(err))
+	}
+}
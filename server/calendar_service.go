@@ -0,0 +1,91 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/auth"
+	cfg "Go_Test/config"
+	"Go_Test/ical"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"context"
+	"database/sql"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CalendarServiceImpl implements the proto.CalendarServiceServer interface.
+// Like the TaskService, every call is scoped to the workspace of the caller's token.
+type CalendarServiceImpl struct {
+	pb.UnimplementedCalendarServiceServer
+	logger       *zap.Logger
+	calendarRepo repo.CalendarRepository
+	config       *cfg.Config
+}
+
+// NewCalendarServiceImpl creates a new CalendarServiceImpl.
+func NewCalendarServiceImpl(logger *zap.Logger, calendarRepo repo.CalendarRepository, config *cfg.Config) pb.CalendarServiceServer {
+	return &CalendarServiceImpl{logger: logger.Named("server"), calendarRepo: calendarRepo, config: config}
+}
+
+// CreateCalendarFeed handles the RPC call to create a calendar feed. The reply carries
+// the only copy of the feed URL, as the server stores just the hash of its token.
+func (s *CalendarServiceImpl) CreateCalendarFeed(ctx context.Context, req *pb.CreateCalendarFeedRequest) (*pb.CreateCalendarFeedReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("CalendarServiceImpl: CreateCalendarFeed called", zap.String("assignee", req.GetAssignee()), zap.String("project", req.GetProject()))
+	assignee := strings.TrimSpace(req.GetAssignee())
+	project := strings.TrimSpace(req.GetProject())
+	if assignee == "" && project == "" {
+		return nil, invalidArgument("assignee", "either assignee or project must be set")
+	}
+	if len(assignee) > 255 {
+		return nil, invalidArgument("assignee", "must not be longer than 255 characters")
+	}
+	if len(project) > 255 {
+		return nil, invalidArgument("project", "must not be longer than 255 characters")
+	}
+	token, tokenHash, err := auth.GenerateToken()
+	if err != nil {
+		logger.Error("Failed to generate calendar feed token", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to generate token: %v", err)
+	}
+	feed, err := s.calendarRepo.CreateFeed(ctx, tokenHash, assignee, project)
+	if err != nil {
+		logger.Error("Failed to create calendar feed in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to create calendar feed: %v", err)
+	}
+	logger.Info("CalendarServiceImpl: Calendar feed created", zap.String("feed_id", feed.GetId()))
+	return &pb.CreateCalendarFeedReply{Feed: feed, Url: ical.FeedURL(s.config.GatewayURL(), token)}, nil
+}
+
+// ListCalendarFeeds handles the RPC call to list the calendar feeds of the workspace.
+func (s *CalendarServiceImpl) ListCalendarFeeds(ctx context.Context, req *pb.ListCalendarFeedsRequest) (*pb.ListCalendarFeedsReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("CalendarServiceImpl: ListCalendarFeeds called")
+	feeds, err := s.calendarRepo.ListFeeds(ctx)
+	if err != nil {
+		logger.Error("Failed to list calendar feeds in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list calendar feeds: %v", err)
+	}
+	return &pb.ListCalendarFeedsReply{Feeds: feeds}, nil
+}
+
+// DeleteCalendarFeed handles the RPC call to delete a calendar feed, which revokes its URL.
+func (s *CalendarServiceImpl) DeleteCalendarFeed(ctx context.Context, req *pb.DeleteCalendarFeedRequest) (*pb.DeleteCalendarFeedReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("CalendarServiceImpl: DeleteCalendarFeed called", zap.String("feed_id", req.GetFeedId()))
+	if req.GetFeedId() == "" {
+		return nil, invalidArgument("feed_id", "cannot be empty")
+	}
+	feed, err := s.calendarRepo.DeleteFeed(ctx, req.GetFeedId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("calendar feed", req.GetFeedId(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to delete calendar feed in service", zap.String("feed_id", req.GetFeedId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete calendar feed: %v", err)
+	}
+	return &pb.DeleteCalendarFeedReply{Feed: feed}, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"Go_Test/tenant"
+	"context"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// statusWithDetails builds a status error carrying google.rpc error details.
+// Should attaching the details fail, the plain status is returned.
+func statusWithDetails(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// invalidArgument reports a request field that failed validation, with a BadRequest detail.
+func invalidArgument(field, description string) error {
+	return statusWithDetails(codes.InvalidArgument, fmt.Sprintf("%s %s", field, description),
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		}})
+}
+
+// notFound reports a missing resource, with a ResourceInfo detail.
+// owner names the scope that was searched, e.g. "workspace/1"; it may be empty.
+func notFound(resourceType, name, owner string) error {
+	return statusWithDetails(codes.NotFound, fmt.Sprintf("%s with ID '%s' not found", resourceType, name),
+		&errdetails.ResourceInfo{
+			ResourceType: resourceType,
+			ResourceName: name,
+			Owner:        owner,
+			Description:  "the resource does not exist or is not visible to the caller",
+		})
+}
+
+// workspaceOwner names the workspace of the caller as the owner of tenant-scoped resources.
+func workspaceOwner(ctx context.Context) string {
+	if id, ok := tenant.IDFromContext(ctx); ok {
+		return "workspace/" + id
+	}
+	return ""
+}
+
+// failedPrecondition reports a request that conflicts with the current state of a
+// resource, with a PreconditionFailure detail.
+func failedPrecondition(message, violationType, subject, description string) error {
+	return statusWithDetails(codes.FailedPrecondition, message,
+		&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{
+			{Type: violationType, Subject: subject, Description: description},
+		}})
+}
+
+// versionConflict reports a conditional change of a task whose version no longer
+// matches, with an ErrorInfo detail. The caller should read the task again.
+func versionConflict(taskID string, version int32) error {
+	return statusWithDetails(codes.Aborted, fmt.Sprintf("task with ID '%s' no longer has version %d", taskID, version),
+		&errdetails.ErrorInfo{
+			Reason:   "TASK_VERSION_MISMATCH",
+			Domain:   "fx-grpc-app",
+			Metadata: map[string]string{"task_id": taskID, "version": fmt.Sprint(version)},
+		})
+}
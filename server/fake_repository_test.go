@@ -0,0 +1,187 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	repo "Go_Test/repository"
+	"context"
+	"database/sql"
+	"slices"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// memoryTaskRepository keeps the tasks of one tenant in memory. It supports the
+// methods the tests call; the others panic through the nil embedded interface.
+type memoryTaskRepository struct {
+	repo.TaskRepository
+
+	mu     sync.Mutex
+	tasks  []*pb.Task
+	nextID int
+	// fetched counts the tasks returned by FetchTasks and FetchTaskPage.
+	fetched int
+	// statusErr, when set, is returned by UpdateTaskStatus after it changed the task,
+	// like a failure to record the event of the change.
+	statusErr error
+}
+
+// memoryTaskTx runs the operations of a transaction directly on the repository;
+// a failing transaction or savepoint restores the tasks it started with.
+type memoryTaskTx struct {
+	*memoryTaskRepository
+}
+
+func (tx memoryTaskTx) Savepoint(ctx context.Context, fn func() error) error {
+	return tx.rollbackOnError(fn)
+}
+
+func (r *memoryTaskRepository) InTransaction(ctx context.Context, fn func(tx repo.TaskTx) error) error {
+	return r.rollbackOnError(func() error { return fn(memoryTaskTx{r}) })
+}
+
+// rollbackOnError runs fn and restores the tasks when it fails. Like AUTO_INCREMENT,
+// nextID is not rolled back.
+func (r *memoryTaskRepository) rollbackOnError(fn func() error) error {
+	r.mu.Lock()
+	saved := make([]*pb.Task, len(r.tasks))
+	for i, task := range r.tasks {
+		saved[i] = proto.Clone(task).(*pb.Task)
+	}
+	r.mu.Unlock()
+	err := fn()
+	if err != nil {
+		r.mu.Lock()
+		r.tasks = saved
+		r.mu.Unlock()
+	}
+	return err
+}
+
+// matches implements the fields of TaskFilter that the tests use.
+func matches(task *pb.Task, filter repo.TaskFilter) bool {
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, task.GetStatus()) {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(task.GetTags(), tag) {
+			return false
+		}
+	}
+	return (filter.Assignee == "" || task.GetAssignee() == filter.Assignee) &&
+		(filter.Project == "" || task.GetProject() == filter.Project) &&
+		(filter.Priority == "" || task.GetPriority() == filter.Priority)
+}
+
+// matching returns copies of the tasks that match filter in the order of their IDs.
+func (r *memoryTaskRepository) matching(filter repo.TaskFilter) []*pb.Task {
+	var tasks []*pb.Task
+	for _, task := range r.tasks {
+		if matches(task, filter) {
+			tasks = append(tasks, proto.Clone(task).(*pb.Task))
+		}
+	}
+	return tasks
+}
+
+func (r *memoryTaskRepository) FetchTasks(ctx context.Context, filter repo.TaskFilter) ([]*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := r.matching(filter)
+	slices.Reverse(tasks)
+	r.fetched += len(tasks)
+	return tasks, nil
+}
+
+func (r *memoryTaskRepository) FetchTaskPage(ctx context.Context, filter repo.TaskFilter, afterID string, limit int) ([]*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	after, _ := strconv.Atoi(afterID)
+	var page []*pb.Task
+	for _, task := range r.matching(filter) {
+		if id, _ := strconv.Atoi(task.GetId()); id > after && len(page) < limit {
+			page = append(page, task)
+		}
+	}
+	r.fetched += len(page)
+	return page, nil
+}
+
+func (r *memoryTaskRepository) CountTasks(ctx context.Context, filter repo.TaskFilter) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.matching(filter)), nil
+}
+
+func (r *memoryTaskRepository) AddTask(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	added := proto.Clone(task).(*pb.Task)
+	added.Id = strconv.Itoa(r.nextID)
+	added.Version = 1
+	r.tasks = append(r.tasks, added)
+	return proto.Clone(added).(*pb.Task), nil
+}
+
+func (r *memoryTaskRepository) FetchTaskByID(ctx context.Context, taskID string) (*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, task := range r.tasks {
+		if task.GetId() == taskID {
+			return proto.Clone(task).(*pb.Task), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryTaskRepository) UpdateTask(ctx context.Context, task *pb.Task) (*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, existing := range r.tasks {
+		if existing.GetId() != task.GetId() {
+			continue
+		}
+		if task.GetVersion() != 0 && task.GetVersion() != existing.GetVersion() {
+			return nil, repo.ErrVersionConflict
+		}
+		updated := proto.Clone(task).(*pb.Task)
+		updated.Version = existing.GetVersion() + 1
+		r.tasks[i] = updated
+		return proto.Clone(updated).(*pb.Task), nil
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryTaskRepository) UpdateTaskStatus(ctx context.Context, taskID string, newStatus string) (*pb.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, task := range r.tasks {
+		if task.GetId() == taskID {
+			task.Status = newStatus
+			task.Version++
+			if r.statusErr != nil {
+				return nil, r.statusErr
+			}
+			return proto.Clone(task).(*pb.Task), nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (r *memoryTaskRepository) DeleteTask(ctx context.Context, taskID string, version int32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, task := range r.tasks {
+		if task.GetId() != taskID {
+			continue
+		}
+		if version != 0 && version != task.GetVersion() {
+			return repo.ErrVersionConflict
+		}
+		r.tasks = slices.Delete(r.tasks, i, i+1)
+		return nil
+	}
+	return sql.ErrNoRows
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// exportPageSize is the number of tasks per ExportTasks message.
+	exportPageSize = 500
+	// maxImportTasks limits the tasks of one ImportTasks stream, which keeps its
+	// reply well below the default message size limit of gRPC clients.
+	maxImportTasks = 100000
+)
+
+// ExportTasks handles the RPC call to stream the tasks that match the request filter.
+// Tasks are read a page at a time, in the order of their IDs.
+func (s *TaskServiceImpl) ExportTasks(req *pb.ExportTasksRequest, stream grpc.ServerStreamingServer[pb.ExportTasksReply]) error {
+	ctx, span := s.tracer.Start(stream.Context(), "TaskServiceImpl.ExportTasks")
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: ExportTasks called")
+	filter, err := taskFilter(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for afterID := ""; ; {
+		tasks, err := s.taskRepo.FetchTaskPage(ctx, filter, afterID, exportPageSize)
+		if err != nil {
+			logger.Error("Failed to fetch tasks for export", zap.Error(err))
+			return status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
+		}
+		if len(tasks) > 0 {
+			if err := stream.Send(&pb.ExportTasksReply{Tasks: tasks}); err != nil {
+				return err
+			}
+		}
+		count += len(tasks)
+		if len(tasks) < exportPageSize {
+			break
+		}
+		afterID = tasks[len(tasks)-1].GetId()
+	}
+	logger.Info("ExportTasks: Tasks exported", zap.Int("count", count))
+	return nil
+}
+
+// ImportTasks handles the RPC call to add the tasks of a stream. Every task is added
+// in a transaction of its own, so an interrupted import keeps the tasks added so far;
+// running it again skips them as duplicates. Failures of individual tasks are
+// reported in the results, not as an RPC error.
+func (s *TaskServiceImpl) ImportTasks(stream grpc.ClientStreamingServer[pb.ImportTasksRequest, pb.ImportTasksReply]) error {
+	ctx, span := s.tracer.Start(stream.Context(), "TaskServiceImpl.ImportTasks")
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: ImportTasks called")
+
+	var imp *importer
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if imp == nil {
+			span.SetAttributes(attribute.Bool("import.dry_run", req.GetDryRun()))
+			if imp, err = s.newImporter(ctx, logger, req); err != nil {
+				return err
+			}
+		}
+		for _, item := range req.GetTasks() {
+			if len(imp.reply.GetResults()) == maxImportTasks {
+				return invalidArgument("tasks", fmt.Sprintf("cannot contain more than %d tasks", maxImportTasks))
+			}
+			imp.importTask(ctx, item)
+		}
+	}
+	if imp == nil || len(imp.reply.GetResults()) == 0 {
+		return invalidArgument("tasks", "cannot be empty")
+	}
+
+	reply := imp.reply
+	span.SetAttributes(attribute.Int("import.size", len(reply.GetResults())))
+	logger.Info("ImportTasks: Tasks imported",
+		zap.Bool("dry_run", reply.GetDryRun()),
+		zap.Int32("created", reply.GetCreatedCount()),
+		zap.Int32("duplicates", reply.GetDuplicateCount()),
+		zap.Int32("failed", reply.GetFailedCount()))
+	return stream.SendAndClose(reply)
+}
+
+// importer holds the state of one ImportTasks stream.
+type importer struct {
+	s               *TaskServiceImpl
+	logger          *zap.Logger
+	allowDuplicates bool
+	reply           *pb.ImportTasksReply
+	// seen maps the duplicate keys of the existing tasks and of the tasks imported
+	// so far to where they came from.
+	seen map[string]importedFrom
+}
+
+// importedFrom identifies the first task with a duplicate key: an existing or added
+// task by its ID, a task of the stream that a dry run did not add by its line.
+type importedFrom struct {
+	taskID string
+	line   int32
+}
+
+// newImporter prepares an import with the options of its first message, req.
+func (s *TaskServiceImpl) newImporter(ctx context.Context, logger *zap.Logger, req *pb.ImportTasksRequest) (*importer, error) {
+	imp := &importer{
+		s:               s,
+		logger:          logger,
+		allowDuplicates: req.GetAllowDuplicates(),
+		reply:           &pb.ImportTasksReply{DryRun: req.GetDryRun()},
+		seen:            make(map[string]importedFrom),
+	}
+	if imp.allowDuplicates {
+		return imp, nil
+	}
+	for afterID := ""; ; {
+		tasks, err := s.taskRepo.FetchTaskPage(ctx, repo.TaskFilter{}, afterID, exportPageSize)
+		if err != nil {
+			logger.Error("Failed to fetch tasks for duplicate detection", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to fetch tasks: %v", err)
+		}
+		for _, task := range tasks {
+			key := duplicateKey(task)
+			if _, ok := imp.seen[key]; !ok {
+				imp.seen[key] = importedFrom{taskID: task.GetId()}
+			}
+		}
+		if len(tasks) < exportPageSize {
+			return imp, nil
+		}
+		afterID = tasks[len(tasks)-1].GetId()
+	}
+}
+
+// importTask validates, checks and, unless this is a dry run, adds one task, and
+// records its result.
+func (imp *importer) importTask(ctx context.Context, item *pb.ImportTask) {
+	reply := imp.reply
+	result := &pb.ImportResult{Line: item.GetLine()}
+	reply.Results = append(reply.Results, result)
+
+	task, err := importedTask(item.GetTask())
+	if err != nil {
+		imp.fail(result, err)
+		return
+	}
+	key := duplicateKey(task)
+	if first, ok := imp.seen[key]; ok && !imp.allowDuplicates {
+		result.Outcome = pb.ImportOutcome_IMPORT_OUTCOME_DUPLICATE
+		result.TaskId = first.taskID
+		if first.taskID != "" {
+			result.Message = fmt.Sprintf("duplicates task %s", first.taskID)
+		} else {
+			result.Message = fmt.Sprintf("duplicates line %d", first.line)
+		}
+		reply.DuplicateCount++
+		return
+	}
+	if !reply.GetDryRun() {
+		created, err := imp.s.taskRepo.AddTask(ctx, task)
+		if err != nil {
+			imp.logger.Error("Failed to add imported task", zap.Int32("line", item.GetLine()), zap.Error(err))
+			imp.fail(result, status.Errorf(codes.Internal, "failed to add task: %v", err))
+			return
+		}
+		result.TaskId = created.GetId()
+	}
+	if _, ok := imp.seen[key]; !ok {
+		imp.seen[key] = importedFrom{taskID: result.GetTaskId(), line: item.GetLine()}
+	}
+	result.Outcome = pb.ImportOutcome_IMPORT_OUTCOME_CREATED
+	reply.CreatedCount++
+}
+
+// fail records err, converted into its gRPC status, as the result of a task.
+func (imp *importer) fail(result *pb.ImportResult, err error) {
+	st := status.Convert(err).Proto()
+	result.Outcome = pb.ImportOutcome_IMPORT_OUTCOME_FAILED
+	result.Code = st.GetCode()
+	result.Message = st.GetMessage()
+	result.Details = st.GetDetails()
+	imp.reply.FailedCount++
+}
+
+// importedTask validates a task of an import like AddTask does and returns the task
+// to add. Its creation and completion times are kept.
+func importedTask(in *pb.Task) (*pb.Task, error) {
+	if in.GetTitle() == "" {
+		return nil, invalidArgument("title", "cannot be empty")
+	}
+	task := proto.Clone(in).(*pb.Task)
+	task.Id, task.UpdatedAt, task.Version = "", "", 0
+	if task.Status == "" {
+		task.Status = "pending"
+	}
+	if task.Status != "completed" {
+		task.CompletedAt = ""
+	}
+	if err := normalizeTaskFields(task); err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"created_at", &task.CreatedAt},
+		{"completed_at", &task.CompletedAt},
+	} {
+		if *field.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, *field.value)
+		if err != nil {
+			return nil, invalidArgument(field.name, "must be an RFC 3339 timestamp, e.g. 2025-01-31T17:00:00Z")
+		}
+		*field.value = t.UTC().Format(time.RFC3339)
+	}
+	return task, nil
+}
+
+// duplicateKey identifies the tasks an import treats as the same: those with the
+// same title, ignoring case and spacing, project and due date.
+func duplicateKey(task *pb.Task) string {
+	title := strings.ToLower(strings.Join(strings.Fields(task.GetTitle()), " "))
+	dueAt := task.GetDueAt()
+	if t, err := time.Parse(time.RFC3339, dueAt); err == nil {
+		dueAt = t.UTC().Format(time.RFC3339)
+	}
+	return title + "\x00" + task.GetProject() + "\x00" + dueAt
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/tenant"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportStream collects the messages of an ExportTasks call.
+type exportStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies []*pb.ExportTasksReply
+}
+
+func (s *exportStream) Context() context.Context { return s.ctx }
+
+func (s *exportStream) Send(reply *pb.ExportTasksReply) error {
+	s.replies = append(s.replies, reply)
+	return nil
+}
+
+// importStream feeds requests to an ImportTasks call and keeps its reply.
+type importStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*pb.ImportTasksRequest
+	reply    *pb.ImportTasksReply
+}
+
+func (s *importStream) Context() context.Context { return s.ctx }
+
+func (s *importStream) Recv() (*pb.ImportTasksRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *importStream) SendAndClose(reply *pb.ImportTasksReply) error {
+	s.reply = reply
+	return nil
+}
+
+func newImportExportTestService() (*TaskServiceImpl, *memoryTaskRepository, context.Context) {
+	tasks := &memoryTaskRepository{}
+	s := NewTaskServiceImpl(zap.NewNop(), tasks, nil, noop.NewTracerProvider()).(*TaskServiceImpl)
+	return s, tasks, tenant.WithID(context.Background(), "1")
+}
+
+func TestExportTasksPages(t *testing.T) {
+	s, tasks, ctx := newImportExportTestService()
+	n := 2*exportPageSize + 1
+	for i := range n {
+		assignee := "alice"
+		if i%2 == 1 {
+			assignee = "bob"
+		}
+		if _, err := tasks.AddTask(ctx, &pb.Task{Title: fmt.Sprintf("Task %d", i), Status: "pending", Assignee: assignee}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stream := &exportStream{ctx: ctx}
+	if err := s.ExportTasks(&pb.ExportTasksRequest{}, stream); err != nil {
+		t.Fatalf("ExportTasks: %v", err)
+	}
+	if len(stream.replies) != 3 {
+		t.Fatalf("ExportTasks sent %d messages, want 3", len(stream.replies))
+	}
+	var sizes []int
+	previous := 0
+	for _, reply := range stream.replies {
+		sizes = append(sizes, len(reply.GetTasks()))
+		for _, task := range reply.GetTasks() {
+			id, _ := strconv.Atoi(task.GetId())
+			if id != previous+1 {
+				t.Fatalf("task %d follows task %d, want the tasks in the order of their IDs", id, previous)
+			}
+			previous = id
+		}
+	}
+	if sizes[0] != exportPageSize || sizes[1] != exportPageSize || sizes[2] != 1 || previous != n {
+		t.Errorf("messages of %v tasks ending with task %d, want %d, %d and 1 ending with %d", sizes, previous, exportPageSize, exportPageSize, n)
+	}
+
+	// A filtered export of exactly one page sends no empty message after it.
+	stream = &exportStream{ctx: ctx}
+	if err := s.ExportTasks(&pb.ExportTasksRequest{Filter: &pb.TaskFilter{Assignee: "bob"}}, stream); err != nil {
+		t.Fatalf("ExportTasks: %v", err)
+	}
+	if len(stream.replies) != 1 || len(stream.replies[0].GetTasks()) != exportPageSize {
+		t.Errorf("export of bob's %d tasks sent %d messages", exportPageSize, len(stream.replies))
+	}
+	for _, task := range stream.replies[0].GetTasks() {
+		if task.GetAssignee() != "bob" {
+			t.Fatalf("export of bob's tasks contains task %s of %s", task.GetId(), task.GetAssignee())
+		}
+	}
+}
+
+// importRequests splits the tasks to import over two messages; the options are
+// those of the first.
+func importRequests(dryRun, allowDuplicates bool) []*pb.ImportTasksRequest {
+	return []*pb.ImportTasksRequest{
+		{
+			DryRun: dryRun, AllowDuplicates: allowDuplicates,
+			Tasks: []*pb.ImportTask{
+				{Line: 2, Task: &pb.Task{Title: "water  Plants", Project: "home"}},
+				{Line: 3, Task: &pb.Task{Title: "Buy milk", DueAt: "2025-01-31T18:00:00+01:00"}},
+			},
+		},
+		{
+			Tasks: []*pb.ImportTask{
+				{Line: 4, Task: &pb.Task{Title: "buy milk", DueAt: "2025-01-31T17:00:00Z"}},
+				{Line: 5, Task: &pb.Task{Title: ""}},
+				{Line: 6, Task: &pb.Task{Title: "Buy milk"}},
+			},
+		},
+	}
+}
+
+func runImport(t *testing.T, s *TaskServiceImpl, ctx context.Context, requests []*pb.ImportTasksRequest) *pb.ImportTasksReply {
+	t.Helper()
+	stream := &importStream{ctx: ctx, requests: requests}
+	if err := s.ImportTasks(stream); err != nil {
+		t.Fatalf("ImportTasks: %v", err)
+	}
+	return stream.reply
+}
+
+func TestImportTasksCounts(t *testing.T) {
+	s, tasks, ctx := newImportExportTestService()
+	if _, err := tasks.AddTask(ctx, &pb.Task{Title: "Water plants", Status: "pending", Project: "home"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Line 2 duplicates task 1, line 4 line 3 at the same due date, line 5 has no
+	// title; line 6 differs from line 3 in its due date.
+	wantOutcomes := []pb.ImportOutcome{
+		pb.ImportOutcome_IMPORT_OUTCOME_DUPLICATE,
+		pb.ImportOutcome_IMPORT_OUTCOME_CREATED,
+		pb.ImportOutcome_IMPORT_OUTCOME_DUPLICATE,
+		pb.ImportOutcome_IMPORT_OUTCOME_FAILED,
+		pb.ImportOutcome_IMPORT_OUTCOME_CREATED,
+	}
+	checkOutcomes := func(reply *pb.ImportTasksReply) {
+		t.Helper()
+		if reply.GetCreatedCount() != 2 || reply.GetDuplicateCount() != 2 || reply.GetFailedCount() != 1 {
+			t.Errorf("created %d, duplicates %d, failed %d; want 2, 2 and 1",
+				reply.GetCreatedCount(), reply.GetDuplicateCount(), reply.GetFailedCount())
+		}
+		for i, result := range reply.GetResults() {
+			if result.GetLine() != int32(i+2) || result.GetOutcome() != wantOutcomes[i] {
+				t.Errorf("result %d: line %d %v, want line %d %v", i, result.GetLine(), result.GetOutcome(), i+2, wantOutcomes[i])
+			}
+		}
+		if first := reply.GetResults()[0]; first.GetTaskId() != "1" || first.GetMessage() != "duplicates task 1" {
+			t.Errorf("duplicate of an existing task: task_id %q, message %q", first.GetTaskId(), first.GetMessage())
+		}
+		if failed := reply.GetResults()[3]; failed.GetCode() != int32(codes.InvalidArgument) || len(failed.GetDetails()) == 0 {
+			t.Errorf("task without title failed with code %d and %d details, want InvalidArgument with details", failed.GetCode(), len(failed.GetDetails()))
+		}
+	}
+
+	reply := runImport(t, s, ctx, importRequests(true, false))
+	checkOutcomes(reply)
+	if !reply.GetDryRun() || len(tasks.tasks) != 1 {
+		t.Errorf("dry run reported dry_run %v and left %d tasks, want true and 1", reply.GetDryRun(), len(tasks.tasks))
+	}
+	if dup := reply.GetResults()[2]; dup.GetTaskId() != "" || dup.GetMessage() != "duplicates line 3" {
+		t.Errorf("dry-run duplicate of line 3: task_id %q, message %q", dup.GetTaskId(), dup.GetMessage())
+	}
+
+	reply = runImport(t, s, ctx, importRequests(false, false))
+	checkOutcomes(reply)
+	if reply.GetDryRun() || len(tasks.tasks) != 3 {
+		t.Errorf("import reported dry_run %v and left %d tasks, want false and 3", reply.GetDryRun(), len(tasks.tasks))
+	}
+	created := reply.GetResults()[1].GetTaskId()
+	if dup := reply.GetResults()[2]; created == "" || dup.GetTaskId() != created || dup.GetMessage() != "duplicates task "+created {
+		t.Errorf("duplicate of line 3, added as task %q: task_id %q, message %q", created, dup.GetTaskId(), dup.GetMessage())
+	}
+
+	// Running the import again skips every task it added.
+	reply = runImport(t, s, ctx, importRequests(false, false))
+	if reply.GetCreatedCount() != 0 || reply.GetDuplicateCount() != 4 || reply.GetFailedCount() != 1 || len(tasks.tasks) != 3 {
+		t.Errorf("second import created %d, duplicates %d, failed %d and left %d tasks; want 0, 4, 1 and 3",
+			reply.GetCreatedCount(), reply.GetDuplicateCount(), reply.GetFailedCount(), len(tasks.tasks))
+	}
+
+	reply = runImport(t, s, ctx, importRequests(false, true))
+	if reply.GetCreatedCount() != 4 || reply.GetDuplicateCount() != 0 || reply.GetFailedCount() != 1 || len(tasks.tasks) != 7 {
+		t.Errorf("import with allow_duplicates created %d, duplicates %d, failed %d and left %d tasks; want 4, 0, 1 and 7",
+			reply.GetCreatedCount(), reply.GetDuplicateCount(), reply.GetFailedCount(), len(tasks.tasks))
+	}
+}
+
+func TestImportTasksRejectsEmptyStream(t *testing.T) {
+	s, _, ctx := newImportExportTestService()
+	for _, requests := range [][]*pb.ImportTasksRequest{nil, {{DryRun: true}}} {
+		stream := &importStream{ctx: ctx, requests: requests}
+		if err := s.ImportTasks(stream); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("ImportTasks of %d empty messages: %v, want InvalidArgument", len(requests), err)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InboundServiceImpl implements the proto.InboundServiceServer interface.
+// Like the TaskService, every call is scoped to the workspace of the caller's token.
+type InboundServiceImpl struct {
+	pb.UnimplementedInboundServiceServer
+	logger      *zap.Logger
+	inboundRepo repo.InboundRepository
+}
+
+// NewInboundServiceImpl creates a new InboundServiceImpl.
+func NewInboundServiceImpl(logger *zap.Logger, inboundRepo repo.InboundRepository) pb.InboundServiceServer {
+	return &InboundServiceImpl{logger: logger.Named("server"), inboundRepo: inboundRepo}
+}
+
+// AddInboundSender handles the RPC call to allow an address to create tasks by email.
+func (s *InboundServiceImpl) AddInboundSender(ctx context.Context, req *pb.AddInboundSenderRequest) (*pb.AddInboundSenderReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("InboundServiceImpl: AddInboundSender called", zap.String("email", req.GetEmail()), zap.String("assignee", req.GetAssignee()))
+	if addr, err := mail.ParseAddress(req.GetEmail()); err != nil || addr.Address != req.GetEmail() || len(req.GetEmail()) > 320 {
+		return nil, invalidArgument("email", "must be a plain email address such as alice@example.com")
+	}
+	if len(req.GetAssignee()) > 255 {
+		return nil, invalidArgument("assignee", "must not be longer than 255 characters")
+	}
+	sender, err := s.inboundRepo.AddSender(ctx, req.GetEmail(), strings.TrimSpace(req.GetAssignee()))
+	if err != nil {
+		if errors.Is(err, repo.ErrSenderTaken) {
+			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("sender %s is already allowed by another workspace", req.GetEmail()))
+		}
+		logger.Error("Failed to add inbound sender in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to add inbound sender: %v", err)
+	}
+	return &pb.AddInboundSenderReply{Sender: sender}, nil
+}
+
+// ListInboundSenders handles the RPC call to list the allowed senders of the workspace.
+func (s *InboundServiceImpl) ListInboundSenders(ctx context.Context, req *pb.ListInboundSendersRequest) (*pb.ListInboundSendersReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("InboundServiceImpl: ListInboundSenders called")
+	senders, err := s.inboundRepo.ListSenders(ctx)
+	if err != nil {
+		logger.Error("Failed to list inbound senders in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list inbound senders: %v", err)
+	}
+	return &pb.ListInboundSendersReply{Senders: senders}, nil
+}
+
+// RemoveInboundSender handles the RPC call to stop accepting emails from an address.
+func (s *InboundServiceImpl) RemoveInboundSender(ctx context.Context, req *pb.RemoveInboundSenderRequest) (*pb.RemoveInboundSenderReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("InboundServiceImpl: RemoveInboundSender called", zap.String("email", req.GetEmail()))
+	if req.GetEmail() == "" {
+		return nil, invalidArgument("email", "cannot be empty")
+	}
+	sender, err := s.inboundRepo.RemoveSender(ctx, req.GetEmail())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("inbound sender", req.GetEmail(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to remove inbound sender in service", zap.String("email", req.GetEmail()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to remove inbound sender: %v", err)
+	}
+	return &pb.RemoveInboundSenderReply{Sender: sender}, nil
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"Go_Test/logging"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDHeader is the metadata key used to accept and return request IDs.
+const requestIDHeader = "x-request-id"
+
+// requestIDUnaryInterceptor accepts an incoming x-request-id or generates one, returns it
+// in the response header and attaches a request-scoped logger to the context.
+func requestIDUnaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withRequestScope(ctx, logger, info.FullMethod), req)
+	}
+}
+
+// requestIDStreamInterceptor is the streaming counterpart of requestIDUnaryInterceptor.
+func requestIDStreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withRequestScope(ss.Context(), logger, info.FullMethod)
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withRequestScope(ctx context.Context, logger *zap.Logger, fullMethod string) context.Context {
+	requestID := incomingRequestID(ctx)
+	if requestID == "" {
+		requestID = newRequestID()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID)); err != nil {
+		logger.Debug("Failed to set request ID header", zap.Error(err))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", requestID))
+
+	ctx = logging.WithRequestID(ctx, requestID)
+	return logging.WithLogger(ctx, logger.With(
+		zap.String("request_id", requestID),
+		zap.String("method", fullMethod),
+	))
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(requestIDHeader)
+	if len(values) == 0 || len(values[0]) > 128 {
+		return ""
+	}
+	return values[0]
+}
+
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// accessLogUnaryInterceptor writes one log line per RPC with its duration, status code and peer.
+func accessLogUnaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		writeAccessLog(ctx, logger, start, err)
+		return resp, err
+	}
+}
+
+// accessLogStreamInterceptor is the streaming counterpart of accessLogUnaryInterceptor.
+func accessLogStreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		writeAccessLog(ss.Context(), logger, start, err)
+		return err
+	}
+}
+
+func writeAccessLog(ctx context.Context, logger *zap.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("code", code.String()),
+		zap.Duration("duration", time.Since(start)),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		fields = append(fields, zap.String("error", status.Convert(err).Message()))
+	}
+
+	requestLogger := logging.FromContext(ctx, logger)
+	switch code {
+	case codes.OK, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.FailedPrecondition:
+		requestLogger.Info("gRPC request handled", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		requestLogger.Error("gRPC request handled", fields...)
+	default:
+		requestLogger.Warn("gRPC request handled", fields...)
+	}
+}
+
+// recoveryUnaryInterceptor converts a panic in a handler into a codes.Internal error
+// instead of letting it terminate the process.
+func recoveryUnaryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ctx, logger, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor is the streaming counterpart of recoveryUnaryInterceptor.
+func recoveryStreamInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverPanic(ss.Context(), logger, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func recoverPanic(ctx context.Context, logger *zap.Logger, r any) error {
+	logging.FromContext(ctx, logger).Error("Recovered from panic in gRPC handler",
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()))
+	return status.Errorf(codes.Internal, "internal server error")
+}
+
+// contextServerStream overrides the context of a grpc.ServerStream.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
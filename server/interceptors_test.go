@@ -0,0 +1,187 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/metrics"
+	"context"
+	"encoding/hex"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// panickingTaskService panics in AddTask and ExportTasks and answers GetTasks.
+type panickingTaskService struct {
+	pb.UnimplementedTaskServiceServer
+}
+
+func (panickingTaskService) AddTask(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskReply, error) {
+	panic("add task failed")
+}
+
+func (panickingTaskService) GetTasks(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksReply, error) {
+	return &pb.GetTasksReply{}, nil
+}
+
+func (panickingTaskService) ExportTasks(req *pb.ExportTasksRequest, stream grpc.ServerStreamingServer[pb.ExportTasksReply]) error {
+	panic("export failed")
+}
+
+func passUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(ctx, req)
+}
+
+func passStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, ss)
+}
+
+// startInterceptorTestServer serves panickingTaskService through the interceptors
+// of NewGRPCServer, with authentication accepting every call.
+func startInterceptorTestServer(t *testing.T) (pb.TaskServiceClient, *prometheus.Registry) {
+	t.Helper()
+	registry := prometheus.NewRegistry()
+	m, err := metrics.NewGRPCMetrics(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := zap.NewNop()
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors(logger, m, passUnary)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(logger, m, passStream)...),
+	)
+	pb.RegisterTaskServiceServer(server, panickingTaskService{})
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return pb.NewTaskServiceClient(conn), registry
+}
+
+// metricValue returns the value of the counter or the sample count of the
+// histogram with the given name and labels.
+func metricValue(t *testing.T, registry *prometheus.Registry, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if hasLabels(metric, labels) {
+				if h := metric.GetHistogram(); h != nil {
+					return float64(h.GetSampleCount())
+				}
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok {
+			if value != pair.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestRecoveredPanicIsInternalAndCounted(t *testing.T) {
+	client, registry := startInterceptorTestServer(t)
+	ctx := context.Background()
+
+	var header metadata.MD
+	_, err := client.AddTask(ctx, &pb.AddTaskRequest{Title: "Boom"}, grpc.Header(&header))
+	if status.Code(err) != codes.Internal || strings.Contains(status.Convert(err).Message(), "add task failed") {
+		t.Errorf("AddTask error = %v, want Internal without the panic value", err)
+	}
+	if len(header.Get(requestIDHeader)) != 1 {
+		t.Errorf("the failed call returned request IDs %v, want one", header.Get(requestIDHeader))
+	}
+
+	stream, err := client.ExportTasks(ctx, &pb.ExportTasksRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.Internal {
+		t.Errorf("ExportTasks error = %v, want Internal", err)
+	}
+
+	for _, rpc := range []struct{ rpcType, method string }{{"unary", "AddTask"}, {"server_stream", "ExportTasks"}} {
+		labels := map[string]string{"grpc_type": rpc.rpcType, "grpc_service": "api.TaskService", "grpc_method": rpc.method}
+		if v := metricValue(t, registry, "grpc_server_started_total", labels); v != 1 {
+			t.Errorf("%s: grpc_server_started_total = %v, want 1", rpc.method, v)
+		}
+		if v := metricValue(t, registry, "grpc_server_handling_seconds", labels); v != 1 {
+			t.Errorf("%s: grpc_server_handling_seconds has %v samples, want 1", rpc.method, v)
+		}
+		labels["grpc_code"] = codes.Internal.String()
+		if v := metricValue(t, registry, "grpc_server_handled_total", labels); v != 1 {
+			t.Errorf("%s: grpc_server_handled_total{grpc_code=Internal} = %v, want 1", rpc.method, v)
+		}
+	}
+}
+
+func TestRequestIDIsEchoedOrGenerated(t *testing.T) {
+	client, _ := startInterceptorTestServer(t)
+	tests := []struct {
+		name, sent string
+		echoed     bool
+	}{
+		{"echoed", "req-42", true},
+		{"128 characters", strings.Repeat("a", 128), true},
+		{"too long", strings.Repeat("a", 129), false},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.sent != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, requestIDHeader, tt.sent)
+		}
+		var header metadata.MD
+		if _, err := client.GetTasks(ctx, &pb.GetTasksRequest{}, grpc.Header(&header)); err != nil {
+			t.Fatalf("%s: GetTasks: %v", tt.name, err)
+		}
+		ids := header.Get(requestIDHeader)
+		if len(ids) != 1 {
+			t.Errorf("%s: response has request IDs %v, want one", tt.name, ids)
+			continue
+		}
+		if tt.echoed {
+			if ids[0] != tt.sent {
+				t.Errorf("%s: request ID %q, want %q echoed", tt.name, ids[0], tt.sent)
+			}
+			continue
+		}
+		if _, err := hex.DecodeString(ids[0]); err != nil || len(ids[0]) != 32 || ids[0] == tt.sent {
+			t.Errorf("%s: request ID %q, want a generated one of 32 hex digits", tt.name, ids[0])
+		}
+	}
+}
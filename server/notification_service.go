@@ -0,0 +1,117 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	"Go_Test/notify"
+	repo "Go_Test/repository"
+	"context"
+	"database/sql"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotificationServiceImpl implements the proto.NotificationServiceServer interface.
+// Like the TaskService, every call is scoped to the workspace of the caller's token.
+type NotificationServiceImpl struct {
+	pb.UnimplementedNotificationServiceServer
+	logger           *zap.Logger
+	notificationRepo repo.NotificationRepository
+	notifier         *notify.Notifier
+}
+
+// NewNotificationServiceImpl creates a new NotificationServiceImpl.
+func NewNotificationServiceImpl(logger *zap.Logger, notificationRepo repo.NotificationRepository, notifier *notify.Notifier) pb.NotificationServiceServer {
+	return &NotificationServiceImpl{logger: logger.Named("server"), notificationRepo: notificationRepo, notifier: notifier}
+}
+
+// SetNotificationPreferences handles the RPC call to create or replace an assignee's preferences.
+func (s *NotificationServiceImpl) SetNotificationPreferences(ctx context.Context, req *pb.SetNotificationPreferencesRequest) (*pb.SetNotificationPreferencesReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	prefs := req.GetPreferences()
+	logger.Info("NotificationServiceImpl: SetNotificationPreferences called", zap.String("assignee", prefs.GetAssignee()),
+		zap.Bool("reminders", prefs.GetReminders()), zap.Bool("digest", prefs.GetDigest()))
+	if prefs == nil {
+		return nil, invalidArgument("preferences", "cannot be empty")
+	}
+	if strings.TrimSpace(prefs.GetAssignee()) == "" {
+		return nil, invalidArgument("preferences.assignee", "cannot be empty")
+	}
+	if len(prefs.GetAssignee()) > 255 {
+		return nil, invalidArgument("preferences.assignee", "must not be longer than 255 characters")
+	}
+	if addr, err := mail.ParseAddress(prefs.GetEmail()); err != nil || addr.Address != prefs.GetEmail() || len(prefs.GetEmail()) > 320 {
+		return nil, invalidArgument("preferences.email", "must be a plain email address such as alice@example.com")
+	}
+	if _, err := time.LoadLocation(prefs.GetTimeZone()); err != nil || len(prefs.GetTimeZone()) > 64 {
+		return nil, invalidArgument("preferences.time_zone", "must be empty or an IANA time zone such as Europe/Berlin")
+	}
+	saved, err := s.notificationRepo.SetPreferences(ctx, prefs)
+	if err != nil {
+		logger.Error("Failed to set notification preferences in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to set notification preferences: %v", err)
+	}
+	return &pb.SetNotificationPreferencesReply{Preferences: saved}, nil
+}
+
+// ListNotificationPreferences handles the RPC call to list the preferences of the workspace.
+func (s *NotificationServiceImpl) ListNotificationPreferences(ctx context.Context, req *pb.ListNotificationPreferencesRequest) (*pb.ListNotificationPreferencesReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("NotificationServiceImpl: ListNotificationPreferences called")
+	list, err := s.notificationRepo.ListPreferences(ctx)
+	if err != nil {
+		logger.Error("Failed to list notification preferences in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list notification preferences: %v", err)
+	}
+	return &pb.ListNotificationPreferencesReply{Preferences: list}, nil
+}
+
+// DeleteNotificationPreferences handles the RPC call to delete an assignee's preferences.
+func (s *NotificationServiceImpl) DeleteNotificationPreferences(ctx context.Context, req *pb.DeleteNotificationPreferencesRequest) (*pb.DeleteNotificationPreferencesReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("NotificationServiceImpl: DeleteNotificationPreferences called", zap.String("assignee", req.GetAssignee()))
+	if req.GetAssignee() == "" {
+		return nil, invalidArgument("assignee", "cannot be empty")
+	}
+	prefs, err := s.notificationRepo.DeletePreferences(ctx, req.GetAssignee())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("notification preferences", req.GetAssignee(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to delete notification preferences in service", zap.String("assignee", req.GetAssignee()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete notification preferences: %v", err)
+	}
+	return &pb.DeleteNotificationPreferencesReply{Preferences: prefs}, nil
+}
+
+// SendDigest handles the RPC call to send an assignee's digest immediately.
+func (s *NotificationServiceImpl) SendDigest(ctx context.Context, req *pb.SendDigestRequest) (*pb.SendDigestReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("NotificationServiceImpl: SendDigest called", zap.String("assignee", req.GetAssignee()))
+	if req.GetAssignee() == "" {
+		return nil, invalidArgument("assignee", "cannot be empty")
+	}
+	if !s.notifier.Enabled() {
+		return nil, failedPrecondition(notify.ErrDisabled.Error(), "SMTP", "smtp_host", "the server must be configured with an SMTP server")
+	}
+	prefs, err := s.notificationRepo.GetPreferences(ctx, req.GetAssignee())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("notification preferences", req.GetAssignee(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to fetch notification preferences in service", zap.String("assignee", req.GetAssignee()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to send digest: %v", err)
+	}
+	open, overdue, err := s.notifier.SendDigest(ctx, prefs)
+	if err != nil {
+		logger.Error("Failed to send digest in service", zap.String("assignee", req.GetAssignee()), zap.Error(err))
+		return nil, status.Errorf(codes.Unavailable, "failed to send digest: %v", err)
+	}
+	logger.Info("NotificationServiceImpl: Digest sent", zap.String("assignee", req.GetAssignee()), zap.Int("open_tasks", open))
+	return &pb.SendDigestReply{Email: prefs.GetEmail(), OpenTasks: int32(open), OverdueTasks: int32(overdue)}, nil
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	"Go_Test/query"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultSearchLimit and maxSearchLimit bound the number of tasks one SearchTasks call returns.
+	defaultSearchLimit = 50
+	maxSearchLimit     = 1000
+	// defaultFullTextLimit and maxFullTextLimit bound the hits of one FullTextSearch call.
+	defaultFullTextLimit = 20
+	maxFullTextLimit     = 200
+)
+
+// SearchTasks handles the RPC call to find the tasks that match a query of the search language.
+func (s *TaskServiceImpl) SearchTasks(ctx context.Context, req *pb.SearchTasksRequest) (*pb.SearchTasksReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.SearchTasks", trace.WithAttributes(attribute.String("search.query", req.GetQuery())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: SearchTasks called", zap.String("query", req.GetQuery()))
+
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0 || limit > maxSearchLimit:
+		return nil, invalidArgument("limit", fmt.Sprintf("must be between 0 and %d", maxSearchLimit))
+	case limit == 0:
+		limit = defaultSearchLimit
+	}
+	loc := time.UTC
+	if req.GetTimeZone() != "" {
+		var err error
+		if loc, err = time.LoadLocation(req.GetTimeZone()); err != nil {
+			return nil, invalidArgument("time_zone", fmt.Sprintf("%q is not a known IANA time zone", req.GetTimeZone()))
+		}
+	}
+	q, err := query.Parse(req.GetQuery(), time.Now().In(loc))
+	if err != nil {
+		return nil, queryError(err)
+	}
+
+	// One extra row tells whether the result was truncated.
+	tasks, err := s.taskRepo.SearchTasks(ctx, q, limit+1)
+	if err != nil {
+		logger.Error("Failed to search tasks in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to search tasks: %v", err)
+	}
+	reply := &pb.SearchTasksReply{Tasks: tasks}
+	if len(tasks) > limit {
+		reply.Tasks, reply.Truncated = tasks[:limit], true
+	}
+	return reply, nil
+}
+
+// FullTextSearch handles the RPC call to find tasks by the words of their title and description.
+func (s *TaskServiceImpl) FullTextSearch(ctx context.Context, req *pb.FullTextSearchRequest) (*pb.FullTextSearchReply, error) {
+	ctx, span := s.tracer.Start(ctx, "TaskServiceImpl.FullTextSearch", trace.WithAttributes(attribute.String("search.backend", s.searchIndex.Backend())))
+	defer span.End()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("TaskServiceImpl: FullTextSearch called", zap.String("text", req.GetText()))
+
+	if strings.TrimSpace(req.GetText()) == "" {
+		return nil, invalidArgument("text", "cannot be empty")
+	}
+	limit := int(req.GetLimit())
+	switch {
+	case limit < 0 || limit > maxFullTextLimit:
+		return nil, invalidArgument("limit", fmt.Sprintf("must be between 0 and %d", maxFullTextLimit))
+	case limit == 0:
+		limit = defaultFullTextLimit
+	}
+
+	hits, err := s.searchIndex.Search(ctx, req.GetText(), limit)
+	if err != nil {
+		logger.Error("Failed to search the full-text index", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to search tasks: %v", err)
+	}
+	reply := &pb.FullTextSearchReply{Hits: make([]*pb.SearchHit, 0, len(hits))}
+	for _, hit := range hits {
+		task, err := s.taskRepo.FetchTaskByID(ctx, hit.TaskID)
+		if err == sql.ErrNoRows {
+			// The index may briefly lag behind a deletion.
+			continue
+		}
+		if err != nil {
+			logger.Error("FullTextSearch: Failed to fetch task", zap.String("task_id", hit.TaskID), zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to retrieve task details: %v", err)
+		}
+		searchHit := &pb.SearchHit{Task: task, Score: hit.Score}
+		for _, h := range hit.Highlights {
+			highlight := &pb.Highlight{Field: h.Field, Fragment: h.Fragment}
+			for _, m := range h.Matches {
+				highlight.Matches = append(highlight.Matches, &pb.TextRange{Start: int32(m[0]), End: int32(m[1])})
+			}
+			searchHit.Highlights = append(searchHit.Highlights, highlight)
+		}
+		reply.Hits = append(reply.Hits, searchHit)
+	}
+	return reply, nil
+}
+
+// queryError reports an invalid search query. The position of a *query.Error is
+// passed on as the 1-based column in the metadata of an ErrorInfo detail.
+func queryError(err error) error {
+	var qErr *query.Error
+	if !errors.As(err, &qErr) {
+		return invalidArgument("query", err.Error())
+	}
+	description := "is invalid: " + qErr.Error()
+	return statusWithDetails(codes.InvalidArgument, "query "+description,
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "query", Description: description},
+		}},
+		&errdetails.ErrorInfo{
+			Reason:   "INVALID_QUERY",
+			Domain:   "fx-grpc-app",
+			Metadata: map[string]string{"column": strconv.Itoa(qErr.Pos + 1), "message": qErr.Msg},
+		})
+}
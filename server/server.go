@@ -2,41 +2,106 @@ package server
 
 import (
 	pb "Go_Test/api"
+	"Go_Test/auth"
 	cfg "Go_Test/config"
+	"Go_Test/metrics"
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-// Module exports providers for the gRPC server and its TaskService implementation for FX.
+// Module exports providers for the gRPC server and its TaskService, AdminService, WebhookService, NotificationService, InboundService and CalendarService implementations for FX.
 var Module = fx.Options(
 	fx.Provide(NewGRPCServer),
 	fx.Provide(NewTaskServiceImpl),
+	fx.Provide(NewAdminServiceImpl),
+	fx.Provide(NewWebhookServiceImpl),
+	fx.Provide(NewNotificationServiceImpl),
+	fx.Provide(NewInboundServiceImpl),
+	fx.Provide(NewCalendarServiceImpl),
 )
 
+// Browsers reach the gRPC port over HTTP/1.1, so slow or idle clients must not
+// hold connections open indefinitely. There is deliberately no read or write
+// timeout, as that would cut off streaming RPCs.
+const (
+	grpcReadHeaderTimeout = 10 * time.Second
+	grpcIdleTimeout       = 2 * time.Minute
+)
+
+// unaryInterceptors returns the interceptors of the server in the order they run.
+// Request scoping, access logging and metrics wrap panic recovery, so that
+// recovered panics are logged and counted as codes.Internal; authentication runs
+// last, so that rejected calls are logged and counted too.
+func unaryInterceptors(logger *zap.Logger, m *metrics.GRPCMetrics, authenticate grpc.UnaryServerInterceptor) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		requestIDUnaryInterceptor(logger),
+		accessLogUnaryInterceptor(logger),
+		m.UnaryServerInterceptor(),
+		recoveryUnaryInterceptor(logger),
+		authenticate,
+	}
+}
+
+// streamInterceptors is the streaming counterpart of unaryInterceptors.
+func streamInterceptors(logger *zap.Logger, m *metrics.GRPCMetrics, authenticate grpc.StreamServerInterceptor) []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		requestIDStreamInterceptor(logger),
+		accessLogStreamInterceptor(logger),
+		m.StreamServerInterceptor(),
+		recoveryStreamInterceptor(logger),
+		authenticate,
+	}
+}
+
 type GRPCServerParams struct {
 	fx.In
-	Lifecycle         fx.Lifecycle
-	Logger            *zap.Logger
-	Config            *cfg.Config
-	TaskServiceServer pb.TaskServiceServer
+	Lifecycle          fx.Lifecycle
+	Logger             *zap.Logger
+	Config             *cfg.Config
+	TaskServiceServer  pb.TaskServiceServer
+	AdminServiceServer pb.AdminServiceServer
+	WebhookServer      pb.WebhookServiceServer
+	NotificationServer pb.NotificationServiceServer
+	InboundServer      pb.InboundServiceServer
+	CalendarServer     pb.CalendarServiceServer
+	Authenticator      *auth.Authenticator
+	Metrics            *metrics.GRPCMetrics
+	TracerProvider     trace.TracerProvider
 }
 
 // NewGRPCServer creates, configures, and manages the lifecycle of the main gRPC server.
+// Its port also serves the TaskService over gRPC-Web and Connect for browsers.
 func NewGRPCServer(p GRPCServerParams) (*grpc.Server, error) {
-	p.Logger.Info("Setting up gRPC server for TaskService")
+	logger := p.Logger.Named("server")
+	logger.Info("Setting up gRPC server for TaskService")
 
-	var serverOpts []grpc.ServerOption
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithTracerProvider(p.TracerProvider))),
+		grpc.ChainUnaryInterceptor(unaryInterceptors(logger, p.Metrics, p.Authenticator.UnaryServerInterceptor())...),
+		grpc.ChainStreamInterceptor(streamInterceptors(logger, p.Metrics, p.Authenticator.StreamServerInterceptor())...),
+	}
 	server := grpc.NewServer(serverOpts...)
 
 	pb.RegisterTaskServiceServer(server, p.TaskServiceServer)
+	pb.RegisterAdminServiceServer(server, p.AdminServiceServer)
+	pb.RegisterWebhookServiceServer(server, p.WebhookServer)
+	pb.RegisterNotificationServiceServer(server, p.NotificationServer)
+	pb.RegisterInboundServiceServer(server, p.InboundServer)
+	pb.RegisterCalendarServiceServer(server, p.CalendarServer)
 	reflection.Register(server)
 
 	healthServer := health.NewServer()
@@ -44,25 +109,60 @@ func NewGRPCServer(p GRPCServerParams) (*grpc.Server, error) {
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus(pb.TaskService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
 
+	// gRPC-Web and Connect calls are forwarded to the server over a loopback connection.
+	target := p.Config.LocalGRPCTarget()
+	conn, err := grpc.NewClient(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithTracerProvider(p.TracerProvider))),
+		// Task attachments are up to 16 MiB, above the default limit of 4 MiB.
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(32<<20)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create loopback client for %s: %w", target, err)
+	}
+	// The port speaks HTTP/1.1 for gRPC-Web and Connect and HTTP/2 without TLS
+	// (prior knowledge, as gRPC clients use it) for all three protocols.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	httpServer := &http.Server{
+		Addr:              p.Config.GRPCServerAddress,
+		Handler:           newWebHandler(server, pb.NewTaskServiceClient(conn), p.Config.CORSOrigins()),
+		Protocols:         protocols,
+		ReadHeaderTimeout: grpcReadHeaderTimeout,
+		IdleTimeout:       grpcIdleTimeout,
+	}
+
 	p.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			p.Logger.Info("Starting gRPC server", zap.String("address", p.Config.GRPCServerAddress))
+			logger.Info("Starting gRPC server", zap.String("address", p.Config.GRPCServerAddress),
+				zap.Strings("cors_allowed_origins", p.Config.CORSOrigins()))
 			lis, err := net.Listen("tcp", p.Config.GRPCServerAddress)
 			if err != nil {
-				p.Logger.Error("Failed to listen for gRPC", zap.Error(err))
+				logger.Error("Failed to listen for gRPC", zap.Error(err))
 				return fmt.Errorf("failed to listen for gRPC: %w", err)
 			}
 			go func() {
-				if err := server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
-					p.Logger.Error("gRPC server failed to serve", zap.Error(err))
+				if err := httpServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("gRPC server failed to serve", zap.Error(err))
 				}
 			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			p.Logger.Info("Stopping gRPC server")
-			server.GracefulStop()
-			p.Logger.Info("gRPC server stopped")
+			logger.Info("Stopping gRPC server")
+			// Shutdown waits for in-flight calls; GracefulStop cannot drain
+			// connections served through grpc.Server.ServeHTTP.
+			err := httpServer.Shutdown(ctx)
+			server.Stop()
+			if closeErr := conn.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				logger.Error("Failed to stop gRPC server", zap.Error(err))
+				return err
+			}
+			logger.Info("gRPC server stopped")
 			return nil
 		},
 	})
@@ -0,0 +1,240 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/api/apiconnect"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	connectcors "connectrpc.com/cors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedHeaders are passed from gRPC-Web and Connect requests to the gRPC server as metadata.
+var forwardedHeaders = []string{"Authorization", requestIDHeader}
+
+// newWebHandler serves the gRPC port to all three protocols. Native gRPC requests
+// go straight to grpcServer. gRPC-Web and Connect requests for the TaskService are
+// decoded by Connect handlers and forwarded to grpcServer through client, so that
+// the interceptors (authentication, access logs, metrics) apply to every protocol.
+// Cross-origin requests are answered for the given origins only.
+func newWebHandler(grpcServer *grpc.Server, client pb.TaskServiceClient, origins []string) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(apiconnect.NewTaskServiceHandler(&connectTaskService{client: client}))
+	web := withCORS(origins, mux)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isNativeGRPC(r) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		web.ServeHTTP(w, r)
+	})
+}
+
+// isNativeGRPC reports whether r is a gRPC request over HTTP/2, as opposed to
+// gRPC-Web (application/grpc-web) or Connect.
+func isNativeGRPC(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.ProtoMajor == 2 && (contentType == "application/grpc" ||
+		strings.HasPrefix(contentType, "application/grpc+") ||
+		strings.HasPrefix(contentType, "application/grpc;"))
+}
+
+// withCORS answers preflight requests and adds CORS headers to the responses for
+// requests from one of origins. "*" allows any origin. Credentials are sent in
+// the Authorization header, not in cookies, so they are not allowed.
+func withCORS(origins []string, next http.Handler) http.Handler {
+	if len(origins) == 0 {
+		return next
+	}
+	anyOrigin := slices.Contains(origins, "*")
+	allowMethods := strings.Join(connectcors.AllowedMethods(), ", ")
+	allowHeaders := strings.Join(append(connectcors.AllowedHeaders(), forwardedHeaders...), ", ")
+	exposeHeaders := strings.Join(append(connectcors.ExposedHeaders(), requestIDHeader), ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !(anyOrigin || slices.Contains(origins, origin)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		header := w.Header()
+		header.Add("Vary", "Origin")
+		header.Set("Access-Control-Allow-Origin", origin)
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			header.Set("Access-Control-Allow-Methods", allowMethods)
+			header.Set("Access-Control-Allow-Headers", allowHeaders)
+			header.Set("Access-Control-Max-Age", "7200")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		header.Set("Access-Control-Expose-Headers", exposeHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// connectTaskService implements the Connect handlers of the TaskService by
+// calling the gRPC server of this process.
+type connectTaskService struct {
+	client pb.TaskServiceClient
+}
+
+var _ apiconnect.TaskServiceHandler = (*connectTaskService)(nil)
+
+func (s *connectTaskService) GetTasks(ctx context.Context, req *connect.Request[pb.GetTasksRequest]) (*connect.Response[pb.GetTasksReply], error) {
+	return forward(ctx, req, s.client.GetTasks)
+}
+
+func (s *connectTaskService) AddTask(ctx context.Context, req *connect.Request[pb.AddTaskRequest]) (*connect.Response[pb.AddTaskReply], error) {
+	return forward(ctx, req, s.client.AddTask)
+}
+
+func (s *connectTaskService) CompleteTask(ctx context.Context, req *connect.Request[pb.CompleteTaskRequest]) (*connect.Response[pb.CompleteTaskReply], error) {
+	return forward(ctx, req, s.client.CompleteTask)
+}
+
+func (s *connectTaskService) UpdateTask(ctx context.Context, req *connect.Request[pb.UpdateTaskRequest]) (*connect.Response[pb.UpdateTaskReply], error) {
+	return forward(ctx, req, s.client.UpdateTask)
+}
+
+func (s *connectTaskService) BatchMutate(ctx context.Context, req *connect.Request[pb.BatchMutateRequest]) (*connect.Response[pb.BatchMutateReply], error) {
+	return forward(ctx, req, s.client.BatchMutate)
+}
+
+func (s *connectTaskService) UpdateTasksByQuery(ctx context.Context, req *connect.Request[pb.UpdateTasksByQueryRequest]) (*connect.Response[pb.UpdateTasksByQueryReply], error) {
+	return forward(ctx, req, s.client.UpdateTasksByQuery)
+}
+
+func (s *connectTaskService) SearchTasks(ctx context.Context, req *connect.Request[pb.SearchTasksRequest]) (*connect.Response[pb.SearchTasksReply], error) {
+	return forward(ctx, req, s.client.SearchTasks)
+}
+
+func (s *connectTaskService) FullTextSearch(ctx context.Context, req *connect.Request[pb.FullTextSearchRequest]) (*connect.Response[pb.FullTextSearchReply], error) {
+	return forward(ctx, req, s.client.FullTextSearch)
+}
+
+func (s *connectTaskService) ListAttachments(ctx context.Context, req *connect.Request[pb.ListAttachmentsRequest]) (*connect.Response[pb.ListAttachmentsReply], error) {
+	return forward(ctx, req, s.client.ListAttachments)
+}
+
+func (s *connectTaskService) GetAttachment(ctx context.Context, req *connect.Request[pb.GetAttachmentRequest]) (*connect.Response[pb.GetAttachmentReply], error) {
+	return forward(ctx, req, s.client.GetAttachment)
+}
+
+func (s *connectTaskService) ExportTasks(ctx context.Context, req *connect.Request[pb.ExportTasksRequest], stream *connect.ServerStream[pb.ExportTasksReply]) error {
+	ctx, cancel := context.WithCancel(outgoingContext(ctx, req.Header()))
+	defer cancel()
+	var header metadata.MD
+	calls, err := s.client.ExportTasks(ctx, req.Msg, grpc.Header(&header))
+	for err == nil {
+		var reply *pb.ExportTasksReply
+		if reply, err = calls.Recv(); err != nil {
+			break
+		}
+		// The header of the gRPC call is known once its first message has arrived.
+		setRequestID(stream.ResponseHeader(), header)
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+	if err == io.EOF {
+		return nil
+	}
+	connectErr := connectError(err)
+	setRequestID(connectErr.Meta(), header)
+	return connectErr
+}
+
+func (s *connectTaskService) ImportTasks(ctx context.Context, stream *connect.ClientStream[pb.ImportTasksRequest]) (*connect.Response[pb.ImportTasksReply], error) {
+	ctx, cancel := context.WithCancel(outgoingContext(ctx, stream.RequestHeader()))
+	defer cancel()
+	var header metadata.MD
+	calls, err := s.client.ImportTasks(ctx, grpc.Header(&header))
+	if err != nil {
+		return nil, connectError(err)
+	}
+	// A failed Send means the server has ended the call; CloseAndRecv returns its status.
+	for stream.Receive() {
+		if calls.Send(stream.Msg()) != nil {
+			break
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	reply, err := calls.CloseAndRecv()
+	if err != nil {
+		connectErr := connectError(err)
+		setRequestID(connectErr.Meta(), header)
+		return nil, connectErr
+	}
+	res := connect.NewResponse(reply)
+	setRequestID(res.Header(), header)
+	return res, nil
+}
+
+// forward makes a unary gRPC call with the message, forwarded headers and trace
+// context of req, and converts the reply or status back for Connect.
+func forward[Req, Reply any](ctx context.Context, req *connect.Request[Req], call func(context.Context, *Req, ...grpc.CallOption) (*Reply, error)) (*connect.Response[Reply], error) {
+	ctx = outgoingContext(ctx, req.Header())
+	var header metadata.MD
+	reply, err := call(ctx, req.Msg, grpc.Header(&header))
+	if err != nil {
+		connectErr := connectError(err)
+		setRequestID(connectErr.Meta(), header)
+		return nil, connectErr
+	}
+	res := connect.NewResponse(reply)
+	setRequestID(res.Header(), header)
+	return res, nil
+}
+
+// outgoingContext carries the forwarded headers and the trace context of a Connect
+// request over to the gRPC call that serves it.
+func outgoingContext(ctx context.Context, h http.Header) context.Context {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+	md := metadata.MD{}
+	for _, key := range forwardedHeaders {
+		if values := h.Values(key); len(values) > 0 {
+			md.Set(key, values...)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func setRequestID(h http.Header, md metadata.MD) {
+	if values := md.Get(requestIDHeader); len(values) > 0 {
+		h.Set(requestIDHeader, values[0])
+	}
+}
+
+// connectError converts a gRPC status, including its error details, to a Connect error.
+func connectError(err error) *connect.Error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return connect.NewError(connect.CodeUnknown, err)
+	}
+	connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	for _, detail := range st.Proto().GetDetails() {
+		msg, err := detail.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		if errDetail, err := connect.NewErrorDetail(msg); err == nil {
+			connectErr.AddDetail(errDetail)
+		}
+	}
+	return connectErr
+}
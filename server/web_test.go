@@ -0,0 +1,233 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/api/apiconnect"
+	"Go_Test/tenant"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	webTestToken  = "Bearer web-token"
+	webTestOrigin = "https://app.example.com"
+)
+
+// webTestAuthInterceptor stands in for the Authenticator: it accepts webTestToken
+// only, so a call succeeds only if its Authorization header was forwarded.
+func webTestAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if !slices.Contains(md.Get("authorization"), webTestToken) {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return handler(tenant.WithID(ctx, "1"), req)
+}
+
+// startWebTestServer serves a TaskService over an in-memory repository the way
+// NewGRPCServer does: through newWebHandler on a port that speaks HTTP/1.1 and
+// HTTP/2 without TLS, with a loopback client to the same port.
+func startWebTestServer(t *testing.T) string {
+	t.Helper()
+	logger := zap.NewNop()
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(requestIDUnaryInterceptor(logger), webTestAuthInterceptor))
+	pb.RegisterTaskServiceServer(grpcServer, NewTaskServiceImpl(logger, &memoryTaskRepository{}, nil, noop.NewTracerProvider()))
+
+	httpServer := httptest.NewUnstartedServer(nil)
+	conn, err := grpc.NewClient("passthrough:///"+httpServer.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer.Config.Handler = newWebHandler(grpcServer, pb.NewTaskServiceClient(conn), []string{webTestOrigin})
+	httpServer.Config.Protocols = new(http.Protocols)
+	httpServer.Config.Protocols.SetHTTP1(true)
+	httpServer.Config.Protocols.SetUnencryptedHTTP2(true)
+	httpServer.Start()
+	t.Cleanup(func() {
+		httpServer.Close()
+		grpcServer.Stop()
+		conn.Close()
+	})
+	return httpServer.URL
+}
+
+// webTestClient makes the calls of the test over one protocol and returns the
+// request ID of the response with the reply.
+type webTestClient struct {
+	addTask  func(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskReply, string, error)
+	getTasks func(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksReply, string, error)
+}
+
+func newGRPCTestClient(t *testing.T, url string) webTestClient {
+	t.Helper()
+	conn, err := grpc.NewClient("passthrough:///"+strings.TrimPrefix(url, "http://"), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := pb.NewTaskServiceClient(conn)
+	withToken := func(ctx context.Context) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", webTestToken)
+	}
+	return webTestClient{
+		addTask: func(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskReply, string, error) {
+			var header metadata.MD
+			reply, err := client.AddTask(withToken(ctx), req, grpc.Header(&header))
+			return reply, strings.Join(header.Get(requestIDHeader), ","), err
+		},
+		getTasks: func(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksReply, string, error) {
+			var header metadata.MD
+			reply, err := client.GetTasks(withToken(ctx), req, grpc.Header(&header))
+			return reply, strings.Join(header.Get(requestIDHeader), ","), err
+		},
+	}
+}
+
+func newConnectTestClient(url string, opts ...connect.ClientOption) webTestClient {
+	client := apiconnect.NewTaskServiceClient(http.DefaultClient, url, opts...)
+	return webTestClient{
+		addTask: func(ctx context.Context, req *pb.AddTaskRequest) (*pb.AddTaskReply, string, error) {
+			return connectCall(ctx, req, client.AddTask)
+		},
+		getTasks: func(ctx context.Context, req *pb.GetTasksRequest) (*pb.GetTasksReply, string, error) {
+			return connectCall(ctx, req, client.GetTasks)
+		},
+	}
+}
+
+func connectCall[Req, Reply any](ctx context.Context, msg *Req, call func(context.Context, *connect.Request[Req]) (*connect.Response[Reply], error)) (*Reply, string, error) {
+	req := connect.NewRequest(msg)
+	req.Header().Set("Authorization", webTestToken)
+	res, err := call(ctx, req)
+	if err != nil {
+		var connectErr *connect.Error
+		if errors.As(err, &connectErr) {
+			return nil, connectErr.Meta().Get(requestIDHeader), err
+		}
+		return nil, "", err
+	}
+	return res.Msg, res.Header().Get(requestIDHeader), nil
+}
+
+func TestWebHandlerServesAllProtocols(t *testing.T) {
+	url := startWebTestServer(t)
+	clients := map[string]webTestClient{
+		"grpc":     newGRPCTestClient(t, url),
+		"grpc-web": newConnectTestClient(url, connect.WithGRPCWeb()),
+		"connect":  newConnectTestClient(url),
+	}
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			title := "Task over " + name
+			added, requestID, err := client.addTask(ctx, &pb.AddTaskRequest{Title: title, Assignee: name})
+			if err != nil {
+				t.Fatalf("AddTask: %v", err)
+			}
+			if added.GetTask().GetTitle() != title || added.GetTask().GetStatus() != "pending" {
+				t.Errorf("AddTask returned %v", added.GetTask())
+			}
+			if requestID == "" {
+				t.Error("AddTask reply has no request ID")
+			}
+
+			reply, _, err := client.getTasks(ctx, &pb.GetTasksRequest{Filter: &pb.TaskFilter{Assignee: name}})
+			if err != nil {
+				t.Fatalf("GetTasks: %v", err)
+			}
+			if len(reply.GetTasks()) != 1 || reply.GetTasks()[0].GetId() != added.GetTask().GetId() {
+				t.Errorf("GetTasks returned %v, want the added task %s", reply.GetTasks(), added.GetTask().GetId())
+			}
+
+			// Errors keep their code and request ID across the protocols.
+			_, requestID, err = client.addTask(ctx, &pb.AddTaskRequest{})
+			if code := status.Code(err); code != codes.InvalidArgument && connect.CodeOf(err) != connect.CodeInvalidArgument {
+				t.Errorf("AddTask without title: error %v, want InvalidArgument", err)
+			}
+			if requestID == "" {
+				t.Error("error has no request ID")
+			}
+		})
+	}
+}
+
+func TestWebHandlerForwardsAuthorization(t *testing.T) {
+	url := startWebTestServer(t)
+	client := apiconnect.NewTaskServiceClient(http.DefaultClient, url)
+	_, err := client.GetTasks(context.Background(), connect.NewRequest(&pb.GetTasksRequest{}))
+	if code := connect.CodeOf(err); code != connect.CodeUnauthenticated {
+		t.Errorf("GetTasks without token: code %v, want Unauthenticated", code)
+	}
+}
+
+func TestWebHandlerCORSPreflight(t *testing.T) {
+	url := startWebTestServer(t)
+	path := url + "/" + pb.TaskService_ServiceDesc.ServiceName + "/AddTask"
+	tests := []struct {
+		origin    string
+		wantAllow bool
+	}{
+		{origin: webTestOrigin, wantAllow: true},
+		{origin: "https://evil.example.com"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(http.MethodOptions, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Origin", tt.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		req.Header.Set("Access-Control-Request-Headers", "authorization, content-type, x-grpc-web")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		allowOrigin := res.Header.Get("Access-Control-Allow-Origin")
+		if !tt.wantAllow {
+			if allowOrigin != "" {
+				t.Errorf("preflight from %s allowed origin %q", tt.origin, allowOrigin)
+			}
+			continue
+		}
+		if res.StatusCode != http.StatusNoContent || allowOrigin != tt.origin {
+			t.Fatalf("preflight from %s: status %d, Access-Control-Allow-Origin %q", tt.origin, res.StatusCode, allowOrigin)
+		}
+		allowHeaders := strings.ToLower(res.Header.Get("Access-Control-Allow-Headers"))
+		for _, header := range []string{"authorization", "content-type", "x-grpc-web", requestIDHeader} {
+			if !strings.Contains(allowHeaders, header) {
+				t.Errorf("Access-Control-Allow-Headers %q lacks %s", allowHeaders, header)
+			}
+		}
+		if !strings.Contains(res.Header.Get("Access-Control-Allow-Methods"), http.MethodPost) {
+			t.Errorf("Access-Control-Allow-Methods %q lacks POST", res.Header.Get("Access-Control-Allow-Methods"))
+		}
+	}
+
+	// The responses to the requests themselves expose the request ID to scripts.
+	client := apiconnect.NewTaskServiceClient(http.DefaultClient, url)
+	req := connect.NewRequest(&pb.GetTasksRequest{})
+	req.Header().Set("Authorization", webTestToken)
+	req.Header().Set("Origin", webTestOrigin)
+	res, err := client.GetTasks(context.Background(), req)
+	if err != nil {
+		t.Fatalf("GetTasks: %v", err)
+	}
+	if expose := strings.ToLower(res.Header().Get("Access-Control-Expose-Headers")); !strings.Contains(expose, requestIDHeader) {
+		t.Errorf("Access-Control-Expose-Headers %q lacks %s", expose, requestIDHeader)
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/logging"
+	repo "Go_Test/repository"
+	"Go_Test/webhook"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WebhookServiceImpl implements the proto.WebhookServiceServer interface. Like the
+// TaskService, every call is scoped to the workspace of the caller's token.
+type WebhookServiceImpl struct {
+	pb.UnimplementedWebhookServiceServer
+	logger      *zap.Logger
+	webhookRepo repo.WebhookRepository
+}
+
+// NewWebhookServiceImpl creates a new WebhookServiceImpl.
+func NewWebhookServiceImpl(logger *zap.Logger, webhookRepo repo.WebhookRepository) pb.WebhookServiceServer {
+	return &WebhookServiceImpl{logger: logger.Named("server"), webhookRepo: webhookRepo}
+}
+
+// CreateWebhook handles the RPC call to subscribe a URL to task events.
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.CreateWebhookReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("WebhookServiceImpl: CreateWebhook called", zap.String("url", req.GetUrl()), zap.Strings("event_types", req.GetEventTypes()))
+	u, err := url.Parse(req.GetUrl())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, invalidArgument("url", "must be an absolute http or https URL")
+	}
+	if err := webhook.CheckHost(u.Hostname()); err != nil {
+		return nil, invalidArgument("url", fmt.Sprintf("must point to a public host: %v", err))
+	}
+	if len(req.GetUrl()) > 2048 {
+		return nil, invalidArgument("url", "must not be longer than 2048 characters")
+	}
+	var eventTypes []string
+	for _, eventType := range req.GetEventTypes() {
+		if !slices.Contains(repo.EventTypes, eventType) {
+			return nil, invalidArgument("event_types", fmt.Sprintf("%q is not one of %v", eventType, repo.EventTypes))
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		logger.Error("Failed to generate webhook secret", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to generate secret: %v", err)
+	}
+	created, err := s.webhookRepo.CreateWebhook(ctx, req.GetUrl(), eventTypes, secret)
+	if err != nil {
+		logger.Error("Failed to create webhook in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to create webhook: %v", err)
+	}
+	logger.Info("WebhookServiceImpl: Webhook created", zap.String("webhook_id", created.GetId()))
+	return &pb.CreateWebhookReply{Webhook: created, Secret: secret}, nil
+}
+
+// generateSecret returns a new random signing secret.
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// ListWebhooks handles the RPC call to list the webhooks of the workspace.
+func (s *WebhookServiceImpl) ListWebhooks(ctx context.Context, req *pb.ListWebhooksRequest) (*pb.ListWebhooksReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("WebhookServiceImpl: ListWebhooks called")
+	webhooks, err := s.webhookRepo.ListWebhooks(ctx)
+	if err != nil {
+		logger.Error("Failed to list webhooks in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list webhooks: %v", err)
+	}
+	return &pb.ListWebhooksReply{Webhooks: webhooks}, nil
+}
+
+// DeleteWebhook handles the RPC call to delete a webhook.
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.DeleteWebhookReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("WebhookServiceImpl: DeleteWebhook called", zap.String("webhook_id", req.GetWebhookId()))
+	if req.GetWebhookId() == "" {
+		return nil, invalidArgument("webhook_id", "cannot be empty")
+	}
+	webhook, err := s.webhookRepo.DeleteWebhook(ctx, req.GetWebhookId())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, notFound("webhook", req.GetWebhookId(), workspaceOwner(ctx))
+		}
+		logger.Error("Failed to delete webhook in service", zap.String("webhook_id", req.GetWebhookId()), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to delete webhook: %v", err)
+	}
+	return &pb.DeleteWebhookReply{Webhook: webhook}, nil
+}
+
+// ListDeadLetters handles the RPC call to list the failed deliveries of the workspace.
+func (s *WebhookServiceImpl) ListDeadLetters(ctx context.Context, req *pb.ListDeadLettersRequest) (*pb.ListDeadLettersReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("WebhookServiceImpl: ListDeadLetters called", zap.String("webhook_id", req.GetWebhookId()))
+	deadLetters, err := s.webhookRepo.ListDeadLetters(ctx, req.GetWebhookId())
+	if err != nil {
+		logger.Error("Failed to list dead letters in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to list dead letters: %v", err)
+	}
+	return &pb.ListDeadLettersReply{DeadLetters: deadLetters}, nil
+}
+
+// RedeliverWebhook handles the RPC call to queue dead letters for delivery again.
+// Naming a dead letter that does not exist fails the whole call.
+func (s *WebhookServiceImpl) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.RedeliverWebhookReply, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("WebhookServiceImpl: RedeliverWebhook called", zap.Strings("dead_letter_ids", req.GetDeadLetterIds()),
+		zap.Bool("all", req.GetAll()), zap.String("webhook_id", req.GetWebhookId()))
+	ids := req.GetDeadLetterIds()
+	if req.GetAll() == (len(ids) > 0) {
+		return nil, invalidArgument("dead_letter_ids", "must be set unless all is set, and not together with all")
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	if len(ids) > 0 {
+		// Check first, so that a typo does not requeue only part of the list.
+		deadLetters, err := s.webhookRepo.ListDeadLetters(ctx, req.GetWebhookId())
+		if err != nil {
+			logger.Error("Failed to list dead letters in service", zap.Error(err))
+			return nil, status.Errorf(codes.Internal, "failed to redeliver: %v", err)
+		}
+		for _, id := range ids {
+			if !slices.ContainsFunc(deadLetters, func(d *pb.DeadLetter) bool { return d.GetId() == id }) {
+				return nil, notFound("dead letter", id, workspaceOwner(ctx))
+			}
+		}
+	}
+	requeued, err := s.webhookRepo.Redeliver(ctx, req.GetWebhookId(), ids)
+	if err != nil {
+		logger.Error("Failed to redeliver dead letters in service", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to redeliver: %v", err)
+	}
+	logger.Info("WebhookServiceImpl: Dead letters requeued", zap.Int64("requeued", requeued))
+	return &pb.RedeliverWebhookReply{Requeued: int32(requeued)}, nil
+}
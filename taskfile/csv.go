@@ -0,0 +1,188 @@
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// csvColumns are the columns written by the CSV encoder. Tags are separated by
+// spaces; times are RFC 3339 timestamps.
+var csvColumns = []string{"id", "title", "description", "status", "priority", "due_at", "tags", "assignee", "project", "created_at", "completed_at"}
+
+// csvIgnoredColumns are read but not imported: the server assigns them, as it
+// does for tasks added with add-task. They appear in get-tasks -o csv output.
+var csvIgnoredColumns = []string{"id", "updated_at", "version"}
+
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) Encode(task *pb.Task) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	return e.w.Write([]string{
+		task.GetId(), task.GetTitle(), task.GetDescription(), task.GetStatus(), task.GetPriority(),
+		task.GetDueAt(), strings.Join(task.GetTags(), " "), task.GetAssignee(), task.GetProject(),
+		task.GetCreatedAt(), task.GetCompletedAt(),
+	})
+}
+
+func (e *csvEncoder) writeHeader() error {
+	if e.header {
+		return nil
+	}
+	e.header = true
+	return e.w.Write(csvColumns)
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvDecoder reads a CSV file with a header row. Column names are matched without
+// regard to case, and "Due At" matches due_at.
+type csvDecoder struct {
+	r        *csv.Reader
+	columns  []string
+	location *time.Location
+	err      error
+}
+
+func newCSVDecoder(r io.Reader, loc *time.Location) *csvDecoder {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return &csvDecoder{r: reader, location: loc}
+}
+
+func (d *csvDecoder) Next() (Record, error) {
+	if d.err != nil {
+		return Record{}, d.err
+	}
+	if d.columns == nil {
+		if err := d.readHeader(); err != nil {
+			// No row can be read without the header, so the file ends after its
+			// error; callers go on reading after a *LineError.
+			d.err = err
+			var lineErr *LineError
+			if errors.As(err, &lineErr) {
+				d.err = io.EOF
+			}
+			return Record{}, err
+		}
+	}
+	for {
+		fields, err := d.r.Read()
+		if err == io.EOF {
+			return Record{}, io.EOF
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Record{}, &LineError{Line: parseErr.StartLine, Err: parseErr.Err}
+		}
+		if err != nil {
+			return Record{}, err
+		}
+		line, _ := d.r.FieldPos(0)
+		if !slices.ContainsFunc(fields, func(f string) bool { return strings.TrimSpace(f) != "" }) {
+			continue
+		}
+		if len(fields) != len(d.columns) {
+			return Record{}, &LineError{Line: line, Err: fmt.Errorf("has %d fields, the header %d", len(fields), len(d.columns))}
+		}
+		task, err := d.task(fields)
+		if err != nil {
+			return Record{}, &LineError{Line: line, Err: err}
+		}
+		return Record{Line: line, Task: task}, nil
+	}
+}
+
+func (d *csvDecoder) readHeader() error {
+	header, err := d.r.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &LineError{Line: parseErr.StartLine, Err: parseErr.Err}
+	}
+	if err != nil {
+		return err
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.Join(strings.Fields(strings.TrimPrefix(name, "\ufeff")), "_"))
+		if !slices.Contains(csvColumns, name) && !slices.Contains(csvIgnoredColumns, name) {
+			return &LineError{Line: 1, Err: fmt.Errorf("unknown column %q; the columns are %s", header[i], strings.Join(csvColumns, ", "))}
+		}
+		if slices.Contains(columns, name) {
+			return &LineError{Line: 1, Err: fmt.Errorf("column %q appears twice", header[i])}
+		}
+		columns[i] = name
+	}
+	if !slices.Contains(columns, "title") {
+		return &LineError{Line: 1, Err: errors.New("the header has no title column")}
+	}
+	d.columns = columns
+	return nil
+}
+
+// task returns the task of a row. Times without a zone are local times.
+func (d *csvDecoder) task(fields []string) (*pb.Task, error) {
+	task := &pb.Task{}
+	for i, value := range fields {
+		if d.columns[i] != "description" {
+			value = strings.TrimSpace(value)
+		}
+		var err error
+		switch d.columns[i] {
+		case "title":
+			task.Title = value
+		case "description":
+			task.Description = value
+		case "status":
+			task.Status = strings.ToLower(value)
+		case "priority":
+			task.Priority = strings.ToLower(value)
+		case "due_at":
+			task.DueAt, err = d.time(value, endOfDay)
+		case "tags":
+			task.Tags = splitTags(value)
+		case "assignee":
+			task.Assignee = value
+		case "project":
+			task.Project = value
+		case "created_at":
+			task.CreatedAt, err = d.time(value, 0)
+		case "completed_at":
+			task.CompletedAt, err = d.time(value, 0)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", d.columns[i], err)
+		}
+	}
+	return task, nil
+}
+
+func (d *csvDecoder) time(value string, dateClock time.Duration) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	return parseTime(value, d.location, dateClock)
+}
@@ -0,0 +1,120 @@
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// jsonEncoder writes an array of tasks in the JSON mapping of the API, one task
+// per line, like the JSON output of the client commands.
+type jsonEncoder struct {
+	w *bufio.Writer
+	n int
+}
+
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *jsonEncoder) Encode(task *pb.Task) error {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(task)
+	if err != nil {
+		return err
+	}
+	// protojson varies its whitespace on purpose; compact output is stable.
+	var line bytes.Buffer
+	if err := json.Compact(&line, data); err != nil {
+		return err
+	}
+	if e.n == 0 {
+		e.w.WriteString("[\n")
+	} else {
+		e.w.WriteString(",\n")
+	}
+	e.n++
+	_, err = e.w.Write(line.Bytes())
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.n == 0 {
+		e.w.WriteString("[]\n")
+	} else {
+		e.w.WriteString("\n]\n")
+	}
+	return e.w.Flush()
+}
+
+// jsonDecoder reads an array of tasks, or a sequence of task objects such as JSON
+// Lines.
+type jsonDecoder struct {
+	data  []byte
+	dec   *json.Decoder
+	array bool
+	// line is the number of the line that starts at offset.
+	line, offset int
+	done         bool
+}
+
+func newJSONDecoder(r io.Reader) (*jsonDecoder, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	d := &jsonDecoder{data: data, dec: json.NewDecoder(bytes.NewReader(data)), line: 1}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		d.array = true
+		if _, err := d.dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *jsonDecoder) Next() (Record, error) {
+	if d.done || !d.dec.More() {
+		d.done = true
+		return Record{}, io.EOF
+	}
+	start := int(d.dec.InputOffset())
+	// The value starts after the whitespace and comma that follow the last token.
+	for start < len(d.data) && bytes.IndexByte([]byte(" \t\r\n,"), d.data[start]) >= 0 {
+		start++
+	}
+	line := d.lineAt(start)
+	var raw json.RawMessage
+	if err := d.dec.Decode(&raw); err != nil {
+		// The rest of the file cannot be read after a syntax error.
+		d.done = true
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return Record{}, &LineError{Line: d.lineAt(int(syntaxErr.Offset)), Err: err}
+		}
+		if err == io.ErrUnexpectedEOF {
+			return Record{}, &LineError{Line: d.lineAt(len(d.data)), Err: errors.New("unexpected end of JSON input")}
+		}
+		return Record{}, &LineError{Line: line, Err: err}
+	}
+	task := &pb.Task{}
+	if err := (protojson.UnmarshalOptions{}).Unmarshal(raw, task); err != nil {
+		return Record{}, &LineError{Line: line, Err: err}
+	}
+	return Record{Line: line, Task: task}, nil
+}
+
+// lineAt returns the number of the line at offset, which must not be before the
+// offset of an earlier call.
+func (d *jsonDecoder) lineAt(offset int) int {
+	offset = min(offset, len(d.data))
+	if offset > d.offset {
+		d.line += bytes.Count(d.data[d.offset:offset], []byte("\n"))
+		d.offset = offset
+	}
+	return d.line
+}
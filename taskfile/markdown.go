@@ -0,0 +1,202 @@
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"Go_Test/quickadd"
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// markdownBoxes maps statuses to the character in the box of a checklist item;
+// "/" and "-" follow the convention of Markdown task apps for started and
+// cancelled items. Blocked tasks are written as open items.
+var markdownBoxes = map[string]string{
+	"pending":     " ",
+	"in_progress": "/",
+	"completed":   "x",
+	"cancelled":   "-",
+}
+
+// markdownItem matches a checklist item such as "- [x] Title", at any indentation.
+var markdownItem = regexp.MustCompile(`^\s*[-*+]\s+\[([ xX/-])\]\s+(.*)$`)
+
+// markdownEncoder writes a checklist item per task, such as
+// "- [ ] Fix login bug #backend !high @alice +Website 2025-01-31 17:00": the title
+// is followed by the other fields in quick-add syntax, and the description by lines
+// indented with two spaces.
+type markdownEncoder struct {
+	w   *bufio.Writer
+	loc *time.Location
+}
+
+func newMarkdownEncoder(w io.Writer, loc *time.Location) *markdownEncoder {
+	return &markdownEncoder{w: bufio.NewWriter(w), loc: loc}
+}
+
+func (e *markdownEncoder) Encode(task *pb.Task) error {
+	box, ok := markdownBoxes[task.GetStatus()]
+	if !ok {
+		box = " "
+	}
+	fmt.Fprintf(e.w, "- [%s] %s", box, markdownTitle(task.GetTitle()))
+	for _, tag := range task.GetTags() {
+		e.w.WriteString(" #" + tag)
+	}
+	if task.GetPriority() != "" {
+		e.w.WriteString(" !" + task.GetPriority())
+	}
+	if task.GetAssignee() != "" {
+		e.w.WriteString(" @" + oneWord(task.GetAssignee()))
+	}
+	if task.GetProject() != "" {
+		e.w.WriteString(" +" + oneWord(task.GetProject()))
+	}
+	if due, ok := localTime(task.GetDueAt(), e.loc); ok {
+		e.w.WriteString(" " + due.Format("2006-01-02"))
+		if clock := time.Duration(due.Hour())*time.Hour + time.Duration(due.Minute())*time.Minute; clock != endOfDay {
+			e.w.WriteString(" " + due.Format("15:04"))
+		}
+	}
+	e.w.WriteString("\n")
+	if task.GetDescription() != "" {
+		for _, line := range strings.Split(strings.TrimRight(task.GetDescription(), "\n"), "\n") {
+			if line != "" {
+				line = "  " + line
+			}
+			e.w.WriteString(line + "\n")
+		}
+	}
+	return nil
+}
+
+func (e *markdownEncoder) Close() error {
+	return e.w.Flush()
+}
+
+// markdownTitle returns title as quick-add text that parses back to it: quoted if
+// quick-add would take any of its words for a tag, date or other field. Quick-add
+// texts cannot quote double quotes, which become single quotes.
+func markdownTitle(title string) string {
+	if !strings.Contains(title, `"`) {
+		parsed, err := quickadd.Parse(title, time.Now())
+		if err == nil && parsed.Title == title && parsed.Due.IsZero() && len(parsed.Tags) == 0 &&
+			parsed.Priority == "" && parsed.Assignee == "" && parsed.Project == "" {
+			return title
+		}
+	}
+	return `"` + strings.ReplaceAll(title, `"`, "'") + `"`
+}
+
+// oneWord joins the words of s with underscores, as quick-add and todo.txt names
+// cannot contain whitespace.
+func oneWord(s string) string {
+	return strings.Join(strings.Fields(s), "_")
+}
+
+// markdownDecoder reads the checklist items of a Markdown file and ignores all
+// other lines but those of descriptions: indented or blank lines that follow an
+// item.
+type markdownDecoder struct {
+	s    *bufio.Scanner
+	now  time.Time
+	line int
+	// next is an item read ahead while reading the description of the last one.
+	next *markdownLine
+}
+
+// markdownLine is the line of a checklist item.
+type markdownLine struct {
+	line      int
+	box, text string
+}
+
+func newMarkdownDecoder(r io.Reader, now time.Time) *markdownDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	return &markdownDecoder{s: s, now: now}
+}
+
+func (d *markdownDecoder) Next() (Record, error) {
+	item := d.next
+	d.next = nil
+	for item == nil {
+		text, ok := d.scan()
+		if !ok {
+			return Record{}, d.eof()
+		}
+		item = d.item(text)
+	}
+
+	var description []string
+	for {
+		text, ok := d.scan()
+		if !ok {
+			break
+		}
+		if next := d.item(text); next != nil {
+			d.next = next
+			break
+		}
+		if strings.TrimSpace(text) == "" {
+			description = append(description, "")
+			continue
+		}
+		indented, ok := strings.CutPrefix(text, "  ")
+		if !ok {
+			if indented, ok = strings.CutPrefix(text, "\t"); !ok {
+				break
+			}
+		}
+		description = append(description, indented)
+	}
+
+	parsed, err := quickadd.Parse(item.text, d.now)
+	if err != nil {
+		return Record{}, &LineError{Line: item.line, Err: err}
+	}
+	task := &pb.Task{
+		Title:       parsed.Title,
+		Description: strings.TrimRight(strings.Join(description, "\n"), "\n"),
+		Status:      "pending",
+		Priority:    parsed.Priority,
+		Tags:        parsed.Tags,
+		Assignee:    parsed.Assignee,
+		Project:     parsed.Project,
+	}
+	for status, box := range markdownBoxes {
+		if strings.EqualFold(item.box, box) {
+			task.Status = status
+		}
+	}
+	if !parsed.Due.IsZero() {
+		task.DueAt = parsed.Due.UTC().Format(time.RFC3339)
+	}
+	return Record{Line: item.line, Task: task}, nil
+}
+
+func (d *markdownDecoder) scan() (string, bool) {
+	if !d.s.Scan() {
+		return "", false
+	}
+	d.line++
+	return d.s.Text(), true
+}
+
+func (d *markdownDecoder) item(text string) *markdownLine {
+	m := markdownItem.FindStringSubmatch(text)
+	if m == nil {
+		return nil
+	}
+	return &markdownLine{line: d.line, box: m[1], text: m[2]}
+}
+
+func (d *markdownDecoder) eof() error {
+	if err := d.s.Err(); err != nil {
+		return err
+	}
+	return io.EOF
+}
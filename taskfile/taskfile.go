@@ -0,0 +1,164 @@
+// Package taskfile reads and writes tasks in the file formats of client export
+// and client import.
+//
+// JSON keeps every field of a task and is the format for backups. CSV keeps every
+// field but the server-managed updated_at and version, for spreadsheets. Markdown
+// checklists and todo.txt files keep what their syntax can express: Markdown
+// items are written in quick-add syntax and lose the creation and completion
+// times, todo.txt lines lose the description and the time of day of due dates.
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Format names a file format.
+type Format string
+
+const (
+	JSON     Format = "json"
+	CSV      Format = "csv"
+	Markdown Format = "markdown"
+	TodoTxt  Format = "todotxt"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{JSON, CSV, Markdown, TodoTxt}
+
+// ParseFormat parses the name of a format; "md" is accepted for Markdown.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case JSON, CSV, Markdown, TodoTxt:
+		return f, nil
+	case "md":
+		return Markdown, nil
+	}
+	return "", fmt.Errorf("unknown format %q; use json, csv, markdown or todotxt", s)
+}
+
+// FormatOf returns the format of a file by its extension: .json, .csv, .md,
+// .markdown or .txt.
+func FormatOf(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl":
+		return JSON, true
+	case ".csv":
+		return CSV, true
+	case ".md", ".markdown":
+		return Markdown, true
+	case ".txt":
+		return TodoTxt, true
+	}
+	return "", false
+}
+
+// Encoder writes tasks in one of the formats.
+type Encoder interface {
+	// Encode writes a task.
+	Encode(task *pb.Task) error
+	// Close writes the end of the file, if the format has one, and flushes. It does
+	// not close the underlying writer.
+	Close() error
+}
+
+// NewEncoder returns an encoder that writes tasks to w. Formats that write local
+// dates and times use loc.
+func NewEncoder(w io.Writer, format Format, loc *time.Location) (Encoder, error) {
+	switch format {
+	case JSON:
+		return newJSONEncoder(w), nil
+	case CSV:
+		return newCSVEncoder(w), nil
+	case Markdown:
+		return newMarkdownEncoder(w, loc), nil
+	case TodoTxt:
+		return newTodoTxtEncoder(w, loc), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// Record is a task read from a file, together with the line it starts on.
+type Record struct {
+	Line int
+	Task *pb.Task
+}
+
+// Decoder reads tasks in one of the formats.
+type Decoder interface {
+	// Next returns the next task. It returns a *LineError for a line that holds no
+	// valid task, after which reading goes on, and io.EOF after the last task. Any
+	// other error ends the file.
+	Next() (Record, error)
+}
+
+// NewDecoder returns a decoder that reads tasks from r. Local dates and times are
+// read in the location of now, and relative dates of Markdown items such as
+// "tomorrow" are resolved against now.
+func NewDecoder(r io.Reader, format Format, now time.Time) (Decoder, error) {
+	switch format {
+	case JSON:
+		return newJSONDecoder(r)
+	case CSV:
+		return newCSVDecoder(r, now.Location()), nil
+	case Markdown:
+		return newMarkdownDecoder(r, now), nil
+	case TodoTxt:
+		return newTodoTxtDecoder(r, now.Location()), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// LineError reports a line of a file that holds no valid task.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string { return fmt.Sprintf("line %d: %v", e.Line, e.Err) }
+func (e *LineError) Unwrap() error { return e.Err }
+
+// endOfDay is the time of day of a due date given without a time, as in quick-add
+// texts: the task is due at the end of that day.
+const endOfDay = 23*time.Hour + 59*time.Minute
+
+// parseTime parses an RFC 3339 timestamp, or a local date with an optional time
+// such as "2025-01-31 17:00"; a date alone means dateClock on that day. It returns
+// the time as an RFC 3339 timestamp in UTC, as the TaskService stores it.
+func parseTime(value string, loc *time.Location, dateClock time.Duration) (string, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC().Format(time.RFC3339), nil
+		}
+	}
+	t, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return "", fmt.Errorf("invalid time %q: use RFC 3339, e.g. 2025-01-31T17:00:00Z, or a date such as 2025-01-31 with an optional time", value)
+	}
+	t = time.Date(t.Year(), t.Month(), t.Day(), int(dateClock/time.Hour), int(dateClock%time.Hour/time.Minute), 0, 0, loc)
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// localTime returns an RFC 3339 timestamp in loc, or false if it is empty or
+// invalid.
+func localTime(value string, loc *time.Location) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.In(loc), true
+}
+
+// splitTags splits a list of tags separated by commas or whitespace.
+func splitTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+}
@@ -0,0 +1,219 @@
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// testLocation is the local time zone of the tests, an hour ahead of UTC.
+var testLocation = time.FixedZone("CET", 3600)
+
+var testNow = time.Date(2026, 10, 19, 12, 0, 0, 0, testLocation)
+
+// roundTripTasks use only what every format keeps, but for the fields that a
+// format is documented to lose: due dates at the end of a local day and
+// creation and completion times at midnight survive todo.txt.
+func roundTripTasks() []*pb.Task {
+	return []*pb.Task{
+		{
+			Id: "1", Title: "Fix login bug", Description: "Steps:\n  1. open the app\n\n2. sign in",
+			Status: "pending", Priority: "high", Tags: []string{"backend", "urgent-fix"},
+			Assignee: "alice", Project: "Website", DueAt: "2025-01-31T22:59:00Z",
+			CreatedAt: "2025-01-01T23:00:00Z", UpdatedAt: "2025-01-03T10:00:00Z", Version: 4,
+		},
+		{
+			Id: "2", Title: "Write release notes", Status: "completed", Priority: "medium", Project: "Website",
+			CreatedAt: "2025-01-01T23:00:00Z", CompletedAt: "2025-01-19T23:00:00Z", Version: 2,
+		},
+		{
+			Id: "3", Title: "Review #42 tomorrow", Status: "in_progress", Priority: "urgent",
+			Tags: []string{"review"}, CreatedAt: "2025-01-04T23:00:00Z", Version: 1,
+		},
+	}
+}
+
+func encodeTasks(t *testing.T, format Format, tasks []*pb.Task) string {
+	t.Helper()
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, format, testLocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, task := range tasks {
+		if err := enc.Encode(task); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.String()
+}
+
+// decodeTasks returns the records of a file and its line errors.
+func decodeTasks(t *testing.T, format Format, data string) ([]Record, []*LineError) {
+	t.Helper()
+	dec, err := NewDecoder(strings.NewReader(data), format, testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	var lineErrs []*LineError
+	for {
+		record, err := dec.Next()
+		var lineErr *LineError
+		switch {
+		case err == io.EOF:
+			return records, lineErrs
+		case errors.As(err, &lineErr):
+			lineErrs = append(lineErrs, lineErr)
+		case err != nil:
+			t.Fatalf("Next: %v", err)
+		default:
+			records = append(records, record)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		format Format
+		// keep clears the fields of a task that the format does not keep.
+		keep func(task *pb.Task)
+	}{
+		{JSON, func(task *pb.Task) {}},
+		{CSV, func(task *pb.Task) {
+			task.Id, task.UpdatedAt, task.Version = "", "", 0
+		}},
+		{Markdown, func(task *pb.Task) {
+			task.Id, task.UpdatedAt, task.Version = "", "", 0
+			task.CreatedAt, task.CompletedAt = "", ""
+		}},
+		{TodoTxt, func(task *pb.Task) {
+			task.Id, task.UpdatedAt, task.Version = "", "", 0
+			task.Description = ""
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			data := encodeTasks(t, tt.format, roundTripTasks())
+			records, lineErrs := decodeTasks(t, tt.format, data)
+			if len(lineErrs) > 0 {
+				t.Fatalf("line errors: %v\n%s", lineErrs, data)
+			}
+			want := roundTripTasks()
+			if len(records) != len(want) {
+				t.Fatalf("decoded %d tasks, want %d:\n%s", len(records), len(want), data)
+			}
+			for i, record := range records {
+				tt.keep(want[i])
+				if !proto.Equal(record.Task, want[i]) {
+					t.Errorf("task %d decoded as\n%v\nwant\n%v\nfrom:\n%s", i+1, record.Task, want[i], data)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeEmptyFile(t *testing.T) {
+	for _, format := range Formats {
+		records, lineErrs := decodeTasks(t, format, encodeTasks(t, format, nil))
+		if len(records) != 0 || len(lineErrs) != 0 {
+			t.Errorf("%s: an empty export decodes to %d tasks and %d errors", format, len(records), len(lineErrs))
+		}
+	}
+}
+
+func TestLineErrors(t *testing.T) {
+	tests := []struct {
+		format Format
+		data   string
+		// lines are the lines of the tasks read, errLines those of the line errors.
+		lines, errLines []int
+	}{
+		{
+			format: CSV,
+			data: "Title,Due At,Description\n" +
+				"Plan sprint,2025-01-31,\n" +
+				"Multi-line,,\"first\nsecond\"\n" +
+				"Bad due,next week,\n" +
+				"\n" +
+				"Too,many,fields,here\n" +
+				"Last,2025-02-01 09:00,done\n",
+			lines:    []int{2, 3, 8},
+			errLines: []int{5, 7},
+		},
+		{
+			format: Markdown,
+			data: "# Sprint\n\n" +
+				"- [ ] Plan sprint #planning\n" +
+				"  with the whole team\n" +
+				"- [x] Deploy !critical\n" +
+				"Some notes\n" +
+				"* [ ] Pay invoice tomorrow 10:00 11:00\n" +
+				"- [/] Review PR @bob\n",
+			lines:    []int{3, 8},
+			errLines: []int{5, 7},
+		},
+		{
+			format: TodoTxt,
+			data: "(A) Call the bank due:2025-01-31\n" +
+				"\n" +
+				"Call mom due:someday\n" +
+				"x 2025-01-20 2025-01-02 Send invoice\n" +
+				"Book flights due:2025-02-30\n",
+			lines:    []int{1, 4},
+			errLines: []int{3, 5},
+		},
+	}
+	for _, tt := range tests {
+		records, lineErrs := decodeTasks(t, tt.format, tt.data)
+		var lines, errLines []int
+		for _, record := range records {
+			lines = append(lines, record.Line)
+		}
+		for _, err := range lineErrs {
+			errLines = append(errLines, err.Line)
+			if !strings.HasPrefix(err.Error(), "line ") {
+				t.Errorf("%s: error %q does not name its line", tt.format, err)
+			}
+		}
+		if !slices.Equal(lines, tt.lines) || !slices.Equal(errLines, tt.errLines) {
+			t.Errorf("%s: tasks on lines %v and errors on lines %v (%v); want %v and %v",
+				tt.format, lines, errLines, lineErrs, tt.lines, tt.errLines)
+		}
+	}
+}
+
+func TestCSVHeaderErrors(t *testing.T) {
+	for _, header := range []string{"title,colour", "title,Title", "description,status"} {
+		_, lineErrs := decodeTasks(t, CSV, header+"\nPlan sprint,x\n")
+		if len(lineErrs) != 1 || lineErrs[0].Line != 1 {
+			t.Errorf("header %q: errors %v, want one on line 1", header, lineErrs)
+		}
+	}
+}
+
+func TestJSONSyntaxErrorLine(t *testing.T) {
+	data := "[\n" +
+		`{"title": "First"},` + "\n" +
+		`{"title": "Second", "priority": 5},` + "\n" +
+		`{"title": "Third",` + "\n" +
+		`  "status" "pending"}` + "\n" +
+		"]\n"
+	records, lineErrs := decodeTasks(t, JSON, data)
+	if len(records) != 1 || records[0].Line != 2 {
+		t.Fatalf("decoded %v, want the task on line 2", records)
+	}
+	if len(lineErrs) != 2 || lineErrs[0].Line != 3 || lineErrs[1].Line != 5 {
+		t.Errorf("line errors %v, want a type error on line 3 and a syntax error on line 5", lineErrs)
+	}
+}
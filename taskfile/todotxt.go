@@ -0,0 +1,194 @@
+package taskfile
+
+import (
+	pb "Go_Test/api"
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// todoTxtPriorities maps priorities to todo.txt priority letters. Letters after D
+// are read as low.
+var todoTxtPriorities = map[string]string{
+	"urgent": "A",
+	"high":   "B",
+	"medium": "C",
+	"low":    "D",
+}
+
+var todoTxtPriority = regexp.MustCompile(`^\([A-Z]\)$`)
+
+// todoTxtEncoder writes a line per task in the todo.txt format
+// (https://github.com/todotxt/todo.txt): tags become contexts, and the due date,
+// assignee and statuses that todo.txt has no syntax for become key:value tags:
+//
+//	(B) 2025-01-02 Fix login bug +Website @backend due:2025-01-31 assignee:alice
+//	x 2025-01-20 2025-01-02 Write release notes +Website pri:C
+//
+// Descriptions and the time of due dates are not written.
+type todoTxtEncoder struct {
+	w   *bufio.Writer
+	loc *time.Location
+}
+
+func newTodoTxtEncoder(w io.Writer, loc *time.Location) *todoTxtEncoder {
+	return &todoTxtEncoder{w: bufio.NewWriter(w), loc: loc}
+}
+
+func (e *todoTxtEncoder) Encode(task *pb.Task) error {
+	var words []string
+	created, hasCreated := localTime(task.GetCreatedAt(), e.loc)
+	priority := todoTxtPriorities[task.GetPriority()]
+	if task.GetStatus() == "completed" {
+		words = append(words, "x")
+		// A creation date is only recognised after a completion date.
+		if completed, ok := localTime(task.GetCompletedAt(), e.loc); ok {
+			words = append(words, completed.Format("2006-01-02"))
+			if hasCreated {
+				words = append(words, created.Format("2006-01-02"))
+			}
+		}
+	} else {
+		if priority != "" {
+			words = append(words, "("+priority+")")
+		}
+		if hasCreated {
+			words = append(words, created.Format("2006-01-02"))
+		}
+	}
+	words = append(words, strings.Fields(task.GetTitle())...)
+	if task.GetProject() != "" {
+		words = append(words, "+"+oneWord(task.GetProject()))
+	}
+	for _, tag := range task.GetTags() {
+		words = append(words, "@"+tag)
+	}
+	if due, ok := localTime(task.GetDueAt(), e.loc); ok {
+		words = append(words, "due:"+due.Format("2006-01-02"))
+	}
+	if task.GetAssignee() != "" {
+		words = append(words, "assignee:"+oneWord(task.GetAssignee()))
+	}
+	if status := task.GetStatus(); status != "" && status != "pending" && status != "completed" {
+		words = append(words, "status:"+status)
+	}
+	if task.GetStatus() == "completed" && priority != "" {
+		words = append(words, "pri:"+priority)
+	}
+	_, err := e.w.WriteString(strings.Join(words, " ") + "\n")
+	return err
+}
+
+func (e *todoTxtEncoder) Close() error {
+	return e.w.Flush()
+}
+
+// todoTxtDecoder reads a task per non-blank line of a todo.txt file. Of several
+// projects the first is kept, the others stay in the title, as do key:value tags
+// other than due, assignee, status and pri.
+type todoTxtDecoder struct {
+	s    *bufio.Scanner
+	loc  *time.Location
+	line int
+}
+
+func newTodoTxtDecoder(r io.Reader, loc *time.Location) *todoTxtDecoder {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<20)
+	return &todoTxtDecoder{s: s, loc: loc}
+}
+
+func (d *todoTxtDecoder) Next() (Record, error) {
+	for d.s.Scan() {
+		d.line++
+		words := strings.Fields(d.s.Text())
+		if len(words) == 0 {
+			continue
+		}
+		task, err := d.task(words)
+		if err != nil {
+			return Record{}, &LineError{Line: d.line, Err: err}
+		}
+		return Record{Line: d.line, Task: task}, nil
+	}
+	if err := d.s.Err(); err != nil {
+		return Record{}, err
+	}
+	return Record{}, io.EOF
+}
+
+func (d *todoTxtDecoder) task(words []string) (*pb.Task, error) {
+	task := &pb.Task{Status: "pending"}
+	// date parses the word at the start of words as a date, if it is one.
+	date := func(field *string) error {
+		if len(words) == 0 || !isDate(words[0]) {
+			return nil
+		}
+		value, err := parseTime(words[0], d.loc, 0)
+		*field, words = value, words[1:]
+		return err
+	}
+	if words[0] == "x" {
+		task.Status = "completed"
+		words = words[1:]
+		if err := date(&task.CompletedAt); err != nil {
+			return nil, err
+		}
+		if task.CompletedAt != "" {
+			if err := date(&task.CreatedAt); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if todoTxtPriority.MatchString(words[0]) {
+			task.Priority = priorityOf(words[0][1:2])
+			words = words[1:]
+		}
+		if err := date(&task.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	var title []string
+	for _, word := range words {
+		switch key, value, _ := strings.Cut(word, ":"); {
+		case len(word) > 1 && word[0] == '+' && task.Project == "":
+			task.Project = word[1:]
+		case len(word) > 1 && word[0] == '@':
+			task.Tags = append(task.Tags, word[1:])
+		case key == "due" && value != "":
+			dueAt, err := parseTime(value, d.loc, endOfDay)
+			if err != nil {
+				return nil, err
+			}
+			task.DueAt = dueAt
+		case key == "assignee" && value != "":
+			task.Assignee = value
+		case key == "status" && value != "":
+			task.Status = strings.ToLower(value)
+		case key == "pri" && len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z':
+			task.Priority = priorityOf(value)
+		default:
+			title = append(title, word)
+		}
+	}
+	task.Title = strings.Join(title, " ")
+	return task, nil
+}
+
+// priorityOf returns the priority of a todo.txt priority letter.
+func priorityOf(letter string) string {
+	for priority, l := range todoTxtPriorities {
+		if l == letter {
+			return priority
+		}
+	}
+	return "low"
+}
+
+func isDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMissing is returned when a tenant-scoped operation is attempted
+// with a context that does not carry a tenant ID.
+var ErrMissing = errors.New("no tenant in context")
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx that carries the given tenant (workspace) ID.
+func WithID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, tenantID)
+}
+
+// IDFromContext returns the tenant ID stored in ctx, if any.
+func IDFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(contextKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// RequireID returns the tenant ID stored in ctx or ErrMissing.
+// Repositories call it before every query so that no query can run unscoped.
+func RequireID(ctx context.Context) (string, error) {
+	tenantID, ok := IDFromContext(ctx)
+	if !ok {
+		return "", ErrMissing
+	}
+	return tenantID, nil
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	cfg "Go_Test/config"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module exports the OpenTelemetry TracerProvider for FX.
+var Module = fx.Options(
+	fx.Provide(NewTracerProvider),
+)
+
+type TracerProviderParams struct {
+	fx.In
+	Lifecycle fx.Lifecycle
+	Logger    *zap.Logger
+	Config    *cfg.Config
+}
+
+// NewTracerProvider creates the TracerProvider selected by TracingExporter, installs it
+// and the W3C trace-context propagator globally, and flushes pending spans on stop.
+func NewTracerProvider(p TracerProviderParams) (trace.TracerProvider, error) {
+	logger := p.Logger.Named("tracing")
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if p.Config.TracingExporter == "" || p.Config.TracingExporter == "none" {
+		return noop.NewTracerProvider(), nil
+	}
+
+	exporter, closer, err := newExporter(p.Config)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", p.Config.TracingServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	logger.Info("Tracing enabled", zap.String("exporter", p.Config.TracingExporter))
+
+	p.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			if err := provider.Shutdown(ctx); err != nil {
+				logger.Error("Failed to shut down tracer provider", zap.Error(err))
+				return err
+			}
+			if closer != nil {
+				return closer.Close()
+			}
+			return nil
+		},
+	})
+	return provider, nil
+}
+
+// newExporter creates the span exporter for the configured backend. The returned
+// closer, if any, must be closed after the provider has been shut down.
+func newExporter(c *cfg.Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch c.TracingExporter {
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(c.OTLPEndpoint)}
+		if c.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case "file":
+		file, err := os.OpenFile(c.TracingFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open tracing file %s: %w", c.TracingFile, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("failed to create file exporter: %w", err)
+		}
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q (want none, otlp, stdout or file)", c.TracingExporter)
+	}
+}
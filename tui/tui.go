@@ -0,0 +1,419 @@
+// Package tui implements the full-screen terminal interface of `client tui`: a task
+// list and detail pane with shortcuts to add, edit, complete and filter tasks.
+// The list is kept current by polling GetTasks, as TaskService has no streaming RPC.
+package tui
+
+import (
+	pb "Go_Test/api"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Options configures the terminal interface.
+type Options struct {
+	// RefreshInterval is how often the task list is reloaded from the server.
+	RefreshInterval time.Duration
+	// RequestTimeout bounds every RPC issued by the interface.
+	RequestTimeout time.Duration
+}
+
+type mode int
+
+const (
+	modeList mode = iota
+	modeFilter
+	modeAdd
+	modeEdit
+)
+
+// Form fields of the add and edit forms, in tab order.
+const (
+	fieldTitle = iota
+	fieldDescription
+	fieldStatus
+	fieldCount
+)
+
+type tasksLoadedMsg struct {
+	tasks []*pb.Task
+	err   error
+}
+
+type taskSavedMsg struct {
+	task   *pb.Task
+	action string
+	err    error
+}
+
+type tickMsg time.Time
+
+// Model is the bubbletea model of the interface.
+type Model struct {
+	ctx    context.Context
+	client pb.TaskServiceClient
+	opts   Options
+
+	tasks    []*pb.Task
+	visible  []*pb.Task
+	cursor   int
+	offset   int
+	filter   textinput.Model
+	mode     mode
+	inputs   [fieldCount]textinput.Model
+	focus    int
+	editing  *pb.Task
+	message  string
+	err      error
+	loading  bool
+	loadedAt time.Time
+
+	width, height int
+}
+
+// New creates the interface model. RPCs are issued with contexts derived from ctx,
+// so they share its trace and cancellation.
+func New(ctx context.Context, client pb.TaskServiceClient, opts Options) Model {
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = 5 * time.Second
+	}
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = 10 * time.Second
+	}
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by title, description, status or ID"
+
+	m := Model{ctx: ctx, client: client, opts: opts, filter: filter, loading: true}
+	placeholders := [fieldCount]string{"Title (required)", "Description", "Status, e.g. pending or in_progress"}
+	prompts := [fieldCount]string{"Title:       ", "Description: ", "Status:      "}
+	for i := range m.inputs {
+		m.inputs[i] = textinput.New()
+		m.inputs[i].Prompt = prompts[i]
+		m.inputs[i].Placeholder = placeholders[i]
+		m.inputs[i].CharLimit = 1024
+	}
+	return m
+}
+
+// Run starts the interface on the alternate screen and blocks until the user quits.
+func Run(ctx context.Context, client pb.TaskServiceClient, opts Options) error {
+	_, err := tea.NewProgram(New(ctx, client, opts), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+// Init loads the task list and starts polling.
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(m.fetchTasks(), m.tick())
+}
+
+// Update handles key presses, window resizes and RPC results.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.clampCursor()
+		return m, nil
+
+	case tickMsg:
+		return m, tea.Batch(m.fetchTasks(), m.tick())
+
+	case tasksLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.loadedAt = time.Now()
+		selectedID := m.selectedID()
+		m.tasks = msg.tasks
+		m.applyFilter(selectedID)
+		return m, nil
+
+	case taskSavedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.message = "Task " + msg.task.GetId() + " " + msg.action + "."
+		m.replaceTask(msg.task)
+		m.applyFilter(msg.task.GetId())
+		return m, m.fetchTasks()
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeFilter:
+			return m.updateFilter(msg)
+		case modeAdd, modeEdit:
+			return m.updateForm(msg)
+		default:
+			return m.updateList(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message = ""
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup":
+		m.cursor -= m.listHeight()
+	case "pgdown":
+		m.cursor += m.listHeight()
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.visible) - 1
+	case "r":
+		m.loading = true
+		return m, m.fetchTasks()
+	case "/":
+		m.mode = modeFilter
+		return m, m.filter.Focus()
+	case "esc":
+		m.filter.SetValue("")
+		m.applyFilter(m.selectedID())
+	case "a":
+		return m.openForm(modeAdd, nil)
+	case "e", "enter":
+		if task := m.selected(); task != nil {
+			return m.openForm(modeEdit, task)
+		}
+	case "c", " ":
+		if task := m.selected(); task != nil {
+			return m, m.completeTask(task.GetId())
+		}
+	}
+	m.clampCursor()
+	return m, nil
+}
+
+func (m Model) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.mode = modeList
+		m.filter.Blur()
+		return m, nil
+	case "esc":
+		m.mode = modeList
+		m.filter.Blur()
+		m.filter.SetValue("")
+		m.applyFilter(m.selectedID())
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.applyFilter(m.selectedID())
+	return m, cmd
+}
+
+func (m Model) openForm(formMode mode, task *pb.Task) (tea.Model, tea.Cmd) {
+	m.mode = formMode
+	m.editing = task
+	m.focus = fieldTitle
+	m.err = nil
+	m.inputs[fieldTitle].SetValue(task.GetTitle())
+	m.inputs[fieldDescription].SetValue(task.GetDescription())
+	m.inputs[fieldStatus].SetValue(task.GetStatus())
+	return m, m.focusInput()
+}
+
+func (m Model) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.closeForm()
+		return m, nil
+	case "tab", "down":
+		m.focus = (m.focus + 1) % fieldCount
+		return m, m.focusInput()
+	case "shift+tab", "up":
+		m.focus = (m.focus + fieldCount - 1) % fieldCount
+		return m, m.focusInput()
+	case "enter":
+		if m.focus < fieldCount-1 {
+			m.focus++
+			return m, m.focusInput()
+		}
+		return m.submitForm()
+	case "ctrl+s":
+		return m.submitForm()
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) submitForm() (tea.Model, tea.Cmd) {
+	title := strings.TrimSpace(m.inputs[fieldTitle].Value())
+	description := strings.TrimSpace(m.inputs[fieldDescription].Value())
+	taskStatus := strings.TrimSpace(m.inputs[fieldStatus].Value())
+	if title == "" {
+		m.message = "The title is required."
+		m.focus = fieldTitle
+		return m, m.focusInput()
+	}
+	var cmd tea.Cmd
+	if m.mode == modeAdd {
+		cmd = m.addTask(title, description, taskStatus)
+	} else {
+		if taskStatus == "" {
+			taskStatus = m.editing.GetStatus()
+		}
+		cmd = m.updateTask(m.editing.GetId(), title, description, taskStatus)
+	}
+	m.closeForm()
+	return m, cmd
+}
+
+func (m *Model) closeForm() {
+	m.mode = modeList
+	m.editing = nil
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+}
+
+func (m *Model) focusInput() tea.Cmd {
+	for i := range m.inputs {
+		m.inputs[i].Blur()
+	}
+	return m.inputs[m.focus].Focus()
+}
+
+// applyFilter recomputes the visible tasks and keeps the task with selectedID selected if it is still shown.
+func (m *Model) applyFilter(selectedID string) {
+	query := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	visible := make([]*pb.Task, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		if query == "" || matches(task, query) {
+			visible = append(visible, task)
+		}
+	}
+	m.visible = visible
+	for i, task := range m.visible {
+		if task.GetId() == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.clampCursor()
+}
+
+func matches(task *pb.Task, query string) bool {
+	for _, field := range []string{task.GetId(), task.GetTitle(), task.GetDescription(), task.GetStatus()} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceTask updates task in the local list, or prepends it when it is new,
+// so that the change is visible before the next refresh.
+func (m *Model) replaceTask(task *pb.Task) {
+	for i := range m.tasks {
+		if m.tasks[i].GetId() == task.GetId() {
+			m.tasks[i] = task
+			return
+		}
+	}
+	m.tasks = append([]*pb.Task{task}, m.tasks...)
+}
+
+func (m *Model) clampCursor() {
+	if m.cursor >= len(m.visible) {
+		m.cursor = len(m.visible) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+func (m Model) selected() *pb.Task {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return nil
+	}
+	return m.visible[m.cursor]
+}
+
+func (m Model) selectedID() string {
+	return m.selected().GetId()
+}
+
+func (m Model) tick() tea.Cmd {
+	return tea.Tick(m.opts.RefreshInterval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m Model) fetchTasks() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, m.opts.RequestTimeout)
+		defer cancel()
+		reply, err := m.client.GetTasks(ctx, &pb.GetTasksRequest{})
+		return tasksLoadedMsg{tasks: reply.GetTasks(), err: err}
+	}
+}
+
+func (m Model) addTask(title, description, taskStatus string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, m.opts.RequestTimeout)
+		defer cancel()
+		reply, err := m.client.AddTask(ctx, &pb.AddTaskRequest{Title: title, Description: description, Status: taskStatus})
+		return taskSavedMsg{task: reply.GetTask(), action: "added", err: err}
+	}
+}
+
+func (m Model) updateTask(taskID, title, description, taskStatus string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, m.opts.RequestTimeout)
+		defer cancel()
+		reply, err := m.client.UpdateTask(ctx, &pb.UpdateTaskRequest{
+			TaskId:      taskID,
+			Title:       title,
+			Description: description,
+			Status:      taskStatus,
+			UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"title", "description", "status"}},
+		})
+		return taskSavedMsg{task: reply.GetTask(), action: "updated", err: err}
+	}
+}
+
+func (m Model) completeTask(taskID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, m.opts.RequestTimeout)
+		defer cancel()
+		reply, err := m.client.CompleteTask(ctx, &pb.CompleteTaskRequest{TaskId: taskID})
+		return taskSavedMsg{task: reply.GetTask(), action: "completed", err: err}
+	}
+}
+
+// errorText renders an RPC error as its status message and code.
+func errorText(err error) string {
+	st := status.Convert(err)
+	return st.Message() + " (" + st.Code().String() + ")"
+}
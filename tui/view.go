@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	paneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	doneStyle     = lipgloss.NewStyle().Faint(true)
+	labelStyle    = lipgloss.NewStyle().Bold(true).Width(13)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	messageStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+)
+
+// chromeHeight is the number of lines used by the header, the footer and the pane borders.
+const chromeHeight = 4
+
+// listHeight is the number of task rows that fit into the list pane.
+func (m Model) listHeight() int {
+	if m.height == 0 {
+		return 20
+	}
+	return max(m.height-chromeHeight, 1)
+}
+
+// View renders the header, the list and detail panes and the footer.
+func (m Model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+	listWidth := max(m.width*2/5, 24)
+	detailWidth := max(m.width-listWidth-4, 20)
+	height := m.listHeight()
+
+	// MaxHeight includes the two border lines.
+	// Widths include the horizontal padding of one cell on each side.
+	list := paneStyle.Width(listWidth).Height(height).MaxHeight(height + 2).Render(m.renderList(listWidth-2, height))
+	var right string
+	if m.mode == modeAdd || m.mode == modeEdit {
+		right = m.renderForm()
+	} else {
+		right = m.renderDetail(detailWidth - 2)
+	}
+	detail := paneStyle.Width(detailWidth).Height(height).MaxHeight(height + 2).Render(right)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.renderHeader(),
+		lipgloss.JoinHorizontal(lipgloss.Top, list, detail),
+		m.renderFooter(),
+	)
+}
+
+func (m Model) renderHeader() string {
+	header := titleStyle.Render("Tasks")
+	header += fmt.Sprintf("  %d of %d", len(m.visible), len(m.tasks))
+	if m.filter.Value() != "" && m.mode != modeFilter {
+		header += fmt.Sprintf("  filter: %q", m.filter.Value())
+	}
+	switch {
+	case m.loading:
+		header += "  refreshing..."
+	case !m.loadedAt.IsZero():
+		header += "  updated " + m.loadedAt.Format("15:04:05")
+	}
+	return header
+}
+
+func (m Model) renderList(width, height int) string {
+	if len(m.visible) == 0 {
+		if m.loading {
+			return "Loading tasks..."
+		}
+		return "No tasks found."
+	}
+	var rows []string
+	end := min(m.offset+height, len(m.visible))
+	for i := m.offset; i < end; i++ {
+		task := m.visible[i]
+		marker := "[ ]"
+		if task.GetStatus() == "completed" {
+			marker = "[x]"
+		}
+		row := truncate(fmt.Sprintf("%s %s %s", marker, task.GetId(), task.GetTitle()), width)
+		switch {
+		case i == m.cursor:
+			row = selectedStyle.Render(row)
+		case task.GetStatus() == "completed":
+			row = doneStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (m Model) renderDetail(width int) string {
+	task := m.selected()
+	if task == nil {
+		return "Select a task to see its details."
+	}
+	field := func(label, value string) string {
+		return labelStyle.Render(label) + value
+	}
+	description := task.GetDescription()
+	if description == "" {
+		description = helpStyle.Render("(no description)")
+	}
+	lines := []string{
+		titleStyle.Render(task.GetTitle()),
+		"",
+		field("ID", task.GetId()),
+		field("Status", task.GetStatus()),
+	}
+	// Optional fields are only shown when set.
+	for _, f := range []struct{ label, value string }{
+		{"Priority", task.GetPriority()},
+		{"Due at", task.GetDueAt()},
+		{"Tags", strings.Join(task.GetTags(), ", ")},
+		{"Assignee", task.GetAssignee()},
+		{"Project", task.GetProject()},
+	} {
+		if f.value != "" {
+			lines = append(lines, field(f.label, f.value))
+		}
+	}
+	lines = append(lines,
+		field("Created at", task.GetCreatedAt()),
+		field("Updated at", task.GetUpdatedAt()),
+		"",
+		lipgloss.NewStyle().Width(width).Render(description),
+	)
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderForm() string {
+	heading := "New task"
+	if m.mode == modeEdit {
+		heading = "Edit task " + m.editing.GetId()
+	}
+	lines := []string{titleStyle.Render(heading), ""}
+	for i := range m.inputs {
+		lines = append(lines, m.inputs[i].View())
+	}
+	lines = append(lines, "", helpStyle.Render("tab next field • enter on last field or ctrl+s save • esc cancel"))
+	return strings.Join(lines, "\n")
+}
+
+func (m Model) renderFooter() string {
+	switch {
+	case m.mode == modeFilter:
+		return m.filter.View()
+	case m.err != nil:
+		return errorStyle.Render("Error: " + errorText(m.err))
+	case m.message != "":
+		return messageStyle.Render(m.message)
+	}
+	return helpStyle.Render("↑/↓ move • a add • e edit • c complete • / filter • esc clear filter • r refresh • q quit")
+}
+
+// truncate shortens s to at most width cells, marking the cut with an ellipsis.
+func truncate(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// newClient returns the HTTP client of the dispatcher. Webhook URLs are chosen by
+// the users of a workspace, so the client only connects to public addresses: it
+// checks every address it dials, after DNS resolution, and does not follow
+// redirects or use a proxy, which would connect on its behalf.
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   dialControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// A redirect is answered as a non-2xx response, so the delivery is retried.
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
+
+// dialControl rejects connections to addresses that are not public.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublic(ip) {
+		return fmt.Errorf("webhook address %s is not public", ip)
+	}
+	return nil
+}
+
+// isPublic reports whether ip is neither loopback, private, link-local nor unspecified.
+// Private includes the unique local IPv6 range; IPv4-mapped IPv6 addresses are
+// checked as IPv4.
+func isPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() && !ip.IsUnspecified()
+}
+
+// CheckHost returns an error when host, the host of a webhook URL without its port,
+// is localhost or an address that is not public. Host names are resolved only when
+// a delivery is made, where the dispatcher checks the addresses again.
+func CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%s is a loopback host", host)
+	}
+	if ip, err := netip.ParseAddr(strings.Trim(host, "[]")); err == nil && !isPublic(ip) {
+		return fmt.Errorf("%s is not a public address", ip)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package webhook
+
+import (
+	cfg "Go_Test/config"
+	repo "Go_Test/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Module exports the webhook Dispatcher for FX.
+var Module = fx.Options(
+	fx.Provide(NewDispatcher),
+)
+
+const (
+	// pollInterval is the pause between two rounds when the outbox and the queue are drained.
+	pollInterval = time.Second
+	// batchSize bounds the events fanned out and the deliveries claimed per round.
+	batchSize = 50
+	// pruneInterval and eventRetention control the cleanup of delivered outbox events.
+	pruneInterval  = time.Hour
+	eventRetention = 7 * 24 * time.Hour
+	// maxErrorLength truncates the error stored with a failed delivery.
+	maxErrorLength = 1024
+)
+
+// Dispatcher delivers the events of the outbox to webhooks. Several servers can
+// run one against the same database: events and deliveries are claimed with
+// SKIP LOCKED row locks and leases. Deliveries are not ordered: an event can
+// overtake an older one that is locked, retried or claimed by another server.
+type Dispatcher struct {
+	repo        repo.WebhookRepository
+	logger      *zap.Logger
+	tracer      trace.Tracer
+	client      *http.Client
+	maxAttempts int
+	lease       time.Duration
+}
+
+type DispatcherParams struct {
+	fx.In
+	Lifecycle      fx.Lifecycle
+	Logger         *zap.Logger
+	Config         *cfg.Config
+	Repository     repo.WebhookRepository
+	TracerProvider trace.TracerProvider
+}
+
+// NewDispatcher creates the dispatcher and runs it for the lifetime of the FX app.
+func NewDispatcher(p DispatcherParams) *Dispatcher {
+	timeout := time.Duration(p.Config.WebhookTimeoutSeconds) * time.Second
+	d := &Dispatcher{
+		repo:        p.Repository,
+		logger:      p.Logger.Named("webhook"),
+		tracer:      p.TracerProvider.Tracer("Go_Test/webhook"),
+		client:      newClient(timeout),
+		maxAttempts: p.Config.WebhookMaxAttempts,
+		// A claimed delivery is retried by any dispatcher once its lease ends, so the
+		// lease must outlast the slowest attempt.
+		lease: timeout + 30*time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			d.logger.Info("Starting webhook dispatcher", zap.Int("max_attempts", d.maxAttempts), zap.Duration("timeout", timeout))
+			go func() {
+				defer close(done)
+				d.run(ctx)
+			}()
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			d.logger.Info("Stopping webhook dispatcher")
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+	return d
+}
+
+// run polls until ctx is cancelled. Rounds follow each other without pause while
+// there is work, so a burst of events is not throttled by the poll interval.
+func (d *Dispatcher) run(ctx context.Context) {
+	var lastPrune time.Time
+	for {
+		busy := d.round(ctx)
+		if time.Since(lastPrune) > pruneInterval {
+			lastPrune = time.Now()
+			if n, err := d.repo.PruneEvents(ctx, time.Now().Add(-eventRetention), 1000); err != nil {
+				d.logger.Warn("Failed to prune outbox events", zap.Error(err))
+			} else if n > 0 {
+				d.logger.Debug("Pruned outbox events", zap.Int64("count", n))
+			}
+		}
+		if busy {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// round fans out pending events and attempts the due deliveries in parallel. It
+// reports whether either step hit its batch size.
+func (d *Dispatcher) round(ctx context.Context) bool {
+	events, err := d.repo.FanOutEvents(ctx, batchSize)
+	if err != nil && ctx.Err() == nil {
+		d.logger.Error("Failed to fan out outbox events", zap.Error(err))
+	}
+	deliveries, err := d.repo.ClaimDeliveries(ctx, batchSize, d.lease)
+	if err != nil && ctx.Err() == nil {
+		d.logger.Error("Failed to claim webhook deliveries", zap.Error(err))
+	}
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+	return events == batchSize || len(deliveries) == batchSize
+}
+
+// deliver makes one attempt and records its outcome. Attempts interrupted by
+// shutdown are left alone; their lease expires and another round retries them.
+func (d *Dispatcher) deliver(ctx context.Context, delivery repo.WebhookDelivery) {
+	ctx, span := d.tracer.Start(ctx, "webhook.deliver", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("webhook.id", delivery.WebhookID),
+		attribute.String("webhook.event_id", delivery.Event.GetId()),
+		attribute.String("webhook.event_type", repo.EventType(delivery.Event)),
+		attribute.Int("webhook.attempt", delivery.Attempts),
+	))
+	defer span.End()
+	logger := d.logger.With(zap.String("webhookID", delivery.WebhookID), zap.String("eventID", delivery.Event.GetId()),
+		zap.String("eventType", repo.EventType(delivery.Event)), zap.Int("attempt", delivery.Attempts))
+
+	err := d.post(ctx, delivery)
+	if ctx.Err() != nil {
+		return
+	}
+	if err == nil {
+		logger.Debug("Delivered webhook event")
+		if err := d.repo.CompleteDelivery(ctx, delivery.ID); err != nil {
+			logger.Error("Failed to complete webhook delivery", zap.Error(err))
+		}
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	lastError := err.Error()
+	if len(lastError) > maxErrorLength {
+		lastError = lastError[:maxErrorLength]
+	}
+	if delivery.Attempts >= d.maxAttempts {
+		logger.Warn("Webhook delivery failed for the last time; moving it to the dead letters", zap.Error(err))
+		if err := d.repo.DeadLetterDelivery(ctx, delivery, lastError); err != nil {
+			logger.Error("Failed to dead-letter webhook delivery", zap.Error(err))
+		}
+		return
+	}
+	next := time.Now().Add(Backoff(delivery.Attempts))
+	logger.Info("Webhook delivery failed; retrying later", zap.Time("next_attempt_at", next), zap.Error(err))
+	if err := d.repo.RetryDelivery(ctx, delivery.ID, lastError, next); err != nil {
+		logger.Error("Failed to reschedule webhook delivery", zap.Error(err))
+	}
+}
+
+// post sends the signed request. Any response other than 2xx is an error.
+func (d *Dispatcher) post(ctx context.Context, delivery repo.WebhookDelivery) error {
+	event := delivery.Event
+	task, err := protojson.Marshal(repo.EventTask(event))
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(Envelope{
+		ID:          event.GetId(),
+		Type:        repo.EventType(event),
+		OccurredAt:  event.GetOccurredAt(),
+		WorkspaceID: event.GetWorkspaceId(),
+		Task:        task,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "fx-grpc-app-webhook")
+	req.Header.Set(EventIDHeader, event.GetId())
+	req.Header.Set(EventTypeHeader, repo.EventType(event))
+	req.Header.Set(TimestampHeader, fmt.Sprint(now.Unix()))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, now, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
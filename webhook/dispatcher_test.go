@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	pb "Go_Test/api"
+	repo "Go_Test/repository"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// fakeRepository queues deliveries in memory. It supports the methods used by
+// rounds of the dispatcher; the others panic through the nil embedded interface.
+type fakeRepository struct {
+	repo.WebhookRepository
+
+	mu         sync.Mutex
+	queue      []repo.WebhookDelivery
+	due        map[int64]time.Time
+	completed  []int64
+	retried    []string
+	deadLetter []string
+}
+
+func newFakeRepository(deliveries ...repo.WebhookDelivery) *fakeRepository {
+	return &fakeRepository{queue: deliveries, due: make(map[int64]time.Time)}
+}
+
+func (r *fakeRepository) FanOutEvents(ctx context.Context, limit int) (int, error) { return 0, nil }
+
+func (r *fakeRepository) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]repo.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []repo.WebhookDelivery
+	for i := range r.queue {
+		if len(claimed) < limit && !r.due[r.queue[i].ID].After(time.Now()) {
+			r.queue[i].Attempts++
+			r.due[r.queue[i].ID] = time.Now().Add(lease)
+			claimed = append(claimed, r.queue[i])
+		}
+	}
+	return claimed, nil
+}
+
+// remove takes the delivery with the given ID off the queue.
+func (r *fakeRepository) remove(id int64) {
+	for i := range r.queue {
+		if r.queue[i].ID == id {
+			r.queue = append(r.queue[:i], r.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *fakeRepository) CompleteDelivery(ctx context.Context, deliveryID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.completed = append(r.completed, deliveryID)
+	r.remove(deliveryID)
+	return nil
+}
+
+// RetryDelivery makes the delivery due again at once, so that the next round retries it.
+func (r *fakeRepository) RetryDelivery(ctx context.Context, deliveryID int64, lastError string, next time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retried = append(r.retried, lastError)
+	r.due[deliveryID] = time.Time{}
+	return nil
+}
+
+func (r *fakeRepository) DeadLetterDelivery(ctx context.Context, delivery repo.WebhookDelivery, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deadLetter = append(r.deadLetter, lastError)
+	r.remove(delivery.ID)
+	return nil
+}
+
+func testDelivery(url string) repo.WebhookDelivery {
+	return repo.WebhookDelivery{
+		ID: 7, WebhookID: "3", URL: url, Secret: "s3cret",
+		Event: &pb.TaskEvent{
+			Id: "42", WorkspaceId: "1", OccurredAt: "2026-10-19T08:15:00.123Z",
+			Event: &pb.TaskEvent_TaskCompleted{TaskCompleted: &pb.TaskCompleted{Task: &pb.Task{Id: "12", Title: "Write report", Status: "completed"}}},
+		},
+	}
+}
+
+// newTestDispatcher returns a dispatcher whose client connects to loopback
+// addresses, as httptest servers use them, but otherwise is the one of NewDispatcher.
+func newTestDispatcher(r repo.WebhookRepository, maxAttempts int) *Dispatcher {
+	client := newClient(5 * time.Second)
+	client.Transport.(*http.Transport).DialContext = (&net.Dialer{}).DialContext
+	return &Dispatcher{
+		repo:        r,
+		logger:      zap.NewNop(),
+		tracer:      noop.NewTracerProvider().Tracer("test"),
+		client:      client,
+		maxAttempts: maxAttempts,
+		lease:       time.Minute,
+	}
+}
+
+func TestDispatcherDeliversSignedEvent(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received, bodies = append(received, r), append(bodies, body)
+		if len(received) == 1 {
+			http.Error(w, "try again", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	r := newFakeRepository(testDelivery(server.URL + "/hooks"))
+	d := newTestDispatcher(r, 3)
+	d.round(context.Background())
+	d.round(context.Background())
+
+	if len(received) != 2 || len(r.retried) != 1 || len(r.completed) != 1 || len(r.deadLetter) != 0 || len(r.queue) != 0 {
+		t.Fatalf("%d requests, %d retries, %d completed, %d dead letters, %d queued; want 2, 1, 1, 0, 0",
+			len(received), len(r.retried), len(r.completed), len(r.deadLetter), len(r.queue))
+	}
+	if !strings.Contains(r.retried[0], "503") {
+		t.Errorf("retry recorded error %q, want the response status", r.retried[0])
+	}
+	req, body := received[1], bodies[1]
+	if req.Method != http.MethodPost || req.URL.Path != "/hooks" || req.Header.Get("Content-Type") != "application/json" ||
+		req.Header.Get(EventIDHeader) != "42" || req.Header.Get(EventTypeHeader) != "task.completed" {
+		t.Errorf("request %s %s with headers %v", req.Method, req.URL.Path, req.Header)
+	}
+	if !Verify("s3cret", req.Header.Get(SignatureHeader), req.Header.Get(TimestampHeader), body) {
+		t.Errorf("signature %q does not verify", req.Header.Get(SignatureHeader))
+	}
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.ID != "42" || envelope.Type != "task.completed" || envelope.WorkspaceID != "1" || !strings.Contains(string(envelope.Task), `"title":"Write report"`) {
+		t.Errorf("envelope %s", body)
+	}
+}
+
+func TestDispatcherDeadLettersAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "broken", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := newFakeRepository(testDelivery(server.URL))
+	d := newTestDispatcher(r, 3)
+	for range 5 {
+		d.round(context.Background())
+	}
+	if attempts != 3 || len(r.retried) != 2 || len(r.deadLetter) != 1 || len(r.completed) != 0 || len(r.queue) != 0 {
+		t.Fatalf("%d attempts, %d retries, %d dead letters, %d completed, %d queued; want 3, 2, 1, 0, 0",
+			attempts, len(r.retried), len(r.deadLetter), len(r.completed), len(r.queue))
+	}
+	if !strings.Contains(r.deadLetter[0], "500 Internal Server Error") {
+		t.Errorf("dead letter recorded error %q", r.deadLetter[0])
+	}
+}
+
+func TestDispatcherDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hooks", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+	})
+	mux.HandleFunc("/internal", func(w http.ResponseWriter, r *http.Request) { followed = true })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	r := newFakeRepository(testDelivery(server.URL + "/hooks"))
+	newTestDispatcher(r, 3).round(context.Background())
+	if followed || len(r.retried) != 1 || !strings.Contains(r.retried[0], "307") {
+		t.Errorf("redirect followed %v, retries %v; want a retry for the 307 response", followed, r.retried)
+	}
+}
+
+func TestClientRejectsInternalAddresses(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { reached = true }))
+	defer server.Close()
+
+	client := newClient(time.Second)
+	for _, url := range []string{server.URL, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)} {
+		resp, err := client.Post(url, "application/json", strings.NewReader("{}"))
+		if err == nil {
+			resp.Body.Close()
+		}
+		if err == nil || !strings.Contains(err.Error(), "is not public") {
+			t.Errorf("POST %s: %v, want the address to be rejected", url, err)
+		}
+	}
+	if reached {
+		t.Errorf("the client reached a loopback server")
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublic(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("isPublic(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	for _, host := range []string{"localhost", "LOCALHOST.", "api.localhost", "127.0.0.1", "::1", "169.254.169.254", "192.168.0.1", "[fe80::1]"} {
+		if err := CheckHost(host); err == nil {
+			t.Errorf("CheckHost(%q) accepted an internal host", host)
+		}
+	}
+	for _, host := range []string{"example.com", "hooks.example.com", "93.184.216.34", "localhost.example.com"} {
+		if err := CheckHost(host); err != nil {
+			t.Errorf("CheckHost(%q): %v", host, err)
+		}
+	}
+}
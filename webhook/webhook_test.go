@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	sent := time.Unix(1760000000, 0)
+	body := []byte(`{"id":"42","type":"task.completed"}`)
+	signature := Sign("s3cret", sent, body)
+	// printf '1760000000.{"id":"42","type":"task.completed"}' | openssl dgst -sha256 -hmac s3cret
+	if want := "sha256=ee4497c70d3a8ab24c8d0c905e4cc27043f81d1fefb6a2a8bbfc9f4c95888061"; signature != want {
+		t.Fatalf("Sign = %q, want %q", signature, want)
+	}
+	timestamp := fmt.Sprint(sent.Unix())
+
+	tests := []struct {
+		name                         string
+		secret, signature, timestamp string
+		body                         []byte
+		now                          time.Time
+		want                         bool
+	}{
+		{"valid", "s3cret", signature, timestamp, body, sent.Add(time.Second), true},
+		{"at the tolerance", "s3cret", signature, timestamp, body, sent.Add(Tolerance), true},
+		{"clock of the receiver behind", "s3cret", signature, timestamp, body, sent.Add(-Tolerance), true},
+		{"too old", "s3cret", signature, timestamp, body, sent.Add(Tolerance + time.Second), false},
+		{"from the future", "s3cret", signature, timestamp, body, sent.Add(-Tolerance - time.Second), false},
+		{"other secret", "secret", signature, timestamp, body, sent, false},
+		{"changed body", "s3cret", signature, timestamp, []byte(`{"id":"43","type":"task.completed"}`), sent, false},
+		{"changed timestamp", "s3cret", signature, fmt.Sprint(sent.Unix() + 1), body, sent, false},
+		{"malformed timestamp", "s3cret", signature, "yesterday", body, sent, false},
+		{"missing prefix", "s3cret", signature[len("sha256="):], timestamp, body, sent, false},
+	}
+	for _, tt := range tests {
+		if got := verifyAt(tt.secret, tt.signature, tt.timestamp, tt.body, tt.now); got != tt.want {
+			t.Errorf("%s: verify = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	now := time.Now()
+	if !Verify("s3cret", Sign("s3cret", now, body), fmt.Sprint(now.Unix()), body) {
+		t.Errorf("Verify rejected a delivery signed now")
+	}
+	if Verify("s3cret", signature, timestamp, body) {
+		t.Errorf("Verify accepted a delivery signed at %v", sent)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{9, time.Hour},
+		{1000, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
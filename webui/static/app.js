@@ -0,0 +1,233 @@
+// Task list UI. Every call goes to the REST/JSON gateway of the same server with
+// the API token kept in localStorage, exactly like curl against /v1/tasks would.
+"use strict";
+
+const tokenKey = "fx-grpc-app.token";
+const api = "/v1/tasks";
+
+const $ = (selector) => document.querySelector(selector);
+
+// request calls the gateway and returns the decoded JSON reply. Gateway errors
+// carry the gRPC status as {code, message, details}.
+async function request(method, path, body) {
+  const headers = { "Accept": "application/json" };
+  const token = localStorage.getItem(tokenKey);
+  if (token) {
+    headers["Authorization"] = "Bearer " + token;
+  }
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+  }
+  const res = await fetch(path, { method, headers, body: body === undefined ? undefined : JSON.stringify(body) });
+  const text = await res.text();
+  let reply = {};
+  try {
+    reply = text ? JSON.parse(text) : {};
+  } catch {
+    reply = { message: text.trim() };
+  }
+  if (!res.ok) {
+    const message = reply.message || res.statusText;
+    throw new Error(res.status === 401 ? message + " (check the API token)" : message);
+  }
+  return reply;
+}
+
+function showMessage(text, isError) {
+  const el = $("#message");
+  el.textContent = text;
+  el.classList.toggle("error", Boolean(isError));
+  el.hidden = !text;
+}
+
+function splitList(value) {
+  return value.split(",").map((s) => s.trim()).filter(Boolean);
+}
+
+// toRFC3339 converts the value of a datetime-local input, in the browser's time zone.
+function toRFC3339(value) {
+  return value ? new Date(value).toISOString() : "";
+}
+
+// toLocalInput converts an RFC 3339 timestamp for a datetime-local input.
+function toLocalInput(value) {
+  if (!value) {
+    return "";
+  }
+  const d = new Date(value);
+  const pad = (n) => String(n).padStart(2, "0");
+  return `${d.getFullYear()}-${pad(d.getMonth() + 1)}-${pad(d.getDate())}T${pad(d.getHours())}:${pad(d.getMinutes())}`;
+}
+
+function formatDue(value) {
+  return value ? new Date(value).toLocaleString(undefined, { dateStyle: "medium", timeStyle: "short" }) : "";
+}
+
+// taskFields reads the task fields of the add and edit forms in the JSON mapping of the API.
+function taskFields(form) {
+  const data = new FormData(form);
+  return {
+    title: data.get("title").trim(),
+    description: data.get("description"),
+    dueAt: toRFC3339(data.get("dueAt")),
+    tags: splitList(data.get("tags")),
+    priority: data.get("priority"),
+    assignee: data.get("assignee").trim(),
+    project: data.get("project").trim(),
+  };
+}
+
+function filterQuery() {
+  const data = new FormData($("#filter-form"));
+  const params = new URLSearchParams();
+  for (const key of ["statuses", "tags"]) {
+    for (const value of splitList(data.get(key))) {
+      params.append("filter." + key, value);
+    }
+  }
+  for (const key of ["priority", "assignee", "project", "title_contains"]) {
+    const value = data.get(key).trim();
+    if (value) {
+      params.set("filter." + key, value);
+    }
+  }
+  const query = params.toString();
+  return query ? "?" + query : "";
+}
+
+let tasks = [];
+
+async function loadTasks() {
+  try {
+    const reply = await request("GET", api + filterQuery());
+    tasks = reply.tasks || [];
+    render();
+  } catch (err) {
+    tasks = [];
+    render();
+    showMessage("Failed to load tasks: " + err.message, true);
+  }
+}
+
+function cell(row, text) {
+  const td = row.insertCell();
+  td.textContent = text || "";
+  return td;
+}
+
+function button(label, onClick) {
+  const b = document.createElement("button");
+  b.type = "button";
+  b.textContent = label;
+  b.addEventListener("click", onClick);
+  return b;
+}
+
+function render() {
+  const body = $("#tasks tbody");
+  body.replaceChildren();
+  for (const task of tasks) {
+    const row = body.insertRow();
+    row.classList.toggle("completed", task.status === "completed");
+    cell(row, task.id);
+    const title = cell(row, task.title);
+    if (task.description) {
+      const description = document.createElement("div");
+      description.className = "description";
+      description.textContent = task.description;
+      title.append(description);
+    }
+    cell(row, task.status);
+    cell(row, task.priority);
+    cell(row, formatDue(task.dueAt));
+    cell(row, (task.tags || []).join(", "));
+    cell(row, task.assignee);
+    cell(row, task.project);
+    const actions = row.insertCell();
+    actions.append(button("Edit", () => openEditor(task)));
+    if (task.status !== "completed") {
+      actions.append(" ", button("Complete", () => completeTask(task)));
+    }
+  }
+  $("#empty").hidden = tasks.length > 0;
+}
+
+async function completeTask(task) {
+  try {
+    await request("POST", `${api}/${encodeURIComponent(task.id)}:complete`, {});
+    showMessage(`Completed task ${task.id}.`);
+    await loadTasks();
+  } catch (err) {
+    showMessage(`Failed to complete task ${task.id}: ${err.message}`, true);
+  }
+}
+
+let editing = null;
+
+function openEditor(task) {
+  editing = task;
+  $("#edit-dialog").returnValue = "";
+  const form = $("#edit-form");
+  $("#edit-id").textContent = task.id;
+  form.elements.title.value = task.title || "";
+  form.elements.status.value = task.status || "";
+  form.elements.dueAt.value = toLocalInput(task.dueAt);
+  form.elements.priority.value = task.priority || "";
+  form.elements.tags.value = (task.tags || []).join(", ");
+  form.elements.assignee.value = task.assignee || "";
+  form.elements.project.value = task.project || "";
+  form.elements.description.value = task.description || "";
+  $("#edit-dialog").showModal();
+}
+
+// saveEdit sends every field of the form; UpdateTask changes all fields when the
+// update mask is empty.
+async function saveEdit() {
+  const form = $("#edit-form");
+  const body = { ...taskFields(form), status: form.elements.status.value.trim() };
+  try {
+    await request("PATCH", `${api}/${encodeURIComponent(editing.id)}`, body);
+    showMessage(`Updated task ${editing.id}.`);
+    await loadTasks();
+  } catch (err) {
+    showMessage(`Failed to update task ${editing.id}: ${err.message}`, true);
+  }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  $("#token").value = localStorage.getItem(tokenKey) || "";
+
+  $("#token-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    localStorage.setItem(tokenKey, $("#token").value.trim());
+    showMessage("");
+    loadTasks();
+  });
+
+  $("#add-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = event.target;
+    try {
+      const reply = await request("POST", api, taskFields(form));
+      form.reset();
+      showMessage(`Added task ${reply.task.id}.`);
+      await loadTasks();
+    } catch (err) {
+      showMessage("Failed to add task: " + err.message, true);
+    }
+  });
+
+  $("#filter-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    loadTasks();
+  });
+  $("#filter-form").addEventListener("reset", () => setTimeout(loadTasks));
+
+  $("#edit-dialog").addEventListener("close", () => {
+    if ($("#edit-dialog").returnValue === "save") {
+      saveEdit();
+    }
+  });
+
+  loadTasks();
+});
@@ -0,0 +1,31 @@
+// Package webui embeds the browser interface of the task list. It is a static
+// single page that talks to the REST/JSON gateway under /v1/, so it is served by
+// the gateway's HTTP server and needs no build step or runtime besides the binary.
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var static embed.FS
+
+// contentSecurityPolicy only allows the page's own scripts, styles and API calls.
+const contentSecurityPolicy = "default-src 'self'; img-src 'self' data:; frame-ancestors 'none'"
+
+// Handler serves the UI under prefix, which must start and end with a slash.
+func Handler(prefix string) http.Handler {
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		// The directory is embedded at build time; a missing one is a programming error.
+		panic(err)
+	}
+	fileServer := http.StripPrefix(prefix, http.FileServerFS(files))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		fileServer.ServeHTTP(w, r)
+	})
+}